@@ -4,24 +4,51 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/importer"
 	"github.com/dream-horizon-org/datagen/runner"
 	"github.com/dream-horizon-org/datagen/utils"
 )
 
 var (
-	flagCount   int
-	flagTags    string
-	flagOutput  string
-	flagFormat  string
-	flagNoExec  bool
-	flagConfig  string
-	flagSeed    int64
-	flagVerbose bool
-	flagVersion bool
-	version     = "0.1.0"
+	flagCount       int
+	flagTags        string
+	flagOutput      string
+	flagFormat      string
+	flagNoExec      bool
+	flagConfig      string
+	flagSeed        int64
+	flagChunkSize   int
+	flagMemoWindow  int
+	flagParallelism int
+	flagRowGroup    int
+	flagBatchSize   int
+	flagCreateTable bool
+	flagTruncate    bool
+	flagJSONMode    string
+	flagXMLRoot     string
+	flagXMLNS       string
+	flagCSVDelim    string
+	flagCSVHeader   bool
+	flagCSVNull     string
+	flagCSVTime     string
+	flagCSVFloat    int
+	flagCompress    string
+	flagSplitRows   int
+	flagSplitSize   string
+	flagDialect     string
+	flagFrom        string
+	flagDSN         string
+	flagFromDDL     string
+	flagImportDir   string
+	flagForce       bool
+	flagVerbose     bool
+	flagVersion     bool
+	version         = "0.1.0"
 )
 
 func buildRootCommand() *cobra.Command {
@@ -45,7 +72,7 @@ func buildRootCommand() *cobra.Command {
 
 	genCmd := &cobra.Command{
 		Use:   "gen [file|directory]",
-		Short: "Generate data from .dg model files and output to CSV, JSON, XML, or stdout",
+		Short: "Generate data from .dg model files and output to CSV, JSON, XML, Parquet, Avro, Protobuf, SQL, or stdout",
 		Args:  validateSingleFileOrDir,
 		RunE:  runner.BuildAndRunGen,
 	}
@@ -53,9 +80,26 @@ func buildRootCommand() *cobra.Command {
 	genCmd.Flags().IntVarP(&flagCount, "count", "n", -1, "number of records per model")
 	genCmd.Flags().StringVarP(&flagTags, "tags", "t", "", "comma-separated key=value tags to filter models")
 	genCmd.Flags().StringVarP(&flagOutput, "output", "o", ".", "output directory or file path")
-	genCmd.Flags().StringVarP(&flagFormat, "format", "f", "", strings.Join([]string{"csv", "json", "xml", "stdout"}, "|"))
+	genCmd.Flags().StringVarP(&flagFormat, "format", "f", "", strings.Join([]string{"csv", "json", "xml", "parquet", "avro", "protobuf", "sql", "stdout"}, "|"))
 	genCmd.Flags().Int64VarP(&flagSeed, "seed", "s", 0, "deterministic seed for random data generation (default is 0 for random seed)")
+	genCmd.Flags().IntVar(&flagRowGroup, "row-group-size", 100000, "number of records per Parquet row group (0 writes one row group per file)")
+	genCmd.Flags().StringVar(&flagDialect, "dialect", string(codegen.DialectMySQL), "SQL dialect of the sql format: "+strings.Join(dialectNames(codegen.Dialects), "|"))
+	genCmd.Flags().IntVar(&flagBatchSize, "batch-size", 1000, "number of rows per INSERT statement of the sql format")
+	genCmd.Flags().BoolVar(&flagCreateTable, "create-table", false, "start the SQL of each model with its CREATE TABLE statement")
+	genCmd.Flags().BoolVar(&flagTruncate, "truncate", false, "empty the table of each model before the SQL inserts its records")
+	genCmd.Flags().StringVar(&flagJSONMode, "json-mode", "jsonl", "layout of the json format: jsonl|array|pretty")
+	genCmd.Flags().StringVar(&flagXMLRoot, "xml-root", "records", "root element of the documents of the xml format")
+	genCmd.Flags().StringVar(&flagXMLNS, "xml-namespace", "", "default namespace of the documents of the xml format")
+	genCmd.Flags().StringVar(&flagCSVDelim, "csv-delimiter", ",", "cell delimiter of the csv format, a single character or \\t for tabs")
+	genCmd.Flags().BoolVar(&flagCSVHeader, "csv-header", true, "start the files of the csv format with a row of column names")
+	genCmd.Flags().StringVar(&flagCSVNull, "csv-null", "", "text of nil values in the csv format")
+	genCmd.Flags().StringVar(&flagCSVTime, "csv-time-layout", time.RFC3339Nano, "Go layout of times in the csv format")
+	genCmd.Flags().IntVar(&flagCSVFloat, "csv-float-precision", -1, "number of decimals of floats in the csv format (-1 writes the shortest exact value)")
+	genCmd.Flags().StringVar(&flagCompress, "compress", "none", "compression of the output files: none|gzip|zstd")
+	genCmd.Flags().IntVar(&flagSplitRows, "split-rows", 0, "maximum number of records per part file, with a manifest of the parts (0 does not split)")
+	genCmd.Flags().StringVar(&flagSplitSize, "split-size", "", "size past which part files are closed, such as 512MB, with a manifest of the parts")
 	genCmd.Flags().BoolVar(&flagNoExec, "noexec", false, "skip building and executing generated binary")
+	addRunFlags(genCmd)
 
 	rootCmd.AddCommand(genCmd)
 
@@ -69,12 +113,53 @@ func buildRootCommand() *cobra.Command {
 	_ = executeCmd.MarkFlagRequired("config")
 	executeCmd.Flags().StringVarP(&flagOutput, "output", "o", ".", "output directory or file path")
 	executeCmd.Flags().BoolVar(&flagNoExec, "noexec", false, "skip building and executing generated binary")
+	addRunFlags(executeCmd)
 
 	rootCmd.AddCommand(executeCmd)
 
+	schemaCmd := &cobra.Command{
+		Use:   "schema [file|directory]",
+		Short: "Print CREATE TABLE statements for .dg model files",
+		Args:  validateSingleFileOrDir,
+		RunE:  runner.BuildSchema,
+	}
+	schemaCmd.Flags().StringVar(&flagDialect, "dialect", string(codegen.DialectMySQL), strings.Join(dialectNames(codegen.Dialects), "|"))
+	schemaCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "output file path (default is stdout)")
+
+	rootCmd.AddCommand(schemaCmd)
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Scaffold .dg model files from an existing database or its DDL",
+		Args:  cobra.NoArgs,
+		RunE:  runner.ImportModels,
+	}
+	importCmd.Flags().StringVar(&flagFrom, "from", "", strings.Join(dialectNames(importer.Dialects), "|"))
+	_ = importCmd.MarkFlagRequired("from")
+	importCmd.Flags().StringVar(&flagDSN, "dsn", "", "connection string of the database to read tables from")
+	importCmd.Flags().StringVar(&flagFromDDL, "from-ddl", "", "path to a file of CREATE TABLE statements to read tables from")
+	importCmd.Flags().StringVarP(&flagImportDir, "output", "o", ".", "output directory for the .dg files")
+	importCmd.Flags().BoolVar(&flagForce, "force", false, "overwrite existing .dg files")
+
+	rootCmd.AddCommand(importCmd)
+
 	return rootCmd
 }
 
+func addRunFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&flagChunkSize, "chunk-size", 10000, "number of records generated and written per chunk (0 buffers all records of a model)")
+	cmd.Flags().IntVar(&flagMemoWindow, "memo-window", 0, "number of values kept for fields referenced by other fields (0 keeps all)")
+	cmd.Flags().IntVar(&flagParallelism, "parallelism", 1, "number of workers generating records concurrently (0 uses one per CPU)")
+}
+
+func dialectNames(dialects []codegen.Dialect) []string {
+	names := make([]string, 0, len(dialects))
+	for _, d := range dialects {
+		names = append(names, string(d))
+	}
+	return names
+}
+
 func main() {
 	rootCmd := buildRootCommand()
 
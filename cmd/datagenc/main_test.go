@@ -20,8 +20,10 @@ Usage:
 
 Available Commands:
   execute     Generate data from .dg model files and load into configured data stores
-  gen         Generate data from .dg model files and output to CSV, JSON, XML, or stdout
+  gen         Generate data from .dg model files and output to CSV, JSON, XML, Parquet, Avro, Protobuf, SQL, or stdout
   help        Help about any command
+  import      Scaffold .dg model files from an existing database or its DDL
+  schema      Print CREATE TABLE statements for .dg model files
 
 Flags:
   -h, --help      help for datagenc
@@ -31,19 +33,38 @@ Flags:
 Use "datagenc [command] --help" for more information about a command.
 `
 
-	expectedGenHelp = `Generate data from .dg model files and output to CSV, JSON, XML, or stdout
+	expectedGenHelp = `Generate data from .dg model files and output to CSV, JSON, XML, Parquet, Avro, Protobuf, SQL, or stdout
 
 Usage:
   datagenc gen [file|directory] [flags]
 
 Flags:
-  -n, --count int       number of records per model (default -1)
-  -f, --format string   csv|json|xml|stdout
-  -h, --help            help for gen
-      --noexec          skip building and executing generated binary
-  -o, --output string   output directory or file path (default ".")
-  -s, --seed int        deterministic seed for random data generation (default is 0 for random seed)
-  -t, --tags string     comma-separated key=value tags to filter models
+      --batch-size int            number of rows per INSERT statement of the sql format (default 1000)
+      --chunk-size int            number of records generated and written per chunk (0 buffers all records of a model) (default 10000)
+      --compress string           compression of the output files: none|gzip|zstd (default "none")
+  -n, --count int                 number of records per model (default -1)
+      --create-table              start the SQL of each model with its CREATE TABLE statement
+      --csv-delimiter string      cell delimiter of the csv format, a single character or \t for tabs (default ",")
+      --csv-float-precision int   number of decimals of floats in the csv format (-1 writes the shortest exact value) (default -1)
+      --csv-header                start the files of the csv format with a row of column names (default true)
+      --csv-null string           text of nil values in the csv format
+      --csv-time-layout string    Go layout of times in the csv format (default "2006-01-02T15:04:05.999999999Z07:00")
+      --dialect string            SQL dialect of the sql format: mysql|postgres|sqlite (default "mysql")
+  -f, --format string             csv|json|xml|parquet|avro|protobuf|sql|stdout
+  -h, --help                      help for gen
+      --json-mode string          layout of the json format: jsonl|array|pretty (default "jsonl")
+      --memo-window int           number of values kept for fields referenced by other fields (0 keeps all)
+      --noexec                    skip building and executing generated binary
+  -o, --output string             output directory or file path (default ".")
+      --parallelism int           number of workers generating records concurrently (0 uses one per CPU) (default 1)
+      --row-group-size int        number of records per Parquet row group (0 writes one row group per file) (default 100000)
+  -s, --seed int                  deterministic seed for random data generation (default is 0 for random seed)
+      --split-rows int            maximum number of records per part file, with a manifest of the parts (0 does not split)
+      --split-size string         size past which part files are closed, such as 512MB, with a manifest of the parts
+  -t, --tags string               comma-separated key=value tags to filter models
+      --truncate                  empty the table of each model before the SQL inserts its records
+      --xml-namespace string      default namespace of the documents of the xml format
+      --xml-root string           root element of the documents of the xml format (default "records")
 
 Global Flags:
   -v, --verbose   enable verbose (debug level) logging
@@ -56,10 +77,46 @@ Usage:
   datagenc execute [file|directory] [flags]
 
 Flags:
-  -c, --config string   path to config file (specifies models, data stores, and record counts)
-  -h, --help            help for execute
-      --noexec          skip building and executing generated binary
-  -o, --output string   output directory or file path (default ".")
+      --chunk-size int    number of records generated and written per chunk (0 buffers all records of a model) (default 10000)
+  -c, --config string     path to config file (specifies models, data stores, and record counts)
+  -h, --help              help for execute
+      --memo-window int   number of values kept for fields referenced by other fields (0 keeps all)
+      --noexec            skip building and executing generated binary
+  -o, --output string     output directory or file path (default ".")
+      --parallelism int   number of workers generating records concurrently (0 uses one per CPU) (default 1)
+
+Global Flags:
+  -v, --verbose   enable verbose (debug level) logging
+  -V, --version   show version information
+`
+
+	expectedSchemaHelp = `Print CREATE TABLE statements for .dg model files
+
+Usage:
+  datagenc schema [file|directory] [flags]
+
+Flags:
+      --dialect string   mysql|postgres|sqlite (default "mysql")
+  -h, --help             help for schema
+  -o, --output string    output file path (default is stdout)
+
+Global Flags:
+  -v, --verbose   enable verbose (debug level) logging
+  -V, --version   show version information
+`
+
+	expectedImportHelp = `Scaffold .dg model files from an existing database or its DDL
+
+Usage:
+  datagenc import [flags]
+
+Flags:
+      --dsn string        connection string of the database to read tables from
+      --force             overwrite existing .dg files
+      --from string       mysql|postgres
+      --from-ddl string   path to a file of CREATE TABLE statements to read tables from
+  -h, --help              help for import
+  -o, --output string     output directory for the .dg files (default ".")
 
 Global Flags:
   -v, --verbose   enable verbose (debug level) logging
@@ -151,15 +208,19 @@ func TestBuildRootCommand(t *testing.T) {
 	assert.True(t, rootCmd.CompletionOptions.DisableDefaultCmd)
 
 	commands := rootCmd.Commands()
-	assert.Len(t, commands, 2, "expected 2 subcommands")
+	assert.Len(t, commands, 4, "expected 4 subcommands")
 
-	var genCmd, executeCmd *cobra.Command
+	var genCmd, executeCmd, schemaCmd, importCmd *cobra.Command
 	for _, cmd := range commands {
 		switch cmd.Use {
 		case "gen [file|directory]":
 			genCmd = cmd
 		case "execute [file|directory]":
 			executeCmd = cmd
+		case "schema [file|directory]":
+			schemaCmd = cmd
+		case "import":
+			importCmd = cmd
 		default:
 			t.Fatalf("unexpected command found: %q", cmd.Use)
 		}
@@ -167,12 +228,20 @@ func TestBuildRootCommand(t *testing.T) {
 
 	require.NotNil(t, genCmd, "gen command should exist")
 	require.NotNil(t, executeCmd, "execute command should exist")
+	require.NotNil(t, schemaCmd, "schema command should exist")
+	require.NotNil(t, importCmd, "import command should exist")
 
 	assert.NotNil(t, genCmd.Args, "gen command should have Args validator")
 	assert.NotNil(t, genCmd.RunE, "gen command should have RunE handler")
 
 	assert.NotNil(t, executeCmd.Args, "execute command should have Args validator")
 	assert.NotNil(t, executeCmd.RunE, "execute command should have RunE handler")
+
+	assert.NotNil(t, schemaCmd.Args, "schema command should have Args validator")
+	assert.NotNil(t, schemaCmd.RunE, "schema command should have RunE handler")
+
+	assert.NotNil(t, importCmd.Args, "import command should have Args validator")
+	assert.NotNil(t, importCmd.RunE, "import command should have RunE handler")
 }
 
 func TestRootCommandFlags(t *testing.T) {
@@ -223,6 +292,18 @@ func TestGenCommandFlags(t *testing.T) {
 	noexecFlag := genCmd.Flags().Lookup("noexec")
 	require.NotNil(t, noexecFlag, "noexec flag should exist")
 	assert.Equal(t, "false", noexecFlag.DefValue)
+
+	chunkSizeFlag := genCmd.Flags().Lookup("chunk-size")
+	require.NotNil(t, chunkSizeFlag, "chunk-size flag should exist")
+	assert.Equal(t, "10000", chunkSizeFlag.DefValue)
+
+	memoWindowFlag := genCmd.Flags().Lookup("memo-window")
+	require.NotNil(t, memoWindowFlag, "memo-window flag should exist")
+	assert.Equal(t, "0", memoWindowFlag.DefValue)
+
+	parallelismFlag := genCmd.Flags().Lookup("parallelism")
+	require.NotNil(t, parallelismFlag, "parallelism flag should exist")
+	assert.Equal(t, "1", parallelismFlag.DefValue)
 }
 
 func TestExecuteCommandFlags(t *testing.T) {
@@ -248,6 +329,66 @@ func TestExecuteCommandFlags(t *testing.T) {
 	noexecFlag := executeCmd.Flags().Lookup("noexec")
 	require.NotNil(t, noexecFlag, "noexec flag should exist")
 	assert.Equal(t, "false", noexecFlag.DefValue)
+
+	chunkSizeFlag := executeCmd.Flags().Lookup("chunk-size")
+	require.NotNil(t, chunkSizeFlag, "chunk-size flag should exist")
+	assert.Equal(t, "10000", chunkSizeFlag.DefValue)
+
+	memoWindowFlag := executeCmd.Flags().Lookup("memo-window")
+	require.NotNil(t, memoWindowFlag, "memo-window flag should exist")
+	assert.Equal(t, "0", memoWindowFlag.DefValue)
+
+	parallelismFlag := executeCmd.Flags().Lookup("parallelism")
+	require.NotNil(t, parallelismFlag, "parallelism flag should exist")
+	assert.Equal(t, "1", parallelismFlag.DefValue)
+}
+
+func TestSchemaCommandFlags(t *testing.T) {
+	rootCmd := buildRootCommand()
+	schemaCmd, _, err := rootCmd.Find([]string{"schema"})
+	require.NoError(t, err)
+	require.NotNil(t, schemaCmd)
+
+	dialectFlag := schemaCmd.Flags().Lookup("dialect")
+	require.NotNil(t, dialectFlag, "dialect flag should exist")
+	assert.Equal(t, "mysql", dialectFlag.DefValue)
+
+	outputFlag := schemaCmd.Flags().Lookup("output")
+	require.NotNil(t, outputFlag, "output flag should exist")
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "", outputFlag.DefValue)
+}
+
+func TestImportCommandFlags(t *testing.T) {
+	rootCmd := buildRootCommand()
+	importCmd, _, err := rootCmd.Find([]string{"import"})
+	require.NoError(t, err)
+	require.NotNil(t, importCmd)
+
+	fromFlag := importCmd.Flags().Lookup("from")
+	require.NotNil(t, fromFlag, "from flag should exist")
+	assert.Equal(t, "", fromFlag.DefValue)
+	err = importCmd.ValidateRequiredFlags()
+	if err != nil {
+		assert.Contains(t, err.Error(), "from", "from flag should be required")
+	}
+
+	dsnFlag := importCmd.Flags().Lookup("dsn")
+	require.NotNil(t, dsnFlag, "dsn flag should exist")
+	assert.Equal(t, "", dsnFlag.DefValue)
+
+	ddlFlag := importCmd.Flags().Lookup("from-ddl")
+	require.NotNil(t, ddlFlag, "from-ddl flag should exist")
+	assert.Equal(t, "", ddlFlag.DefValue)
+
+	outputFlag := importCmd.Flags().Lookup("output")
+	require.NotNil(t, outputFlag, "output flag should exist")
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, ".", outputFlag.DefValue)
+
+	forceFlag := importCmd.Flags().Lookup("force")
+	require.NotNil(t, forceFlag, "force flag should exist")
+	assert.Equal(t, "false", forceFlag.DefValue)
 }
 
 func TestCommandExecution(t *testing.T) {
@@ -388,3 +529,53 @@ func TestExecuteCommandHelp(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaCommandHelp(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"schema --help", []string{"schema", "--help"}},
+		{"help schema", []string{"help", "schema"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd := buildRootCommand()
+			rootCmd.SetArgs(tt.args)
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&bytes.Buffer{})
+
+			err := rootCmd.Execute()
+			assert.NoError(t, err)
+
+			assert.Equal(t, expectedSchemaHelp, out.String())
+		})
+	}
+}
+
+func TestImportCommandHelp(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"import --help", []string{"import", "--help"}},
+		{"help import", []string{"help", "import"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd := buildRootCommand()
+			rootCmd.SetArgs(tt.args)
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&bytes.Buffer{})
+
+			err := rootCmd.Execute()
+			assert.NoError(t, err)
+
+			assert.Equal(t, expectedImportHelp, out.String())
+		})
+	}
+}
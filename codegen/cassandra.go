@@ -0,0 +1,111 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cqlTypes maps the kinds of valueType to the CQL types storing them.
+// Unsigned integers take the next wider type, as CQL has none.
+var cqlTypes = map[string]string{
+	"bool":     "boolean",
+	"int8":     "tinyint",
+	"int16":    "smallint",
+	"int32":    "int",
+	"int":      "bigint",
+	"int64":    "bigint",
+	"uint8":    "smallint",
+	"uint16":   "int",
+	"uint32":   "bigint",
+	"uint":     "varint",
+	"uint64":   "varint",
+	"float32":  "float",
+	"float64":  "double",
+	kindString: "text",
+	kindBytes:  "blob",
+	kindTime:   "timestamp",
+}
+
+// cassandraVars returns the template variables of the Cassandra sink of the
+// model, with its table and columns quoted and the CQL types of its columns.
+// Its table is created at runtime, as its primary key may come from the
+// config.
+func cassandraVars(d *DatagenParsed) templateVars {
+	vars := fieldsVars(d)
+	schema, table := d.Metadata.table(d.ModelName)
+	vars.Table = cqlTable(schema, table)
+	t, err := d.buildTable()
+	if err != nil {
+		vars.CreateTableError = err.Error()
+		return vars
+	}
+	if t.primaryKey != "" {
+		vars.KeyColumns = []string{t.primaryKey}
+	}
+	types, err := d.cqlColumnTypes()
+	if err != nil {
+		vars.CreateTableError = err.Error()
+		return vars
+	}
+	for i := range vars.Columns {
+		vars.Columns[i].QuotedColumn = cqlIdent(vars.Columns[i].Column)
+		vars.Columns[i].ColumnType = types[vars.Columns[i].Column]
+	}
+	return vars
+}
+
+// cqlColumnTypes returns the CQL types of the columns of the model, keyed by
+// column name.
+func (d *DatagenParsed) cqlColumnTypes() (map[string]string, error) {
+	types := map[string]string{}
+	if d.Fields == nil {
+		return types, nil
+	}
+	for _, field := range d.Fields.List {
+		typ, err := d.miscTypes.valueTypeOf(fieldType(field.Type))
+		if err != nil {
+			return nil, fmt.Errorf("unsupported field type\n  model: %s\n  field: %s\n  cause: %w", d.FullyQualifiedModelName, field.Names[0].Name, err)
+		}
+		for _, name := range field.Names {
+			if column, ok := d.Metadata.column(name.Name); ok {
+				types[column] = cqlType(typ, false)
+			}
+		}
+	}
+	return types, nil
+}
+
+// cqlType returns the CQL type of values of type typ. Lists become lists and
+// maps maps, frozen when nested in another collection as CQL requires, and
+// structs are stored as JSON text. Every CQL column is nullable.
+func cqlType(typ *valueType, nested bool) string {
+	var s string
+	switch typ.kind {
+	case kindList:
+		s = "list<" + cqlType(typ.elem, true) + ">"
+	case kindMap:
+		s = "map<" + cqlType(typ.key, true) + ", " + cqlType(typ.elem, true) + ">"
+	case kindRecord:
+		return "text"
+	default:
+		return cqlTypes[typ.kind]
+	}
+	if nested {
+		s = "frozen<" + s + ">"
+	}
+	return s
+}
+
+// cqlIdent quotes a table or column name for CQL, keeping its case.
+func cqlIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// cqlTable returns the quoted name of a table, prefixed with its keyspace
+// when it has one.
+func cqlTable(keyspace, name string) string {
+	if keyspace == "" {
+		return cqlIdent(name)
+	}
+	return cqlIdent(keyspace) + "." + cqlIdent(name)
+}
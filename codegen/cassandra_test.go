@@ -0,0 +1,57 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+func TestCassandraVars(t *testing.T) {
+	users := typedModel(t, "users", [][3]string{
+		{"id", "int64", "{ return int64(iter) }"},
+		{"email", "*string", "{ return nil }"},
+		{"level", "uint8", "{ return 1 }"},
+		{"balance", "uint64", "{ return 1 }"},
+		{"created_at", "time.Time", "{ return Date() }"},
+		{"tags", "[]string", "{ return nil }"},
+		{"matrix", "[][]int32", "{ return nil }"},
+		{"attributes", "map[string][]float32", "{ return nil }"},
+		{"address", "Address", "{ return Address{} }"},
+		{"avatar", "[]byte", "{ return nil }"},
+		{"scratch", "bool", "{ return true }"},
+	})
+	users.Misc = "type Address struct {\n City string\n}"
+	users.Metadata = &Metadata{Schema: "app", Columns: map[string]string{"scratch": "-", "email": "e\"mail"}}
+	orders := typedModel(t, "shop"+utils.DgDirDelimeter+"orders", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"user_id", "int64", "{ return self.datagen.users().id(iter) }"},
+	})
+	analyze([]*DatagenParsed{users, orders})
+
+	vars := cassandraVars(users)
+	assert.Empty(t, vars.CreateTableError)
+	assert.Equal(t, `"app"."users"`, vars.Table)
+	assert.Equal(t, []string{"id"}, vars.KeyColumns, "the referenced field is the primary key")
+	types := map[string]string{}
+	for _, c := range vars.Columns {
+		types[c.QuotedColumn] = c.ColumnType
+	}
+	assert.Equal(t, map[string]string{
+		`"id"`:         "bigint",
+		`"e""mail"`:    "text",
+		`"level"`:      "smallint",
+		`"balance"`:    "varint",
+		`"created_at"`: "timestamp",
+		`"tags"`:       "list<text>",
+		`"matrix"`:     "list<frozen<list<int>>>",
+		`"attributes"`: "map<text, frozen<list<float>>>",
+		`"address"`:    "text",
+		`"avatar"`:     "blob",
+	}, types)
+
+	vars = cassandraVars(orders)
+	assert.Equal(t, `"orders"`, vars.Table)
+	assert.Empty(t, vars.KeyColumns, "tables without a referenced field have no default key")
+}
@@ -0,0 +1,153 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// clickhouseTypes maps the kinds of valueType to the ClickHouse column types
+// storing them. Bytes are stored as the base64 text JSON writes them as.
+var clickhouseTypes = map[string]string{
+	"bool":     "Bool",
+	"int8":     "Int8",
+	"int16":    "Int16",
+	"int32":    "Int32",
+	"int":      "Int64",
+	"int64":    "Int64",
+	"uint8":    "UInt8",
+	"uint16":   "UInt16",
+	"uint32":   "UInt32",
+	"uint":     "UInt64",
+	"uint64":   "UInt64",
+	"float32":  "Float32",
+	"float64":  "Float64",
+	kindString: "String",
+	kindBytes:  "String",
+	kindTime:   "DateTime64(6)",
+}
+
+// lowCardinalityWords are the words of field names holding one of a few
+// distinct strings, which ClickHouse stores as dictionary codes.
+var lowCardinalityWords = map[string]bool{
+	"category": true,
+	"country":  true,
+	"currency": true,
+	"gender":   true,
+	"kind":     true,
+	"language": true,
+	"level":    true,
+	"locale":   true,
+	"region":   true,
+	"role":     true,
+	"state":    true,
+	"status":   true,
+	"tier":     true,
+	"type":     true,
+}
+
+// clickhouseVars returns the template variables of the ClickHouse sink of the
+// model, with its table and columns quoted and its CREATE TABLE statement.
+func clickhouseVars(d *DatagenParsed) templateVars {
+	vars := fieldsVars(d)
+	for i := range vars.Columns {
+		vars.Columns[i].QuotedColumn = clickhouseIdent(vars.Columns[i].Column)
+	}
+	schema, table := d.Metadata.table(d.ModelName)
+	vars.Table = clickhouseTable(schema, table)
+	stmt, err := d.clickhouseCreateTable()
+	if err != nil {
+		vars.CreateTableError = err.Error()
+		return vars
+	}
+	vars.CreateTable = stmt
+	return vars
+}
+
+// clickhouseCreateTable returns the CREATE TABLE statement of the model in
+// ClickHouse: a MergeTree table ordered by the primary key the SQL sinks
+// give it, if any, with column types derived from the Go types of its fields.
+func (d *DatagenParsed) clickhouseCreateTable() (string, error) {
+	t, err := d.buildTable()
+	if err != nil {
+		return "", err
+	}
+	keys := map[string]bool{}
+	for _, c := range t.columns {
+		keys[c.name] = c.key
+	}
+
+	var lines []string
+	if d.Fields != nil {
+		for _, field := range d.Fields.List {
+			typ, err := d.miscTypes.valueTypeOf(fieldType(field.Type))
+			if err != nil {
+				return "", fmt.Errorf("unsupported field type\n  model: %s\n  field: %s\n  cause: %w", d.FullyQualifiedModelName, field.Names[0].Name, err)
+			}
+			for _, name := range field.Names {
+				column, ok := d.Metadata.column(name.Name)
+				if !ok {
+					continue
+				}
+				lowCardinality := !keys[column] && isLowCardinality(name.Name)
+				lines = append(lines, "  "+clickhouseIdent(column)+" "+clickhouseType(typ, lowCardinality))
+			}
+		}
+	}
+
+	orderBy := "tuple()"
+	if t.primaryKey != "" {
+		orderBy = "(" + clickhouseIdent(t.primaryKey) + ")"
+	}
+	return "CREATE TABLE IF NOT EXISTS " + clickhouseTable(t.schema, t.name) + " (\n" + strings.Join(lines, ",\n") +
+		"\n) ENGINE = MergeTree\nORDER BY " + orderBy, nil
+}
+
+// clickhouseType returns the column type of values of type typ. Lists become
+// arrays and maps maps, whose string elements and keys are low cardinality,
+// as tags and attribute names usually are, and structs are stored as JSON
+// text. Only pointers to scalars are nullable, as arrays and maps cannot be.
+func clickhouseType(typ *valueType, lowCardinality bool) string {
+	var s string
+	switch typ.kind {
+	case kindList:
+		return "Array(" + clickhouseType(typ.elem, true) + ")"
+	case kindMap:
+		return "Map(" + clickhouseType(typ.key, true) + ", " + clickhouseType(typ.elem, false) + ")"
+	case kindRecord:
+		s = "String"
+	default:
+		s = clickhouseTypes[typ.kind]
+	}
+	if typ.nullable {
+		s = "Nullable(" + s + ")"
+	}
+	if lowCardinality && typ.kind == kindString {
+		s = "LowCardinality(" + s + ")"
+	}
+	return s
+}
+
+// isLowCardinality reports whether a word of the name of a string field says
+// it holds one of a few distinct values.
+func isLowCardinality(name string) bool {
+	for _, word := range nameWords(name) {
+		if lowCardinalityWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// clickhouseIdent quotes a table or column name for ClickHouse.
+func clickhouseIdent(name string) string {
+	return "`" + strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(name) + "`"
+}
+
+// clickhouseTable returns the quoted name of a table, prefixed with its
+// database when it has one.
+func clickhouseTable(database, name string) string {
+	if database == "" {
+		return clickhouseIdent(name)
+	}
+	return clickhouseIdent(database) + "." + clickhouseIdent(name)
+}
@@ -0,0 +1,54 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+func TestClickHouseCreateTable(t *testing.T) {
+	users := typedModel(t, "users", [][3]string{
+		{"id", "int64", "{ return int64(iter) }"},
+		{"country", "string", "{ return \"in\" }"},
+		{"email", "*string", "{ return nil }"},
+		{"account_status", "*string", "{ return nil }"},
+		{"level", "uint8", "{ return 1 }"},
+		{"created_at", "time.Time", "{ return Date() }"},
+		{"tags", "[]string", "{ return nil }"},
+		{"scores", "[]*float32", "{ return nil }"},
+		{"attributes", "map[string]string", "{ return nil }"},
+		{"address", "Address", "{ return Address{} }"},
+		{"avatar", "[]byte", "{ return nil }"},
+		{"scratch", "bool", "{ return true }"},
+	})
+	users.Misc = "type Address struct {\n City string\n}"
+	users.Metadata = &Metadata{Schema: "app", Columns: map[string]string{"scratch": "-", "email": "e`mail"}}
+	orders := typedModel(t, "shop"+utils.DgDirDelimeter+"orders", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"user_id", "int64", "{ return self.datagen.users().id(iter) }"},
+	})
+	analyze([]*DatagenParsed{users, orders})
+
+	stmt, err := users.clickhouseCreateTable()
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS `app`.`users` (\n"+
+		"  `id` Int64,\n"+
+		"  `country` LowCardinality(String),\n"+
+		"  `e\\`mail` Nullable(String),\n"+
+		"  `account_status` LowCardinality(Nullable(String)),\n"+
+		"  `level` UInt8,\n"+
+		"  `created_at` DateTime64(6),\n"+
+		"  `tags` Array(LowCardinality(String)),\n"+
+		"  `scores` Array(Nullable(Float32)),\n"+
+		"  `attributes` Map(LowCardinality(String), String),\n"+
+		"  `address` String,\n"+
+		"  `avatar` String\n"+
+		") ENGINE = MergeTree\nORDER BY (`id`)", stmt)
+
+	stmt, err = orders.clickhouseCreateTable()
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS `orders` (\n  `id` Int64,\n  `user_id` Int64\n) ENGINE = MergeTree\nORDER BY tuple()", stmt)
+}
@@ -10,6 +10,7 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/dream-horizon-org/datagen/utils"
@@ -19,32 +20,90 @@ import (
 var templates embed.FS
 
 type fieldData struct {
-	Name     string
-	Type     string
-	InitArgs string
+	Name         string
+	Type         string
+	InitArgs     string
+	InitArgsRand bool
+	Memoized     bool
+	// Pure is set for fields whose values only depend on their iter, so that
+	// their memo may generate a value again rather than keep it.
+	Pure bool
+	// Column is the name the field is stored and written under, and
+	// QuotedColumn that name quoted in the dialect of the sink being rendered.
+	Column       string
+	QuotedColumn string
+	// ColumnType is the type of the column in sinks whose tables are created
+	// at runtime.
+	ColumnType string
+	Persisted  bool
 }
 
 type templateVars struct {
 	ModelName               string
 	FullyQualifiedModelName string
+	DottedModelName         string
 	Fields                  []fieldData
-	Metadata                Metadata
+	// Columns holds the fields that are persisted, in order.
+	Columns  []fieldData
+	Metadata Metadata
+	// Table is the quoted name of the table records are loaded into, in the
+	// dialect of the sink being rendered.
+	Table string
+	// CreateTable is the CREATE TABLE statement of the model in the dialect
+	// of the sink being rendered, or CreateTableError why there is none.
+	CreateTable      string
+	CreateTableError string
+	// KeyColumns name the primary key of the table, which upserts match rows
+	// on unless the config names other key columns.
+	KeyColumns []string
+	// Schema is the schema of the records in the format being rendered, or
+	// SchemaError why there is none.
+	Schema      string
+	SchemaError string
+	// SQLTables holds the statements of the table of the model in every
+	// dialect, for the SQL dump format.
+	SQLTables []sqlTableVars
+	// JSONFields are the fields written to JSON documents, in order, and
+	// JSONEmbeds the models nested in them.
+	JSONFields []jsonFieldVars
+	JSONEmbeds []jsonEmbedVars
+	// XMLFields are the fields written to XML elements, in order.
+	XMLFields []xmlFieldVars
+	// IndexMapping is the body creating the Elasticsearch index of the model,
+	// or IndexMappingError why there is none.
+	IndexMapping      string
+	IndexMappingError string
+}
+
+// sqlTableVars are the statements writing the records of a model to its
+// table in one dialect.
+type sqlTableVars struct {
+	Dialect Dialect
+	// Insert is the INSERT statement up to and including VALUES.
+	Insert           string
+	Truncate         string
+	CreateTable      string
+	CreateTableError string
 }
 
 type wrapperFuncData struct {
 	ModelName               string
 	FullyQualifiedModelName string
+	DottedModelName         string
 	FieldName               string
 	FieldType               string
 	GenFuncParams           string
 	GenFuncVars             string
 	GenFuncBody             string
+	UsesRand                bool
 }
 
 type GenFn struct {
-	Name  string
-	Calls *ast.CallExpr
-	Body  *ast.BlockStmt
+	Name string
+	// NamePos is the position of the name in the .dg file, in Source.Fset.
+	NamePos token.Pos
+	Calls   *ast.CallExpr
+	Body    *ast.BlockStmt
 }
 
 type DatagenParsed struct {
@@ -56,11 +115,37 @@ type DatagenParsed struct {
 	Calls                   []*ast.CallExpr
 	Metadata                *Metadata
 	Filepath                string
+	// Source maps the positions of the parsed nodes back to the .dg file,
+	// and ModelNamePos is the position of the model name in it.
+	Source       *Source
+	ModelNamePos token.Pos
+
+	references *references
+	randFuncs  randFuncs
+	impure     *impureHelpers
+	miscTypes  miscTypes
 }
 
 type Metadata struct {
 	Count int
 	Tags  map[string]string
+	// Table and Schema name the table records are loaded into, which is the
+	// model name in the default schema when they are empty.
+	Table  string
+	Schema string
+	// Columns maps field names to the column they are stored in, or to "-"
+	// for fields that are generated but not persisted.
+	Columns map[string]string
+	// JSONKeys maps field names to the key they are written under in JSON,
+	// which is their column when they have no entry.
+	JSONKeys map[string]string
+	// Embed maps JSON keys to the models whose records referencing a record
+	// of this model are nested under that key in its JSON documents. A model
+	// referencing it through several fields is followed by the field to match.
+	Embed map[string]string
+	// XML maps field names to the node they are written as in XML, an
+	// attribute or an element, which is the default.
+	XML map[string]string
 }
 
 func getMetadata(d *DatagenParsed) Metadata {
@@ -86,6 +171,7 @@ func getFieldData(d *DatagenParsed) []fieldData {
 		for _, field := range d.Fields.List {
 			for _, name := range field.Names {
 				initArgs := ""
+				initArgsRand := false
 				for _, call := range d.Calls {
 					if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == name.Name {
 						var argsBuf bytes.Buffer
@@ -93,20 +179,33 @@ func getFieldData(d *DatagenParsed) []fieldData {
 							if i > 0 {
 								argsBuf.WriteString(", ")
 							}
-							err := printer.Fprint(&argsBuf, token.NewFileSet(), arg)
+							var argBuf bytes.Buffer
+							if err := printer.Fprint(&argBuf, token.NewFileSet(), arg); err != nil {
+								return nil
+							}
+							bound, usesRand, err := d.randHelpers().bindExpr(argBuf.String())
 							if err != nil {
 								return nil
 							}
+							argsBuf.WriteString(bound)
+							initArgsRand = initArgsRand || usesRand
 						}
 						initArgs = argsBuf.String()
 						break
 					}
 				}
 
+				column, persisted := d.Metadata.column(name.Name)
+				memoized := d.references != nil && d.references.memoized(d.FullyQualifiedModelName, name.Name)
 				fields = append(fields, fieldData{
-					Name:     name.Name,
-					Type:     getTypeString(field.Type),
-					InitArgs: initArgs,
+					Name:         name.Name,
+					Column:       column,
+					Persisted:    persisted,
+					Type:         getTypeString(field.Type),
+					InitArgs:     initArgs,
+					InitArgsRand: initArgsRand,
+					Memoized:     memoized,
+					Pure:         memoized && d.pure(name.Name),
 				})
 			}
 		}
@@ -168,7 +267,90 @@ func findFieldTypeAndName(d *DatagenParsed, fieldName string) (string, string) {
 }
 
 func fieldsVars(d *DatagenParsed) templateVars {
-	return templateVars{ModelName: d.ModelName, Fields: getFieldData(d), FullyQualifiedModelName: d.FullyQualifiedModelName}
+	fields := getFieldData(d)
+	var columns []fieldData
+	for _, f := range fields {
+		if f.Persisted {
+			columns = append(columns, f)
+		}
+	}
+	return templateVars{ModelName: d.ModelName, Fields: fields, Columns: columns, FullyQualifiedModelName: d.FullyQualifiedModelName, DottedModelName: d.dottedModelName()}
+}
+
+// sinkVars returns the template variables of a SQL sink of the model, with
+// its table and columns quoted and its CREATE TABLE statement in that sink's
+// dialect.
+func sinkVars(d *DatagenParsed, dialect Dialect) templateVars {
+	vars := fieldsVars(d)
+	for i := range vars.Columns {
+		vars.Columns[i].QuotedColumn = quoteIdent(dialect, vars.Columns[i].Column)
+	}
+	schema, table := d.Metadata.table(d.ModelName)
+	vars.Table = qualifiedTable(dialect, schema, table)
+	t, err := d.buildTable()
+	if err != nil {
+		vars.CreateTableError = err.Error()
+		return vars
+	}
+	vars.CreateTable = t.createStatement(dialect)
+	if t.primaryKey != "" {
+		vars.KeyColumns = []string{t.primaryKey}
+	}
+	return vars
+}
+
+// sqlVars returns the template variables of the SQL dump of the model, with
+// the statements of its table in every dialect.
+func sqlVars(d *DatagenParsed) templateVars {
+	vars := fieldsVars(d)
+	for _, dialect := range Dialects {
+		sink := sinkVars(d, dialect)
+		columns := make([]string, 0, len(sink.Columns))
+		for _, c := range sink.Columns {
+			columns = append(columns, c.QuotedColumn)
+		}
+		vars.SQLTables = append(vars.SQLTables, sqlTableVars{
+			Dialect:          dialect,
+			Insert:           "INSERT INTO " + sink.Table + " (" + strings.Join(columns, ", ") + ") VALUES",
+			Truncate:         truncateStatement(dialect, sink.Table),
+			CreateTable:      sink.CreateTable,
+			CreateTableError: sink.CreateTableError,
+		})
+	}
+	return vars
+}
+
+// randHelpers returns the functions that are passed a random stream when the
+// model calls them. Models compiled on their own only get the stdlib helpers.
+func (d *DatagenParsed) randHelpers() randFuncs {
+	if d.randFuncs == nil {
+		return stdlibRandFuncs
+	}
+	return d.randFuncs
+}
+
+// pure reports whether the values of field only depend on their iter: its gen
+// function draws from nothing but the stream of the field and reads no other
+// row of its own model. Models compiled on their own are never pure, as the
+// helpers they call are not known.
+func (d *DatagenParsed) pure(field string) bool {
+	if d.references == nil || d.impure == nil || !d.references.standalone(d.FullyQualifiedModelName, field) {
+		return false
+	}
+	for _, genFn := range d.GenFuns {
+		if genFn.Name != field || genFn.Body == nil {
+			continue
+		}
+		body, err := resolveBody(token.NewFileSet(), genFn.Body)
+		return err == nil && d.impure.pure(body, d.randHelpers(), true)
+	}
+	return false
+}
+
+// dottedModelName is the name a model goes by at runtime, with directories
+// separated by dots.
+func (d *DatagenParsed) dottedModelName() string {
+	return strings.ReplaceAll(d.FullyQualifiedModelName, utils.DgDirDelimeter, ".")
 }
 
 func metadataVars(d *DatagenParsed) templateVars {
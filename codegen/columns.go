@@ -0,0 +1,104 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// skipColumn is the column fields are mapped to in the metadata section to
+// keep them out of the loaded and written records. Such fields are still
+// generated and can be referenced by other fields.
+const skipColumn = "-"
+
+// column returns the column a field is stored in, and false when the field
+// is not persisted.
+func (m *Metadata) column(field string) (string, bool) {
+	if m == nil {
+		return field, true
+	}
+	name, ok := m.Columns[field]
+	if !ok {
+		return field, true
+	}
+	return name, name != skipColumn
+}
+
+// table returns the schema and name of the table the records of the model
+// named modelName are loaded into.
+func (m *Metadata) table(modelName string) (string, string) {
+	if m == nil {
+		return "", modelName
+	}
+	if m.Table == "" {
+		return m.Schema, modelName
+	}
+	return m.Schema, m.Table
+}
+
+// checkColumns reports column mappings of fields the model does not have,
+// fields that would be stored in the same column, and models none of whose
+// fields are persisted.
+func (d *DatagenParsed) checkColumns() error {
+	if d.Metadata == nil || len(d.Metadata.Columns) == 0 {
+		return nil
+	}
+
+	fields := map[string]struct{}{}
+	var names []string
+	if d.Fields != nil {
+		for _, field := range d.Fields.List {
+			for _, name := range field.Names {
+				fields[name.Name] = struct{}{}
+				names = append(names, name.Name)
+			}
+		}
+	}
+
+	mapped := make([]string, 0, len(d.Metadata.Columns))
+	for field := range d.Metadata.Columns {
+		mapped = append(mapped, field)
+	}
+	sort.Strings(mapped)
+	for _, field := range mapped {
+		if _, ok := fields[field]; !ok {
+			return fmt.Errorf("column mapped for a field the model does not have\n  model: %s\n  field: %s", d.FullyQualifiedModelName, field)
+		}
+		if strings.TrimSpace(d.Metadata.Columns[field]) == "" {
+			return fmt.Errorf("empty column name\n  model: %s\n  field: %s", d.FullyQualifiedModelName, field)
+		}
+	}
+
+	stored := map[string]string{}
+	for _, field := range names {
+		column, ok := d.Metadata.column(field)
+		if !ok {
+			continue
+		}
+		if other, ok := stored[column]; ok {
+			return fmt.Errorf("fields are stored in the same column\n  model: %s\n  column: %s\n  fields: %s, %s", d.FullyQualifiedModelName, column, other, field)
+		}
+		stored[column] = field
+	}
+	if len(stored) == 0 && len(names) > 0 {
+		return fmt.Errorf("no field of the model is persisted\n  model: %s", d.FullyQualifiedModelName)
+	}
+	return nil
+}
+
+// quoteIdent quotes a table or column name in the given dialect.
+func quoteIdent(dialect Dialect, name string) string {
+	if dialect == DialectMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// qualifiedTable returns the quoted name of a table, prefixed with its schema
+// when it has one.
+func qualifiedTable(dialect Dialect, schema, name string) string {
+	if schema == "" {
+		return quoteIdent(dialect, name)
+	}
+	return quoteIdent(dialect, schema) + "." + quoteIdent(dialect, name)
+}
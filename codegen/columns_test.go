@@ -0,0 +1,71 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataColumn(t *testing.T) {
+	var none *Metadata
+	column, ok := none.column("email")
+	assert.True(t, ok)
+	assert.Equal(t, "email", column)
+
+	m := &Metadata{Columns: map[string]string{"email": "E-Mail Address", "helper": "-"}}
+	column, ok = m.column("email")
+	assert.True(t, ok)
+	assert.Equal(t, "E-Mail Address", column)
+
+	_, ok = m.column("helper")
+	assert.False(t, ok)
+
+	schema, name := m.table("users")
+	assert.Equal(t, "", schema)
+	assert.Equal(t, "users", name)
+
+	schema, name = (&Metadata{Table: "user_accounts", Schema: "billing"}).table("users")
+	assert.Equal(t, "billing", schema)
+	assert.Equal(t, "user_accounts", name)
+}
+
+func TestCheckColumns(t *testing.T) {
+	fields := [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"email", "string", "{ return Email() }"},
+	}
+
+	tests := []struct {
+		name    string
+		columns map[string]string
+		errStr  string
+	}{
+		{name: "renamed and skipped", columns: map[string]string{"email": "E-Mail", "id": "-"}},
+		{name: "unknown field", columns: map[string]string{"mail": "E-Mail"}, errStr: "field: mail"},
+		{name: "empty column", columns: map[string]string{"email": " "}, errStr: "empty column name"},
+		{name: "same column", columns: map[string]string{"email": "id"}, errStr: "column: id\n  fields: id, email"},
+		{name: "nothing persisted", columns: map[string]string{"email": "-", "id": "-"}, errStr: "no field of the model is persisted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := typedModel(t, "users", fields)
+			m.Metadata = &Metadata{Columns: tt.columns}
+
+			err := m.checkColumns()
+			if tt.errStr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errStr)
+		})
+	}
+}
+
+func TestQuoteIdent(t *testing.T) {
+	assert.Equal(t, "`a``b`", quoteIdent(DialectMySQL, "a`b"))
+	assert.Equal(t, `"a""b"`, quoteIdent(DialectPostgres, `a"b`))
+	assert.Equal(t, `"billing"."users"`, qualifiedTable(DialectPostgres, "billing", "users"))
+}
@@ -0,0 +1,239 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"strings"
+	"unicode/utf8"
+)
+
+// Diagnostic is an error at a position of a .dg file.
+type Diagnostic struct {
+	// Pos is the position of the error in the .dg file. Its line and column
+	// are 1-based.
+	Pos token.Position
+	// Len is the number of bytes underlined in the excerpt, at least one.
+	Len int
+	Msg string
+	// Source is the line of the .dg file the error is on.
+	Source string
+}
+
+// Error renders the diagnostic as file:line:col, followed by the line it is
+// on with the error underlined.
+func (d *Diagnostic) Error() string {
+	if !d.Pos.IsValid() {
+		return d.Msg
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", d.Pos, d.Msg)
+	if d.Source == "" {
+		return b.String()
+	}
+
+	col := min(d.Pos.Column-1, len(d.Source))
+	length := max(1, min(d.Len, len(d.Source)-col))
+	b.WriteString("\n    ")
+	b.WriteString(d.Source)
+	b.WriteString("\n    ")
+	// tabs are kept so the caret lines up however wide they are shown
+	for _, r := range d.Source[:col] {
+		if r == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	b.WriteByte('^')
+	b.WriteString(strings.Repeat("~", max(0, utf8.RuneCountInString(d.Source[col:col+length])-1)))
+	return b.String()
+}
+
+// Diagnostics returns the diagnostics err carries: err itself, or those of
+// the errors it wraps or joins.
+func Diagnostics(err error) []*Diagnostic {
+	if err == nil {
+		return nil
+	}
+	if d, ok := err.(*Diagnostic); ok {
+		return []*Diagnostic{d}
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		var diags []*Diagnostic
+		for _, inner := range e.Unwrap() {
+			diags = append(diags, Diagnostics(inner)...)
+		}
+		return diags
+	case interface{ Unwrap() error }:
+		return Diagnostics(e.Unwrap())
+	}
+	return nil
+}
+
+// snippet is a piece of Go code from a .dg file, parsed wrapped in synthetic
+// code that makes it a complete expression.
+type snippet struct {
+	base, size int
+	// offset is where the snippet starts in the .dg file, prefix the number
+	// of bytes the wrapping adds before it.
+	offset, prefix, length int
+}
+
+// Source is a .dg file and the positions of the Go snippets parsed from it.
+// The file and every snippet are files of Fset, so the positions of the
+// parsed nodes map back to the .dg file.
+type Source struct {
+	Fset     *token.FileSet
+	file     *token.File
+	text     string
+	snippets []snippet
+}
+
+// NewSource registers the text of the .dg file at path.
+func NewSource(path string, text []byte) *Source {
+	fset := token.NewFileSet()
+	file := fset.AddFile(path, -1, len(text))
+	file.SetLinesForContent(text)
+	return &Source{Fset: fset, file: file, text: string(text)}
+}
+
+// Pos returns the position of an offset of the .dg file.
+func (s *Source) Pos(offset int) token.Pos {
+	if s == nil {
+		return token.NoPos
+	}
+	return s.file.Pos(max(0, min(offset, s.file.Size())))
+}
+
+// AddSnippet records that the file of Fset at base, of size bytes, holds the
+// snippet found at offset in the .dg file behind prefix bytes of wrapping.
+func (s *Source) AddSnippet(base, size, offset, prefix, length int) {
+	s.snippets = append(s.snippets, snippet{base: base, size: size, offset: offset, prefix: prefix, length: length})
+}
+
+// Offset returns the offset in the .dg file of a position of the file or of
+// one of its snippets. Positions in the wrapping of a snippet map to its
+// nearest end.
+func (s *Source) Offset(pos token.Pos) (int, bool) {
+	if s == nil || !pos.IsValid() {
+		return 0, false
+	}
+	if base := s.file.Base(); int(pos) >= base && int(pos) <= base+s.file.Size() {
+		return int(pos) - base, true
+	}
+	for _, sn := range s.snippets {
+		if int(pos) >= sn.base && int(pos) <= sn.base+sn.size {
+			return sn.offset + max(0, min(int(pos)-sn.base-sn.prefix, sn.length)), true
+		}
+	}
+	return 0, false
+}
+
+// Errorf returns a diagnostic at pos, underlining length bytes.
+func (s *Source) Errorf(pos token.Pos, length int, format string, args ...any) error {
+	d := &Diagnostic{Len: length, Msg: fmt.Sprintf(format, args...)}
+	offset, ok := s.Offset(pos)
+	if !ok {
+		return d
+	}
+	d.Pos = s.file.Position(s.file.Pos(offset))
+	start := strings.LastIndexByte(s.text[:offset], '\n') + 1
+	end := strings.IndexByte(s.text[offset:], '\n')
+	if end < 0 {
+		end = len(s.text)
+	} else {
+		end += offset
+	}
+	d.Source = strings.TrimRight(s.text[start:end], "\r")
+	return d
+}
+
+// ErrorAtNode returns a diagnostic underlining a parsed node.
+func (s *Source) ErrorAtNode(node ast.Node, format string, args ...any) error {
+	return s.Errorf(node.Pos(), int(node.End()-node.Pos()), format, args...)
+}
+
+// MapScannerErrors maps the first error go/parser reports for the snippet at
+// base to a diagnostic in the .dg file; the errors after it mostly follow
+// from it. Other errors are returned as they are.
+func (s *Source) MapScannerErrors(base int, err error) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return err
+	}
+	list.Sort()
+	return s.Errorf(token.Pos(base+list[0].Pos.Offset), 1, "%s", list[0].Msg)
+}
+
+// GenBodyPos maps pos, a position in the body generated for the gen function
+// of field, back to the .dg file, and returns it with the number of bytes to
+// underline there. The generated body only differs from the parsed one by the
+// random streams passed to stdlib calls, so the nodes of both are matched up
+// in order and pos is mapped from the innermost node holding it. Positions
+// that cannot be matched map to the name of the gen function.
+func (d *DatagenParsed) GenBodyPos(field string, generated *ast.BlockStmt, pos token.Pos) (token.Pos, int) {
+	var gen *GenFn
+	for _, g := range d.GenFuns {
+		if g != nil && g.Name == field {
+			gen = g
+			break
+		}
+	}
+	if gen == nil {
+		return token.NoPos, 0
+	}
+	if gen.Body == nil || generated == nil {
+		return gen.NamePos, len(gen.Name)
+	}
+
+	parsed, bound := genBodyNodes(gen.Body), genBodyNodes(generated)
+	if len(parsed) != len(bound) {
+		return gen.NamePos, len(gen.Name)
+	}
+	at := -1
+	for i, n := range bound {
+		// nodes are in preorder, so the last one holding pos is the innermost
+		if n.Pos() <= pos && pos < n.End() {
+			at = i
+		}
+	}
+	if at < 0 {
+		return gen.NamePos, len(gen.Name)
+	}
+
+	node, orig := bound[at], parsed[at]
+	switch {
+	case pos == node.Pos():
+		return orig.Pos(), int(orig.End() - orig.Pos())
+	case isRbrace(node, pos):
+		return orig.End() - 1, 1
+	}
+	return orig.Pos() + min(pos-node.Pos(), orig.End()-orig.Pos()-1), 1
+}
+
+// genBodyNodes lists the nodes of a gen function body in the order
+// ast.Inspect visits them, leaving out the random streams codegen adds.
+func genBodyNodes(body *ast.BlockStmt) []ast.Node {
+	var nodes []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == fieldRandIdent {
+			return false
+		}
+		nodes = append(nodes, n)
+		return true
+	})
+	return nodes
+}
+
+func isRbrace(node ast.Node, pos token.Pos) bool {
+	block, ok := node.(*ast.BlockStmt)
+	return ok && block.Rbrace == pos
+}
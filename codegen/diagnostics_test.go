@@ -0,0 +1,70 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticError(t *testing.T) {
+	src := NewSource("models/users.dg", []byte("model users {\n\tfields {\n\t\tid() strin g\n\t}\n}\n"))
+
+	err := src.Errorf(src.Pos(31), 5, "unknown type %s", "strin")
+	assert.Equal(t, "models/users.dg:3:8: unknown type strin\n    \t\tid() strin g\n    \t\t     ^~~~~", err.Error())
+
+	t.Run("underline stops at the end of the line", func(t *testing.T) {
+		err := src.Errorf(src.Pos(37), 100, "trailing")
+		assert.Equal(t, "models/users.dg:3:14: trailing\n    \t\tid() strin g\n    \t\t           ^", err.Error())
+	})
+
+	t.Run("without position", func(t *testing.T) {
+		var none *Source
+		assert.Equal(t, "no position", none.Errorf(none.Pos(3), 1, "no position").Error())
+	})
+}
+
+func TestSourceSnippets(t *testing.T) {
+	text := "model m {\n  gens {\n    func id() {\n      return iter +\n    }\n  }\n}\n"
+	src := NewSource("m.dg", []byte(text))
+
+	body := "return iter +\n    "
+	offset := 41
+	require.Equal(t, body, text[offset:offset+len(body)])
+	wrapped := "func() {\n" + body + "\n}"
+
+	base := src.Fset.Base()
+	_, err := parser.ParseExprFrom(src.Fset, "", wrapped, 0)
+	require.Error(t, err)
+	src.AddSnippet(base, len(wrapped), offset, len("func() {\n"), len(body))
+
+	got, ok := src.Offset(token.Pos(base + 9 + 7)) // the start of iter in the wrapped code
+	require.True(t, ok)
+	assert.Equal(t, offset+7, got)
+
+	got, ok = src.Offset(token.Pos(base + 1)) // inside the wrapping
+	require.True(t, ok)
+	assert.Equal(t, offset, got)
+
+	diag := src.MapScannerErrors(base, err)
+	diags := Diagnostics(diag)
+	require.Len(t, diags, 1)
+	assert.Equal(t, 5, diags[0].Pos.Line)
+	assert.Equal(t, "m.dg", diags[0].Pos.Filename)
+}
+
+func TestDiagnostics(t *testing.T) {
+	src := NewSource("a.dg", []byte("model a {}\n"))
+	first := src.Errorf(src.Pos(6), 1, "first")
+	second := src.Errorf(src.Pos(8), 1, "second")
+
+	assert.Nil(t, Diagnostics(nil))
+	assert.Nil(t, Diagnostics(errors.New("plain")))
+	assert.Len(t, Diagnostics(first), 1)
+	assert.Len(t, Diagnostics(fmt.Errorf("wrapped: %w", first)), 1)
+	assert.Len(t, Diagnostics(errors.Join(first, errors.New("plain"), second)), 2)
+}
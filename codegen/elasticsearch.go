@@ -0,0 +1,150 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// indexFieldTypes maps the kinds of valueType to the Elasticsearch field types
+// storing them. Strings are keywords or text, see stringMapping.
+var indexFieldTypes = map[string]string{
+	"bool":    "boolean",
+	"int8":    "byte",
+	"int16":   "short",
+	"int32":   "integer",
+	"int":     "long",
+	"int64":   "long",
+	"uint8":   "short",
+	"uint16":  "integer",
+	"uint32":  "long",
+	"uint":    "unsigned_long",
+	"uint64":  "unsigned_long",
+	"float32": "float",
+	"float64": "double",
+	kindTime:  "date",
+	kindBytes: "binary",
+}
+
+// textWords are the words of field names holding free text, which is
+// analysed for full-text search rather than matched as a whole.
+var textWords = map[string]bool{
+	"bio":         true,
+	"body":        true,
+	"comment":     true,
+	"content":     true,
+	"description": true,
+	"message":     true,
+	"note":        true,
+	"review":      true,
+	"summary":     true,
+	"text":        true,
+	"title":       true,
+}
+
+// indexVars returns the template variables of the Elasticsearch sink of the
+// model, whose documents are its JSON documents, and its index mapping.
+func indexVars(d *DatagenParsed) templateVars {
+	vars := jsonVars(d)
+	mapping, err := d.indexMapping()
+	if err != nil {
+		vars.IndexMappingError = err.Error()
+		return vars
+	}
+	vars.IndexMapping = mapping
+	return vars
+}
+
+// indexMapping returns the body creating the Elasticsearch index of the
+// model, mapping the fields of its documents, keyed as in JSON output, from
+// their Go types.
+func (d *DatagenParsed) indexMapping() (string, error) {
+	if err := d.checkColumns(); err != nil {
+		return "", err
+	}
+	properties := map[string]any{}
+	if d.Fields != nil {
+		for _, field := range d.Fields.List {
+			typ, err := d.miscTypes.valueTypeOf(fieldType(field.Type))
+			if err != nil {
+				return "", fmt.Errorf("unsupported field type\n  model: %s\n  field: %s\n  cause: %w", d.FullyQualifiedModelName, field.Names[0].Name, err)
+			}
+			for _, name := range field.Names {
+				column, ok := d.Metadata.column(name.Name)
+				if !ok {
+					continue
+				}
+				key := d.Metadata.jsonKey(name.Name, column)
+				properties[key] = fieldMapping(key, typ)
+			}
+		}
+	}
+	body, err := json.Marshal(map[string]any{"mappings": map[string]any{"properties": properties}})
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// fieldMapping returns the mapping of a field named name holding values of
+// type typ. Lists are mapped as their elements, as every field may hold
+// several values, and maps as objects whose fields are mapped dynamically.
+func fieldMapping(name string, typ *valueType) map[string]any {
+	switch typ.kind {
+	case kindString:
+		return stringMapping(name)
+	case kindList:
+		return fieldMapping(name, typ.elem)
+	case kindMap:
+		return map[string]any{"type": "object"}
+	case kindRecord:
+		properties := map[string]any{}
+		for _, f := range typ.fields {
+			if f.key != "-" {
+				properties[f.key] = fieldMapping(f.key, f.typ)
+			}
+		}
+		return map[string]any{"properties": properties}
+	}
+	return map[string]any{"type": indexFieldTypes[typ.kind]}
+}
+
+// stringMapping maps strings as keywords, matched as a whole, unless a word
+// of the name of the field says it holds free text, which is mapped as text
+// with a keyword subfield, as Elasticsearch maps strings it has not seen.
+func stringMapping(name string) map[string]any {
+	for _, word := range nameWords(name) {
+		if textWords[word] || textWords[strings.TrimSuffix(word, "s")] {
+			return map[string]any{
+				"type":   "text",
+				"fields": map[string]any{"keyword": map[string]any{"type": "keyword", "ignore_above": 256}},
+			}
+		}
+	}
+	return map[string]any{"type": "keyword"}
+}
+
+// nameWords splits a snake, kebab or camel case name into lower case words.
+func nameWords(name string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	for i, r := range []rune(name) {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case i > 0 && unicode.IsUpper(r) && len(word) > 0 && !unicode.IsUpper(word[len(word)-1]):
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexMapping(t *testing.T) {
+	articles := typedModel(t, "articles", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"title", "string", "{ return \"\" }"},
+		{"author_name", "string", "{ return \"\" }"},
+		{"ReviewNotes", "*string", "{ return nil }"},
+		{"published_at", "time.Time", "{ return Date() }"},
+		{"rating", "float32", "{ return 0 }"},
+		{"tags", "[]string", "{ return nil }"},
+		{"attributes", "map[string]string", "{ return nil }"},
+		{"source", "Source", "{ return Source{} }"},
+		{"scratch", "string", "{ return \"\" }"},
+	})
+	articles.Misc = "type Source struct {\n Host string `json:\"host_name\"`\n Port uint16\n Secret string `json:\"-\"`\n}"
+	articles.Metadata = &Metadata{
+		Columns:  map[string]string{"scratch": "-"},
+		JSONKeys: map[string]string{"published_at": "publishedAt"},
+	}
+	analyze([]*DatagenParsed{articles})
+
+	mapping, err := articles.indexMapping()
+	require.NoError(t, err)
+
+	var expected any
+	require.NoError(t, json.Unmarshal([]byte(`{"mappings": {"properties": {
+		"id": {"type": "long"},
+		"title": {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}},
+		"author_name": {"type": "keyword"},
+		"ReviewNotes": {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}},
+		"publishedAt": {"type": "date"},
+		"rating": {"type": "float"},
+		"tags": {"type": "keyword"},
+		"attributes": {"type": "object"},
+		"source": {"properties": {"host_name": {"type": "keyword"}, "Port": {"type": "integer"}}}
+	}}}`), &expected))
+
+	var actual any
+	require.NoError(t, json.Unmarshal([]byte(mapping), &actual))
+	assert.Equal(t, expected, actual)
+}
+
+func TestNameWords(t *testing.T) {
+	assert.Equal(t, []string{"author", "name"}, nameWords("author_name"))
+	assert.Equal(t, []string{"review", "notes"}, nameWords("ReviewNotes"))
+	assert.Equal(t, []string{"html", "body2"}, nameWords("HTML-body2"))
+}
@@ -0,0 +1,460 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+const (
+	kindList   = "list"
+	kindMap    = "map"
+	kindRecord = "record"
+)
+
+// valueType is the shape of the values of a field, from which the Avro and
+// Protobuf schemas of the records are derived. It mirrors how the generated
+// encoders walk the values at runtime.
+type valueType struct {
+	// kind is a key of columnTypes other than json, kindList or kindMap.
+	kind string
+	// nullable is set for pointers, which may be nil.
+	nullable bool
+	// elem is the type of the elements of lists and of the values of maps,
+	// and key the type of the keys of maps.
+	elem, key *valueType
+	// name and fields describe records, made of the exported fields of
+	// structs in declaration order. name is empty for anonymous structs.
+	name   string
+	fields []recordField
+}
+
+// valueTypeOf resolves the Go type of a field to the shape of its values.
+func (t miscTypes) valueTypeOf(expr ast.Expr) (*valueType, error) {
+	return t.resolveValueType(expr, map[string]struct{}{})
+}
+
+func (t miscTypes) resolveValueType(expr ast.Expr, seen map[string]struct{}) (*valueType, error) {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		inner, err := t.resolveValueType(e.X, seen)
+		if err != nil {
+			return nil, err
+		}
+		if inner.nullable {
+			return nil, fmt.Errorf("unsupported Go type %s: pointers to pointers have no schema type", stringifyExpr(expr))
+		}
+		nullable := *inner
+		nullable.nullable = true
+		return &nullable, nil
+	case *ast.ParenExpr:
+		return t.resolveValueType(e.X, seen)
+	case *ast.Ident:
+		name := e.Name
+		if alias, ok := kindAliases[name]; ok {
+			name = alias
+		}
+		if _, ok := columnTypes[name]; ok && name != kindJSON {
+			return &valueType{kind: name}, nil
+		}
+		if underlying, ok := t[name]; ok {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				typ, err := t.resolveValueType(underlying, seen)
+				delete(seen, name)
+				if err == nil && typ.kind == kindRecord && typ.name == "" {
+					typ.name = name
+				}
+				return typ, err
+			}
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok && pkg.Name+"."+e.Sel.Name == kindTime {
+			return &valueType{kind: kindTime}, nil
+		}
+	case *ast.StructType:
+		record := &valueType{kind: kindRecord}
+		for _, field := range e.Fields.List {
+			if len(field.Names) == 0 {
+				return nil, fmt.Errorf("unsupported Go type %s: embedded fields have no schema type", stringifyExpr(field.Type))
+			}
+			for _, name := range field.Names {
+				if !name.IsExported() {
+					continue
+				}
+				typ, err := t.resolveValueType(field.Type, seen)
+				if err != nil {
+					return nil, err
+				}
+				record.fields = append(record.fields, recordField{name: name.Name, key: jsonFieldKey(field.Tag, name.Name), typ: typ})
+			}
+		}
+		return record, nil
+	case *ast.ArrayType:
+		if e.Len != nil {
+			break
+		}
+		if elem, ok := e.Elt.(*ast.Ident); ok && (elem.Name == "byte" || elem.Name == "uint8") {
+			return &valueType{kind: kindBytes}, nil
+		}
+		elem, err := t.resolveValueType(e.Elt, seen)
+		if err != nil {
+			return nil, err
+		}
+		return &valueType{kind: kindList, elem: elem}, nil
+	case *ast.MapType:
+		key, err := t.resolveValueType(e.Key, seen)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := t.resolveValueType(e.Value, seen)
+		if err != nil {
+			return nil, err
+		}
+		return &valueType{kind: kindMap, key: key, elem: elem}, nil
+	}
+	return nil, fmt.Errorf("unsupported Go type %s", stringifyExpr(expr))
+}
+
+// recordField is a persisted field of a model, as it appears in the schemas.
+type recordField struct {
+	name string
+	// key is the key of the field in JSON documents, set for the fields of
+	// structs, which is "-" for fields JSON leaves out.
+	key string
+	typ *valueType
+}
+
+// jsonFieldKey returns the key encoding/json writes the struct field named
+// name under, given its tag.
+func jsonFieldKey(tag *ast.BasicLit, name string) string {
+	if tag == nil {
+		return name
+	}
+	value, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return name
+	}
+	key, _, _ := strings.Cut(reflect.StructTag(value).Get("json"), ",")
+	if key == "" {
+		return name
+	}
+	return key
+}
+
+// recordFields resolves the persisted fields of the model, named after their
+// column made a valid schema name.
+func (d *DatagenParsed) recordFields() ([]recordField, error) {
+	if d.Fields == nil {
+		return nil, nil
+	}
+	var fields []recordField
+	names := map[string]string{}
+	for _, field := range d.Fields.List {
+		typ, err := d.miscTypes.valueTypeOf(fieldType(field.Type))
+		if err != nil {
+			return nil, fmt.Errorf("unsupported field type\n  model: %s\n  field: %s\n  cause: %w", d.FullyQualifiedModelName, field.Names[0].Name, err)
+		}
+		for _, name := range field.Names {
+			column, ok := d.Metadata.column(name.Name)
+			if !ok {
+				continue
+			}
+			schemaName := schemaIdent(column)
+			if other, ok := names[schemaName]; ok {
+				return nil, fmt.Errorf("columns have the same schema name\n  model: %s\n  columns: %s, %s\n  name: %s", d.FullyQualifiedModelName, other, column, schemaName)
+			}
+			names[schemaName] = column
+			fields = append(fields, recordField{name: schemaName, typ: typ})
+		}
+	}
+	return fields, nil
+}
+
+// schemaIdent makes s a name both Avro and Protobuf accept, replacing the
+// characters they do not allow with underscores.
+func schemaIdent(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+		default:
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
+
+// schemaNamespace is the Avro namespace and Protobuf package of a model: its
+// directories under datagen.
+func (d *DatagenParsed) schemaNamespace() string {
+	parts := []string{"datagen"}
+	dirs := strings.Split(d.FullyQualifiedModelName, utils.DgDirDelimeter)
+	for _, dir := range dirs[:len(dirs)-1] {
+		parts = append(parts, schemaIdent(dir))
+	}
+	return strings.Join(parts, ".")
+}
+
+// avroTypes maps the kinds of scalar values to their Avro type.
+var avroTypes = map[string]any{
+	"bool":     "boolean",
+	"int8":     "int",
+	"int16":    "int",
+	"int32":    "int",
+	"uint8":    "int",
+	"uint16":   "int",
+	"int":      "long",
+	"int64":    "long",
+	"uint32":   "long",
+	"uint":     "long",
+	"uint64":   "long",
+	"float32":  "float",
+	"float64":  "double",
+	kindString: "string",
+	kindBytes:  "bytes",
+	kindTime:   map[string]string{"type": "long", "logicalType": "timestamp-micros"},
+}
+
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    any             `json:"type"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+// avroSchema returns the Avro schema of the records of the model. Pointers
+// become unions with null, structs nested records and maps must have string
+// keys.
+func (d *DatagenParsed) avroSchema() (string, error) {
+	fields, err := d.recordFields()
+	if err != nil {
+		return "", err
+	}
+	name := schemaIdent(d.ModelName)
+	defined := map[string]bool{name: true}
+	record := avroRecord{Type: "record", Name: name, Namespace: d.schemaNamespace()}
+	if record.Fields, err = avroFields(fields, name, defined); err != nil {
+		return "", fmt.Errorf("no Avro type for field\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
+	}
+	schema, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(schema), nil
+}
+
+// avroFields returns the Avro fields of a record named name. defined holds
+// the names of the records defined so far, which later uses refer to by name.
+func avroFields(fields []recordField, name string, defined map[string]bool) ([]avroField, error) {
+	avro := []avroField{}
+	for _, f := range fields {
+		typ, err := avroType(f.typ, name+"_"+f.name, defined)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		field := avroField{Name: f.name, Type: typ}
+		if f.typ.nullable {
+			field.Default = json.RawMessage("null")
+		}
+		avro = append(avro, field)
+	}
+	return avro, nil
+}
+
+// avroType returns the Avro type of t. Anonymous structs are named path.
+func avroType(t *valueType, path string, defined map[string]bool) (any, error) {
+	var typ any
+	switch t.kind {
+	case kindList:
+		items, err := avroType(t.elem, path, defined)
+		if err != nil {
+			return nil, err
+		}
+		typ = map[string]any{"type": "array", "items": items}
+	case kindMap:
+		if t.key.kind != kindString || t.key.nullable {
+			return nil, fmt.Errorf("map keys must be strings")
+		}
+		values, err := avroType(t.elem, path, defined)
+		if err != nil {
+			return nil, err
+		}
+		typ = map[string]any{"type": "map", "values": values}
+	case kindRecord:
+		name := t.name
+		if name == "" {
+			name = path
+		}
+		if defined[name] {
+			typ = name
+			break
+		}
+		defined[name] = true
+		fields, err := avroFields(t.fields, name, defined)
+		if err != nil {
+			return nil, err
+		}
+		typ = avroRecord{Type: "record", Name: name, Fields: fields}
+	default:
+		typ = avroTypes[t.kind]
+	}
+	if t.nullable {
+		if t.kind == kindList || t.kind == kindMap {
+			return nil, fmt.Errorf("pointers to slices and maps are not supported")
+		}
+		return []any{"null", typ}, nil
+	}
+	return typ, nil
+}
+
+// protoTimestamp is the well-known type time.Time values are encoded as.
+const protoTimestamp = "google.protobuf.Timestamp"
+
+// protoTypes maps the kinds of scalar values to their Protobuf type.
+var protoTypes = map[string]string{
+	"bool":     "bool",
+	"int8":     "int32",
+	"int16":    "int32",
+	"int32":    "int32",
+	"int":      "int64",
+	"int64":    "int64",
+	"uint8":    "uint32",
+	"uint16":   "uint32",
+	"uint32":   "uint32",
+	"uint":     "uint64",
+	"uint64":   "uint64",
+	"float32":  "float",
+	"float64":  "double",
+	kindString: "string",
+	kindBytes:  "bytes",
+	kindTime:   protoTimestamp,
+}
+
+// protoSchema returns the proto3 definition of the message the records of
+// the model are encoded as, with fields numbered in declaration order.
+// Pointers become optional fields, slices repeated fields, maps map fields
+// and structs messages of their own. Slices and maps do not nest.
+func (d *DatagenParsed) protoSchema() (string, error) {
+	fields, err := d.recordFields()
+	if err != nil {
+		return "", err
+	}
+	name := schemaIdent(d.ModelName)
+	messages := &protoMessages{defined: map[string]bool{name: true}}
+	if err := messages.define(name, fields); err != nil {
+		return "", fmt.Errorf("no Protobuf type for field\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
+	}
+
+	var b strings.Builder
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", d.schemaNamespace())
+	if messages.usesTimestamp {
+		b.WriteString("import \"google/protobuf/timestamp.proto\";\n\n")
+	}
+	// the message of the model comes first, followed by the ones it uses
+	for i := len(messages.defs) - 1; i >= 0; i-- {
+		b.WriteString(messages.defs[i])
+		if i > 0 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// protoMessages collects the messages of a Protobuf definition.
+type protoMessages struct {
+	defs          []string
+	defined       map[string]bool
+	usesTimestamp bool
+}
+
+// define adds the message name made of fields, after the messages it uses.
+func (m *protoMessages) define(name string, fields []recordField) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "message %s {\n", name)
+	for i, f := range fields {
+		typ, err := m.fieldType(f.typ, name+"_"+f.name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+		fmt.Fprintf(&b, "  %s %s = %d;\n", typ, f.name, i+1)
+	}
+	b.WriteString("}\n")
+	m.defs = append(m.defs, b.String())
+	return nil
+}
+
+// fieldType returns the type of a field of type t, with its label. Anonymous
+// structs are named path.
+func (m *protoMessages) fieldType(t *valueType, path string) (string, error) {
+	switch t.kind {
+	case kindList:
+		if t.nullable {
+			return "", fmt.Errorf("pointers to slices are not supported")
+		}
+		if t.elem.kind == kindList || t.elem.kind == kindMap || t.elem.nullable {
+			return "", fmt.Errorf("slice elements must not be slices, maps or pointers")
+		}
+		elem, err := m.valueType(t.elem, path)
+		return "repeated " + elem, err
+	case kindMap:
+		if t.nullable {
+			return "", fmt.Errorf("pointers to maps are not supported")
+		}
+		switch t.key.kind {
+		case kindString, "bool", "int8", "int16", "int32", "int", "int64", "uint8", "uint16", "uint32", "uint", "uint64":
+		default:
+			return "", fmt.Errorf("map keys must be strings, integers or booleans")
+		}
+		if t.key.nullable || t.elem.kind == kindList || t.elem.kind == kindMap || t.elem.nullable {
+			return "", fmt.Errorf("map values must not be slices, maps or pointers")
+		}
+		elem, err := m.valueType(t.elem, path)
+		return fmt.Sprintf("map<%s, %s>", protoTypes[t.key.kind], elem), err
+	}
+	typ, err := m.valueType(t, path)
+	if t.nullable {
+		return "optional " + typ, err
+	}
+	return typ, err
+}
+
+// valueType returns the Protobuf type of the scalars and structs of type t,
+// defining the messages of structs on first use.
+func (m *protoMessages) valueType(t *valueType, path string) (string, error) {
+	if t.kind != kindRecord {
+		m.usesTimestamp = m.usesTimestamp || t.kind == kindTime
+		return protoTypes[t.kind], nil
+	}
+	name := t.name
+	if name == "" {
+		name = path
+	}
+	if !m.defined[name] {
+		m.defined[name] = true
+		if err := m.define(name, t.fields); err != nil {
+			return "", err
+		}
+	}
+	return name, nil
+}
@@ -0,0 +1,130 @@
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+func encodedModel(t *testing.T) *DatagenParsed {
+	t.Helper()
+
+	events := typedModel(t, "shop"+utils.DgDirDelimeter+"events", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"level", "int8", "{ return 1 }"},
+		{"at", "time.Time", "{ return Date() }"},
+		{"score", "*float64", "{ return nil }"},
+		{"payload", "[]byte", "{ return nil }"},
+		{"tags", "[]string", "{ return nil }"},
+		{"counts", "map[string]int", "{ return nil }"},
+		{"origin", "Point", "{ return Point{} }"},
+		{"path", "[]Point", "{ return nil }"},
+		{"scratch", "string", "{ return \"\" }"},
+	})
+	events.Misc = "type Point struct {\n X, Y float32\n hidden bool\n Meta struct { Label string }\n}"
+	events.Metadata = &Metadata{Columns: map[string]string{"level": "log-level", "scratch": "-"}}
+	analyze([]*DatagenParsed{events})
+	return events
+}
+
+func TestAvroSchema(t *testing.T) {
+	schema, err := encodedModel(t).avroSchema()
+	require.NoError(t, err)
+
+	var expected any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "record", "name": "events", "namespace": "datagen.shop",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "log_level", "type": "int"},
+			{"name": "at", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+			{"name": "score", "type": ["null", "double"], "default": null},
+			{"name": "payload", "type": "bytes"},
+			{"name": "tags", "type": {"type": "array", "items": "string"}},
+			{"name": "counts", "type": {"type": "map", "values": "long"}},
+			{"name": "origin", "type": {"type": "record", "name": "Point", "fields": [
+				{"name": "X", "type": "float"},
+				{"name": "Y", "type": "float"},
+				{"name": "Meta", "type": {"type": "record", "name": "Point_Meta", "fields": [
+					{"name": "Label", "type": "string"}
+				]}}
+			]}},
+			{"name": "path", "type": {"type": "array", "items": "Point"}}
+		]
+	}`), &expected))
+	var actual any
+	require.NoError(t, json.Unmarshal([]byte(schema), &actual))
+	assert.Equal(t, expected, actual)
+}
+
+func TestProtoSchema(t *testing.T) {
+	schema, err := encodedModel(t).protoSchema()
+	require.NoError(t, err)
+	assert.Equal(t, "syntax = \"proto3\";\n\n"+
+		"package datagen.shop;\n\n"+
+		"import \"google/protobuf/timestamp.proto\";\n\n"+
+		"message events {\n"+
+		"  int64 id = 1;\n"+
+		"  int32 log_level = 2;\n"+
+		"  google.protobuf.Timestamp at = 3;\n"+
+		"  optional double score = 4;\n"+
+		"  bytes payload = 5;\n"+
+		"  repeated string tags = 6;\n"+
+		"  map<string, int64> counts = 7;\n"+
+		"  Point origin = 8;\n"+
+		"  repeated Point path = 9;\n"+
+		"}\n\n"+
+		"message Point {\n"+
+		"  float X = 1;\n"+
+		"  float Y = 2;\n"+
+		"  Point_Meta Meta = 3;\n"+
+		"}\n\n"+
+		"message Point_Meta {\n"+
+		"  string Label = 1;\n"+
+		"}\n", schema)
+}
+
+func TestEncodingSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   string
+		avro  string
+		proto string
+	}{
+		{"map with int keys", "map[int]string", "map keys must be strings", ""},
+		{"nested slices", "[][]string", "", "slice elements must not be slices, maps or pointers"},
+		{"pointer to slice", "*[]int", "pointers to slices and maps are not supported", "pointers to slices are not supported"},
+		{"channel", "chan int", "field: f", "field: f"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := typedModel(t, "m", [][3]string{{"f", tt.typ, "{ return nil }"}})
+			analyze([]*DatagenParsed{m})
+
+			_, err := m.avroSchema()
+			if tt.avro == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.avro)
+			}
+			_, err = m.protoSchema()
+			if tt.proto == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.proto)
+			}
+		})
+	}
+}
+
+func TestSchemaIdent(t *testing.T) {
+	assert.Equal(t, "E_Mail_Address", schemaIdent("E-Mail Address"))
+	assert.Equal(t, "_1st", schemaIdent("1st"))
+	assert.Equal(t, "_", schemaIdent(""))
+}
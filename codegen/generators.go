@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"github.com/dream-horizon-org/datagen/utils"
 )
@@ -25,8 +26,23 @@ const (
 	tmplTags              = "templates/tags.go.tmpl"
 	tmplConfig            = "templates/config.go.tmpl"
 	tmplLinks             = "templates/links.go.tmpl"
+	tmplMemo              = "templates/memo.go.tmpl"
+	tmplRand              = "templates/rand.go.tmpl"
+	tmplShards            = "templates/shards.go.tmpl"
 	tmplMySQLConfig       = "templates/mysql_config.tmpl"
 	tmplPostgresConfig    = "templates/postgres_config.tmpl"
+	tmplSQLiteConfig      = "templates/sqlite_config.tmpl"
+	tmplMongoDBConfig     = "templates/mongodb_config.tmpl"
+	tmplESConfig          = "templates/elasticsearch_config.tmpl"
+	tmplESClient          = "templates/elasticsearch.go.tmpl"
+	tmplRedisConfig       = "templates/redis_config.tmpl"
+	tmplRedisClient       = "templates/redis.go.tmpl"
+	tmplClickHouseConfig  = "templates/clickhouse_config.tmpl"
+	tmplClickHouseClient  = "templates/clickhouse.go.tmpl"
+	tmplCassandraConfig   = "templates/cassandra_config.tmpl"
+	tmplCassandraClient   = "templates/cassandra.go.tmpl"
+	tmplWriteMode         = "templates/write_mode.go.tmpl"
+	tmplBulk              = "templates/bulk.go.tmpl"
 	tmplKafkaConfig       = "templates/kafka_config.tmpl"
 	tmplWriters           = "templates/writers.tmpl"
 	tmplGoMod             = "templates/go.mod.tmpl"
@@ -43,11 +59,43 @@ const (
 	tmplCSV               = "templates/csv_function.tmpl"
 	tmplJSON              = "templates/json_function.tmpl"
 	tmplXML               = "templates/xml_function.tmpl"
+	tmplParquet           = "templates/parquet_function.tmpl"
+	tmplAvro              = "templates/avro_function.tmpl"
+	tmplProtobuf          = "templates/protobuf_function.tmpl"
+	tmplAvroEncoder       = "templates/avro.go.tmpl"
+	tmplProtobufEncoder   = "templates/protobuf.go.tmpl"
+	tmplSQL               = "templates/sql_function.tmpl"
+	tmplSQLWriter         = "templates/sql.go.tmpl"
+	tmplJSONWriter        = "templates/json.go.tmpl"
+	tmplXMLWriter         = "templates/xml.go.tmpl"
+	tmplCSVWriter         = "templates/csv.go.tmpl"
+	tmplOutput            = "templates/output.go.tmpl"
+	tmplDestination       = "templates/destination.go.tmpl"
+	tmplS3                = "templates/s3.go.tmpl"
 	tmplMysqlSink         = "templates/load_mysql.tmpl"
 	tmplMysqlInit         = "templates/init_mysql.tmpl"
 	tmplPostgresSink      = "templates/load_postgres.tmpl"
 	tmplPostgresInit      = "templates/init_postgres.tmpl"
 	tmplSinkPostgresModel = "templates/sink_postgres_model.tmpl"
+	tmplSQLiteSink        = "templates/load_sqlite.tmpl"
+	tmplSQLiteInit        = "templates/init_sqlite.tmpl"
+	tmplSinkSQLiteModel   = "templates/sink_sqlite_model.tmpl"
+	tmplMongoDBSink       = "templates/load_mongodb.tmpl"
+	tmplMongoDBInit       = "templates/init_mongodb.tmpl"
+	tmplSinkMongoDBModel  = "templates/sink_mongodb_model.tmpl"
+	tmplESSink            = "templates/load_elasticsearch.tmpl"
+	tmplSinkESModel       = "templates/sink_elasticsearch_model.tmpl"
+	tmplRedisSink         = "templates/load_redis.tmpl"
+	tmplSinkRedisModel    = "templates/sink_redis_model.tmpl"
+	tmplClickHouseSink    = "templates/load_clickhouse.tmpl"
+	tmplClickHouseInit    = "templates/init_clickhouse.tmpl"
+	tmplSinkClickHouse    = "templates/sink_clickhouse_model.tmpl"
+	tmplCassandraSink     = "templates/load_cassandra.tmpl"
+	tmplCassandraInit     = "templates/init_cassandra.tmpl"
+	tmplSinkCassandra     = "templates/sink_cassandra_model.tmpl"
+	tmplKafkaSink         = "templates/load_kafka.tmpl"
+	tmplKafkaInit         = "templates/init_kafka.tmpl"
+	tmplSinkKafkaModel    = "templates/sink_kafka_model.tmpl"
 )
 
 type SectionGenerator func(d *DatagenParsed) (string, error)
@@ -56,6 +104,7 @@ type modelNameData struct {
 	DgDir                    *utils.DgDir
 	SanitisedModelNames      []string
 	FullyQualifiedModelNames []string
+	Dependencies             map[string][]string
 }
 
 func Codegen(parsed []*DatagenParsed, dirPath string, dgDir *utils.DgDir) error {
@@ -63,37 +112,69 @@ func Codegen(parsed []*DatagenParsed, dirPath string, dgDir *utils.DgDir) error
 		return nil
 	}
 
+	refs := analyze(parsed)
+
 	for _, result := range parsed {
 		if err := codegenModel(result, dirPath); err != nil {
 			return fmt.Errorf("failed to generate code for model\n  model: %s\n  cause: %w", result.ModelName, err)
 		}
 	}
 
-	if err := codegenCommons(parsed, dirPath, dgDir); err != nil {
+	if err := codegenCommons(parsed, dirPath, dgDir, refs); err != nil {
 		return fmt.Errorf("error generating main.go: %v", err)
 	}
 
 	return nil
 }
 
+// analyze runs the analyses that span every model and records their results
+// on each of them.
+func analyze(parsed []*DatagenParsed) *references {
+	rands := analyzeRandFuncs(parsed)
+	impure := analyzeImpureHelpers(parsed, rands)
+	refs := analyzeReferences(parsed)
+	types := collectMiscTypes(parsed)
+	for _, result := range parsed {
+		result.references = refs
+		result.randFuncs = rands
+		result.impure = impure
+		result.miscTypes = types
+	}
+	return refs
+}
+
 func codegenModel(parsed *DatagenParsed, dirPath string) error {
+	if err := parsed.checkColumns(); err != nil {
+		return err
+	}
+	if err := parsed.checkJSON(); err != nil {
+		return err
+	}
+	if err := parsed.checkXML(); err != nil {
+		return err
+	}
+
 	modelDir := dirPath
 	if err := os.MkdirAll(modelDir, 0o750); err != nil {
 		return err
 	}
 
 	generators := map[string]SectionGenerator{
-		"misc":             generateMiscSection,
-		"metadata":         generateMetadataSection,
-		"base_struct":      generateBaseStruct,
-		"generator_struct": generateGeneratorStruct,
-		"data_holder":      generateDataHolderStruct,
-		"generator_funcs":  generateGeneratorFuncs,
-		"gen_function":     generateGenFunction,
-		"init_function":    generateInitFunction,
-		"csv_functions":    generateCSVFunctions,
-		"json_functions":   generateJSONFunctions,
-		"xml_functions":    generateXMLFunctions,
+		"misc":               generateMiscSection,
+		"metadata":           generateMetadataSection,
+		"base_struct":        generateBaseStruct,
+		"generator_struct":   generateGeneratorStruct,
+		"data_holder":        generateDataHolderStruct,
+		"generator_funcs":    generateGeneratorFuncs,
+		"gen_function":       generateGenFunction,
+		"init_function":      generateInitFunction,
+		"csv_functions":      generateCSVFunctions,
+		"json_functions":     generateJSONFunctions,
+		"xml_functions":      generateXMLFunctions,
+		"parquet_functions":  generateParquetFunctions,
+		"avro_functions":     generateAvroFunctions,
+		"protobuf_functions": generateProtobufFunctions,
+		"sql_functions":      generateSQLFunctions,
 	}
 
 	sections := make(map[string]string, len(generators))
@@ -140,15 +221,81 @@ func codegenModel(parsed *DatagenParsed, dirPath string) error {
 		return fmt.Errorf("failed to generate Postgres sink file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
 	}
 
+	if err := parsed.generateSQLiteInitFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate SQLite init file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateSQLiteLoadFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate SQLite load file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateSQLiteSinkFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate SQLite sink file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+
+	if err := parsed.generateMongoDBInitFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate MongoDB init file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateMongoDBLoadFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate MongoDB load file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateMongoDBSinkFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate MongoDB sink file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+
+	if err := parsed.generateElasticsearchLoadFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Elasticsearch load file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateElasticsearchSinkFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Elasticsearch sink file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+
+	if err := parsed.generateRedisLoadFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Redis load file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateRedisSinkFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Redis sink file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+
+	if err := parsed.generateClickHouseInitFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate ClickHouse init file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateClickHouseLoadFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate ClickHouse load file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateClickHouseSinkFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate ClickHouse sink file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+
+	if err := parsed.generateCassandraInitFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Cassandra init file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateCassandraLoadFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Cassandra load file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateCassandraSinkFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Cassandra sink file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+
+	if err := parsed.generateKafkaInitFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Kafka init file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateKafkaLoadFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Kafka load file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+	if err := parsed.generateKafkaSinkFile(modelDir); err != nil {
+		return fmt.Errorf("failed to generate Kafka sink file\n  model: %s\n  cause: %w", parsed.FullyQualifiedModelName, err)
+	}
+
 	return nil
 }
 
-func codegenCommons(parsed []*DatagenParsed, dirPath string, dgDir *utils.DgDir) error {
+func codegenCommons(parsed []*DatagenParsed, dirPath string, dgDir *utils.DgDir, refs *references) error {
 	modelNames := make([]string, 0, len(parsed))
 	sanitisedModelNames := make([]string, 0, len(parsed))
+	dependencies := make(map[string][]string, len(parsed))
 	for _, p := range parsed {
 		modelNames = append(modelNames, p.FullyQualifiedModelName)
 		sanitisedModelNames = append(sanitisedModelNames, strings.ReplaceAll(p.FullyQualifiedModelName, utils.DgDirDelimeter, "."))
+		dependencies[p.FullyQualifiedModelName] = refs.dependencies(p.FullyQualifiedModelName)
 	}
 
 	if err := generateMainFile(dirPath); err != nil {
@@ -159,7 +306,7 @@ func codegenCommons(parsed []*DatagenParsed, dirPath string, dgDir *utils.DgDir)
 		return fmt.Errorf("failed to generate commands.go: %v", err)
 	}
 
-	if err := generateModelManagerFile(dirPath, modelNames, dgDir); err != nil {
+	if err := generateModelManagerFile(dirPath, &modelNameData{DgDir: dgDir, SanitisedModelNames: modelNames, Dependencies: dependencies}); err != nil {
 		return fmt.Errorf("failed to generate model_manager.go: %v", err)
 	}
 
@@ -197,15 +344,39 @@ func codegenCommons(parsed []*DatagenParsed, dirPath string, dgDir *utils.DgDir)
 	}
 
 	staticFiles := map[string]string{
-		tmplWriters:        "writers.go",
-		tmplGoMod:          "go.mod",
-		tmplGoSum:          "go.sum",
-		tmplStdlib:         "stdlib.go",
-		tmplLogger:         "logger.go",
-		tmplMySQLConfig:    "mysql_config.go",
-		tmplPostgresConfig: "postgres_config.go",
-		tmplKafkaConfig:    "kafka_config.go",
-		tmplLinks:          "links.go",
+		tmplWriters:          "writers.go",
+		tmplGoMod:            "go.mod",
+		tmplGoSum:            "go.sum",
+		tmplStdlib:           "stdlib.go",
+		tmplLogger:           "logger.go",
+		tmplMySQLConfig:      "mysql_config.go",
+		tmplPostgresConfig:   "postgres_config.go",
+		tmplSQLiteConfig:     "sqlite_config.go",
+		tmplMongoDBConfig:    "mongodb_config.go",
+		tmplESConfig:         "elasticsearch_config.go",
+		tmplESClient:         "elasticsearch.go",
+		tmplRedisConfig:      "redis_config.go",
+		tmplRedisClient:      "redis.go",
+		tmplClickHouseConfig: "clickhouse_config.go",
+		tmplClickHouseClient: "clickhouse.go",
+		tmplCassandraConfig:  "cassandra_config.go",
+		tmplCassandraClient:  "cassandra.go",
+		tmplWriteMode:        "write_mode.go",
+		tmplBulk:             "bulk.go",
+		tmplKafkaConfig:      "kafka_config.go",
+		tmplLinks:            "links.go",
+		tmplMemo:             "memo.go",
+		tmplRand:             "rand.go",
+		tmplShards:           "shards.go",
+		tmplAvroEncoder:      "avro.go",
+		tmplProtobufEncoder:  "protobuf.go",
+		tmplSQLWriter:        "sql.go",
+		tmplJSONWriter:       "json.go",
+		tmplXMLWriter:        "xml.go",
+		tmplCSVWriter:        "csv.go",
+		tmplOutput:           "output.go",
+		tmplDestination:      "destination.go",
+		tmplS3:               "s3.go",
 	}
 	if err := copyStaticTemplates(dirPath, staticFiles); err != nil {
 		return fmt.Errorf("failed to copy static templates\n  output_dir: %s\n  cause: %w", dirPath, err)
@@ -224,7 +395,7 @@ func generateMetadataSection(d *DatagenParsed) (string, error) {
 
 func generateMiscSection(d *DatagenParsed) (string, error) {
 	var buf bytes.Buffer
-	_, err := fmt.Fprintf(&buf, "%s\n", d.Misc)
+	_, err := fmt.Fprintf(&buf, "%s\n", d.randHelpers().bindDecls(d.Misc))
 	if err != nil {
 		return "", err
 	}
@@ -298,22 +469,26 @@ func generateGeneratorFuncs(d *DatagenParsed) (string, error) {
 			}
 		}
 
-		var bodyBuf bytes.Buffer
+		var body string
+		var usesRand bool
 		if genFn.Body != nil {
-			err := printer.Fprint(&bodyBuf, fset, genFn.Body)
+			var err error
+			body, usesRand, err = d.randHelpers().bindBody(genFn.Body)
 			if err != nil {
-				return "", err
+				return "", fmt.Errorf("failed to generate wrapper function\n  model: %s\n  field: %s\n  cause: %w", d.FullyQualifiedModelName, fieldName, err)
 			}
 		}
 
 		data := wrapperFuncData{
 			ModelName:               d.ModelName,
 			FullyQualifiedModelName: d.FullyQualifiedModelName,
+			DottedModelName:         d.dottedModelName(),
 			FieldName:               fieldName,
 			FieldType:               fieldType,
 			GenFuncParams:           paramsBuf.String(),
 			GenFuncVars:             varsBuf.String(),
-			GenFuncBody:             bodyBuf.String(),
+			GenFuncBody:             body,
+			UsesRand:                usesRand,
 		}
 
 		if err := tmpl.Execute(&buf, data); err != nil {
@@ -349,7 +524,7 @@ func generateCSVFunctions(d *DatagenParsed) (string, error) {
 }
 
 func generateJSONFunctions(d *DatagenParsed) (string, error) {
-	s, err := renderFS(tmplJSON, fieldsVars(d))
+	s, err := renderFS(tmplJSON, jsonVars(d))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate JSON functions section\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
 	}
@@ -357,28 +532,101 @@ func generateJSONFunctions(d *DatagenParsed) (string, error) {
 }
 
 func generateXMLFunctions(d *DatagenParsed) (string, error) {
+	s, err := renderFS(tmplXML, xmlVars(d))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate XML functions section\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
+	}
+	return s, nil
+}
+
+func generateParquetFunctions(d *DatagenParsed) (string, error) {
 	funcs := template.FuncMap{
-		"XmlPrefix": func(s string) string {
-			if s == "" {
-				return s
-			}
-			return "Xml_" + s
+		"ParquetPrefix": func(s string) string {
+			return "Parquet_" + s
 		},
+		"ParquetTag": parquetTag,
 	}
-	s, err := renderFSWithFuncs(tmplXML, funcs, "xml_function.tmpl", fieldsVars(d))
+	s, err := renderFSWithFuncs(tmplParquet, funcs, "parquet_function.tmpl", fieldsVars(d))
 	if err != nil {
-		return "", fmt.Errorf("failed to generate XML functions section\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
+		return "", fmt.Errorf("failed to generate Parquet functions section\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
+	}
+	return s, nil
+}
+
+func generateAvroFunctions(d *DatagenParsed) (string, error) {
+	vars := fieldsVars(d)
+	schema, err := d.avroSchema()
+	if err != nil {
+		vars.SchemaError = err.Error()
+	}
+	vars.Schema = schema
+	s, err := renderFS(tmplAvro, vars)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate Avro functions section\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
+	}
+	return s, nil
+}
+
+func generateProtobufFunctions(d *DatagenParsed) (string, error) {
+	vars := fieldsVars(d)
+	schema, err := d.protoSchema()
+	if err != nil {
+		vars.SchemaError = err.Error()
+	}
+	vars.Schema = schema
+	s, err := renderFS(tmplProtobuf, vars)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate Protobuf functions section\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
+	}
+	return s, nil
+}
+
+func generateSQLFunctions(d *DatagenParsed) (string, error) {
+	s, err := renderFS(tmplSQL, sqlVars(d))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate SQL functions section\n  model: %s\n  cause: %w", d.FullyQualifiedModelName, err)
 	}
 	return s, nil
 }
 
+// parquetTag returns the parquet struct tag of a field: its column, which
+// keeps the field name when it cannot be put in a tag, and the logical type
+// of times and slices. Other types map to the parquet type of their kind.
+func parquetTag(field fieldData) string {
+	tag := field.Column
+	if tag == "" || strings.ContainsAny(tag, ",\"`\\") {
+		tag = field.Name
+	}
+	switch {
+	case field.Type == "time.Time":
+		// Spark does not read nanosecond timestamps, the default of time.Time
+		tag += ",timestamp(microsecond)"
+	case strings.HasPrefix(field.Type, "[]") && field.Type != "[]byte" && field.Type != "[]uint8":
+		tag += ",list"
+	}
+	return tag
+}
+
+// isXMLName reports whether s can be used as an XML element name as it is.
+func isXMLName(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_', unicode.IsLetter(r):
+		case i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
 // generateMySQLLoadFile renders templates/load_mysql.tmpl into <ModelName>_mysql.go
 func (d *DatagenParsed) generateMySQLLoadFile(modelDir string) error {
 	if len(getFieldData(d)) == 0 {
 		return nil
 	}
 
-	ib, err := renderFS(tmplMysqlSink, fieldsVars(d))
+	ib, err := renderFS(tmplMysqlSink, sinkVars(d, DialectMySQL))
 	if err != nil {
 		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplMysqlSink, err)
 	}
@@ -424,7 +672,7 @@ func (d *DatagenParsed) generatePostgresLoadFile(modelDir string) error {
 		return nil
 	}
 
-	ib, err := renderFS(tmplPostgresSink, fieldsVars(d))
+	ib, err := renderFS(tmplPostgresSink, sinkVars(d, DialectPostgres))
 	if err != nil {
 		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplPostgresSink, err)
 	}
@@ -464,6 +712,300 @@ func (d *DatagenParsed) generatePostgresSinkFile(modelDir string) error {
 	return nil
 }
 
+// generateSQLiteLoadFile renders templates/load_sqlite.tmpl into <ModelName>_sqlite.go
+func (d *DatagenParsed) generateSQLiteLoadFile(modelDir string) error {
+	if len(getFieldData(d)) == 0 {
+		return nil
+	}
+
+	ib, err := renderFS(tmplSQLiteSink, sinkVars(d, DialectSQLite))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSQLiteSink, err)
+	}
+
+	outPath := filepath.Join(modelDir, fmt.Sprintf("%s_sqlite.go", d.FullyQualifiedModelName))
+	if err := writeFormattedGoFile(outPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", outPath, err)
+	}
+	return nil
+}
+
+// generateSQLiteInitFile renders templates/init_sqlite.tmpl into <ModelName>_init_sqlite.go
+func (d *DatagenParsed) generateSQLiteInitFile(modelDir string) error {
+	ib, err := renderFS(tmplSQLiteInit, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSQLiteInit, err)
+	}
+	initSQLitePath := filepath.Join(modelDir, fmt.Sprintf("%s_init_sqlite.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(initSQLitePath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", initSQLitePath, err)
+	}
+	return nil
+}
+
+// generateSQLiteSinkFile renders templates/sink_sqlite_model.tmpl into <ModelName>_sink_sqlite.go
+func (d *DatagenParsed) generateSQLiteSinkFile(modelDir string) error {
+	ib, err := renderFS(tmplSinkSQLiteModel, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSinkSQLiteModel, err)
+	}
+	sinkSQLitePath := filepath.Join(modelDir, fmt.Sprintf("%s_sink_sqlite.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(sinkSQLitePath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", sinkSQLitePath, err)
+	}
+	return nil
+}
+
+// generateKafkaLoadFile renders templates/load_kafka.tmpl into <ModelName>_kafka.go
+func (d *DatagenParsed) generateKafkaLoadFile(modelDir string) error {
+	if len(getFieldData(d)) == 0 {
+		return nil
+	}
+
+	ib, err := renderFS(tmplKafkaSink, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplKafkaSink, err)
+	}
+
+	outPath := filepath.Join(modelDir, fmt.Sprintf("%s_kafka.go", d.FullyQualifiedModelName))
+	if err := writeFormattedGoFile(outPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", outPath, err)
+	}
+	return nil
+}
+
+// generateKafkaInitFile renders templates/init_kafka.tmpl into <ModelName>_init_kafka.go
+func (d *DatagenParsed) generateKafkaInitFile(modelDir string) error {
+	ib, err := renderFS(tmplKafkaInit, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplKafkaInit, err)
+	}
+	initKafkaPath := filepath.Join(modelDir, fmt.Sprintf("%s_init_kafka.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(initKafkaPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", initKafkaPath, err)
+	}
+	return nil
+}
+
+// generateKafkaSinkFile renders templates/sink_kafka_model.tmpl into <ModelName>_sink_kafka.go
+func (d *DatagenParsed) generateKafkaSinkFile(modelDir string) error {
+	ib, err := renderFS(tmplSinkKafkaModel, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSinkKafkaModel, err)
+	}
+	sinkKafkaPath := filepath.Join(modelDir, fmt.Sprintf("%s_sink_kafka.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(sinkKafkaPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", sinkKafkaPath, err)
+	}
+	return nil
+}
+
+// generateMongoDBLoadFile renders templates/load_mongodb.tmpl into <ModelName>_mongodb.go
+func (d *DatagenParsed) generateMongoDBLoadFile(modelDir string) error {
+	if len(getFieldData(d)) == 0 {
+		return nil
+	}
+
+	ib, err := renderFS(tmplMongoDBSink, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplMongoDBSink, err)
+	}
+
+	outPath := filepath.Join(modelDir, fmt.Sprintf("%s_mongodb.go", d.FullyQualifiedModelName))
+	if err := writeFormattedGoFile(outPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", outPath, err)
+	}
+	return nil
+}
+
+// generateMongoDBInitFile renders templates/init_mongodb.tmpl into <ModelName>_init_mongodb.go
+func (d *DatagenParsed) generateMongoDBInitFile(modelDir string) error {
+	ib, err := renderFS(tmplMongoDBInit, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplMongoDBInit, err)
+	}
+	initMongoDBPath := filepath.Join(modelDir, fmt.Sprintf("%s_init_mongodb.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(initMongoDBPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", initMongoDBPath, err)
+	}
+	return nil
+}
+
+// generateMongoDBSinkFile renders templates/sink_mongodb_model.tmpl into <ModelName>_sink_mongodb.go
+func (d *DatagenParsed) generateMongoDBSinkFile(modelDir string) error {
+	ib, err := renderFS(tmplSinkMongoDBModel, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSinkMongoDBModel, err)
+	}
+	sinkMongoDBPath := filepath.Join(modelDir, fmt.Sprintf("%s_sink_mongodb.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(sinkMongoDBPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", sinkMongoDBPath, err)
+	}
+	return nil
+}
+
+// generateElasticsearchLoadFile renders templates/load_elasticsearch.tmpl into <ModelName>_elasticsearch.go
+func (d *DatagenParsed) generateElasticsearchLoadFile(modelDir string) error {
+	if len(getFieldData(d)) == 0 {
+		return nil
+	}
+
+	ib, err := renderFS(tmplESSink, indexVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplESSink, err)
+	}
+
+	outPath := filepath.Join(modelDir, fmt.Sprintf("%s_elasticsearch.go", d.FullyQualifiedModelName))
+	if err := writeFormattedGoFile(outPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", outPath, err)
+	}
+	return nil
+}
+
+// generateElasticsearchSinkFile renders templates/sink_elasticsearch_model.tmpl into <ModelName>_sink_elasticsearch.go
+func (d *DatagenParsed) generateElasticsearchSinkFile(modelDir string) error {
+	ib, err := renderFS(tmplSinkESModel, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSinkESModel, err)
+	}
+	sinkPath := filepath.Join(modelDir, fmt.Sprintf("%s_sink_elasticsearch.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(sinkPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", sinkPath, err)
+	}
+	return nil
+}
+
+// generateRedisLoadFile renders templates/load_redis.tmpl into <ModelName>_redis.go
+func (d *DatagenParsed) generateRedisLoadFile(modelDir string) error {
+	if len(getFieldData(d)) == 0 {
+		return nil
+	}
+
+	ib, err := renderFS(tmplRedisSink, jsonVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplRedisSink, err)
+	}
+
+	outPath := filepath.Join(modelDir, fmt.Sprintf("%s_redis.go", d.FullyQualifiedModelName))
+	if err := writeFormattedGoFile(outPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", outPath, err)
+	}
+	return nil
+}
+
+// generateRedisSinkFile renders templates/sink_redis_model.tmpl into <ModelName>_sink_redis.go
+func (d *DatagenParsed) generateRedisSinkFile(modelDir string) error {
+	ib, err := renderFS(tmplSinkRedisModel, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSinkRedisModel, err)
+	}
+	sinkPath := filepath.Join(modelDir, fmt.Sprintf("%s_sink_redis.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(sinkPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", sinkPath, err)
+	}
+	return nil
+}
+
+// generateClickHouseLoadFile renders templates/load_clickhouse.tmpl into <ModelName>_clickhouse.go
+func (d *DatagenParsed) generateClickHouseLoadFile(modelDir string) error {
+	if len(getFieldData(d)) == 0 {
+		return nil
+	}
+
+	ib, err := renderFS(tmplClickHouseSink, clickhouseVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplClickHouseSink, err)
+	}
+
+	outPath := filepath.Join(modelDir, fmt.Sprintf("%s_clickhouse.go", d.FullyQualifiedModelName))
+	if err := writeFormattedGoFile(outPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", outPath, err)
+	}
+	return nil
+}
+
+// generateClickHouseInitFile renders templates/init_clickhouse.tmpl into <ModelName>_init_clickhouse.go
+func (d *DatagenParsed) generateClickHouseInitFile(modelDir string) error {
+	ib, err := renderFS(tmplClickHouseInit, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplClickHouseInit, err)
+	}
+	initPath := filepath.Join(modelDir, fmt.Sprintf("%s_init_clickhouse.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(initPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", initPath, err)
+	}
+	return nil
+}
+
+// generateClickHouseSinkFile renders templates/sink_clickhouse_model.tmpl into <ModelName>_sink_clickhouse.go
+func (d *DatagenParsed) generateClickHouseSinkFile(modelDir string) error {
+	ib, err := renderFS(tmplSinkClickHouse, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSinkClickHouse, err)
+	}
+	sinkPath := filepath.Join(modelDir, fmt.Sprintf("%s_sink_clickhouse.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(sinkPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", sinkPath, err)
+	}
+	return nil
+}
+
+// generateCassandraLoadFile renders templates/load_cassandra.tmpl into <ModelName>_cassandra.go
+func (d *DatagenParsed) generateCassandraLoadFile(modelDir string) error {
+	if len(getFieldData(d)) == 0 {
+		return nil
+	}
+
+	ib, err := renderFS(tmplCassandraSink, cassandraVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplCassandraSink, err)
+	}
+
+	outPath := filepath.Join(modelDir, fmt.Sprintf("%s_cassandra.go", d.FullyQualifiedModelName))
+	if err := writeFormattedGoFile(outPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", outPath, err)
+	}
+	return nil
+}
+
+// generateCassandraInitFile renders templates/init_cassandra.tmpl into <ModelName>_init_cassandra.go
+func (d *DatagenParsed) generateCassandraInitFile(modelDir string) error {
+	ib, err := renderFS(tmplCassandraInit, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplCassandraInit, err)
+	}
+	initPath := filepath.Join(modelDir, fmt.Sprintf("%s_init_cassandra.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(initPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", initPath, err)
+	}
+	return nil
+}
+
+// generateCassandraSinkFile renders templates/sink_cassandra_model.tmpl into <ModelName>_sink_cassandra.go
+func (d *DatagenParsed) generateCassandraSinkFile(modelDir string) error {
+	ib, err := renderFS(tmplSinkCassandra, fieldsVars(d))
+	if err != nil {
+		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplSinkCassandra, err)
+	}
+	sinkPath := filepath.Join(modelDir, fmt.Sprintf("%s_sink_cassandra.go", d.FullyQualifiedModelName))
+
+	if err := writeFormattedGoFile(sinkPath, []byte(ib)); err != nil {
+		return fmt.Errorf("failed to write generated file\n  path: %s\n  cause: %w", sinkPath, err)
+	}
+	return nil
+}
+
 // generateMainFile generates the main.go file (CLI entry point)
 func generateMainFile(dirPath string) error {
 	content, err := templates.ReadFile(tmplMain)
@@ -498,7 +1040,7 @@ func generateCommandsFile(dirPath string, modelNames []string) error {
 }
 
 // generateModelManagerFile generates the model_manager.go file
-func generateModelManagerFile(dirPath string, modelNames []string, dgDir *utils.DgDir) error {
+func generateModelManagerFile(dirPath string, modelNameData *modelNameData) error {
 	funcs := template.FuncMap{
 		"last": func(full string) string {
 			parts := strings.Split(full, utils.DgDirDelimeter)
@@ -537,7 +1079,7 @@ func generateModelManagerFile(dirPath string, modelNames []string, dgDir *utils.
 
 	var buf bytes.Buffer
 
-	if err := tmpl.Execute(&buf, &modelNameData{DgDir: dgDir, SanitisedModelNames: modelNames}); err != nil {
+	if err := tmpl.Execute(&buf, modelNameData); err != nil {
 		return fmt.Errorf("failed to render template\n  template: %s\n  cause: %w", tmplModelManager, err)
 	}
 
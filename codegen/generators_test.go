@@ -0,0 +1,70 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParquetTag(t *testing.T) {
+	tests := []struct {
+		field fieldData
+		want  string
+	}{
+		{field: fieldData{Name: "id", Column: "id", Type: "int"}, want: "id"},
+		{field: fieldData{Name: "created", Column: "created_at", Type: "time.Time"}, want: "created_at,timestamp(microsecond)"},
+		{field: fieldData{Name: "tags", Column: "tags", Type: "[]string"}, want: "tags,list"},
+		{field: fieldData{Name: "blob", Column: "blob", Type: "[]byte"}, want: "blob"},
+		{field: fieldData{Name: "attrs", Column: "attrs", Type: "map[string]int"}, want: "attrs"},
+		{field: fieldData{Name: "total", Column: "a,b", Type: "float64"}, want: "total"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, parquetTag(tt.field))
+		})
+	}
+}
+
+func TestSQLTables(t *testing.T) {
+	users := typedModel(t, "users", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"email", "string", "{ return Email() }"},
+		{"scratch", "string", "{ return \"\" }"},
+		{"tags", "[]string", "{ return nil }"},
+	})
+	users.Metadata = &Metadata{Schema: "crm", Columns: map[string]string{"email": "E-Mail", "scratch": "-"}}
+
+	tables := map[Dialect]sqlTableVars{}
+	for _, table := range sqlVars(users).SQLTables {
+		tables[table.Dialect] = table
+	}
+	require.Len(t, tables, len(Dialects))
+
+	assert.Equal(t, "INSERT INTO `crm`.`users` (`id`, `E-Mail`, `tags`) VALUES", tables[DialectMySQL].Insert)
+	assert.Equal(t, "DELETE FROM `crm`.`users`;", tables[DialectMySQL].Truncate)
+	assert.Equal(t, `INSERT INTO "crm"."users" ("id", "E-Mail", "tags") VALUES`, tables[DialectPostgres].Insert)
+	assert.Equal(t, `TRUNCATE TABLE "crm"."users" RESTART IDENTITY CASCADE;`, tables[DialectPostgres].Truncate)
+	assert.Equal(t, `DELETE FROM "crm"."users";`, tables[DialectSQLite].Truncate)
+	for dialect, table := range tables {
+		assert.Empty(t, table.CreateTableError, dialect)
+		assert.Contains(t, table.CreateTable, "CREATE TABLE IF NOT EXISTS "+qualifiedTable(dialect, "crm", "users"), dialect)
+	}
+}
+
+func TestSinkVarsKeyColumns(t *testing.T) {
+	users := typedModel(t, "users", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"email", "string", "{ return Email() }"},
+	})
+	users.Metadata = &Metadata{Columns: map[string]string{"id": "user id"}}
+	orders := typedModel(t, "orders", [][3]string{
+		{"user_id", "int", "{ return self.datagen.users().id(iter) }"},
+	})
+	analyze([]*DatagenParsed{users, orders})
+
+	vars := sinkVars(users, DialectPostgres)
+	assert.Equal(t, []string{"user id"}, vars.KeyColumns, "the referenced field is the primary key")
+	assert.Equal(t, `"users"`, vars.Table)
+	assert.Empty(t, sinkVars(orders, DialectPostgres).KeyColumns, "tables without a primary key have no key columns")
+}
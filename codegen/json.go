@@ -0,0 +1,175 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+// jsonFieldVars is a field written to the JSON documents of its model under
+// Key.
+type jsonFieldVars struct {
+	Name string
+	Key  string
+}
+
+// jsonEmbedVars nests the records of the model Model, whose ChildField holds
+// the ParentField of a record, under Key in the JSON document of that record.
+// Model is dotted and ChildType fully qualified.
+type jsonEmbedVars struct {
+	Key         string
+	Model       string
+	ChildType   string
+	ChildField  string
+	ParentField string
+}
+
+// jsonKey returns the key a persisted field is written under in JSON.
+func (m *Metadata) jsonKey(field, column string) string {
+	if m == nil {
+		return column
+	}
+	if key, ok := m.JSONKeys[field]; ok {
+		return key
+	}
+	return column
+}
+
+// embeddedForeignKey returns the field of the embedded model whose every
+// value is read from a field of d, and that field. embed is the dotted name of
+// the model, which must have a single such field, or that name followed by
+// the field.
+func (d *DatagenParsed) embeddedForeignKey(embed string) (from, to fieldRef, err error) {
+	childModel, field := strings.ReplaceAll(embed, ".", utils.DgDirDelimeter), ""
+	if d.references == nil {
+		return fieldRef{}, fieldRef{}, fmt.Errorf("embedded model not found\n  model: %s\n  embed: %s", d.FullyQualifiedModelName, embed)
+	}
+	if _, ok := d.references.metadata[childModel]; !ok {
+		i := strings.LastIndex(embed, ".")
+		if i < 0 {
+			return fieldRef{}, fieldRef{}, fmt.Errorf("embedded model not found\n  model: %s\n  embed: %s", d.FullyQualifiedModelName, embed)
+		}
+		childModel, field = strings.ReplaceAll(embed[:i], ".", utils.DgDirDelimeter), embed[i+1:]
+		if _, ok := d.references.metadata[childModel]; !ok {
+			return fieldRef{}, fieldRef{}, fmt.Errorf("embedded model not found\n  model: %s\n  embed: %s", d.FullyQualifiedModelName, embed)
+		}
+	}
+
+	var fields []string
+	for f, t := range d.references.foreignKeys {
+		if f.model == childModel && t.model == d.FullyQualifiedModelName && (field == "" || f.field == field) {
+			from, to = f, t
+			fields = append(fields, f.field)
+		}
+	}
+	sort.Strings(fields)
+	switch len(fields) {
+	case 0:
+		if field != "" {
+			return fieldRef{}, fieldRef{}, fmt.Errorf("embedded field is not read from the model through self.datagen\n  model: %s\n  embed: %s", d.FullyQualifiedModelName, embed)
+		}
+		return fieldRef{}, fieldRef{}, fmt.Errorf("embedded model has no field read from the model through self.datagen\n  model: %s\n  embed: %s", d.FullyQualifiedModelName, embed)
+	case 1:
+		return from, to, nil
+	}
+	return fieldRef{}, fieldRef{}, fmt.Errorf("embedded model has several fields read from the model, name one as <model>.<field>\n  model: %s\n  embed: %s\n  fields: %s", d.FullyQualifiedModelName, embed, strings.Join(fields, ", "))
+}
+
+// checkJSON reports JSON keys of fields the model does not write, empty keys,
+// keys used twice in a document, and embedded models that cannot be matched
+// to the records of the model.
+func (d *DatagenParsed) checkJSON() error {
+	if d.Metadata == nil || (len(d.Metadata.JSONKeys) == 0 && len(d.Metadata.Embed) == 0) {
+		return nil
+	}
+
+	fields := getFieldData(d)
+	types := map[string]string{}
+	persisted := map[string]bool{}
+	for _, f := range fields {
+		types[f.Name] = f.Type
+		persisted[f.Name] = f.Persisted
+	}
+
+	for _, field := range sortedKeys(d.Metadata.JSONKeys) {
+		ok, known := persisted[field]
+		if !known {
+			return fmt.Errorf("JSON key set for a field the model does not have\n  model: %s\n  field: %s", d.FullyQualifiedModelName, field)
+		}
+		if !ok {
+			return fmt.Errorf("JSON key set for a field that is not persisted\n  model: %s\n  field: %s", d.FullyQualifiedModelName, field)
+		}
+		if strings.TrimSpace(d.Metadata.JSONKeys[field]) == "" {
+			return fmt.Errorf("empty JSON key\n  model: %s\n  field: %s", d.FullyQualifiedModelName, field)
+		}
+	}
+
+	written := map[string]string{}
+	for _, f := range fields {
+		if !f.Persisted {
+			continue
+		}
+		key := d.Metadata.jsonKey(f.Name, f.Column)
+		if other, ok := written[key]; ok {
+			return fmt.Errorf("fields are written under the same JSON key\n  model: %s\n  key: %s\n  fields: %s, %s", d.FullyQualifiedModelName, key, other, f.Name)
+		}
+		written[key] = f.Name
+	}
+
+	for _, key := range sortedKeys(d.Metadata.Embed) {
+		child := d.Metadata.Embed[key]
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("empty JSON key\n  model: %s\n  embed: %s", d.FullyQualifiedModelName, child)
+		}
+		if field, ok := written[key]; ok {
+			return fmt.Errorf("embedded model is written under the JSON key of a field\n  model: %s\n  key: %s\n  field: %s", d.FullyQualifiedModelName, key, field)
+		}
+		_, to, err := d.embeddedForeignKey(child)
+		if err != nil {
+			return err
+		}
+		if typ := types[to.field]; strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || strings.HasPrefix(typ, "func") {
+			return fmt.Errorf("embedded model references a field that cannot be matched\n  model: %s\n  embed: %s\n  field: %s\n  type: %s", d.FullyQualifiedModelName, child, to.field, typ)
+		}
+	}
+	return nil
+}
+
+// jsonVars returns the template variables of the JSON documents of the
+// model: its persisted fields under their keys, and the models embedded in
+// it. The metadata must have passed checkJSON.
+func jsonVars(d *DatagenParsed) templateVars {
+	vars := fieldsVars(d)
+	for _, c := range vars.Columns {
+		vars.JSONFields = append(vars.JSONFields, jsonFieldVars{Name: c.Name, Key: d.Metadata.jsonKey(c.Name, c.Column)})
+	}
+	if d.Metadata == nil {
+		return vars
+	}
+	for _, key := range sortedKeys(d.Metadata.Embed) {
+		child := d.Metadata.Embed[key]
+		from, to, err := d.embeddedForeignKey(child)
+		if err != nil {
+			continue
+		}
+		vars.JSONEmbeds = append(vars.JSONEmbeds, jsonEmbedVars{
+			Key:         key,
+			Model:       strings.ReplaceAll(from.model, utils.DgDirDelimeter, "."),
+			ChildType:   from.model,
+			ChildField:  from.field,
+			ParentField: to.field,
+		})
+	}
+	return vars
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+// embeddingModels returns users and orders in the shop directory, whose
+// owner field is read from the id of users.
+func embeddingModels(t *testing.T, userMetadata *Metadata) (*DatagenParsed, *DatagenParsed) {
+	t.Helper()
+
+	users := typedModel(t, "users", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"full_name", "string", "{ return Name() }"},
+		{"scratch", "string", "{ return \"\" }"},
+		{"tags", "[]string", "{ return nil }"},
+	})
+	users.Metadata = userMetadata
+	orders := typedModel(t, "shop"+utils.DgDirDelimeter+"orders", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"owner", "int", "{ return self.datagen.users().id(iter) }"},
+		{"labels", "[]string", "{ return self.datagen.users().tags(iter) }"},
+	})
+	analyze([]*DatagenParsed{users, orders})
+	return users, orders
+}
+
+func TestCheckJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *Metadata
+		errStr   string
+	}{
+		{name: "renamed and embedded", metadata: &Metadata{JSONKeys: map[string]string{"full_name": "fullName"}, Embed: map[string]string{"orders": "shop.orders.owner"}}},
+		{name: "several references", metadata: &Metadata{Embed: map[string]string{"orders": "shop.orders"}}, errStr: "fields: labels, owner"},
+		{name: "field without reference", metadata: &Metadata{Embed: map[string]string{"orders": "shop.orders.id"}}, errStr: "embedded field is not read from the model"},
+		{name: "unknown field", metadata: &Metadata{JSONKeys: map[string]string{"name": "fullName"}}, errStr: "field: name"},
+		{name: "skipped field", metadata: &Metadata{Columns: map[string]string{"scratch": "-"}, JSONKeys: map[string]string{"scratch": "s"}}, errStr: "not persisted"},
+		{name: "empty key", metadata: &Metadata{JSONKeys: map[string]string{"full_name": " "}}, errStr: "empty JSON key"},
+		{name: "same key", metadata: &Metadata{JSONKeys: map[string]string{"full_name": "id"}}, errStr: "key: id\n  fields: id, full_name"},
+		{name: "embed under a field key", metadata: &Metadata{Embed: map[string]string{"id": "shop.orders.owner"}}, errStr: "key: id\n  field: id"},
+		{name: "unknown model", metadata: &Metadata{Embed: map[string]string{"orders": "orders"}}, errStr: "embedded model not found"},
+		{name: "model without reference", metadata: &Metadata{Embed: map[string]string{"people": "users"}}, errStr: "no field read from the model"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, _ := embeddingModels(t, tt.metadata)
+
+			err := users.checkJSON()
+			if tt.errStr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errStr)
+		})
+	}
+}
+
+func TestJSONVars(t *testing.T) {
+	users, _ := embeddingModels(t, &Metadata{
+		Columns:  map[string]string{"scratch": "-", "tags": "labels"},
+		JSONKeys: map[string]string{"full_name": "fullName"},
+		Embed:    map[string]string{"orders": "shop.orders.owner"},
+	})
+	require.NoError(t, users.checkJSON())
+
+	vars := jsonVars(users)
+	assert.Equal(t, []jsonFieldVars{
+		{Name: "id", Key: "id"},
+		{Name: "full_name", Key: "fullName"},
+		{Name: "tags", Key: "labels"},
+	}, vars.JSONFields)
+	assert.Equal(t, []jsonEmbedVars{{
+		Key:         "orders",
+		Model:       "shop.orders",
+		ChildType:   "shop" + utils.DgDirDelimeter + "orders",
+		ChildField:  "owner",
+		ParentField: "id",
+	}}, vars.JSONEmbeds)
+}
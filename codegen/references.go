@@ -0,0 +1,306 @@
+package codegen
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+const (
+	selfIdent    = "self"
+	datagenIdent = "datagen"
+	iterIdent    = "iter"
+)
+
+type fieldRef struct {
+	model string
+	field string
+}
+
+// references records which generated values have to outlive the record they
+// belong to. Gen functions may read any row of their own model via
+// self.<field>(i) and any row of another model via
+// self.datagen.<dirs...>.<Model>().<field>(i); only fields read this way need
+// a memo that keeps more than the current row.
+type references struct {
+	fields map[fieldRef]struct{}
+	deps   map[string]map[string]struct{}
+	// selfEscapes holds models whose self is used in a way the analysis cannot
+	// follow (for example passed to a helper); all their fields are memoized.
+	selfEscapes map[string]struct{}
+	// datagenEscapes is set when self.datagen is used in a way the analysis
+	// cannot follow; every field of every model is memoized then.
+	datagenEscapes bool
+	// otherRows holds the fields that read another row of their own model.
+	otherRows map[fieldRef]struct{}
+	// foreignKeys maps the fields whose every value is read from a field of
+	// another model to that field.
+	foreignKeys map[fieldRef]fieldRef
+	// metadata holds the metadata of each model, which names the tables and
+	// columns foreign keys point to.
+	metadata map[string]*Metadata
+}
+
+// analyzeReferences walks every gen function body and collects the fields
+// that are referenced through self or self.datagen, along with the models
+// each model depends on.
+func analyzeReferences(parsed []*DatagenParsed) *references {
+	refs := &references{
+		fields:      map[fieldRef]struct{}{},
+		deps:        map[string]map[string]struct{}{},
+		selfEscapes: map[string]struct{}{},
+		otherRows:   map[fieldRef]struct{}{},
+		foreignKeys: map[fieldRef]fieldRef{},
+		metadata:    map[string]*Metadata{},
+	}
+
+	// same-model references with the current iter only need the value for
+	// the row being generated, unless the referencing field itself is memoized
+	sameIter := map[fieldRef][]fieldRef{}
+
+	for _, d := range parsed {
+		refs.metadata[d.FullyQualifiedModelName] = d.Metadata
+
+		fields := map[string]struct{}{}
+		for _, f := range getFieldData(d) {
+			fields[f.Name] = struct{}{}
+		}
+
+		for _, genFn := range d.GenFuns {
+			if genFn.Body == nil {
+				continue
+			}
+			from := fieldRef{model: d.FullyQualifiedModelName, field: genFn.Name}
+			w := &referenceWalker{refs: refs, model: d.FullyQualifiedModelName, fields: fields}
+			ast.Inspect(genFn.Body, w.visit)
+			for _, to := range w.sameIter {
+				sameIter[from] = append(sameIter[from], to)
+			}
+			if w.otherRows {
+				refs.otherRows[from] = struct{}{}
+			}
+			if to, ok := foreignKeyOf(genFn.Body); ok && to.model != from.model {
+				refs.foreignKeys[from] = to
+			}
+		}
+	}
+
+	// propagate memoization through same-iter references until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for from, tos := range sameIter {
+			if !refs.memoized(from.model, from.field) {
+				continue
+			}
+			for _, to := range tos {
+				if _, ok := refs.fields[to]; !ok {
+					refs.fields[to] = struct{}{}
+					changed = true
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// memoized reports whether values of the given field must be kept beyond the
+// row currently being generated.
+func (r *references) memoized(model, field string) bool {
+	if r.datagenEscapes {
+		return true
+	}
+	if _, ok := r.selfEscapes[model]; ok {
+		return true
+	}
+	_, ok := r.fields[fieldRef{model: model, field: field}]
+	return ok
+}
+
+// standalone reports whether a value of the given field can be generated on
+// its own, without generating the values of other rows of its model first.
+func (r *references) standalone(model, field string) bool {
+	if r.datagenEscapes {
+		return false
+	}
+	if _, ok := r.selfEscapes[model]; ok {
+		return false
+	}
+	_, ok := r.otherRows[fieldRef{model: model, field: field}]
+	return !ok
+}
+
+// dependencies returns the sorted fully qualified names of the models the
+// given model references through self.datagen.
+func (r *references) dependencies(model string) []string {
+	deps := make([]string, 0, len(r.deps[model]))
+	for dep := range r.deps[model] {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// foreignKey returns the field of another model every value of the given
+// field is read from.
+func (r *references) foreignKey(model, field string) (fieldRef, bool) {
+	to, ok := r.foreignKeys[fieldRef{model: model, field: field}]
+	return to, ok
+}
+
+// keyFields returns the fields of the given model that other models hold
+// foreign keys to.
+func (r *references) keyFields(model string) map[string]struct{} {
+	keys := map[string]struct{}{}
+	for _, to := range r.foreignKeys {
+		if to.model == model {
+			keys[to.field] = struct{}{}
+		}
+	}
+	return keys
+}
+
+// foreignKeyOf matches gen function bodies whose every return statement
+// returns self.datagen.<dirs...>.<Model>().<field>(args) for the same field.
+func foreignKeyOf(body *ast.BlockStmt) (fieldRef, bool) {
+	var to fieldRef
+	found, ok := false, true
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			ref, matched := datagenFieldCall(node.Results)
+			if !matched || (found && ref != to) {
+				ok = false
+				return false
+			}
+			to, found = ref, true
+		}
+		return ok
+	})
+	return to, found && ok
+}
+
+func datagenFieldCall(results []ast.Expr) (fieldRef, bool) {
+	if len(results) != 1 {
+		return fieldRef{}, false
+	}
+	call, ok := results[0].(*ast.CallExpr)
+	if !ok {
+		return fieldRef{}, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return fieldRef{}, false
+	}
+	model, ok := datagenModel(sel.X)
+	if !ok {
+		return fieldRef{}, false
+	}
+	return fieldRef{model: model, field: sel.Sel.Name}, true
+}
+
+type referenceWalker struct {
+	refs      *references
+	model     string
+	fields    map[string]struct{}
+	sameIter  []fieldRef
+	otherRows bool
+}
+
+func (w *referenceWalker) visit(n ast.Node) bool {
+	switch node := n.(type) {
+	case *ast.CallExpr:
+		sel, ok := node.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if isSelf(sel.X) && sel.Sel.Name != datagenIdent {
+			// self.<field>(args)
+			if _, ok := w.fields[sel.Sel.Name]; !ok {
+				w.refs.selfEscapes[w.model] = struct{}{}
+			} else {
+				ref := fieldRef{model: w.model, field: sel.Sel.Name}
+				if isCurrentIter(node.Args) {
+					w.sameIter = append(w.sameIter, ref)
+				} else {
+					w.refs.fields[ref] = struct{}{}
+					w.otherRows = true
+				}
+			}
+			w.inspectAll(node.Args)
+			return false
+		}
+
+		if model, ok := datagenModel(sel.X); ok {
+			// self.datagen.<dirs...>.<Model>().<field>(args)
+			w.refs.fields[fieldRef{model: model, field: sel.Sel.Name}] = struct{}{}
+			if _, ok := w.refs.deps[w.model]; !ok {
+				w.refs.deps[w.model] = map[string]struct{}{}
+			}
+			w.refs.deps[w.model][model] = struct{}{}
+			w.inspectAll(node.Args)
+			return false
+		}
+	case *ast.SelectorExpr:
+		if isSelf(node.X) && node.Sel.Name == datagenIdent {
+			w.refs.datagenEscapes = true
+			return false
+		}
+	case *ast.Ident:
+		if node.Name == selfIdent {
+			w.refs.selfEscapes[w.model] = struct{}{}
+		}
+	}
+	return true
+}
+
+func (w *referenceWalker) inspectAll(exprs []ast.Expr) {
+	for _, e := range exprs {
+		ast.Inspect(e, w.visit)
+	}
+}
+
+func isSelf(e ast.Expr) bool {
+	ident, ok := e.(*ast.Ident)
+	return ok && ident.Name == selfIdent
+}
+
+func isCurrentIter(args []ast.Expr) bool {
+	if len(args) != 1 {
+		return false
+	}
+	ident, ok := args[0].(*ast.Ident)
+	return ok && ident.Name == iterIdent
+}
+
+// datagenModel matches self.datagen.<dirs...>.<Model>() and returns the fully
+// qualified model name it refers to.
+func datagenModel(e ast.Expr) (string, bool) {
+	call, ok := e.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return "", false
+	}
+
+	var path []string
+	cur := call.Fun
+	for {
+		sel, ok := cur.(*ast.SelectorExpr)
+		if !ok {
+			return "", false
+		}
+		if isSelf(sel.X) && sel.Sel.Name == datagenIdent {
+			break
+		}
+		path = append([]string{sel.Sel.Name}, path...)
+		cur = sel.X
+	}
+	if len(path) == 0 {
+		return "", false
+	}
+	return strings.Join(path, utils.DgDirDelimeter), true
+}
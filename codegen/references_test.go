@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+func parsedModel(t *testing.T, fqName string, gens map[string]string) *DatagenParsed {
+	t.Helper()
+
+	d := &DatagenParsed{
+		ModelName:               modelNameOf(fqName),
+		FullyQualifiedModelName: fqName,
+		Fields:                  &ast.FieldList{},
+	}
+	for name, body := range gens {
+		expr, err := parser.ParseExpr("func() " + body)
+		require.NoError(t, err)
+		d.Fields.List = append(d.Fields.List, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(name)},
+			Type:  ast.NewIdent("int"),
+		})
+		d.GenFuns = append(d.GenFuns, &GenFn{Name: name, Body: expr.(*ast.FuncLit).Body})
+	}
+	return d
+}
+
+func TestAnalyzeReferences(t *testing.T) {
+	orders := "shop" + utils.DgDirDelimeter + "orders"
+
+	users := parsedModel(t, "users", map[string]string{
+		"id":   "{ return iter }",
+		"age":  "{ return IntBetween(18, 90) }",
+		"tier": "{ return self.age(iter) / 10 }",
+	})
+	shopOrders := parsedModel(t, orders, map[string]string{
+		"id":      "{ return iter }",
+		"user_id": "{ return self.datagen.users().id(IntBetween(0, 9)) }",
+		"amount":  "{ return IntBetween(1, 100) }",
+		"total": `{
+			if iter == 0 {
+				return self.amount(iter)
+			}
+			return self.total(iter-1) + self.amount(iter)
+		}`,
+	})
+
+	refs := analyzeReferences([]*DatagenParsed{users, shopOrders})
+
+	assert.True(t, refs.memoized("users", "id"), "cross-model reference")
+	assert.False(t, refs.memoized("users", "age"), "same-iter reference from a field that is not memoized")
+	assert.False(t, refs.memoized("users", "tier"))
+
+	assert.True(t, refs.memoized(orders, "total"), "reference to a previous iter")
+	assert.True(t, refs.memoized(orders, "amount"), "same-iter reference from a memoized field")
+	assert.False(t, refs.memoized(orders, "id"))
+	assert.False(t, refs.memoized(orders, "user_id"))
+
+	assert.False(t, refs.standalone(orders, "total"), "reads another row of its model")
+	assert.True(t, refs.standalone(orders, "amount"))
+	assert.True(t, refs.standalone(orders, "user_id"), "reads a row of another model")
+
+	assert.Equal(t, []string{"users"}, refs.dependencies(orders))
+	assert.Empty(t, refs.dependencies("users"))
+
+	to, ok := refs.foreignKey(orders, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, fieldRef{model: "users", field: "id"}, to)
+	_, ok = refs.foreignKey(orders, "total")
+	assert.False(t, ok)
+	assert.Equal(t, map[string]struct{}{"id": {}}, refs.keyFields("users"))
+}
+
+func TestAnalyzeReferencesNestedModel(t *testing.T) {
+	tableB := "serviceA" + utils.DgDirDelimeter + "tableB"
+	b := parsedModel(t, tableB, map[string]string{"id": "{ return iter }"})
+	a := parsedModel(t, "tableA", map[string]string{
+		"b_id": "{ return self.datagen.serviceA.tableB().id(iter) }",
+	})
+
+	refs := analyzeReferences([]*DatagenParsed{a, b})
+
+	assert.True(t, refs.memoized(tableB, "id"))
+	assert.Equal(t, []string{tableB}, refs.dependencies("tableA"))
+}
+
+func TestAnalyzeReferencesEscapes(t *testing.T) {
+	t.Run("self passed around", func(t *testing.T) {
+		m := parsedModel(t, "m", map[string]string{
+			"a": "{ return iter }",
+			"b": "{ return helper(self, iter) }",
+		})
+		other := parsedModel(t, "other", map[string]string{"c": "{ return iter }"})
+
+		refs := analyzeReferences([]*DatagenParsed{m, other})
+
+		assert.True(t, refs.memoized("m", "a"))
+		assert.True(t, refs.memoized("m", "b"))
+		assert.False(t, refs.memoized("other", "c"))
+	})
+
+	t.Run("self.datagen stored in a variable", func(t *testing.T) {
+		m := parsedModel(t, "m", map[string]string{
+			"a": "{ dg := self.datagen; return dg.other().c(iter) }",
+		})
+		other := parsedModel(t, "other", map[string]string{"c": "{ return iter }"})
+
+		refs := analyzeReferences([]*DatagenParsed{m, other})
+
+		assert.True(t, refs.memoized("m", "a"))
+		assert.True(t, refs.memoized("other", "c"))
+	})
+}
@@ -0,0 +1,368 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+// Dialect is the SQL dialect table definitions are written in.
+type Dialect string
+
+const (
+	DialectMySQL    Dialect = "mysql"
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// Dialects lists the supported dialects, in the order they are documented.
+var Dialects = []Dialect{DialectMySQL, DialectPostgres, DialectSQLite}
+
+// ParseDialect returns the dialect named s.
+func ParseDialect(s string) (Dialect, error) {
+	for _, d := range Dialects {
+		if string(d) == s {
+			return d, nil
+		}
+	}
+	names := make([]string, 0, len(Dialects))
+	for _, d := range Dialects {
+		names = append(names, string(d))
+	}
+	return "", fmt.Errorf("unsupported dialect %q, expected one of %s", s, strings.Join(names, "|"))
+}
+
+const (
+	kindString = "string"
+	kindBytes  = "[]byte"
+	kindTime   = "time.Time"
+	kindJSON   = "json"
+)
+
+// columnTypes maps the kinds of values a field may hold to the column type
+// storing them in each dialect. Kinds are the Go basic types, plus time.Time,
+// []byte and json for slices, maps and structs.
+var columnTypes = map[string]map[Dialect]string{
+	"bool":     {DialectMySQL: "BOOLEAN", DialectPostgres: "BOOLEAN", DialectSQLite: "BOOLEAN"},
+	"int8":     {DialectMySQL: "TINYINT", DialectPostgres: "SMALLINT", DialectSQLite: "INTEGER"},
+	"int16":    {DialectMySQL: "SMALLINT", DialectPostgres: "SMALLINT", DialectSQLite: "INTEGER"},
+	"int32":    {DialectMySQL: "INT", DialectPostgres: "INTEGER", DialectSQLite: "INTEGER"},
+	"int":      {DialectMySQL: "BIGINT", DialectPostgres: "BIGINT", DialectSQLite: "INTEGER"},
+	"int64":    {DialectMySQL: "BIGINT", DialectPostgres: "BIGINT", DialectSQLite: "INTEGER"},
+	"uint8":    {DialectMySQL: "TINYINT UNSIGNED", DialectPostgres: "SMALLINT", DialectSQLite: "INTEGER"},
+	"uint16":   {DialectMySQL: "SMALLINT UNSIGNED", DialectPostgres: "INTEGER", DialectSQLite: "INTEGER"},
+	"uint32":   {DialectMySQL: "INT UNSIGNED", DialectPostgres: "BIGINT", DialectSQLite: "INTEGER"},
+	"uint":     {DialectMySQL: "BIGINT UNSIGNED", DialectPostgres: "NUMERIC(20)", DialectSQLite: "NUMERIC"},
+	"uint64":   {DialectMySQL: "BIGINT UNSIGNED", DialectPostgres: "NUMERIC(20)", DialectSQLite: "NUMERIC"},
+	"float32":  {DialectMySQL: "FLOAT", DialectPostgres: "REAL", DialectSQLite: "REAL"},
+	"float64":  {DialectMySQL: "DOUBLE", DialectPostgres: "DOUBLE PRECISION", DialectSQLite: "REAL"},
+	kindString: {DialectMySQL: "TEXT", DialectPostgres: "TEXT", DialectSQLite: "TEXT"},
+	kindBytes:  {DialectMySQL: "BLOB", DialectPostgres: "BYTEA", DialectSQLite: "BLOB"},
+	kindTime:   {DialectMySQL: "TIMESTAMP", DialectPostgres: "TIMESTAMP", DialectSQLite: "TIMESTAMP"},
+	kindJSON:   {DialectMySQL: "JSON", DialectPostgres: "JSONB", DialectSQLite: "TEXT"},
+}
+
+// keyStringType is the type of string columns that are part of a key in
+// MySQL, which cannot index TEXT columns without a prefix length.
+const keyStringType = "VARCHAR(255)"
+
+var kindAliases = map[string]string{
+	"byte": "uint8",
+	"rune": "int32",
+}
+
+// miscTypes holds the types declared in the misc sections of all models, by
+// name, so that fields of those types can be given a column type.
+type miscTypes map[string]ast.Expr
+
+func collectMiscTypes(parsed []*DatagenParsed) miscTypes {
+	types := miscTypes{}
+	for _, d := range parsed {
+		file, err := parseMisc(token.NewFileSet(), d.Misc)
+		if err != nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && ts.TypeParams == nil {
+					types[ts.Name.Name] = ts.Type
+				}
+			}
+		}
+	}
+	return types
+}
+
+// columnKind resolves the Go type of a field to a key of columnTypes, and
+// reports whether the column may hold NULL.
+func (t miscTypes) columnKind(expr ast.Expr) (string, bool, error) {
+	return t.resolveKind(expr, map[string]struct{}{})
+}
+
+func (t miscTypes) resolveKind(expr ast.Expr, seen map[string]struct{}) (string, bool, error) {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		kind, _, err := t.resolveKind(e.X, seen)
+		return kind, true, err
+	case *ast.ParenExpr:
+		return t.resolveKind(e.X, seen)
+	case *ast.Ident:
+		name := e.Name
+		if alias, ok := kindAliases[name]; ok {
+			name = alias
+		}
+		if _, ok := columnTypes[name]; ok && name != kindJSON {
+			return name, false, nil
+		}
+		if underlying, ok := t[name]; ok {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				return t.resolveKind(underlying, seen)
+			}
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok && pkg.Name+"."+e.Sel.Name == kindTime {
+			return kindTime, false, nil
+		}
+	case *ast.ArrayType:
+		if e.Len == nil {
+			if elem, ok := e.Elt.(*ast.Ident); ok && (elem.Name == "byte" || elem.Name == "uint8") {
+				return kindBytes, true, nil
+			}
+			return kindJSON, true, nil
+		}
+		return kindJSON, false, nil
+	case *ast.MapType:
+		return kindJSON, true, nil
+	case *ast.StructType:
+		return kindJSON, false, nil
+	}
+	return "", false, fmt.Errorf("no column type for Go type %s", stringifyExpr(expr))
+}
+
+// table is the SQL table the records of a model are loaded into.
+type table struct {
+	schema      string
+	name        string
+	columns     []column
+	primaryKey  string
+	unique      []string
+	foreignKeys []foreignKey
+}
+
+type column struct {
+	name     string
+	kind     string
+	nullable bool
+	// key is set for columns that are part of a key or foreign key
+	key bool
+}
+
+type foreignKey struct {
+	column      string
+	tableSchema string
+	table       string
+	refColumn   string
+}
+
+// buildTable derives the table of a model from its persisted fields. Fields
+// other models hold foreign keys to become the primary key of the table, or
+// unique keys when there are several of them or they may be NULL.
+func (d *DatagenParsed) buildTable() (*table, error) {
+	if err := d.checkColumns(); err != nil {
+		return nil, err
+	}
+	refs := d.references
+	if refs == nil {
+		refs = analyzeReferences([]*DatagenParsed{d})
+	}
+	keys := refs.keyFields(d.FullyQualifiedModelName)
+
+	t := &table{}
+	t.schema, t.name = d.Metadata.table(d.ModelName)
+	var keyColumns []column
+	if d.Fields != nil {
+		for _, field := range d.Fields.List {
+			kind, nullable, err := d.miscTypes.columnKind(fieldType(field.Type))
+			if err != nil {
+				return nil, fmt.Errorf("unsupported field type\n  model: %s\n  field: %s\n  cause: %w", d.FullyQualifiedModelName, field.Names[0].Name, err)
+			}
+			for _, name := range field.Names {
+				colName, ok := d.Metadata.column(name.Name)
+				if !ok {
+					continue
+				}
+				c := column{name: colName, kind: kind, nullable: nullable}
+				if to, ok := refs.foreignKey(d.FullyQualifiedModelName, name.Name); ok {
+					target := refs.metadata[to.model]
+					if refColumn, ok := target.column(to.field); ok {
+						c.key = true
+						refSchema, refTable := target.table(modelNameOf(to.model))
+						t.foreignKeys = append(t.foreignKeys, foreignKey{column: colName, tableSchema: refSchema, table: refTable, refColumn: refColumn})
+					}
+				}
+				if _, ok := keys[name.Name]; ok {
+					c.key = true
+					keyColumns = append(keyColumns, c)
+				}
+				t.columns = append(t.columns, c)
+			}
+		}
+	}
+
+	if len(keyColumns) == 1 && !keyColumns[0].nullable {
+		t.primaryKey = keyColumns[0].name
+	} else {
+		for _, c := range keyColumns {
+			t.unique = append(t.unique, c.name)
+		}
+	}
+	return t, nil
+}
+
+// createStatement renders the CREATE TABLE statement of the table, which
+// leaves an existing table alone.
+func (t *table) createStatement(dialect Dialect) string {
+	quote := func(name string) string {
+		return quoteIdent(dialect, name)
+	}
+
+	lines := make([]string, 0, len(t.columns)+len(t.unique)+len(t.foreignKeys)+1)
+	for _, c := range t.columns {
+		typ := columnTypes[c.kind][dialect]
+		if c.kind == kindString && c.key && dialect == DialectMySQL {
+			typ = keyStringType
+		}
+		line := "  " + quote(c.name) + " " + typ
+		if !c.nullable {
+			line += " NOT NULL"
+		}
+		lines = append(lines, line)
+	}
+	if t.primaryKey != "" {
+		lines = append(lines, "  PRIMARY KEY ("+quote(t.primaryKey)+")")
+	}
+	for _, name := range t.unique {
+		lines = append(lines, "  UNIQUE ("+quote(name)+")")
+	}
+	for _, fk := range t.foreignKeys {
+		lines = append(lines, "  FOREIGN KEY ("+quote(fk.column)+") REFERENCES "+qualifiedTable(dialect, fk.tableSchema, fk.table)+" ("+quote(fk.refColumn)+")")
+	}
+	return "CREATE TABLE IF NOT EXISTS " + qualifiedTable(dialect, t.schema, t.name) + " (\n" + strings.Join(lines, ",\n") + "\n);"
+}
+
+// truncateStatement returns the statement emptying a table, as the sinks of
+// each dialect do before loading it.
+func truncateStatement(dialect Dialect, table string) string {
+	if dialect == DialectPostgres {
+		return "TRUNCATE TABLE " + table + " RESTART IDENTITY CASCADE;"
+	}
+	return "DELETE FROM " + table + ";"
+}
+
+// createTable returns the CREATE TABLE statement of the model in the given
+// dialect.
+func (d *DatagenParsed) createTable(dialect Dialect) (string, error) {
+	t, err := d.buildTable()
+	if err != nil {
+		return "", err
+	}
+	return t.createStatement(dialect), nil
+}
+
+// Schema returns the CREATE TABLE statements of the models in the given
+// dialect, ordered so that every table comes after the tables its foreign
+// keys point to.
+func Schema(parsed []*DatagenParsed, dialect Dialect) (string, error) {
+	analyze(parsed)
+
+	ordered, err := tableOrder(parsed)
+	if err != nil {
+		return "", err
+	}
+
+	statements := make([]string, 0, len(ordered))
+	for _, d := range ordered {
+		stmt, err := d.createTable(dialect)
+		if err != nil {
+			return "", err
+		}
+		statements = append(statements, stmt)
+	}
+	return strings.Join(statements, "\n\n") + "\n", nil
+}
+
+// tableOrder sorts the models topologically by the models they reference,
+// breaking ties by fully qualified name.
+func tableOrder(parsed []*DatagenParsed) ([]*DatagenParsed, error) {
+	byName := make(map[string]*DatagenParsed, len(parsed))
+	for _, d := range parsed {
+		byName[d.FullyQualifiedModelName] = d
+	}
+
+	pending := make(map[string]map[string]struct{}, len(parsed))
+	for _, d := range parsed {
+		deps := map[string]struct{}{}
+		for _, dep := range d.references.dependencies(d.FullyQualifiedModelName) {
+			if _, ok := byName[dep]; ok && dep != d.FullyQualifiedModelName {
+				deps[dep] = struct{}{}
+			}
+		}
+		pending[d.FullyQualifiedModelName] = deps
+	}
+
+	ordered := make([]*DatagenParsed, 0, len(parsed))
+	for len(pending) > 0 {
+		var ready []string
+		for name, deps := range pending {
+			if len(deps) == 0 {
+				ready = append(ready, name)
+			}
+		}
+		if len(ready) == 0 {
+			var cycle []string
+			for name := range pending {
+				cycle = append(cycle, name)
+			}
+			sort.Strings(cycle)
+			return nil, fmt.Errorf("models reference each other in a cycle\n  models: %s", strings.Join(cycle, ", "))
+		}
+
+		sort.Strings(ready)
+		for _, name := range ready {
+			ordered = append(ordered, byName[name])
+			delete(pending, name)
+		}
+		for _, deps := range pending {
+			for _, name := range ready {
+				delete(deps, name)
+			}
+		}
+	}
+	return ordered, nil
+}
+
+// fieldType returns the type of the values of a field, declared in the fields
+// section as the result of a function.
+func fieldType(expr ast.Expr) ast.Expr {
+	if ft, ok := expr.(*ast.FuncType); ok && ft.Results != nil && len(ft.Results.List) > 0 {
+		return ft.Results.List[0].Type
+	}
+	return expr
+}
+
+// modelNameOf returns the model name of a fully qualified model name.
+func modelNameOf(fqName string) string {
+	if i := strings.LastIndex(fqName, utils.DgDirDelimeter); i >= 0 {
+		return fqName[i+len(utils.DgDirDelimeter):]
+	}
+	return fqName
+}
@@ -0,0 +1,161 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+// typedModel builds a model whose fields are declared with the given types,
+// in order, and generated by the given bodies.
+func typedModel(t *testing.T, fqName string, fields [][3]string) *DatagenParsed {
+	t.Helper()
+
+	d := parsedModel(t, fqName, nil)
+	for _, f := range fields {
+		typ, err := parser.ParseExpr(f[1])
+		require.NoError(t, err)
+		d.Fields.List = append(d.Fields.List, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(f[0])},
+			Type:  &ast.FuncType{Results: &ast.FieldList{List: []*ast.Field{{Type: typ}}}},
+		})
+
+		body, err := parser.ParseExpr("func() " + f[2])
+		require.NoError(t, err)
+		d.GenFuns = append(d.GenFuns, &GenFn{Name: f[0], Body: body.(*ast.FuncLit).Body})
+	}
+	return d
+}
+
+func TestSchema(t *testing.T) {
+	users := typedModel(t, "users", [][3]string{
+		{"id", "int64", "{ return int64(iter) }"},
+		{"email", "string", "{ return Email() }"},
+		{"tags", "[]string", "{ return nil }"},
+		{"created_at", "time.Time", "{ return Date() }"},
+		{"manager_id", "*int", "{ return nil }"},
+	})
+	orders := typedModel(t, "shop"+utils.DgDirDelimeter+"orders", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"user_id", "int64", `{
+			if iter%2 == 0 {
+				return self.datagen.users().id(iter)
+			}
+			return self.datagen.users().id(0)
+		}`},
+		{"status", "Status", "{ return Status(\"new\") }"},
+		{"attrs", "map[string]any", "{ return nil }"},
+	})
+	orders.Misc = "type Status string"
+
+	mysql, err := Schema([]*DatagenParsed{orders, users}, DialectMySQL)
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS `users` (\n"+
+		"  `id` BIGINT NOT NULL,\n"+
+		"  `email` TEXT NOT NULL,\n"+
+		"  `tags` JSON,\n"+
+		"  `created_at` TIMESTAMP NOT NULL,\n"+
+		"  `manager_id` BIGINT,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		");\n\n"+
+		"CREATE TABLE IF NOT EXISTS `orders` (\n"+
+		"  `id` BIGINT NOT NULL,\n"+
+		"  `user_id` BIGINT NOT NULL,\n"+
+		"  `status` TEXT NOT NULL,\n"+
+		"  `attrs` JSON,\n"+
+		"  FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)\n"+
+		");\n", mysql)
+
+	postgres, err := Schema([]*DatagenParsed{orders, users}, DialectPostgres)
+	require.NoError(t, err)
+	assert.Contains(t, postgres, "\"tags\" JSONB,\n")
+	assert.Contains(t, postgres, "FOREIGN KEY (\"user_id\") REFERENCES \"users\" (\"id\")")
+
+	sqlite, err := Schema([]*DatagenParsed{orders, users}, DialectSQLite)
+	require.NoError(t, err)
+	assert.Contains(t, sqlite, "\"id\" INTEGER NOT NULL,\n")
+	assert.Contains(t, sqlite, "\"tags\" TEXT,\n")
+	assert.Contains(t, sqlite, "FOREIGN KEY (\"user_id\") REFERENCES \"users\" (\"id\")")
+}
+
+func TestSchemaKeys(t *testing.T) {
+	users := typedModel(t, "users", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"handle", "string", "{ return Username() }"},
+	})
+	posts := typedModel(t, "posts", [][3]string{
+		{"author_id", "int", "{ return self.datagen.users().id(iter) }"},
+		{"author", "string", "{ return self.datagen.users().handle(iter) }"},
+		{"title", "string", "{ return \"re: \" + self.datagen.users().handle(iter) }"},
+	})
+
+	mysql, err := Schema([]*DatagenParsed{users, posts}, DialectMySQL)
+	require.NoError(t, err)
+	assert.Contains(t, mysql, "`handle` VARCHAR(255) NOT NULL,\n  UNIQUE (`id`),\n  UNIQUE (`handle`)\n")
+	assert.Contains(t, mysql, "`author` VARCHAR(255) NOT NULL")
+	assert.Contains(t, mysql, "`title` TEXT NOT NULL", "value derived from a reference is not a foreign key")
+	assert.NotContains(t, mysql, "FOREIGN KEY (`title`)")
+}
+
+func TestSchemaMapping(t *testing.T) {
+	users := typedModel(t, "users", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"domain", "string", "{ return \"example.com\" }"},
+		{"email", "string", "{ return \"user@\" + self.domain(iter) }"},
+	})
+	users.Metadata = &Metadata{
+		Table:   "user_accounts",
+		Schema:  "billing",
+		Columns: map[string]string{"id": "user id", "domain": "-"},
+	}
+	orders := typedModel(t, "orders", [][3]string{
+		{"user_id", "int", "{ return self.datagen.users().id(iter) }"},
+		{"user_domain", "string", "{ return self.datagen.users().domain(iter) }"},
+	})
+
+	postgres, err := Schema([]*DatagenParsed{orders, users}, DialectPostgres)
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS \"billing\".\"user_accounts\" (\n"+
+		"  \"user id\" BIGINT NOT NULL,\n"+
+		"  \"email\" TEXT NOT NULL,\n"+
+		"  PRIMARY KEY (\"user id\")\n"+
+		");\n\n"+
+		"CREATE TABLE IF NOT EXISTS \"orders\" (\n"+
+		"  \"user_id\" BIGINT NOT NULL,\n"+
+		"  \"user_domain\" TEXT NOT NULL,\n"+
+		"  FOREIGN KEY (\"user_id\") REFERENCES \"billing\".\"user_accounts\" (\"user id\")\n"+
+		");\n", postgres, "fields that are not persisted are neither columns nor keys")
+}
+
+func TestSchemaErrors(t *testing.T) {
+	t.Run("unsupported type", func(t *testing.T) {
+		m := typedModel(t, "m", [][3]string{{"ch", "chan int", "{ return nil }"}})
+
+		_, err := Schema([]*DatagenParsed{m}, DialectPostgres)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field: ch")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		a := typedModel(t, "a", [][3]string{{"b_id", "int", "{ return self.datagen.b().id(iter) }"}})
+		b := typedModel(t, "b", [][3]string{{"id", "int", "{ return self.datagen.a().b_id(iter) }"}})
+
+		_, err := Schema([]*DatagenParsed{a, b}, DialectMySQL)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "models: a, b")
+	})
+}
+
+func TestParseDialect(t *testing.T) {
+	d, err := ParseDialect("postgres")
+	require.NoError(t, err)
+	assert.Equal(t, DialectPostgres, d)
+
+	_, err = ParseDialect("oracle")
+	assert.EqualError(t, err, `unsupported dialect "oracle", expected one of mysql|postgres|sqlite`)
+}
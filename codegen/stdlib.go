@@ -0,0 +1,404 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+const (
+	// fieldRandIdent is the parameter holding the random stream of the value
+	// being generated, in gen functions and in the misc helpers they call.
+	fieldRandIdent = "__dgi_r"
+	// sharedRandIdent is the random stream used by misc code that is not
+	// passed the stream of a field.
+	sharedRandIdent = "__dgi_sharedRand"
+	randTypeName    = "__dgi_Rand"
+
+	miscHeader = "package main\n"
+)
+
+// randFuncs holds the functions that take the random stream to draw from as
+// their first parameter. Models call them without it and the compiler passes
+// the stream in.
+type randFuncs map[string]struct{}
+
+// stdlibRandFuncs holds the stdlib helpers that draw random values.
+var stdlibRandFuncs = loadStdlibRandFuncs()
+
+func loadStdlibRandFuncs() randFuncs {
+	src, err := templates.ReadFile(tmplStdlib)
+	if err != nil {
+		panic(fmt.Sprintf("reading %s: %v", tmplStdlib, err))
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), tmplStdlib, src, parser.SkipObjectResolution)
+	if err != nil {
+		panic(fmt.Sprintf("parsing %s: %v", tmplStdlib, err))
+	}
+
+	funcs := randFuncs{}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || len(fn.Type.Params.List) == 0 {
+			continue
+		}
+		star, ok := fn.Type.Params.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		if ident, ok := star.X.(*ast.Ident); ok && ident.Name == randTypeName {
+			funcs[fn.Name.Name] = struct{}{}
+		}
+	}
+	return funcs
+}
+
+// analyzeRandFuncs adds to the stdlib helpers the misc helpers, of any model,
+// that call one of them directly or through other misc helpers. Those helpers
+// are passed the stream of the field calling them too, so the values they
+// draw do not depend on anything else being generated. Helpers that are used
+// as values rather than called keep drawing from the shared stream, as their
+// signature cannot change.
+func analyzeRandFuncs(parsed []*DatagenParsed) randFuncs {
+	var decls []*ast.FuncDecl
+	var nodes []ast.Node
+	for _, d := range parsed {
+		if file, err := parseMisc(token.NewFileSet(), d.Misc); err == nil {
+			nodes = append(nodes, file)
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Body != nil && fn.Name.Name != "init" {
+					decls = append(decls, fn)
+				}
+			}
+		}
+		for _, genFn := range d.GenFuns {
+			if genFn.Body != nil {
+				nodes = append(nodes, genFn.Body)
+			}
+		}
+		for _, call := range d.Calls {
+			nodes = append(nodes, call)
+		}
+	}
+
+	escaped := map[string]struct{}{}
+	for _, node := range nodes {
+		collectFuncValues(node, escaped)
+	}
+
+	funcs := randFuncs{}
+	for name := range stdlibRandFuncs {
+		funcs[name] = struct{}{}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range decls {
+			if _, ok := funcs[fn.Name.Name]; ok {
+				continue
+			}
+			if _, ok := escaped[fn.Name.Name]; ok {
+				continue
+			}
+			if funcs.calledIn(fn.Body) {
+				funcs[fn.Name.Name] = struct{}{}
+				changed = true
+			}
+		}
+	}
+	return funcs
+}
+
+// deterministicPkgs are the packages whose functions only depend on their
+// arguments. Calls into any other package may draw random values of their own.
+var deterministicPkgs = map[string]struct{}{
+	"base64": {}, "bits": {}, "bytes": {}, "errors": {}, "fmt": {}, "hex": {},
+	"json": {}, "maps": {}, "math": {}, "slices": {}, "sort": {}, "strconv": {},
+	"strings": {}, "time": {}, "unicode": {}, "utf8": {},
+}
+
+// clockFuncs are the functions of the time package that read the clock.
+var clockFuncs = map[string]struct{}{"Now": {}, "Since": {}, "Until": {}}
+
+// impureHelpers holds the stdlib and misc helpers, functions and methods,
+// whose results may depend on more than their arguments and the stream they
+// are passed, because they draw from the shared stream, gofakeit or
+// math/rand, or read the clock. Methods are only known by their name.
+type impureHelpers struct {
+	funcs   map[string]struct{}
+	methods map[string]struct{}
+}
+
+// analyzeImpureHelpers finds the impure helpers among the stdlib helpers and
+// the misc helpers of every model, including the ones that are only impure
+// through the helpers they call.
+func analyzeImpureHelpers(parsed []*DatagenParsed, rands randFuncs) *impureHelpers {
+	var decls []*ast.FuncDecl
+	if src, err := templates.ReadFile(tmplStdlib); err == nil {
+		if file, err := parser.ParseFile(token.NewFileSet(), tmplStdlib, src, 0); err == nil {
+			decls = append(decls, funcDecls(file)...)
+		}
+	}
+	for _, d := range parsed {
+		if file, err := parseMisc(token.NewFileSet(), d.Misc); err == nil {
+			decls = append(decls, funcDecls(file)...)
+		}
+	}
+
+	impure := &impureHelpers{funcs: map[string]struct{}{}, methods: map[string]struct{}{}}
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range decls {
+			names := impure.funcs
+			if fn.Recv != nil {
+				names = impure.methods
+			}
+			if _, ok := names[fn.Name.Name]; ok {
+				continue
+			}
+			_, bound := rands[fn.Name.Name]
+			if !impure.pure(fn, rands, bound && fn.Recv == nil) {
+				names[fn.Name.Name] = struct{}{}
+				changed = true
+			}
+		}
+	}
+	return impure
+}
+
+func funcDecls(file *ast.File) []*ast.FuncDecl {
+	var decls []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+			decls = append(decls, fn)
+		}
+	}
+	return decls
+}
+
+// pure reports whether node draws random values from nothing but the stream
+// of its field. Calls to helpers in rands only count as such when bound is
+// set, that is when they are passed the stream of the field rather than the
+// shared one. Identifiers must have been resolved, so that packages and
+// package level variables can be told apart from what node declares itself.
+func (impure *impureHelpers) pure(node ast.Node, rands randFuncs, bound bool) bool {
+	pure := true
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if ident := calledIdent(n); ident != nil && !declaredIn(ident, node) {
+				if _, ok := impure.funcs[ident.Name]; ok {
+					pure = false
+				}
+				if !bound && rands.drawsRand(n) {
+					pure = false
+				}
+			}
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+				x, isIdent := sel.X.(*ast.Ident)
+				if _, isField := datagenModel(sel.X); isField || isSelf(sel.X) {
+					// a field, read through its memo
+				} else if isIdent && !declaredIn(x, node) {
+					// a package, or a variable declared outside of node
+					if _, ok := deterministicPkgs[x.Name]; !ok {
+						pure = false
+					}
+				} else if _, ok := impure.methods[sel.Sel.Name]; ok {
+					pure = false
+				}
+			}
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && x.Name == "time" && !declaredIn(x, node) {
+				if _, ok := clockFuncs[n.Sel.Name]; ok {
+					pure = false
+				}
+			}
+		case *ast.Ident:
+			if n.Name == sharedRandIdent {
+				pure = false
+			}
+		}
+		return pure
+	})
+	return pure
+}
+
+// declaredIn reports whether ident refers to something declared within node.
+func declaredIn(ident *ast.Ident, node ast.Node) bool {
+	if ident.Obj == nil {
+		return false
+	}
+	decl, ok := ident.Obj.Decl.(ast.Node)
+	return ok && decl.Pos() >= node.Pos() && decl.End() <= node.End()
+}
+
+// collectFuncValues adds to names every identifier in node that is not the
+// function of a call or the name of a function declaration.
+func collectFuncValues(node ast.Node, names map[string]struct{}) {
+	skip := map[*ast.Ident]struct{}{}
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			skip[n.Name] = struct{}{}
+		case *ast.CallExpr:
+			if ident := calledIdent(n); ident != nil {
+				skip[ident] = struct{}{}
+			}
+		case *ast.Ident:
+			if _, ok := skip[n]; !ok {
+				names[n.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+}
+
+// calledIdent returns the function a call is made to when it is named by a
+// plain identifier, possibly instantiated with type arguments.
+func calledIdent(call *ast.CallExpr) *ast.Ident {
+	fun := call.Fun
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	ident, _ := fun.(*ast.Ident)
+	return ident
+}
+
+// drawsRand reports whether call is a call to one of funcs. Identifiers
+// declared as anything but a top level function are left alone, so a local
+// variable may still shadow a helper.
+func (funcs randFuncs) drawsRand(call *ast.CallExpr) bool {
+	ident := calledIdent(call)
+	if ident == nil || (ident.Obj != nil && ident.Obj.Kind != ast.Fun) {
+		return false
+	}
+	_, ok := funcs[ident.Name]
+	return ok
+}
+
+func (funcs randFuncs) calledIn(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && funcs.drawsRand(call) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// bind passes randExpr as the random stream to every call to one of funcs in
+// node. It reports whether any call was bound.
+func (funcs randFuncs) bind(node ast.Node, randExpr string) bool {
+	bound := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && funcs.drawsRand(call) {
+			call.Args = append([]ast.Expr{ast.NewIdent(randExpr)}, call.Args...)
+			bound = true
+		}
+		return true
+	})
+	return bound
+}
+
+// bindBody prints a gen function body with its calls bound to the stream of
+// the field. The body is printed and parsed again so that the parsed model
+// itself is left untouched.
+func (funcs randFuncs) bindBody(body *ast.BlockStmt) (string, bool, error) {
+	fset := token.NewFileSet()
+	resolved, err := resolveBody(fset, body)
+	if err != nil {
+		return "", false, err
+	}
+	bound := funcs.bind(resolved, fieldRandIdent)
+
+	var out bytes.Buffer
+	if err := printer.Fprint(&out, fset, resolved); err != nil {
+		return "", false, err
+	}
+	return out.String(), bound, nil
+}
+
+// resolveBody prints a gen function body and parses it again as the body of
+// a function of its own. Only files get their identifiers resolved, which is
+// what tells local variables apart from helpers and packages.
+func resolveBody(fset *token.FileSet, body *ast.BlockStmt) (*ast.BlockStmt, error) {
+	var src bytes.Buffer
+	if err := printer.Fprint(&src, token.NewFileSet(), body); err != nil {
+		return nil, err
+	}
+
+	file, err := parseMisc(fset, "func _() "+src.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gen function body\n  cause: %w", err)
+	}
+	return file.Decls[0].(*ast.FuncDecl).Body, nil
+}
+
+// bindExpr binds the calls of a single calls argument to the stream of the
+// field.
+func (funcs randFuncs) bindExpr(src string) (string, bool, error) {
+	fset := token.NewFileSet()
+	expr, err := parser.ParseExprFrom(fset, "", src, 0)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse expression\n  expr: %s\n  cause: %w", src, err)
+	}
+	bound := funcs.bind(expr, fieldRandIdent)
+
+	var out bytes.Buffer
+	if err := printer.Fprint(&out, fset, expr); err != nil {
+		return "", false, err
+	}
+	return out.String(), bound, nil
+}
+
+// bindDecls rewrites a misc section so that the helpers in funcs take the
+// stream of the calling field as their first parameter and pass it on. Any
+// other declaration draws from the shared stream. Sections that do not parse
+// are returned as they are and left for the Go compiler to report.
+func (funcs randFuncs) bindDecls(src string) string {
+	fset := token.NewFileSet()
+	file, err := parseMisc(fset, src)
+	if err != nil {
+		return src
+	}
+
+	bound := false
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+			if _, ok := funcs[fn.Name.Name]; ok {
+				// positioned right after the opening parenthesis, so the
+				// printer keeps the parameters on the line they were on
+				pos := fn.Type.Params.Opening + 1
+				param := &ast.Field{
+					Names: []*ast.Ident{{NamePos: pos, Name: fieldRandIdent}},
+					Type:  &ast.StarExpr{Star: pos, X: &ast.Ident{NamePos: pos, Name: randTypeName}},
+				}
+				fn.Type.Params.List = append([]*ast.Field{param}, fn.Type.Params.List...)
+				funcs.bind(fn.Body, fieldRandIdent)
+				bound = true
+				continue
+			}
+		}
+		bound = funcs.bind(decl, sharedRandIdent) || bound
+	}
+	if !bound {
+		return src
+	}
+
+	var out bytes.Buffer
+	if err := printer.Fprint(&out, fset, file); err != nil {
+		return src
+	}
+	return strings.TrimPrefix(out.String(), miscHeader)
+}
+
+func parseMisc(fset *token.FileSet, src string) (*ast.File, error) {
+	return parser.ParseFile(fset, "", miscHeader+src, parser.ParseComments)
+}
@@ -0,0 +1,129 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindBody(t *testing.T) {
+	expr, err := parser.ParseExpr(`func() {
+		IntBetween := func(a, b int) int { return a }
+		name := RandomFrom[string]("a", "b")
+		return FirstName() + name + fmt.Sprint(IntBetween(1, 2))
+	}`)
+	require.NoError(t, err)
+
+	body, bound, err := stdlibRandFuncs.bindBody(expr.(*ast.FuncLit).Body)
+	require.NoError(t, err)
+
+	assert.True(t, bound)
+	assert.Contains(t, body, `RandomFrom[string](__dgi_r, "a", "b")`)
+	assert.Contains(t, body, "FirstName(__dgi_r)")
+	assert.Contains(t, body, "IntBetween(1, 2)", "a local variable shadows the helper")
+}
+
+func TestBindExpr(t *testing.T) {
+	src, bound, err := stdlibRandFuncs.bindExpr("FloatBetween(10, 20)")
+	require.NoError(t, err)
+	assert.True(t, bound)
+	assert.Equal(t, "FloatBetween(__dgi_r, 10, 20)", src)
+
+	src, bound, err = stdlibRandFuncs.bindExpr("10 * 2")
+	require.NoError(t, err)
+	assert.False(t, bound)
+	assert.Equal(t, "10 * 2", src)
+}
+
+func TestAnalyzeRandFuncs(t *testing.T) {
+	users := parsedModel(t, "users", map[string]string{
+		"code":  "{ return code() }",
+		"label": "{ return sort.Slice(nil, less) }",
+	})
+	users.Misc = `
+func code() string { return prefix() + Numerify("###") }
+func prefix() string { return Letter() }
+func plain() string { return "x" }
+func less(i, j int) bool { return IntBetween(0, 1) == 0 }
+`
+	orders := parsedModel(t, "orders", map[string]string{"ref": "{ return ref() }"})
+	orders.Misc = `func ref() string { return "o-" + code() }`
+
+	funcs := analyzeRandFuncs([]*DatagenParsed{users, orders})
+
+	assert.Contains(t, funcs, "IntBetween")
+	assert.Contains(t, funcs, "code")
+	assert.Contains(t, funcs, "prefix")
+	assert.Contains(t, funcs, "ref", "misc helper of another model")
+	assert.NotContains(t, funcs, "plain")
+	assert.NotContains(t, funcs, "less", "helper used as a value")
+}
+
+func TestAnalyzeImpureHelpers(t *testing.T) {
+	users := parsedModel(t, "users", map[string]string{
+		"code":    "{ return code() }",
+		"nick":    "{ return nick() }",
+		"label":   `{ return strings.ToUpper(FirstName()) + fmt.Sprint(iter) }`,
+		"signup":  "{ return time.Now().Unix() }",
+		"city":    "{ return gofakeit.City() }",
+		"picked":  "{ return pick.Name() }",
+		"grouped": "{ return group{}.label() }",
+		"since":   `{ return DateBetweenStr("2020-01-01 00:00:00", "") }`,
+	})
+	users.Misc = `
+func code() string { return prefix() + Numerify("###") }
+func prefix() string { return strings.Repeat(Letter(), 2) }
+func nick() string { return shout(gofakeit.Username()) }
+func shout(s string) string { return strings.ToUpper(s) }
+func escaped() string { return Letter() }
+var pick = gofakeit.New(0)
+var names = []func() string{escaped}
+
+type group struct{}
+
+func (group) label() string { return __dgi_sharedRand.faker.Word() }
+`
+	parsed := []*DatagenParsed{users}
+	analyze(parsed)
+
+	assert.Contains(t, users.impure.funcs, "nick")
+	assert.Contains(t, users.impure.funcs, "escaped", "helper used as a value draws from the shared stream")
+	assert.Contains(t, users.impure.funcs, "DateBetweenStr", "stdlib helper reading the clock")
+	assert.Contains(t, users.impure.methods, "label")
+	assert.NotContains(t, users.impure.funcs, "code")
+	assert.NotContains(t, users.impure.funcs, "shout")
+	assert.NotContains(t, users.impure.funcs, "FirstName")
+
+	assert.True(t, users.pure("code"), "misc helpers passed the stream of the field")
+	assert.True(t, users.pure("label"))
+	assert.False(t, users.pure("nick"))
+	assert.False(t, users.pure("signup"))
+	assert.False(t, users.pure("city"))
+	assert.False(t, users.pure("picked"), "method of a package level variable")
+	assert.False(t, users.pure("grouped"))
+	assert.False(t, users.pure("since"))
+}
+
+func TestBindDecls(t *testing.T) {
+	funcs := randFuncs{"Numerify": {}, "IntBetween": {}, "code": {}}
+
+	misc := `func code() string {
+	return Numerify("###")
+}
+
+var start = IntBetween(1, 10)
+`
+	src := funcs.bindDecls(misc)
+	assert.Contains(t, src, "func code(__dgi_r *__dgi_Rand) string")
+	assert.Contains(t, src, `Numerify(__dgi_r, "###")`)
+	assert.Contains(t, src, "IntBetween(__dgi_sharedRand, 1, 10)")
+
+	plain := "func plain() string { return \"x\" }\n"
+	assert.Equal(t, plain, funcs.bindDecls(plain))
+
+	broken := "func code( {"
+	assert.Equal(t, broken, funcs.bindDecls(broken))
+}
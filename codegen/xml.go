@@ -0,0 +1,90 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// the nodes fields are written as in XML, set per field in the metadata
+const (
+	xmlAttr    = "attr"
+	xmlElement = "element"
+)
+
+// xmlFieldVars is a field written as the attribute or child element Node of
+// the XML element of its record.
+type xmlFieldVars struct {
+	Name string
+	Node string
+	Attr bool
+}
+
+// xmlNodeName returns the name of the attribute or element of a field: its
+// column, or the field name when the column is not a valid XML name.
+func xmlNodeName(field fieldData) string {
+	if isXMLName(field.Column) {
+		return field.Column
+	}
+	return field.Name
+}
+
+// isXMLAttrType reports whether values of a field type can be written as an
+// attribute, which holds text only.
+func isXMLAttrType(typ string) bool {
+	typ = strings.TrimPrefix(typ, "*")
+	if typ == "[]byte" {
+		return true
+	}
+	return !strings.HasPrefix(typ, "[") && !strings.HasPrefix(typ, "map[") && !strings.HasPrefix(typ, "struct")
+}
+
+// checkXML reports XML nodes set for fields the model does not write, nodes
+// other than attributes and elements, attributes of types holding more than
+// text, and attributes written under the same name.
+func (d *DatagenParsed) checkXML() error {
+	if d.Metadata == nil || len(d.Metadata.XML) == 0 {
+		return nil
+	}
+
+	fields := map[string]fieldData{}
+	for _, f := range getFieldData(d) {
+		fields[f.Name] = f
+	}
+
+	attrs := map[string]string{}
+	for _, name := range sortedKeys(d.Metadata.XML) {
+		field, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("XML node set for a field the model does not have\n  model: %s\n  field: %s", d.FullyQualifiedModelName, name)
+		}
+		if !field.Persisted {
+			return fmt.Errorf("XML node set for a field that is not persisted\n  model: %s\n  field: %s", d.FullyQualifiedModelName, name)
+		}
+		switch d.Metadata.XML[name] {
+		case xmlElement:
+		case xmlAttr:
+			if !isXMLAttrType(field.Type) {
+				return fmt.Errorf("field cannot be written as an XML attribute\n  model: %s\n  field: %s\n  type: %s", d.FullyQualifiedModelName, name, field.Type)
+			}
+			node := xmlNodeName(field)
+			if other, ok := attrs[node]; ok {
+				return fmt.Errorf("fields are written as the same XML attribute\n  model: %s\n  attribute: %s\n  fields: %s, %s", d.FullyQualifiedModelName, node, other, name)
+			}
+			attrs[node] = name
+		default:
+			return fmt.Errorf("invalid XML node, expected %s or %s\n  model: %s\n  field: %s\n  node: %s", xmlAttr, xmlElement, d.FullyQualifiedModelName, name, d.Metadata.XML[name])
+		}
+	}
+	return nil
+}
+
+// xmlVars returns the template variables of the XML elements of the model,
+// with its persisted fields as attributes or child elements.
+func xmlVars(d *DatagenParsed) templateVars {
+	vars := fieldsVars(d)
+	for _, c := range vars.Columns {
+		attr := d.Metadata != nil && d.Metadata.XML[c.Name] == xmlAttr
+		vars.XMLFields = append(vars.XMLFields, xmlFieldVars{Name: c.Name, Node: xmlNodeName(c), Attr: attr})
+	}
+	return vars
+}
@@ -0,0 +1,68 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func xmlModel(t *testing.T, metadata *Metadata) *DatagenParsed {
+	t.Helper()
+
+	d := typedModel(t, "users", [][3]string{
+		{"id", "int", "{ return iter }"},
+		{"full_name", "string", "{ return Name() }"},
+		{"avatar", "[]byte", "{ return nil }"},
+		{"tags", "[]string", "{ return nil }"},
+		{"scratch", "string", "{ return \"\" }"},
+	})
+	d.Metadata = metadata
+	return d
+}
+
+func TestCheckXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *Metadata
+		errStr   string
+	}{
+		{name: "attributes and elements", metadata: &Metadata{XML: map[string]string{"id": "attr", "avatar": "attr", "tags": "element"}}},
+		{name: "unknown field", metadata: &Metadata{XML: map[string]string{"name": "attr"}}, errStr: "field the model does not have\n  model: users\n  field: name"},
+		{name: "skipped field", metadata: &Metadata{Columns: map[string]string{"scratch": "-"}, XML: map[string]string{"scratch": "attr"}}, errStr: "not persisted"},
+		{name: "slice attribute", metadata: &Metadata{XML: map[string]string{"tags": "attr"}}, errStr: "cannot be written as an XML attribute"},
+		{name: "invalid node", metadata: &Metadata{XML: map[string]string{"id": "text"}}, errStr: "invalid XML node, expected attr or element"},
+		{
+			name:     "same attribute",
+			metadata: &Metadata{Columns: map[string]string{"full_name": "id"}, XML: map[string]string{"id": "attr", "full_name": "attr"}},
+			errStr:   "attribute: id\n  fields: full_name, id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := xmlModel(t, tt.metadata).checkXML()
+			if tt.errStr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errStr)
+		})
+	}
+}
+
+func TestXMLVars(t *testing.T) {
+	d := xmlModel(t, &Metadata{
+		Columns: map[string]string{"full_name": "full name", "tags": "labels", "scratch": "-"},
+		XML:     map[string]string{"id": "attr"},
+	})
+	require.NoError(t, d.checkXML())
+
+	assert.Equal(t, []xmlFieldVars{
+		{Name: "id", Node: "id", Attr: true},
+		{Name: "full_name", Node: "full_name"},
+		{Name: "avatar", Node: "avatar"},
+		{Name: "tags", Node: "labels"},
+	}, xmlVars(d).XMLFields)
+}
@@ -72,6 +72,14 @@ export default defineConfig({
                 'sinks/overview',
                 'sinks/config',
                 'sinks/mysql',
+                'sinks/postgres',
+                'sinks/sqlite',
+                'sinks/clickhouse',
+                'sinks/cassandra',
+                'sinks/mongodb',
+                'sinks/elasticsearch',
+                'sinks/redis',
+                'sinks/kafka',
               ],
             },
           ],
@@ -0,0 +1,469 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+)
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokIdent
+	tokString
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func (t token) is(words ...string) bool {
+	if t.kind != tokWord {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(t.text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t token) isPunct(p string) bool {
+	return t.kind == tokPunct && t.text == p
+}
+
+// tokenize splits SQL into words, quoted identifiers, strings and
+// punctuation, dropping comments.
+func tokenize(src string, dialect codegen.Dialect) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		ch := src[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f':
+			i++
+		case strings.HasPrefix(src[i:], "--"), ch == '#' && dialect == codegen.DialectMySQL:
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+		case ch == '`' || ch == '"' || ch == '\'':
+			text, n, err := quoted(src[i:], ch, ch == '\'' && dialect == codegen.DialectMySQL)
+			if err != nil {
+				return nil, err
+			}
+			kind := tokIdent
+			if ch == '\'' {
+				kind = tokString
+			}
+			tokens = append(tokens, token{kind: kind, text: text})
+			i += n
+		case isWordByte(ch):
+			start := i
+			for i < len(src) && isWordByte(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokWord, text: src[start:i]})
+		default:
+			tokens = append(tokens, token{kind: tokPunct, text: string(ch)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isWordByte(ch byte) bool {
+	return ch == '_' || ch == '$' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= 0x80
+}
+
+// quoted reads the quoted text at the start of src, where a doubled quote
+// stands for the quote itself, and returns it along with the number of bytes
+// read.
+func quoted(src string, quote byte, backslashes bool) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(src); i++ {
+		switch {
+		case backslashes && src[i] == '\\' && i+1 < len(src):
+			b.WriteByte(src[i+1])
+			i++
+		case src[i] == quote && i+1 < len(src) && src[i+1] == quote:
+			b.WriteByte(quote)
+			i++
+		case src[i] == quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(src[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted text %s", strings.SplitN(src, "\n", 2)[0])
+}
+
+// ParseDDL reads the tables defined by the CREATE TABLE statements in src,
+// along with the keys added to them by ALTER TABLE statements as dumped by
+// pg_dump. Any other statement is skipped.
+func ParseDDL(src string, dialect codegen.Dialect) ([]*Table, error) {
+	tokens, err := tokenize(src, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DDL\n  cause: %w", err)
+	}
+
+	p := &ddlParser{dialect: dialect, tables: newTableSet()}
+	for _, stmt := range splitStatements(tokens) {
+		if err := p.statement(stmt); err != nil {
+			return nil, err
+		}
+	}
+	p.resolveForeignKeys()
+	return p.tables.tables, nil
+}
+
+func splitStatements(tokens []token) [][]token {
+	var stmts [][]token
+	start := 0
+	for i, tok := range tokens {
+		if tok.isPunct(";") {
+			stmts = append(stmts, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(stmts, tokens[start:])
+}
+
+type ddlParser struct {
+	dialect codegen.Dialect
+	tables  *tableSet
+}
+
+// ident returns the name a token stands for as an identifier. Postgres folds
+// names that are not quoted to lower case.
+func (p *ddlParser) ident(tok token) string {
+	if tok.kind == tokWord && p.dialect == codegen.DialectPostgres {
+		return strings.ToLower(tok.text)
+	}
+	return tok.text
+}
+
+// tableName reads a possibly qualified table name, returning its schema, name
+// and the rest of the tokens.
+func (p *ddlParser) tableName(toks []token) (string, string, []token, error) {
+	var parts []string
+	for len(toks) > 0 && (toks[0].kind == tokWord || toks[0].kind == tokIdent) {
+		parts = append(parts, p.ident(toks[0]))
+		toks = toks[1:]
+		if len(toks) == 0 || !toks[0].isPunct(".") {
+			break
+		}
+		toks = toks[1:]
+	}
+	switch len(parts) {
+	case 0:
+		return "", "", nil, fmt.Errorf("expected table name")
+	case 1:
+		return "", parts[0], toks, nil
+	default:
+		return defaultSchema(p.dialect, parts[len(parts)-2]), parts[len(parts)-1], toks, nil
+	}
+}
+
+func (p *ddlParser) statement(toks []token) error {
+	if len(toks) < 3 {
+		return nil
+	}
+	switch {
+	case toks[0].is("CREATE"):
+		i := 1
+		for i < len(toks) && !toks[i].is("TABLE") {
+			if !toks[i].is("OR", "REPLACE", "TEMPORARY", "TEMP", "UNLOGGED", "GLOBAL", "LOCAL") {
+				return nil
+			}
+			i++
+		}
+		if i >= len(toks) {
+			return nil
+		}
+		return p.createTable(skipWords(toks[i+1:], "IF", "NOT", "EXISTS"))
+	case toks[0].is("ALTER") && toks[1].is("TABLE"):
+		return p.alterTable(skipWords(toks[2:], "ONLY", "IF", "EXISTS"))
+	}
+	return nil
+}
+
+func skipWords(toks []token, words ...string) []token {
+	for len(toks) > 0 && toks[0].is(words...) {
+		toks = toks[1:]
+	}
+	return toks
+}
+
+func (p *ddlParser) createTable(toks []token) error {
+	schema, name, rest, err := p.tableName(toks)
+	if err != nil {
+		return fmt.Errorf("invalid CREATE TABLE statement\n  cause: %w", err)
+	}
+	if len(rest) == 0 || !rest[0].isPunct("(") {
+		// CREATE TABLE ... AS SELECT and the like define no columns
+		return nil
+	}
+	body, _ := parenthesized(rest)
+
+	t := p.tables.get(schema, name)
+	for _, def := range splitTopLevel(body) {
+		if len(def) == 0 {
+			continue
+		}
+		if err := p.definition(t, def); err != nil {
+			return fmt.Errorf("invalid definition in table %s\n  cause: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (p *ddlParser) alterTable(toks []token) error {
+	schema, name, rest, err := p.tableName(toks)
+	if err != nil {
+		return fmt.Errorf("invalid ALTER TABLE statement\n  cause: %w", err)
+	}
+	t := p.tables.lookup(schema, name)
+	if t == nil {
+		return nil
+	}
+	for _, action := range splitTopLevel(rest) {
+		if len(action) > 1 && action[0].is("ADD") {
+			if err := p.constraint(t, action[1:]); err != nil {
+				return fmt.Errorf("invalid ALTER TABLE statement on table %s\n  cause: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parenthesized returns the tokens inside the parentheses toks starts with,
+// and the tokens after them.
+func parenthesized(toks []token) ([]token, []token) {
+	depth := 0
+	for i, tok := range toks {
+		switch {
+		case tok.isPunct("("):
+			depth++
+		case tok.isPunct(")"):
+			depth--
+			if depth == 0 {
+				return toks[1:i], toks[i+1:]
+			}
+		}
+	}
+	return toks[1:], nil
+}
+
+// splitTopLevel splits tokens on the commas outside of parentheses.
+func splitTopLevel(toks []token) [][]token {
+	var parts [][]token
+	depth, start := 0, 0
+	for i, tok := range toks {
+		switch {
+		case tok.isPunct("("):
+			depth++
+		case tok.isPunct(")"):
+			depth--
+		case tok.isPunct(",") && depth == 0:
+			parts = append(parts, toks[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, toks[start:])
+}
+
+func (p *ddlParser) names(toks []token) []string {
+	var names []string
+	for _, part := range splitTopLevel(toks) {
+		if len(part) > 0 {
+			names = append(names, p.ident(part[0]))
+		}
+	}
+	return names
+}
+
+func (p *ddlParser) definition(t *Table, def []token) error {
+	if def[0].is("CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "KEY", "INDEX", "FULLTEXT", "SPATIAL", "CHECK", "EXCLUDE", "LIKE") {
+		return p.constraint(t, def)
+	}
+	return p.column(t, def)
+}
+
+// constraint applies a table constraint. Keys spanning several columns are
+// not unique by column, and only their foreign keys are kept.
+func (p *ddlParser) constraint(t *Table, def []token) error {
+	if def[0].is("CONSTRAINT") {
+		if len(def) < 3 {
+			return fmt.Errorf("incomplete constraint")
+		}
+		def = def[2:]
+	}
+
+	cols := func(toks []token) ([]string, []token) {
+		for len(toks) > 0 && !toks[0].isPunct("(") {
+			toks = toks[1:]
+		}
+		if len(toks) == 0 {
+			return nil, nil
+		}
+		inner, rest := parenthesized(toks)
+		return p.names(inner), rest
+	}
+
+	switch {
+	case def[0].is("PRIMARY"):
+		names, _ := cols(def[1:])
+		for _, name := range names {
+			if c := t.column(name); c != nil {
+				c.PrimaryKey = true
+				c.Nullable = false
+			}
+		}
+	case def[0].is("UNIQUE"):
+		names, _ := cols(def[1:])
+		if len(names) == 1 {
+			if c := t.column(names[0]); c != nil {
+				c.Unique = true
+			}
+		}
+	case def[0].is("FOREIGN"):
+		names, rest := cols(def[1:])
+		rest = skipWords(rest, "REFERENCES")
+		schema, table, rest, err := p.tableName(rest)
+		if err != nil {
+			return fmt.Errorf("invalid foreign key\n  cause: %w", err)
+		}
+		refs, _ := cols(rest)
+		for i, name := range names {
+			fk := ForeignKey{Column: name, RefSchema: schema, RefTable: table}
+			if i < len(refs) {
+				fk.RefColumn = refs[i]
+			}
+			t.ForeignKeys = append(t.ForeignKeys, fk)
+		}
+	}
+	return nil
+}
+
+// columnStopWords end the type of a column definition.
+var columnStopWords = []string{
+	"NOT", "NULL", "PRIMARY", "UNIQUE", "REFERENCES", "DEFAULT", "CHECK", "CONSTRAINT",
+	"COMMENT", "AUTO_INCREMENT", "AUTOINCREMENT", "COLLATE", "CHARSET", "GENERATED", "ON",
+	"KEY", "AS", "VISIBLE", "INVISIBLE", "SRID",
+}
+
+func (p *ddlParser) column(t *Table, def []token) error {
+	if def[0].kind != tokWord && def[0].kind != tokIdent {
+		return fmt.Errorf("expected column name, got %q", def[0].text)
+	}
+	c := &Column{Name: p.ident(def[0]), Nullable: true}
+
+	rest := def[1:]
+	var typ strings.Builder
+	for len(rest) > 0 {
+		tok := rest[0]
+		if tok.is(columnStopWords...) || tok.is("CHARACTER") && len(rest) > 1 && rest[1].is("SET") {
+			break
+		}
+		if tok.isPunct("(") {
+			inner, after := parenthesized(rest)
+			typ.WriteString("(" + joinTokens(inner) + ")")
+			rest = after
+			continue
+		}
+		if typ.Len() > 0 && tok.kind == tokWord {
+			typ.WriteByte(' ')
+		}
+		typ.WriteString(tok.text)
+		rest = rest[1:]
+	}
+	c.Type = strings.ToLower(typ.String())
+	if c.Type == "" {
+		return fmt.Errorf("column %s has no type", c.Name)
+	}
+	if strings.Contains(c.Type, "serial") {
+		c.AutoIncrement = true
+	}
+
+	for len(rest) > 0 {
+		tok := rest[0]
+		rest = rest[1:]
+		switch {
+		case tok.isPunct("("):
+			// expressions of defaults and checks
+			_, rest = parenthesized(append([]token{tok}, rest...))
+		case tok.is("NOT") && len(rest) > 0 && rest[0].is("NULL"):
+			c.Nullable = false
+			rest = rest[1:]
+		case tok.is("NULL"):
+			c.Nullable = true
+		case tok.is("PRIMARY"):
+			c.PrimaryKey = true
+			c.Nullable = false
+		case tok.is("UNIQUE"):
+			c.Unique = true
+		case tok.is("AUTO_INCREMENT", "AUTOINCREMENT", "IDENTITY", "nextval"):
+			c.AutoIncrement = true
+		case tok.is("REFERENCES"):
+			schema, table, after, err := p.tableName(rest)
+			if err != nil {
+				return fmt.Errorf("invalid reference of column %s\n  cause: %w", c.Name, err)
+			}
+			fk := ForeignKey{Column: c.Name, RefSchema: schema, RefTable: table}
+			if len(after) > 0 && after[0].isPunct("(") {
+				var inner []token
+				inner, after = parenthesized(after)
+				if names := p.names(inner); len(names) > 0 {
+					fk.RefColumn = names[0]
+				}
+			}
+			t.ForeignKeys = append(t.ForeignKeys, fk)
+			rest = after
+		}
+	}
+
+	t.Columns = append(t.Columns, c)
+	return nil
+}
+
+// joinTokens writes the arguments of a type back as SQL.
+func joinTokens(toks []token) string {
+	var b strings.Builder
+	for _, tok := range toks {
+		if tok.kind == tokString {
+			b.WriteString("'" + strings.ReplaceAll(tok.text, "'", "''") + "'")
+			continue
+		}
+		b.WriteString(tok.text)
+	}
+	return b.String()
+}
+
+// resolveForeignKeys points foreign keys that do not name the referenced
+// column to the primary key of the referenced table.
+func (p *ddlParser) resolveForeignKeys() {
+	for _, t := range p.tables.tables {
+		for i, fk := range t.ForeignKeys {
+			if fk.RefColumn != "" {
+				continue
+			}
+			if ref := p.tables.lookup(fk.RefSchema, fk.RefTable); ref != nil {
+				if pk := ref.primaryKey(); pk != nil {
+					t.ForeignKeys[i].RefColumn = pk.Name
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+)
+
+func TestParseDDLMySQL(t *testing.T) {
+	src := "-- users\n" +
+		"CREATE TABLE IF NOT EXISTS `users` (\n" +
+		"  `id` int unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `email` varchar(255) CHARACTER SET utf8mb4 NOT NULL,\n" +
+		"  `status` enum('active','it''s banned') DEFAULT 'active', # inline\n" +
+		"  `bio` text,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `email_idx` (`email`)\n" +
+		") ENGINE=InnoDB;\n" +
+		"/* orders */\n" +
+		"CREATE TABLE orders (\n" +
+		"  id bigint PRIMARY KEY,\n" +
+		"  user_id int unsigned NOT NULL,\n" +
+		"  CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE\n" +
+		");\n" +
+		"INSERT INTO users VALUES (1, 'a;b', 'active', NULL);\n"
+
+	tables, err := ParseDDL(src, codegen.DialectMySQL)
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+
+	users := tables[0]
+	assert.Equal(t, "", users.Schema)
+	assert.Equal(t, "users", users.Name)
+	assert.Equal(t, []*Column{
+		{Name: "id", Type: "int unsigned", PrimaryKey: true, AutoIncrement: true},
+		{Name: "email", Type: "varchar(255)", Unique: true},
+		{Name: "status", Type: "enum('active','it''s banned')", Nullable: true},
+		{Name: "bio", Type: "text", Nullable: true},
+	}, users.Columns)
+	assert.Equal(t, []string{"active", "it's banned"}, enumValues("'active','it''s banned'"))
+
+	orders := tables[1]
+	assert.Equal(t, []ForeignKey{{Column: "user_id", RefTable: "users", RefColumn: "id"}}, orders.ForeignKeys)
+	assert.True(t, orders.column("id").PrimaryKey)
+}
+
+func TestParseDDLPostgres(t *testing.T) {
+	src := `CREATE TABLE public."Accounts" (
+    id uuid NOT NULL,
+    Name character varying(100),
+    tags text[]
+);
+CREATE TABLE billing.invoices (
+    id bigserial PRIMARY KEY,
+    account_id uuid REFERENCES "Accounts",
+    total numeric(12, 2) NOT NULL
+);
+ALTER TABLE ONLY public."Accounts" ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
+`
+
+	tables, err := ParseDDL(src, codegen.DialectPostgres)
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+
+	accounts := tables[0]
+	assert.Equal(t, "", accounts.Schema)
+	assert.Equal(t, "Accounts", accounts.Name)
+	require.Len(t, accounts.Columns, 3)
+	assert.Equal(t, "name", accounts.Columns[1].Name)
+	assert.True(t, accounts.column("id").PrimaryKey)
+	assert.Equal(t, "[]string", goType(accounts.column("tags")))
+
+	invoices := tables[1]
+	assert.Equal(t, "billing", invoices.Schema)
+	assert.True(t, invoices.column("id").AutoIncrement)
+	assert.Equal(t, []ForeignKey{{Column: "account_id", RefTable: "Accounts", RefColumn: "id"}}, invoices.ForeignKeys)
+	assert.Equal(t, "numeric(12,2)", invoices.column("total").Type)
+}
+
+func TestParseDDLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{"unterminated comment", "CREATE TABLE t (id int); /* open", "unterminated comment"},
+		{"unterminated string", "CREATE TABLE t (s enum('a)", "unterminated quoted text"},
+		{"missing table name", "CREATE TABLE (id int);", "expected table name"},
+		{"column without type", "CREATE TABLE t (id);", "column id has no type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDDL(tt.src, codegen.DialectMySQL)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want string
+	}{
+		{"tinyint(1)", "bool"},
+		{"tinyint(4)", "int8"},
+		{"smallint unsigned", "uint16"},
+		{"int", "int"},
+		{"int(10) unsigned zerofill", "uint32"},
+		{"bigint unsigned", "uint64"},
+		{"float", "float32"},
+		{"decimal(10,2)", "float64"},
+		{"double precision", "float64"},
+		{"timestamp with time zone", "time.Time"},
+		{"bytea", "[]byte"},
+		{"bit(8)", "[]byte"},
+		{"varchar(20)", "string"},
+		{"integer[]", "[]int"},
+		{"int4[]", "[]int"},
+		{"timestamptz[]", "[]time.Time"},
+		{"varchar(20)[3]", "[]string"},
+		{"integer[][]", "[][]int"},
+		{"character varying array", "[]string"},
+		{"array", "[]string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			assert.Equal(t, tt.want, goType(&Column{Type: tt.typ}))
+		})
+	}
+}
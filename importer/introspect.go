@@ -0,0 +1,213 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+)
+
+const (
+	mysqlColumnsQuery = `
+SELECT c.table_name, c.column_name, c.column_type, c.is_nullable, c.column_key, c.extra
+FROM information_schema.columns c
+JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema = DATABASE() AND t.table_type = 'BASE TABLE'
+ORDER BY c.table_name, c.ordinal_position`
+
+	mysqlForeignKeysQuery = `
+SELECT table_name, column_name, referenced_table_schema, referenced_table_name, referenced_column_name
+FROM information_schema.key_column_usage
+WHERE table_schema = DATABASE() AND referenced_table_name IS NOT NULL
+ORDER BY table_name, constraint_name, ordinal_position`
+
+	postgresColumnsQuery = `
+SELECT c.table_schema, c.table_name, c.column_name,
+       CASE WHEN c.data_type = 'USER-DEFINED' THEN c.udt_name
+            WHEN c.data_type = 'ARRAY' THEN substr(c.udt_name, 2) || '[]'
+            ELSE c.data_type END,
+       c.is_nullable, COALESCE(c.column_default, ''), c.is_identity
+FROM information_schema.columns c
+JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE t.table_type = 'BASE TABLE' AND c.table_schema NOT IN ('pg_catalog', 'information_schema')
+ORDER BY c.table_schema, c.table_name, c.ordinal_position`
+
+	postgresKeysQuery = `
+SELECT tc.table_schema, tc.table_name, tc.constraint_name, tc.constraint_type, kcu.column_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+  ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+WHERE tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE') AND tc.table_schema NOT IN ('pg_catalog', 'information_schema')
+ORDER BY tc.table_schema, tc.table_name, tc.constraint_name, kcu.ordinal_position`
+
+	postgresForeignKeysQuery = `
+SELECT kcu.table_schema, kcu.table_name, kcu.column_name, rku.table_schema, rku.table_name, rku.column_name
+FROM information_schema.referential_constraints rc
+JOIN information_schema.key_column_usage kcu
+  ON kcu.constraint_schema = rc.constraint_schema AND kcu.constraint_name = rc.constraint_name
+JOIN information_schema.key_column_usage rku
+  ON rku.constraint_schema = rc.unique_constraint_schema AND rku.constraint_name = rc.unique_constraint_name
+ AND rku.ordinal_position = kcu.position_in_unique_constraint
+ORDER BY kcu.table_schema, kcu.table_name, kcu.constraint_name, kcu.ordinal_position`
+)
+
+// Dialects lists the dialects tables can be imported from.
+var Dialects = []codegen.Dialect{codegen.DialectMySQL, codegen.DialectPostgres}
+
+// Introspect reads the tables of the database dsn connects to. For MySQL
+// these are the tables of the database named in dsn, and for Postgres those
+// of every schema but the system ones.
+func Introspect(ctx context.Context, dialect codegen.Dialect, dsn string) ([]*Table, error) {
+	db, err := sql.Open(string(dialect), dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s connection\n  cause: %w", dialect, err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s\n  cause: %w", dialect, err)
+	}
+
+	if dialect == codegen.DialectMySQL {
+		return introspectMySQL(ctx, db)
+	}
+	return introspectPostgres(ctx, db)
+}
+
+// query runs a query and scans every row into dest, calling row after each.
+func query(ctx context.Context, db *sql.DB, q string, row func() error, dest ...any) error {
+	rows, err := db.QueryContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("failed to query information_schema\n  cause: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to read information_schema\n  cause: %w", err)
+		}
+		if err := row(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func introspectMySQL(ctx context.Context, db *sql.DB) ([]*Table, error) {
+	var database string
+	if err := db.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&database); err != nil {
+		return nil, fmt.Errorf("failed to read the current database\n  cause: %w", err)
+	}
+
+	tables := newTableSet()
+	var table, name, typ, nullable, key, extra string
+	err := query(ctx, db, mysqlColumnsQuery, func() error {
+		t := tables.get("", table)
+		t.Columns = append(t.Columns, &Column{
+			Name:          name,
+			Type:          strings.ToLower(typ),
+			Nullable:      nullable == "YES",
+			PrimaryKey:    key == "PRI",
+			Unique:        key == "UNI",
+			AutoIncrement: strings.Contains(strings.ToLower(extra), "auto_increment"),
+		})
+		return nil
+	}, &table, &name, &typ, &nullable, &key, &extra)
+	if err != nil {
+		return nil, err
+	}
+
+	var column, refSchema, refTable, refColumn string
+	err = query(ctx, db, mysqlForeignKeysQuery, func() error {
+		if t := tables.lookup("", table); t != nil {
+			if refSchema == database {
+				refSchema = ""
+			}
+			t.ForeignKeys = append(t.ForeignKeys, ForeignKey{Column: column, RefSchema: refSchema, RefTable: refTable, RefColumn: refColumn})
+		}
+		return nil
+	}, &table, &column, &refSchema, &refTable, &refColumn)
+	if err != nil {
+		return nil, err
+	}
+	return tables.tables, nil
+}
+
+func introspectPostgres(ctx context.Context, db *sql.DB) ([]*Table, error) {
+	tables := newTableSet()
+	var schema, table, name, typ, nullable, def, identity string
+	err := query(ctx, db, postgresColumnsQuery, func() error {
+		t := tables.get(defaultSchema(codegen.DialectPostgres, schema), table)
+		t.Columns = append(t.Columns, &Column{
+			Name:          name,
+			Type:          strings.ToLower(typ),
+			Nullable:      nullable == "YES",
+			AutoIncrement: identity == "YES" || strings.HasPrefix(def, "nextval("),
+		})
+		return nil
+	}, &schema, &table, &name, &typ, &nullable, &def, &identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys are read per constraint, as only single column unique keys make
+	// their column unique
+	type constraintKey struct{ schema, table, name string }
+	keyColumns := map[constraintKey][]string{}
+	keyTypes := map[constraintKey]string{}
+	var keys []constraintKey
+	var constraint, constraintType, column string
+	err = query(ctx, db, postgresKeysQuery, func() error {
+		k := constraintKey{schema: defaultSchema(codegen.DialectPostgres, schema), table: table, name: constraint}
+		if _, ok := keyTypes[k]; !ok {
+			keys = append(keys, k)
+			keyTypes[k] = constraintType
+		}
+		keyColumns[k] = append(keyColumns[k], column)
+		return nil
+	}, &schema, &table, &constraint, &constraintType, &column)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		t := tables.lookup(k.schema, k.table)
+		if t == nil {
+			continue
+		}
+		for _, name := range keyColumns[k] {
+			c := t.column(name)
+			if c == nil {
+				continue
+			}
+			if keyTypes[k] == "PRIMARY KEY" {
+				c.PrimaryKey = true
+			} else if len(keyColumns[k]) == 1 {
+				c.Unique = true
+			}
+		}
+	}
+
+	var refSchema, refTable, refColumn string
+	err = query(ctx, db, postgresForeignKeysQuery, func() error {
+		if t := tables.lookup(defaultSchema(codegen.DialectPostgres, schema), table); t != nil {
+			t.ForeignKeys = append(t.ForeignKeys, ForeignKey{
+				Column:    column,
+				RefSchema: defaultSchema(codegen.DialectPostgres, refSchema),
+				RefTable:  refTable,
+				RefColumn: refColumn,
+			})
+		}
+		return nil
+	}, &schema, &table, &column, &refSchema, &refTable, &refColumn)
+	if err != nil {
+		return nil, err
+	}
+	return tables.tables, nil
+}
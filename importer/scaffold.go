@@ -0,0 +1,371 @@
+package importer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	gotoken "go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed templates
+var templates embed.FS
+
+const tmplModel = "templates/model.dg.tmpl"
+
+// reservedFieldNames are the names the generated code already uses on the
+// structs fields become members of.
+var reservedFieldNames = map[string]struct{}{
+	"all": {}, "datagen": {}, "self": {}, "iter": {},
+	"Gen": {}, "Metadata": {}, "ToCSV": {}, "CSVHeaders": {}, "ToJSON": {}, "ToXML": {},
+}
+
+// File is a scaffolded model, at its path relative to the directory models
+// are imported into.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+type model struct {
+	table *Table
+	dir   string
+	name  string
+	// fields maps the columns of the table to the fields they are generated by
+	fields map[string]string
+}
+
+// accessor is the expression other models read the fields of m through.
+func (m *model) accessor() string {
+	if m.dir == "" {
+		return "self.datagen." + m.name + "()"
+	}
+	return "self.datagen." + m.dir + "." + m.name + "()"
+}
+
+type fieldVars struct {
+	Name    string
+	Type    string
+	Gen     string
+	Comment string
+	// Pointer is set for fields of nullable columns, whose gen returns a
+	// pointer to the value Gen generates
+	Pointer bool
+}
+
+type modelVars struct {
+	Name    string
+	Table   string
+	Schema  string
+	Columns [][2]string
+	Fields  []fieldVars
+}
+
+// Scaffold writes a model for every table. Tables of schemas other than the
+// default one are put in a directory named after their schema. Gen functions
+// are picked by column type and name, and foreign keys to imported tables
+// read the referenced field of the same iteration.
+func Scaffold(tables []*Table) ([]File, error) {
+	tmpl, err := template.New(filepath.Base(tmplModel)).Funcs(template.FuncMap{"quote": strconv.Quote}).ParseFS(templates, tmplModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template\n  template: %s\n  cause: %w", tmplModel, err)
+	}
+
+	models := make(map[tableKey]*model, len(tables))
+	taken := map[string]map[string]struct{}{}
+	ordered := make([]*model, 0, len(tables))
+	for _, t := range tables {
+		dir := ""
+		if t.Schema != "" {
+			dir = identifier(t.Schema)
+		}
+		if taken[dir] == nil {
+			taken[dir] = map[string]struct{}{}
+		}
+		m := &model{table: t, dir: dir, name: unique(identifier(t.Name), taken[dir]), fields: map[string]string{}}
+
+		fieldNames := map[string]struct{}{}
+		for name := range reservedFieldNames {
+			fieldNames[name] = struct{}{}
+		}
+		// columns named as valid fields keep their names, the others are
+		// renamed around them
+		for _, c := range t.Columns {
+			if _, ok := fieldNames[c.Name]; !ok && identifier(c.Name) == c.Name {
+				m.fields[c.Name] = unique(c.Name, fieldNames)
+			}
+		}
+		for _, c := range t.Columns {
+			if _, ok := m.fields[c.Name]; !ok {
+				m.fields[c.Name] = unique(identifier(c.Name), fieldNames)
+			}
+		}
+		models[tableKey{schema: t.Schema, name: t.Name}] = m
+		ordered = append(ordered, m)
+	}
+
+	files := make([]File, 0, len(ordered))
+	for _, m := range ordered {
+		vars := modelVars{Name: m.name, Schema: m.table.Schema}
+		if m.name != m.table.Name {
+			vars.Table = m.table.Name
+		}
+		for _, c := range m.table.Columns {
+			field := m.fields[c.Name]
+			if field != c.Name {
+				vars.Columns = append(vars.Columns, [2]string{field, c.Name})
+			}
+			gen, comment := genExpr(m, c, field, models)
+			typ := fieldType(m.table, c, models, 0)
+			_, _, isRef := referenced(m.table, c, models)
+			vars.Fields = append(vars.Fields, fieldVars{
+				Name:    field,
+				Type:    typ,
+				Gen:     gen,
+				Comment: comment,
+				Pointer: strings.HasPrefix(typ, "*") && !isRef,
+			})
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			return nil, fmt.Errorf("failed to scaffold model\n  table: %s\n  cause: %w", m.table.Name, err)
+		}
+		files = append(files, File{Path: filepath.Join(m.dir, m.name+".dg"), Content: buf.Bytes()})
+	}
+	return files, nil
+}
+
+// identifier turns a table or column name into a Go identifier.
+func identifier(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	id := b.String()
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "_" + id
+	}
+	if gotoken.IsKeyword(id) {
+		id += "_"
+	}
+	return id
+}
+
+// unique suffixes name until it is not in taken, and adds it.
+func unique(name string, taken map[string]struct{}) string {
+	candidate := name
+	for i := 2; ; i++ {
+		if _, ok := taken[candidate]; !ok {
+			break
+		}
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	taken[candidate] = struct{}{}
+	return candidate
+}
+
+// referenced returns the model and field a foreign key column reads from,
+// when the referenced table is imported too.
+func referenced(t *Table, c *Column, models map[tableKey]*model) (*model, string, bool) {
+	fk, ok := t.foreignKey(c.Name)
+	if !ok || fk.RefColumn == "" {
+		return nil, "", false
+	}
+	ref, ok := models[tableKey{schema: fk.RefSchema, name: fk.RefTable}]
+	if !ok {
+		return nil, "", false
+	}
+	field, ok := ref.fields[fk.RefColumn]
+	if !ok || ref.table == t && fk.RefColumn == c.Name {
+		return nil, "", false
+	}
+	return ref, field, true
+}
+
+// fieldType returns the Go type of a column, a pointer when the column is
+// nullable so that the model maps it back to a nullable column. Slices need
+// no pointer, as they are nullable already. Foreign keys take the type of
+// the field they reference so the values read from it need no conversion,
+// and stay foreign keys of the model.
+func fieldType(t *Table, c *Column, models map[tableKey]*model, depth int) string {
+	if ref, _, ok := referenced(t, c, models); ok && depth < len(models) {
+		fk, _ := t.foreignKey(c.Name)
+		if refColumn := ref.table.column(fk.RefColumn); refColumn != nil {
+			return fieldType(ref.table, refColumn, models, depth+1)
+		}
+	}
+	typ := goType(c)
+	if c.Nullable && !strings.HasPrefix(typ, "[]") {
+		return "*" + typ
+	}
+	return typ
+}
+
+// dateRange is the range DateBetween picks dates from. It is fixed so that
+// seeded runs generate the same dates.
+const dateRange = "time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)"
+
+type heuristic struct {
+	names []string
+	gen   string
+	// long is set for generators of text that does not fit short columns
+	long bool
+}
+
+// stringHeuristics pick the generator of string columns by their name, or
+// the end of it. Earlier entries win.
+var stringHeuristics = []heuristic{
+	{names: []string{"email", "email_address", "mail"}, gen: "Email()"},
+	{names: []string{"first_name", "firstname", "given_name"}, gen: "FirstName()"},
+	{names: []string{"last_name", "lastname", "surname", "family_name"}, gen: "LastName()"},
+	{names: []string{"username", "user_name", "login", "handle"}, gen: "Username()"},
+	{names: []string{"name", "full_name", "fullname", "display_name"}, gen: "Name()"},
+	{names: []string{"phone", "phone_number", "mobile", "telephone"}, gen: "Phone()"},
+	{names: []string{"url", "website", "homepage", "link"}, gen: "Url()"},
+	{names: []string{"company", "company_name", "organization"}, gen: "Company()"},
+	{names: []string{"street", "address", "address_line", "address1"}, gen: "Street()"},
+	{names: []string{"city"}, gen: "City()"},
+	{names: []string{"state", "province", "region"}, gen: "State()"},
+	{names: []string{"country"}, gen: "Country()"},
+	{names: []string{"country_code"}, gen: "CountryAbr()"},
+	{names: []string{"zip", "zipcode", "zip_code", "postal_code", "postcode"}, gen: "Zip()"},
+	{names: []string{"uuid", "guid"}, gen: "UUID()"},
+	{names: []string{"ip", "ip_address"}, gen: "IPv4Address()"},
+	{names: []string{"user_agent"}, gen: "UserAgent()"},
+	{names: []string{"domain"}, gen: "DomainName()"},
+	{names: []string{"color", "colour"}, gen: "Color()"},
+	{names: []string{"currency", "currency_code"}, gen: "Currency()"},
+	{names: []string{"language", "locale"}, gen: "Language()"},
+	{names: []string{"gender"}, gen: "Gender()"},
+	{names: []string{"job_title"}, gen: "JobTitle()"},
+	{names: []string{"title", "subject"}, gen: "Sentence(3)", long: true},
+	{names: []string{"description", "bio", "notes", "comment", "summary", "body", "content", "message"}, gen: "Sentence(10)", long: true},
+}
+
+func pickHeuristic(name string, heuristics []heuristic) (heuristic, bool) {
+	name = strings.ToLower(name)
+	for _, h := range heuristics {
+		for _, n := range h.names {
+			if name == n {
+				return h, true
+			}
+		}
+	}
+	for _, h := range heuristics {
+		for _, n := range h.names {
+			if strings.HasSuffix(name, "_"+n) {
+				return h, true
+			}
+		}
+	}
+	return heuristic{}, false
+}
+
+// genExpr returns the expression the field of a column is generated by, and
+// a comment to go with it.
+func genExpr(m *model, c *Column, field string, models map[tableKey]*model) (string, string) {
+	comment := ""
+	if fk, ok := m.table.foreignKey(c.Name); ok {
+		if ref, field, ok := referenced(m.table, c, models); ok {
+			if ref == m {
+				return "self." + field + "(iter)", ""
+			}
+			return ref.accessor() + "." + field + "(iter)", ""
+		}
+		comment = fmt.Sprintf("references %s.%s, which is not imported", fk.RefTable, fk.RefColumn)
+	}
+
+	typ := goType(c)
+	base, args, _ := sqlType(c.Type)
+	name := strings.ToLower(strings.TrimSuffix(field, "_"))
+	key := c.PrimaryKey || c.Unique || c.AutoIncrement
+
+	if base == "array" {
+		elem, _ := genExpr(m, &Column{Type: args}, field, models)
+		return typ + "{" + elem + ", " + elem + "}", comment
+	}
+
+	switch typ {
+	case "bool":
+		return "Boolean()", comment
+	case "int", "int8", "int16", "int64", "uint8", "uint16", "uint32", "uint64":
+		expr := "IntBetween(1, 100)"
+		switch {
+		case key:
+			expr = "iter + 1"
+		case name == "age" || strings.HasSuffix(name, "_age"):
+			expr = "IntBetween(18, 90)"
+		case name == "year" || strings.HasSuffix(name, "_year"):
+			expr = "Year()"
+		}
+		if typ != "int" {
+			expr = typ + "(" + expr + ")"
+		}
+		return expr, comment
+	case "float32":
+		return "Float32Between(0, 1000)", comment
+	case "float64":
+		switch {
+		case name == "lat" || name == "latitude" || strings.HasSuffix(name, "_latitude"):
+			return "Latitude()", comment
+		case name == "lng" || name == "lon" || name == "longitude" || strings.HasSuffix(name, "_longitude"):
+			return "Longitude()", comment
+		}
+		return "FloatBetween(0, 1000)", comment
+	case "time.Time":
+		return "DateBetween(" + dateRange + ")", comment
+	case "[]byte":
+		return "[]byte(Word())", comment
+	}
+
+	length, _ := strconv.Atoi(args)
+	var expr string
+	switch {
+	case base == "enum" || base == "set":
+		values := enumValues(args)
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, strconv.Quote(v))
+		}
+		if len(quoted) > 0 {
+			return "RandomFrom(" + strings.Join(quoted, ", ") + ")", comment
+		}
+		expr = "Word()"
+	case base == "uuid":
+		return "UUID()", comment
+	case base == "json" || base == "jsonb":
+		return "ToJSON(Map())", comment
+	case base == "time" || base == "time without time zone" || base == "time with time zone":
+		return `fmt.Sprintf("%02d:%02d:%02d", Hour(), Minute(), Second())`, comment
+	default:
+		h, ok := pickHeuristic(name, stringHeuristics)
+		switch {
+		case ok && !(h.long && length > 0 && length < 100):
+			expr = h.gen
+		case length > 0 && length < 8:
+			expr = "Text(" + args + ")"
+		default:
+			expr = "Word()"
+		}
+	}
+
+	if key {
+		if expr == "UUID()" {
+			return expr, comment
+		}
+		if strings.Contains(expr, "Email") {
+			return `fmt.Sprintf("%d.%s", iter, ` + expr + ")", comment
+		}
+		return `fmt.Sprintf("%s_%d", ` + expr + ", iter)", comment
+	}
+	return expr, comment
+}
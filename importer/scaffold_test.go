@@ -0,0 +1,179 @@
+package importer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/parser"
+)
+
+func TestScaffold(t *testing.T) {
+	tables := []*Table{
+		{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "int unsigned", PrimaryKey: true, AutoIncrement: true},
+				{Name: "email", Type: "varchar(255)", Unique: true},
+				{Name: "type", Type: "enum('admin','member')"},
+				{Name: "created_at", Type: "datetime"},
+			},
+		},
+		{
+			Schema: "billing",
+			Name:   "order-items",
+			Columns: []*Column{
+				{Name: "user_id", Type: "int"},
+				{Name: "Display Name", Type: "varchar(100)"},
+				{Name: "coupon_id", Type: "int"},
+			},
+			ForeignKeys: []ForeignKey{
+				{Column: "user_id", RefTable: "users", RefColumn: "id"},
+				{Column: "coupon_id", RefTable: "coupons", RefColumn: "id"},
+			},
+		},
+	}
+
+	files, err := Scaffold(tables)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "users.dg", files[0].Path)
+	assert.Equal(t, `model users {
+  metadata {
+    columns: {
+      "type_": "type"
+    }
+  }
+
+  fields {
+    id() uint32
+    email() string
+    type_() string
+    created_at() time.Time
+  }
+
+  gens {
+    func id() {
+      return uint32(iter + 1)
+    }
+
+    func email() {
+      return fmt.Sprintf("%d.%s", iter, Email())
+    }
+
+    func type_() {
+      return RandomFrom("admin", "member")
+    }
+
+    func created_at() {
+      return DateBetween(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+    }
+  }
+}
+`, string(files[0].Content))
+
+	assert.Equal(t, filepath.Join("billing", "order_items.dg"), files[1].Path)
+	items := string(files[1].Content)
+	assert.Contains(t, items, "    table: \"order-items\"\n    schema: \"billing\"\n")
+	assert.Contains(t, items, "\"Display_Name\": \"Display Name\"")
+	assert.Contains(t, items, "user_id() uint32")
+	assert.Contains(t, items, "return self.datagen.users().id(iter)")
+	assert.Contains(t, items, "// references coupons.id, which is not imported\n      return IntBetween(1, 100)")
+	assert.Contains(t, items, "return Name()")
+
+	for _, f := range files {
+		parsed, err := parser.Parse(f.Content, f.Path)
+		require.NoError(t, err, "scaffolded model %s should parse", f.Path)
+		assert.Len(t, parsed.GenFuns, parsed.Fields.NumFields())
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"users":        "users",
+		"order-items":  "order_items",
+		"Display Name": "Display_Name",
+		"2fa_codes":    "_2fa_codes",
+		"type":         "type_",
+		"":             "_",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, identifier(name), name)
+	}
+}
+
+func TestScaffoldReservedNames(t *testing.T) {
+	files, err := Scaffold([]*Table{{
+		Name: "events",
+		Columns: []*Column{
+			{Name: "iter", Type: "int"},
+			{Name: "iter_2", Type: "int"},
+			{Name: "all", Type: "text"},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content := string(files[0].Content)
+	assert.Contains(t, content, "\"iter_3\": \"iter\"")
+	assert.Contains(t, content, "\"all_2\": \"all\"")
+	assert.Contains(t, content, "    iter_2() int\n")
+}
+
+func TestScaffoldNullableColumns(t *testing.T) {
+	tables := []*Table{
+		{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "bigint", PrimaryKey: true},
+				{Name: "bio", Type: "text", Nullable: true},
+				{Name: "score", Type: "integer", Nullable: true},
+				{Name: "tags", Type: "text[]", Nullable: true},
+				{Name: "scores", Type: "int4[]"},
+			},
+		},
+		{
+			Name: "orders",
+			Columns: []*Column{
+				{Name: "id", Type: "bigint", PrimaryKey: true},
+				{Name: "user_id", Type: "bigint", Nullable: true},
+			},
+			ForeignKeys: []ForeignKey{{Column: "user_id", RefTable: "users", RefColumn: "id"}},
+		},
+	}
+
+	files, err := Scaffold(tables)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	users := string(files[0].Content)
+	assert.Contains(t, users, "    bio() *string\n    score() *int\n    tags() []string\n    scores() []int\n")
+	assert.Contains(t, users, "    func bio() {\n      value := Sentence(10)\n      return &value\n    }")
+	assert.Contains(t, users, "    func score() {\n      value := IntBetween(1, 100)\n      return &value\n    }")
+	assert.Contains(t, users, "    func tags() {\n      return []string{Word(), Word()}\n    }")
+	assert.Contains(t, users, "    func scores() {\n      return []int{IntBetween(1, 100), IntBetween(1, 100)}\n    }")
+	// foreign keys read the referenced field as is, so that they stay foreign keys
+	orders := string(files[1].Content)
+	assert.Contains(t, orders, "    user_id() int64\n")
+	assert.Contains(t, orders, "      return self.datagen.users().id(iter)\n")
+
+	parsed := make([]*codegen.DatagenParsed, 0, len(files))
+	for _, f := range files {
+		p, err := parser.Parse(f.Content, f.Path)
+		require.NoError(t, err, "scaffolded model %s should parse", f.Path)
+		p.FullyQualifiedModelName = p.ModelName
+		parsed = append(parsed, p)
+	}
+	schema, err := codegen.Schema(parsed, codegen.DialectPostgres)
+	require.NoError(t, err)
+	assert.Contains(t, schema, `CREATE TABLE IF NOT EXISTS "users" (
+  "id" BIGINT NOT NULL,
+  "bio" TEXT,
+  "score" BIGINT,
+  "tags" JSONB,
+  "scores" JSONB,`)
+}
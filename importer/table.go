@@ -0,0 +1,222 @@
+package importer
+
+import (
+	"strings"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+)
+
+// Table is a table read from a database or from the statements defining it.
+type Table struct {
+	Schema      string
+	Name        string
+	Columns     []*Column
+	ForeignKeys []ForeignKey
+}
+
+// Column is a column of a table. Type is the SQL type the column is declared
+// with, in lower case, including its length, precision or values.
+type Column struct {
+	Name          string
+	Type          string
+	Nullable      bool
+	PrimaryKey    bool
+	Unique        bool
+	AutoIncrement bool
+}
+
+// ForeignKey points a column of a table to a column of another table, or of
+// the same one.
+type ForeignKey struct {
+	Column    string
+	RefSchema string
+	RefTable  string
+	RefColumn string
+}
+
+func (t *Table) column(name string) *Column {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (t *Table) foreignKey(column string) (ForeignKey, bool) {
+	for _, fk := range t.ForeignKeys {
+		if fk.Column == column {
+			return fk, true
+		}
+	}
+	return ForeignKey{}, false
+}
+
+// primaryKey returns the column of a single column primary key.
+func (t *Table) primaryKey() *Column {
+	var pk *Column
+	for _, c := range t.Columns {
+		if c.PrimaryKey {
+			if pk != nil {
+				return nil
+			}
+			pk = c
+		}
+	}
+	return pk
+}
+
+// postgresDefaultSchema is the schema Postgres creates tables in when they
+// are not qualified.
+const postgresDefaultSchema = "public"
+
+// defaultSchema returns the schema of a table as it is imported, which is
+// empty for the schema tables are found in when they are not qualified.
+func defaultSchema(dialect codegen.Dialect, schema string) string {
+	if dialect == codegen.DialectPostgres && schema == postgresDefaultSchema {
+		return ""
+	}
+	return schema
+}
+
+type tableKey struct {
+	schema string
+	name   string
+}
+
+// tableSet collects tables in the order they are first seen.
+type tableSet struct {
+	tables []*Table
+	byKey  map[tableKey]*Table
+}
+
+func newTableSet() *tableSet {
+	return &tableSet{byKey: map[tableKey]*Table{}}
+}
+
+func (s *tableSet) get(schema, name string) *Table {
+	key := tableKey{schema: schema, name: name}
+	if t, ok := s.byKey[key]; ok {
+		return t
+	}
+	t := &Table{Schema: schema, Name: name}
+	s.byKey[key] = t
+	s.tables = append(s.tables, t)
+	return t
+}
+
+func (s *tableSet) lookup(schema, name string) *Table {
+	return s.byKey[tableKey{schema: schema, name: name}]
+}
+
+// sqlType splits a column type into its base type, the arguments in its
+// parentheses and whether it is unsigned. Postgres arrays are reported with
+// the base type "array" and the type of their elements as arguments, which
+// is empty when it is not known.
+func sqlType(typ string) (base, args string, unsigned bool) {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	if typ == "array" {
+		return "array", "", false
+	}
+	if strings.HasSuffix(typ, "]") || strings.HasSuffix(typ, " array") {
+		if open := strings.LastIndex(typ, "["); open >= 0 && strings.HasSuffix(typ, "]") {
+			typ = strings.TrimSpace(typ[:open])
+		}
+		return "array", strings.TrimSuffix(typ, " array"), false
+	}
+	if open := strings.Index(typ, "("); open >= 0 {
+		if end := strings.LastIndex(typ, ")"); end > open {
+			args = typ[open+1 : end]
+			typ = typ[:open] + typ[end+1:]
+		}
+	}
+	words := strings.Fields(typ)
+	kept := words[:0]
+	for _, w := range words {
+		switch w {
+		case "unsigned":
+			unsigned = true
+		case "zerofill", "signed":
+		default:
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " "), args, unsigned
+}
+
+// enumValues returns the values of an enum or set type, written as
+// single-quoted strings separated by commas.
+func enumValues(args string) []string {
+	var values []string
+	var b strings.Builder
+	inString := false
+	for i := 0; i < len(args); i++ {
+		ch := args[i]
+		switch {
+		case !inString && ch == '\'':
+			inString = true
+			b.Reset()
+		case inString && ch == '\'' && i+1 < len(args) && args[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case inString && ch == '\\' && i+1 < len(args):
+			b.WriteByte(args[i+1])
+			i++
+		case inString && ch == '\'':
+			inString = false
+			values = append(values, b.String())
+		case inString:
+			b.WriteByte(ch)
+		}
+	}
+	return values
+}
+
+// goType returns the Go type the values of a column are generated as, not
+// counting NULL. Arrays are generated as slices of their elements, and
+// columns of types without a better match as strings.
+func goType(c *Column) string {
+	base, args, unsigned := sqlType(c.Type)
+	intType := func(signed string) string {
+		if unsigned {
+			return "u" + signed
+		}
+		return signed
+	}
+
+	switch base {
+	case "array":
+		return "[]" + goType(&Column{Type: args})
+	case "bool", "boolean":
+		return "bool"
+	case "bit":
+		if args == "" || args == "1" {
+			return "bool"
+		}
+		return "[]byte"
+	case "tinyint":
+		if args == "1" && !unsigned {
+			return "bool"
+		}
+		return intType("int8")
+	case "smallint", "int2", "smallserial", "serial2":
+		return intType("int16")
+	case "mediumint", "int", "integer", "int4", "serial", "serial4", "year":
+		if unsigned {
+			return "uint32"
+		}
+		return "int"
+	case "bigint", "int8", "bigserial", "serial8":
+		return intType("int64")
+	case "float", "real", "float4":
+		return "float32"
+	case "double", "double precision", "float8", "decimal", "numeric", "dec", "fixed", "money":
+		return "float64"
+	case "date", "datetime", "timestamp", "timestamptz",
+		"timestamp with time zone", "timestamp without time zone":
+		return "time.Time"
+	case "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "bytea":
+		return "[]byte"
+	}
+	return "string"
+}
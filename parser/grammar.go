@@ -15,11 +15,14 @@ type yySymType struct {
 	fields    *ast.FieldList
 	misc      string
 	tags      map[string]string
+	columns   map[string]string
 	genFuns   []*codegen.GenFn
 	calls     []*ast.CallExpr
 	count     int
 	str       string
 	metadata  *codegen.Metadata
+	// pos is the offset of the token in the .dg file
+	pos int
 }
 
 const MODEL = 57346
@@ -31,20 +34,28 @@ const CALLS = 57351
 const FN = 57352
 const COUNT = 57353
 const TAGS = 57354
-const L_BRACE = 57355
-const R_BRACE = 57356
-const L_PARENTHESIS = 57357
-const R_PARENTHESIS = 57358
-const COLON = 57359
-const COUNT_INT = 57360
-const MODEL_NAME = 57361
-const FN_NAME = 57362
-const FN_ARGS = 57363
-const FN_BODY = 57364
-const FIELDS_BODY = 57365
-const MISC_BODY = 57366
-const TAGS_BODY = 57367
-const CALLS_BODY = 57368
+const TABLE = 57355
+const SCHEMA = 57356
+const COLUMNS = 57357
+const JSON_KEYS = 57358
+const EMBED = 57359
+const XML = 57360
+const L_BRACE = 57361
+const R_BRACE = 57362
+const L_PARENTHESIS = 57363
+const R_PARENTHESIS = 57364
+const COLON = 57365
+const COUNT_INT = 57366
+const MODEL_NAME = 57367
+const FN_NAME = 57368
+const FN_ARGS = 57369
+const FN_BODY = 57370
+const FIELDS_BODY = 57371
+const MISC_BODY = 57372
+const TAGS_BODY = 57373
+const CALLS_BODY = 57374
+const COLUMNS_BODY = 57375
+const STRING_LIT = 57376
 
 var yyToknames = [...]string{
 	"$end",
@@ -59,6 +70,12 @@ var yyToknames = [...]string{
 	"FN",
 	"COUNT",
 	"TAGS",
+	"TABLE",
+	"SCHEMA",
+	"COLUMNS",
+	"JSON_KEYS",
+	"EMBED",
+	"XML",
 	"L_BRACE",
 	"R_BRACE",
 	"L_PARENTHESIS",
@@ -73,6 +90,8 @@ var yyToknames = [...]string{
 	"MISC_BODY",
 	"TAGS_BODY",
 	"CALLS_BODY",
+	"COLUMNS_BODY",
+	"STRING_LIT",
 }
 
 var yyStatenames = [...]string{}
@@ -89,63 +108,82 @@ var yyExca = [...]int8{
 
 const yyPrivate = 57344
 
-const yyLast = 63
+const yyLast = 102
 
 var yyAct = [...]int8{
-	39, 32, 38, 55, 31, 29, 60, 56, 50, 4,
-	51, 47, 46, 58, 6, 53, 61, 57, 49, 48,
-	43, 42, 18, 19, 20, 21, 22, 41, 17, 59,
-	52, 35, 36, 40, 27, 44, 45, 26, 25, 24,
-	23, 12, 13, 14, 16, 15, 5, 2, 1, 37,
-	54, 30, 28, 33, 10, 11, 34, 9, 8, 7,
-	3, 0, 62,
+	51, 86, 32, 78, 77, 87, 50, 85, 31, 29,
+	99, 91, 74, 41, 42, 43, 44, 45, 46, 47,
+	48, 4, 75, 71, 70, 2, 69, 68, 67, 66,
+	65, 64, 97, 83, 100, 96, 56, 57, 58, 59,
+	60, 61, 62, 63, 95, 94, 93, 92, 73, 72,
+	55, 54, 53, 17, 98, 82, 81, 80, 79, 76,
+	27, 26, 25, 24, 23, 5, 6, 12, 13, 14,
+	16, 15, 1, 52, 18, 19, 20, 21, 22, 36,
+	35, 49, 88, 89, 90, 84, 30, 28, 33, 10,
+	11, 40, 39, 38, 37, 34, 9, 8, 7, 3,
+	0, 101,
 }
 
 var yyPact = [...]int16{
-	43, -1000, -10, 33, -1000, 36, 14, 36, 36, 36,
-	36, 36, 27, 26, 25, 24, 21, -1000, -1000, -1000,
-	-1000, -1000, -1000, -18, -20, 20, -24, 23, 13, -1000,
-	7, -1000, 6, 20, 20, -5, -6, 5, -1000, 4,
-	-12, -1000, -1000, -1000, -1000, -1000, -8, 17, -1000, -1000,
-	0, -1000, -22, -14, 3, -1000, -3, -1000, 16, -16,
-	2, 23, -1000,
+	21, -32768, -4, 46, -32768, 62, 33, 62, 62, 62,
+	62, 62, 45, 44, 43, 42, 41, -32768, -32768, -32768,
+	-32768, -32768, -32768, -20, -22, 2, -26, 63, 32, -32768,
+	31, -32768, 30, 2, 2, 2, 2, 2, 2, 2,
+	2, 8, 7, 6, 5, 4, 3, 1, 0, 29,
+	-32768, 28, -14, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -2, 40, -30, -31, 39, 38,
+	37, 36, -32768, -32768, 12, -32768, -24, -32768, -32768, -28,
+	-28, -28, -28, -16, 27, -32768, 26, -32768, 25, 24,
+	15, 10, -32768, -32768, -32768, -32768, -32768, 35, -18, 14,
+	63, -32768,
 }
 
 var yyPgo = [...]int8{
-	0, 60, 14, 59, 58, 57, 1, 56, 55, 0,
-	54, 53, 52, 51, 50, 49, 48,
+	0, 99, 66, 98, 97, 96, 2, 95, 94, 93,
+	92, 91, 90, 0, 89, 88, 87, 86, 85, 81,
+	1, 80, 79, 72,
 }
 
 var yyR1 = [...]int8{
-	0, 16, 1, 2, 2, 2, 2, 2, 2, 3,
-	12, 4, 13, 5, 6, 6, 6, 11, 7, 14,
-	10, 15, 8, 9, 9,
+	0, 23, 1, 2, 2, 2, 2, 2, 2, 3,
+	16, 4, 17, 5, 6, 6, 6, 6, 6, 6,
+	6, 6, 6, 15, 7, 18, 21, 22, 8, 20,
+	9, 10, 11, 14, 19, 12, 13, 13,
 }
 
 var yyR2 = [...]int8{
 	0, 5, 1, 2, 2, 2, 2, 2, 0, 4,
-	1, 4, 1, 4, 2, 2, 0, 3, 5, 1,
-	4, 1, 4, 9, 0,
+	1, 4, 1, 4, 2, 2, 2, 2, 2, 2,
+	2, 2, 0, 3, 5, 1, 3, 3, 5, 1,
+	5, 5, 5, 4, 1, 4, 9, 0,
 }
 
 var yyChk = [...]int16{
-	-1000, -16, 4, -1, 19, 13, -2, -3, -4, -5,
-	-10, -8, 5, 6, 7, 9, 8, 14, -2, -2,
-	-2, -2, -2, 13, 13, 13, 13, 13, -12, 23,
-	-13, 24, -6, -11, -7, 11, 12, -15, 26, -9,
-	10, 14, 14, 14, -6, -6, 17, 17, 14, 14,
-	20, 18, 13, 15, -14, 25, 21, 14, 16, 13,
-	22, 14, -9,
+	-32768, -23, 4, -1, 25, 19, -2, -3, -4, -5,
+	-14, -12, 5, 6, 7, 9, 8, 20, -2, -2,
+	-2, -2, -2, 19, 19, 19, 19, 19, -16, 29,
+	-17, 30, -6, -15, -7, -21, -22, -8, -9, -10,
+	-11, 11, 12, 13, 14, 15, 16, 17, 18, -19,
+	32, -13, 10, 20, 20, 20, -6, -6, -6, -6,
+	-6, -6, -6, -6, 23, 23, 23, 23, 23, 23,
+	23, 23, 20, 20, 26, 24, 19, 34, 34, 19,
+	19, 19, 19, 21, -18, 31, -20, 33, -20, -20,
+	-20, 27, 20, 20, 20, 20, 20, 22, 19, 28,
+	20, -13,
 }
 
 var yyDef = [...]int8{
 	0, -2, 0, 0, 2, 8, 0, 8, 8, 8,
 	8, 8, 0, 0, 0, 0, 0, 1, 3, 4,
-	5, 6, 7, 0, 0, 16, 0, 24, 0, 10,
-	0, 12, 0, 16, 16, 0, 0, 0, 21, 0,
-	0, 9, 11, 13, 14, 15, 0, 0, 20, 22,
-	0, 17, 0, 0, 0, 19, 0, 18, 0, 0,
-	0, 24, 23,
+	5, 6, 7, 0, 0, 22, 0, 37, 0, 10,
+	0, 12, 0, 22, 22, 22, 22, 22, 22, 22,
+	22, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	34, 0, 0, 9, 11, 13, 14, 15, 16, 17,
+	18, 19, 20, 21, 0, 0, 0, 0, 0, 0,
+	0, 0, 33, 35, 0, 23, 0, 26, 27, 0,
+	0, 0, 0, 0, 0, 25, 0, 29, 0, 0,
+	0, 0, 24, 28, 30, 31, 32, 0, 0, 0,
+	37, 36,
 }
 
 var yyTok1 = [...]int8{
@@ -155,7 +193,8 @@ var yyTok1 = [...]int8{
 var yyTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
-	22, 23, 24, 25, 26,
+	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+	32, 33, 34,
 }
 
 var yyTok3 = [...]int8{
@@ -199,7 +238,7 @@ func yyNewParser() yyParser {
 	return &yyParserImpl{}
 }
 
-const yyFlag = -1000
+const yyFlag = -32768
 
 func yyTokname(c int) string {
 	if c >= 1 && c-1 < len(yyToknames) {
@@ -540,7 +579,7 @@ yydefault:
 	case 9:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
-			yyVAL.fields = yylex.(*lex).parse_fields(yyDollar[3].str)
+			yyVAL.fields = yylex.(*lex).parse_fields(yyDollar[3].str, yyDollar[3].pos)
 		}
 	case 10:
 		yyDollar = yyS[yypt-1 : yypt+1]
@@ -550,7 +589,7 @@ yydefault:
 	case 11:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
-			yyVAL.misc = yylex.(*lex).parse_misc(yyDollar[3].str)
+			yyVAL.misc = yylex.(*lex).parse_misc(yyDollar[3].str, yyDollar[3].pos)
 		}
 	case 12:
 		yyDollar = yyS[yypt-1 : yypt+1]
@@ -581,46 +620,135 @@ yydefault:
 			yyVAL.metadata = yyDollar[2].metadata
 		}
 	case 16:
-		yyDollar = yyS[yypt-0 : yypt+1]
+		yyDollar = yyS[yypt-2 : yypt+1]
 		{
+			if yyDollar[2].metadata == nil {
+				yyDollar[2].metadata = &codegen.Metadata{}
+			}
+			yyDollar[2].metadata.Table = yyDollar[1].str
+			yyVAL.metadata = yyDollar[2].metadata
 		}
 	case 17:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			if yyDollar[2].metadata == nil {
+				yyDollar[2].metadata = &codegen.Metadata{}
+			}
+			yyDollar[2].metadata.Schema = yyDollar[1].str
+			yyVAL.metadata = yyDollar[2].metadata
+		}
+	case 18:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			if yyDollar[2].metadata == nil {
+				yyDollar[2].metadata = &codegen.Metadata{}
+			}
+			yyDollar[2].metadata.Columns = yyDollar[1].columns
+			yyVAL.metadata = yyDollar[2].metadata
+		}
+	case 19:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			if yyDollar[2].metadata == nil {
+				yyDollar[2].metadata = &codegen.Metadata{}
+			}
+			yyDollar[2].metadata.JSONKeys = yyDollar[1].columns
+			yyVAL.metadata = yyDollar[2].metadata
+		}
+	case 20:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			if yyDollar[2].metadata == nil {
+				yyDollar[2].metadata = &codegen.Metadata{}
+			}
+			yyDollar[2].metadata.Embed = yyDollar[1].columns
+			yyVAL.metadata = yyDollar[2].metadata
+		}
+	case 21:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			if yyDollar[2].metadata == nil {
+				yyDollar[2].metadata = &codegen.Metadata{}
+			}
+			yyDollar[2].metadata.XML = yyDollar[1].columns
+			yyVAL.metadata = yyDollar[2].metadata
+		}
+	case 22:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+		}
+	case 23:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.count = yyDollar[3].count
 		}
-	case 18:
+	case 24:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
-			yyVAL.tags = yylex.(*lex).parse_tags(yyDollar[4].str)
+			yyVAL.tags = yylex.(*lex).parse_tags(yyDollar[4].str, yyDollar[4].pos)
 		}
-	case 19:
+	case 25:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.str = yyDollar[1].str
 		}
-	case 20:
+	case 26:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.str = yyDollar[3].str
+		}
+	case 27:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.str = yyDollar[3].str
+		}
+	case 28:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.columns = yylex.(*lex).parse_columns(yyDollar[4].str, yyDollar[4].pos)
+		}
+	case 29:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 30:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.columns = yylex.(*lex).parse_json_keys(yyDollar[4].str, yyDollar[4].pos)
+		}
+	case 31:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.columns = yylex.(*lex).parse_embed(yyDollar[4].str, yyDollar[4].pos)
+		}
+	case 32:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.columns = yylex.(*lex).parse_xml(yyDollar[4].str, yyDollar[4].pos)
+		}
+	case 33:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
-			yyVAL.calls = yylex.(*lex).parse_calls(yyDollar[3].str)
+			yyVAL.calls = yylex.(*lex).parse_calls(yyDollar[3].str, yyDollar[3].pos)
 		}
-	case 21:
+	case 34:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.str = yyDollar[1].str
 		}
-	case 22:
+	case 35:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.genFuns = yyDollar[3].genFuns
 		}
-	case 23:
+	case 36:
 		yyDollar = yyS[yypt-9 : yypt+1]
 		{
-			yylex.(*lex).add_gen_fn(yyDollar[2].str, yyDollar[4].str, yyDollar[7].str)
+			yylex.(*lex).add_gen_fn(yyDollar[2].str, yyDollar[4].str, yyDollar[7].str, yyDollar[2].pos, yyDollar[4].pos, yyDollar[7].pos)
 			yyVAL.genFuns = yylex.(*lex).parsed.GenFuns
 		}
-	case 24:
+	case 37:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.genFuns = yylex.(*lex).parsed.GenFuns
@@ -1,9 +1,9 @@
 %{
-package main
+package parser
 
 import (
 	  "go/ast"
-	  "github.com/ds-horizon/datagen/codegen"
+	  "github.com/dream-horizon-org/datagen/codegen"
 )
 %}
 
@@ -14,24 +14,27 @@ import (
     fields    *ast.FieldList
     misc      string
     tags      map[string]string
+    columns   map[string]string
     genFuns   []*codegen.GenFn
     calls     []*ast.CallExpr
     count     int
     str       string
     metadata  *codegen.Metadata
+    // pos is the offset of the token in the .dg file
+    pos       int
 }
 
 /* ------------ Terminals (tokens) ------------ */
 
 /* Keywords */
-%token MODEL FIELDS MISC METADATA GEN_FNS CALLS FN COUNT TAGS
+%token MODEL FIELDS MISC METADATA GEN_FNS CALLS FN COUNT TAGS TABLE SCHEMA COLUMNS JSON_KEYS EMBED XML
 
 /* Punctuators */
 %token L_BRACE R_BRACE L_PARENTHESIS R_PARENTHESIS COLON
 
 /* Literals / lexeme-carrying terminals */
 %token<count> COUNT_INT
-%token<str>   MODEL_NAME FN_NAME FN_ARGS FN_BODY FIELDS_BODY MISC_BODY TAGS_BODY CALLS_BODY
+%token<str>   MODEL_NAME FN_NAME FN_ARGS FN_BODY FIELDS_BODY MISC_BODY TAGS_BODY CALLS_BODY COLUMNS_BODY STRING_LIT
 
 /* ------------ Nonterminals (typed) ------------ */
 
@@ -42,11 +45,12 @@ import (
 %type<misc>      misc_section
 %type<metadata>  metadata_section metadata_body
 %type<tags>      tags_entry
+%type<columns>   columns_entry json_keys_entry embed_entry xml_entry
 %type<genFuns>   gen_fns_section gen_fns
 %type<calls>     calls_section
 %type<count>     count_entry
 
-%type<str>       fields_body misc_body tags_body calls_body
+%type<str>       fields_body misc_body tags_body calls_body columns_body table_entry schema_entry
 
 
 %start main
@@ -90,7 +94,7 @@ body: fields_section body
 
 fields_section: FIELDS L_BRACE fields_body R_BRACE
 {
-  $$ = yylex.(*lex).parse_fields($3)
+  $$ = yylex.(*lex).parse_fields($3, $<pos>3)
 }
 
 /* separate non-terminal to further lex the fields_body if required */
@@ -100,7 +104,7 @@ fields_body: FIELDS_BODY
 // misc
 misc_section: MISC L_BRACE misc_body R_BRACE
 {
-  $$ = yylex.(*lex).parse_misc($3)
+  $$ = yylex.(*lex).parse_misc($3, $<pos>3)
 }
 
 misc_body: MISC_BODY
@@ -126,6 +130,54 @@ metadata_body: count_entry metadata_body
 		    $2.Tags = $1
 		    $$ = $2
  	        }
+               | table_entry metadata_body
+ 	        {
+		    if $2 == nil {
+		        $2 = &codegen.Metadata{}
+		    }
+		    $2.Table = $1
+		    $$ = $2
+ 	        }
+               | schema_entry metadata_body
+ 	        {
+		    if $2 == nil {
+		        $2 = &codegen.Metadata{}
+		    }
+		    $2.Schema = $1
+		    $$ = $2
+ 	        }
+               | columns_entry metadata_body
+ 	        {
+		    if $2 == nil {
+		        $2 = &codegen.Metadata{}
+		    }
+		    $2.Columns = $1
+		    $$ = $2
+ 	        }
+               | json_keys_entry metadata_body
+ 	        {
+		    if $2 == nil {
+		        $2 = &codegen.Metadata{}
+		    }
+		    $2.JSONKeys = $1
+		    $$ = $2
+ 	        }
+               | embed_entry metadata_body
+ 	        {
+		    if $2 == nil {
+		        $2 = &codegen.Metadata{}
+		    }
+		    $2.Embed = $1
+		    $$ = $2
+ 	        }
+               | xml_entry metadata_body
+ 	        {
+		    if $2 == nil {
+		        $2 = &codegen.Metadata{}
+		    }
+		    $2.XML = $1
+		    $$ = $2
+ 	        }
                | // empty
 	       {}
 
@@ -137,16 +189,52 @@ count_entry: COUNT COLON COUNT_INT
 // tags
 tags_entry: TAGS COLON L_BRACE tags_body R_BRACE
 {
-  $$ = yylex.(*lex).parse_tags($4)
+  $$ = yylex.(*lex).parse_tags($4, $<pos>4)
 }
 
 tags_body: TAGS_BODY
 { $$ = $1 }
 
+// table and schema
+table_entry: TABLE COLON STRING_LIT
+{
+  $$ = $3
+}
+
+schema_entry: SCHEMA COLON STRING_LIT
+{
+  $$ = $3
+}
+
+// columns
+columns_entry: COLUMNS COLON L_BRACE columns_body R_BRACE
+{
+  $$ = yylex.(*lex).parse_columns($4, $<pos>4)
+}
+
+columns_body: COLUMNS_BODY
+{ $$ = $1 }
+
+// json keys, embedded models and xml nodes, whose bodies are lexed like columns
+json_keys_entry: JSON_KEYS COLON L_BRACE columns_body R_BRACE
+{
+  $$ = yylex.(*lex).parse_json_keys($4, $<pos>4)
+}
+
+embed_entry: EMBED COLON L_BRACE columns_body R_BRACE
+{
+  $$ = yylex.(*lex).parse_embed($4, $<pos>4)
+}
+
+xml_entry: XML COLON L_BRACE columns_body R_BRACE
+{
+  $$ = yylex.(*lex).parse_xml($4, $<pos>4)
+}
+
 // calls
 calls_section: CALLS L_BRACE calls_body R_BRACE
 {
-  $$ = yylex.(*lex).parse_calls($3)
+  $$ = yylex.(*lex).parse_calls($3, $<pos>3)
 }
 
 calls_body: CALLS_BODY
@@ -160,7 +248,7 @@ gen_fns_section: GEN_FNS L_BRACE gen_fns R_BRACE
 
 gen_fns: FN FN_NAME L_PARENTHESIS FN_ARGS R_PARENTHESIS L_BRACE FN_BODY R_BRACE gen_fns
             {
-	        yylex.(*lex).add_gen_fn($2, $4, $7)
+	        yylex.(*lex).add_gen_fn($2, $4, $7, $<pos>2, $<pos>4, $<pos>7)
 		$$ = yylex.(*lex).parsed.GenFuns
 	    }
          | // empty
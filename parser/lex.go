@@ -10,6 +10,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/utils"
 )
 
 //go:generate goyacc -l -o grammar.go grammar.y
@@ -21,6 +22,12 @@ type MetadataEntry int
 const (
 	Count MetadataEntry = iota
 	Tags
+	Table
+	Schema
+	Columns
+	JSONKeys
+	Embed
+	XMLNodes
 	MetadataEof
 )
 
@@ -54,18 +61,44 @@ type lex struct {
 	curSection    Section
 	metadataEntry MetadataEntry
 	err           error
+	source        *codegen.Source
+	// tokStart is the offset the token being lexed starts at
+	tokStart int
 }
 
+// error reports an error at the token being lexed, underlining what has been
+// consumed of it.
 func (l *lex) error(msg string, args ...any) (stateFn, int) {
-	l.err = fmt.Errorf(msg, args...)
+	l.err = l.source.Errorf(l.source.Pos(l.tokStart), l.curPos-l.tokStart, msg, args...)
 	return nil, -1
 }
 
+// snippetError reports err, from parsing the Go snippet at offset, prefixed
+// with what was being parsed. Errors without a position of their own are
+// reported at the start of the snippet.
+func (l *lex) snippetError(offset int, what string, err error) {
+	diags := codegen.Diagnostics(err)
+	if len(diags) == 0 {
+		l.err = l.source.Errorf(l.source.Pos(offset), 1, "%s: %s", what, err)
+		return
+	}
+	errs := make([]error, 0, len(diags))
+	for _, d := range diags {
+		d.Msg = what + ": " + d.Msg
+		errs = append(errs, d)
+	}
+	if len(errs) == 1 {
+		l.err = errs[0]
+		return
+	}
+	l.err = errors.Join(errs...)
+}
+
 // Error implements yyLexer.
 func (l *lex) Error(s string) {
 	// Preserve earlier, more specific errors set via l.error
 	if l.err == nil {
-		l.err = errors.New(s)
+		l.err = l.source.Errorf(l.source.Pos(l.tokStart), l.curPos-l.tokStart, "%s", s)
 	}
 }
 
@@ -117,6 +150,7 @@ func lexModelName(l *lex) (stateFn, int) {
 		return l.error("expected valid model name, got '%s'", val)
 	}
 	l.parsed.ModelName = val
+	l.parsed.ModelNamePos = l.source.Pos(l.tokStart)
 	return lexLBrace, MODEL_NAME
 }
 
@@ -175,6 +209,43 @@ func lexTagsBody(l *lex) (stateFn, int) {
 	return lexRBrace, TAGS_BODY
 }
 
+func lexColumnsBody(l *lex) (stateFn, int) {
+	val, err := l.consumeBodyTillRBrace()
+	if err != nil {
+		return l.error("invalid columns body %s", err)
+	}
+	l.lval.str = val
+	return lexRBrace, COLUMNS_BODY
+}
+
+// lexMetadataString reads a double-quoted or raw Go string literal.
+func lexMetadataString(l *lex) (stateFn, int) {
+	start := l.curPos
+	quote := l.nextByte()
+	if quote != '"' && quote != '`' {
+		l.backup()
+		return l.error("expected quoted string, got '%s'", l.consumeString())
+	}
+Loop:
+	for {
+		switch b := l.nextByte(); {
+		case b == eof, b == '\n' && quote == '"':
+			return l.error("unterminated string %s", l.input[start:l.curPos])
+		case b == '\\' && quote == '"':
+			l.nextByte()
+		case b == quote:
+			break Loop
+		}
+	}
+
+	val, err := strconv.Unquote(l.input[start:l.curPos])
+	if err != nil {
+		return l.error("invalid string %s: %s", l.input[start:l.curPos], err)
+	}
+	l.lval.str = val
+	return lexMetadataBody, STRING_LIT
+}
+
 func lexMetadataCount(l *lex) (stateFn, int) {
 	val := l.consumeString()
 	valInt, err := strconv.Atoi(val)
@@ -195,7 +266,11 @@ func lexMetadataColon(l *lex) (stateFn, int) {
 		return lexMetadataCount, COLON
 	}
 
-	if l.metadataEntry == Tags {
+	if l.metadataEntry == Table || l.metadataEntry == Schema {
+		return lexMetadataString, COLON
+	}
+
+	if l.metadataEntry == Tags || l.metadataEntry == Columns || l.metadataEntry == JSONKeys || l.metadataEntry == Embed || l.metadataEntry == XMLNodes {
 		return lexLBrace, COLON
 	}
 
@@ -218,6 +293,36 @@ func lexMetadataBody(l *lex) (stateFn, int) {
 		return lexMetadataColon, TAGS
 	}
 
+	if val == "table" {
+		l.metadataEntry = Table
+		return lexMetadataColon, TABLE
+	}
+
+	if val == "schema" {
+		l.metadataEntry = Schema
+		return lexMetadataColon, SCHEMA
+	}
+
+	if val == "columns" {
+		l.metadataEntry = Columns
+		return lexMetadataColon, COLUMNS
+	}
+
+	if val == "json_keys" {
+		l.metadataEntry = JSONKeys
+		return lexMetadataColon, JSON_KEYS
+	}
+
+	if val == "embed" {
+		l.metadataEntry = Embed
+		return lexMetadataColon, EMBED
+	}
+
+	if val == "xml" {
+		l.metadataEntry = XMLNodes
+		return lexMetadataColon, XML
+	}
+
 	if val != "" {
 		return l.error("invalid metadata field")
 	}
@@ -358,6 +463,10 @@ func lexLBrace(l *lex) (stateFn, int) {
 		return lexTagsBody, L_BRACE
 	}
 
+	if l.curSection == Metadata && (l.metadataEntry == Columns || l.metadataEntry == JSONKeys || l.metadataEntry == Embed || l.metadataEntry == XMLNodes) {
+		return lexColumnsBody, L_BRACE
+	}
+
 	if l.curSection == Metadata {
 		return lexMetadataBody, L_BRACE
 	}
@@ -385,49 +494,85 @@ func lexModel(l *lex) (stateFn, int) {
 	return lexModelName, MODEL
 }
 
-func (l *lex) parse_fields(s string) *ast.FieldList {
-	fieldList, err := parseFieldList(s, parseWrappedExpr)
+func (l *lex) parse_fields(s string, offset int) *ast.FieldList {
+	fieldList, err := parseFieldList(s, snippetParser(l.source, offset))
 	if err != nil {
-		l.error("could not parse field list: %s", err)
+		l.snippetError(offset, "could not parse field list", err)
 	}
 	return fieldList
 }
 
-func (l *lex) parse_misc(s string) string {
+func (l *lex) parse_misc(s string, offset int) string {
+	if err := parseMisc(s, l.source, offset); err != nil {
+		l.snippetError(offset, "could not parse misc", err)
+	}
 	return s
 }
 
-func (l *lex) parse_tags(s string) map[string]string {
-	tags, err := parseTags(s, parseWrappedExpr)
+func (l *lex) parse_tags(s string, offset int) map[string]string {
+	tags, err := parseTags(s, snippetParser(l.source, offset))
 	if err != nil {
-		l.error("could not parse tags: %s", err)
+		l.snippetError(offset, "could not parse tags", err)
 	}
 	return tags
 }
 
-func (l *lex) parse_calls(s string) []*ast.CallExpr {
-	calls, err := parseCallList(s, parseWrappedExpr)
+func (l *lex) parse_columns(s string, offset int) map[string]string {
+	columns, err := parseTags(s, snippetParser(l.source, offset))
+	if err != nil {
+		l.snippetError(offset, "could not parse columns", err)
+	}
+	return columns
+}
+
+func (l *lex) parse_json_keys(s string, offset int) map[string]string {
+	keys, err := parseTags(s, snippetParser(l.source, offset))
+	if err != nil {
+		l.snippetError(offset, "could not parse json_keys", err)
+	}
+	return keys
+}
+
+func (l *lex) parse_embed(s string, offset int) map[string]string {
+	embed, err := parseTags(s, snippetParser(l.source, offset))
+	if err != nil {
+		l.snippetError(offset, "could not parse embed", err)
+	}
+	return embed
+}
+
+func (l *lex) parse_xml(s string, offset int) map[string]string {
+	nodes, err := parseTags(s, snippetParser(l.source, offset))
+	if err != nil {
+		l.snippetError(offset, "could not parse xml", err)
+	}
+	return nodes
+}
+
+func (l *lex) parse_calls(s string, offset int) []*ast.CallExpr {
+	calls, err := parseCallList(s, snippetParser(l.source, offset))
 	if err != nil {
-		l.error("could not parse calls: %s", err)
+		l.snippetError(offset, "could not parse calls", err)
 	}
 	return calls
 }
 
-func (l *lex) add_gen_fn(name, args, body string) {
-	argsList, err := parseParamList(args, parseWrappedExpr)
+func (l *lex) add_gen_fn(name, args, body string, nameOffset, argsOffset, bodyOffset int) {
+	argsList, err := parseParamList(args, snippetParser(l.source, argsOffset))
 	if err != nil {
-		l.error("could not parse args list: %s", err)
+		l.snippetError(argsOffset, "could not parse args list", err)
 	}
 
-	funcBody, err := parseFunctionBlock(body, parseWrappedExpr)
+	funcBody, err := parseFunctionBlock(body, snippetParser(l.source, bodyOffset))
 	if err != nil {
-		l.error("could not parse func block: %s", err)
+		l.snippetError(bodyOffset, "could not parse func block", err)
 	}
 
 	l.parsed.GenFuns = append(l.parsed.GenFuns, &codegen.GenFn{
-		Name:  name,
-		Calls: argsList,
-		Body:  funcBody,
+		Name:    name,
+		NamePos: l.source.Pos(nameOffset),
+		Calls:   argsList,
+		Body:    funcBody,
 	})
 }
 
@@ -442,6 +587,8 @@ func (l *lex) Lex(lval *yySymType) int {
 
 	l.lval = lval
 	l.ditchSpacesAndComments()
+	l.tokStart = l.curPos
+	lval.pos = l.curPos
 	nextFn, val := l.fn(l)
 	l.fn = nextFn
 	return val
@@ -520,12 +667,17 @@ func (l *lex) prevByte() {
 }
 
 // ---- Entry point ----
+// Parse parses the .dg file read from path, a key of utils.DgDir.Models.
+// Errors are *codegen.Diagnostic values, or joins of them, positioned in the
+// file.
 func Parse(src []byte, path string) (*codegen.DatagenParsed, error) {
+	source := codegen.NewSource(utils.ModelFilePath(path), src)
 	l := lex{
 		input:      string(src),
 		fn:         lexModel,
 		curSection: None,
-		parsed:     &codegen.DatagenParsed{Filepath: path},
+		parsed:     &codegen.DatagenParsed{Filepath: path, Source: source},
+		source:     source,
 	}
 	yyParse(&l)
 	return l.parsed, l.err
@@ -1,12 +1,15 @@
 package parser
 
 import (
+	"go/ast"
+	"go/token"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/utils"
 )
 
 func TestDitchSpaces(t *testing.T) {
@@ -295,6 +298,79 @@ model test {
 			expectedCalls:     false,
 			fail:              false,
 		},
+		{
+			name: "model with table, schema and columns",
+			input: `model users {
+  metadata {
+    table: "user_accounts"
+    schema: ` + "`billing`" + `
+    columns: {
+      "email": "E-Mail Address",
+      "helper": "-"
+    }
+  }
+}`,
+			expectedMetadata: &codegen.Metadata{
+				Table:  "user_accounts",
+				Schema: "billing",
+				Columns: map[string]string{
+					"email":  "E-Mail Address",
+					"helper": "-",
+				},
+			},
+			expectedModelName: "users",
+			expectedFilepath:  "test.dg",
+			expectedFields:    false,
+			expectedMisc:      false,
+			expectedGenFuncs:  false,
+			expectedCalls:     false,
+			fail:              false,
+		},
+		{
+			name: "model with json keys and embedded models",
+			input: `model users {
+  metadata {
+    json_keys: {
+      "full_name": "fullName"
+    }
+    embed: {
+      "orders": "shop.orders"
+    }
+  }
+}`,
+			expectedMetadata: &codegen.Metadata{
+				JSONKeys: map[string]string{"full_name": "fullName"},
+				Embed:    map[string]string{"orders": "shop.orders"},
+			},
+			expectedModelName: "users",
+			expectedFilepath:  "test.dg",
+			expectedFields:    false,
+			expectedMisc:      false,
+			expectedGenFuncs:  false,
+			expectedCalls:     false,
+			fail:              false,
+		},
+		{
+			name: "model with xml nodes",
+			input: `model users {
+  metadata {
+    xml: {
+      "id": "attr",
+      "bio": "element"
+    }
+  }
+}`,
+			expectedMetadata: &codegen.Metadata{
+				XML: map[string]string{"id": "attr", "bio": "element"},
+			},
+			expectedModelName: "users",
+			expectedFilepath:  "test.dg",
+			expectedFields:    false,
+			expectedMisc:      false,
+			expectedGenFuncs:  false,
+			expectedCalls:     false,
+			fail:              false,
+		},
 		{
 			name: "model with all sections",
 			input: `model complete {
@@ -386,6 +462,36 @@ model test {
 			fail:   true,
 			errStr: "invalid metadata field",
 		},
+		{
+			name:   "unquoted metadata table",
+			input:  "model test { metadata { table: users } }",
+			fail:   true,
+			errStr: "expected quoted string, got 'users'",
+		},
+		{
+			name:   "unterminated metadata schema",
+			input:  "model test { metadata { schema: \"billing } }",
+			fail:   true,
+			errStr: "unterminated string",
+		},
+		{
+			name:   "invalid metadata columns",
+			input:  "model test { metadata { columns: { \"email\": 1 } } }",
+			fail:   true,
+			errStr: "could not parse columns",
+		},
+		{
+			name:   "invalid metadata embed",
+			input:  "model test { metadata { embed: { \"orders\" } } }",
+			fail:   true,
+			errStr: "could not parse embed",
+		},
+		{
+			name:   "invalid metadata xml",
+			input:  "model test { metadata { xml: { \"id\": attr } } }",
+			fail:   true,
+			errStr: "could not parse xml",
+		},
 		{
 			name:   "incomplete gens section",
 			input:  "model test { gens { func } }",
@@ -450,6 +556,16 @@ model test {
 					assert.Equal(t, tt.expectedMetadata.Tags, got.Metadata.Tags,
 						"Metadata.Tags mismatch")
 				}
+				assert.Equal(t, tt.expectedMetadata.Table, got.Metadata.Table,
+					"Metadata.Table mismatch")
+				assert.Equal(t, tt.expectedMetadata.Schema, got.Metadata.Schema,
+					"Metadata.Schema mismatch")
+				assert.Equal(t, tt.expectedMetadata.Columns, got.Metadata.Columns,
+					"Metadata.Columns mismatch")
+				assert.Equal(t, tt.expectedMetadata.JSONKeys, got.Metadata.JSONKeys,
+					"Metadata.JSONKeys mismatch")
+				assert.Equal(t, tt.expectedMetadata.Embed, got.Metadata.Embed,
+					"Metadata.Embed mismatch")
 			}
 
 			assert.Equal(t, tt.expectedFields, got.Fields != nil, "Fields presence mismatch")
@@ -459,3 +575,94 @@ model test {
 		})
 	}
 }
+
+func TestParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantPos  string
+		wantMsg  string
+		wantLine string
+	}{
+		{
+			name:     "lexer error",
+			input:    "model test {\n  fieldz {\n  }\n}\n",
+			wantPos:  "dir/test.dg:2:3",
+			wantMsg:  "expected section header",
+			wantLine: "  fieldz {",
+		},
+		{
+			name:     "invalid count",
+			input:    "model test {\n  metadata {\n    count: ten\n  }\n}\n",
+			wantPos:  "dir/test.dg:3:12",
+			wantMsg:  "invalid count",
+			wantLine: "    count: ten",
+		},
+		{
+			name:     "field list",
+			input:    "model test {\n  fields {\n    id() int\n    name() strin g\n  }\n}\n",
+			wantPos:  "dir/test.dg:4:18",
+			wantMsg:  "could not parse field list: expected ';', found g",
+			wantLine: "    name() strin g",
+		},
+		{
+			name:     "gen fn body",
+			input:    "model test {\n  fields {\n    id() int\n  }\n  gens {\n    func id() {\n      return 1 +* \n    }\n  }\n}\n",
+			wantPos:  "dir/test.dg:8:5",
+			wantMsg:  "could not parse func block: expected operand, found '}'",
+			wantLine: "    }",
+		},
+		{
+			name:     "gen fn args",
+			input:    "model test {\n  gens {\n    func id(a b c) {\n      return 1\n    }\n  }\n}\n",
+			wantPos:  "dir/test.dg:3:17",
+			wantMsg:  "could not parse args list",
+			wantLine: "    func id(a b c) {",
+		},
+		{
+			name:     "misc",
+			input:    "model test {\n  misc {\n    var x = \n  }\n}\n",
+			wantPos:  "dir/test.dg:4:3",
+			wantMsg:  "could not parse misc: expected operand",
+			wantLine: "  }",
+		},
+		{
+			name:     "tags",
+			input:    "model test {\n  metadata {\n    tags: {\n      \"a\": 1\n    }\n  }\n}\n",
+			wantPos:  "dir/test.dg:4:7",
+			wantMsg:  "could not parse tags: map value must be a string literal",
+			wantLine: "      \"a\": 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.input), "dir"+utils.DgDirDelimeter+"test")
+			require.Error(t, err)
+
+			diags := codegen.Diagnostics(err)
+			require.Len(t, diags, 1, "expected one diagnostic, got: %v", err)
+			assert.Equal(t, tt.wantPos, diags[0].Pos.String())
+			assert.Contains(t, diags[0].Msg, tt.wantMsg)
+			assert.Equal(t, tt.wantLine, diags[0].Source)
+		})
+	}
+}
+
+func TestParsePositions(t *testing.T) {
+	src := "model test {\n  fields {\n    id() int\n  }\n  gens {\n    func id() {\n      return iter\n    }\n  }\n}\n"
+	got, err := Parse([]byte(src), "test")
+	require.NoError(t, err)
+
+	position := func(pos token.Pos) string {
+		offset, ok := got.Source.Offset(pos)
+		require.True(t, ok)
+		return got.Source.Fset.Position(got.Source.Pos(offset)).String()
+	}
+	assert.Equal(t, "test.dg:1:7", position(got.ModelNamePos))
+	assert.Equal(t, "test.dg:3:5", position(got.Fields.List[0].Names[0].Pos()))
+	require.Len(t, got.GenFuns, 1)
+	assert.Equal(t, "test.dg:6:10", position(got.GenFuns[0].NamePos))
+	ret := got.GenFuns[0].Body.List[0].(*ast.ReturnStmt)
+	assert.Equal(t, "test.dg:7:14", position(ret.Results[0].Pos()))
+}
@@ -7,6 +7,8 @@ import (
 	"go/token"
 	"strconv"
 	"strings"
+
+	"github.com/dream-horizon-org/datagen/codegen"
 )
 
 type wrapperFunc func(input string, wrapper func(string) string) (ast.Expr, error)
@@ -20,6 +22,39 @@ func parseWrappedExpr(input string, wrapper func(string) string) (ast.Expr, erro
 	return expr, nil
 }
 
+// snippetMarker stands in for a snippet to find where wrappers put it.
+const snippetMarker = "\x00"
+
+// snippetParser returns a wrapperFunc for the snippet found at offset in the
+// .dg file of src. The wrapped code is parsed into src.Fset, so positions in
+// the nodes and errors it returns map back to the .dg file.
+func snippetParser(src *codegen.Source, offset int) wrapperFunc {
+	return func(input string, wrapper func(string) string) (ast.Expr, error) {
+		wrappedCode := wrapper(input)
+		base := src.Fset.Base()
+		expr, err := parser.ParseExprFrom(src.Fset, "", wrappedCode, 0)
+		prefix := strings.Index(wrapper(snippetMarker), snippetMarker)
+		src.AddSnippet(base, len(wrappedCode), offset, prefix, len(input))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse expression: %w", src.MapScannerErrors(base, err))
+		}
+		return expr, nil
+	}
+}
+
+// parseMisc checks that the misc section at offset in the .dg file of src is
+// valid Go source, once it is put in a package.
+func parseMisc(input string, src *codegen.Source, offset int) error {
+	const header = "package main\n"
+	base := src.Fset.Base()
+	_, err := parser.ParseFile(src.Fset, "", header+input, parser.SkipObjectResolution)
+	src.AddSnippet(base, len(header)+len(input), offset, len(header), len(input))
+	if err != nil {
+		return src.MapScannerErrors(base, err)
+	}
+	return nil
+}
+
 // parseFieldList parses a string containing field definitions into an *ast.FieldList.
 // The input string should be in the format of Go interface methods.
 func parseFieldList(input string, wrapperFunc wrapperFunc) (*ast.FieldList, error) {
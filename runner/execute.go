@@ -21,43 +21,22 @@ import (
 func BuildAndRunGen(cmd *cobra.Command, args []string) error {
 	inputPath := args[0]
 
-	count, err := cmd.Flags().GetInt("count")
+	flags, err := getGenFlags(cmd)
 	if err != nil {
-		return fmt.Errorf("invalid value for --count: %w", err)
-	}
-	tags, err := cmd.Flags().GetString("tags")
-	if err != nil {
-		return fmt.Errorf("invalid value for --tags: %w", err)
-	}
-	output, err := cmd.Flags().GetString("output")
-	if err != nil {
-		return fmt.Errorf("invalid value for --output: %w", err)
-	}
-	format, err := cmd.Flags().GetString("format")
-	if err != nil {
-		return fmt.Errorf("invalid value for --format: %w", err)
-	}
-	seed, err := cmd.Flags().GetInt64("seed")
-	if err != nil {
-		return fmt.Errorf("invalid value for --seed: %w", err)
+		return err
 	}
 	noexec, err := cmd.Flags().GetBool("noexec")
 	if err != nil {
 		return fmt.Errorf("invalid value for --noexec: %w", err)
 	}
 
-	outDir := filepath.Join(output, "target")
+	outDir := targetDir(flags.output)
 	if err := findAndTranspileDatagenModels(outDir, inputPath); err != nil {
 		return err
 	}
 
-	verbose, err := cmd.Flags().GetBool("verbose")
-	if err != nil {
-		return fmt.Errorf("invalid value for --verbose: %w", err)
-	}
-
 	if !noexec {
-		if err := invokeGen(outDir, count, tags, output, format, seed, inputPath, verbose); err != nil {
+		if err := invokeGen(outDir, inputPath, flags); err != nil {
 			return err
 		}
 	}
@@ -65,26 +44,9 @@ func BuildAndRunGen(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func invokeGen(outDir string, count int, tags, output, format string, seed int64, inputPath string, verbose bool) error {
+func invokeGen(outDir, inputPath string, flags genFlags) error {
 	binaryPath, _ := buildTranspiledBinary(filepath.Clean(filepath.Join(outDir, utils.DatagenDirName)))
-	args := []string{"gen", inputPath}
-	args = append(args, "-n", fmt.Sprintf("%d", count))
-	if strings.TrimSpace(tags) != "" {
-		args = append(args, "-t", tags)
-	}
-	if strings.TrimSpace(output) != "" {
-		args = append(args, "-o", output)
-	}
-	if strings.TrimSpace(format) != "" {
-		args = append(args, "-f", format)
-	}
-	if seed != 0 {
-		args = append(args, "--seed", fmt.Sprintf("%d", seed))
-	}
-	if verbose {
-		args = append(args, "-v")
-	}
-	err := executeCmd(binaryPath, args)
+	err := executeCmd(binaryPath, flags.args(inputPath))
 	if err != nil {
 		return err
 	}
@@ -110,20 +72,24 @@ func BuildAndRunExecute(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("invalid value for --verbose: %w", err)
 	}
+	run, err := getRunFlags(cmd)
+	if err != nil {
+		return err
+	}
 
-	outDir := filepath.Join(output, "target")
+	outDir := targetDir(output)
 	if err := findAndTranspileDatagenModels(outDir, inputPath); err != nil {
 		return err
 	}
 	if !noexec {
-		if err := invokeExecute(outDir, output, config, inputPath, verbose); err != nil {
+		if err := invokeExecute(outDir, output, config, inputPath, verbose, run); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func invokeExecute(outDir, output, config, inputPath string, verbose bool) error {
+func invokeExecute(outDir, output, config, inputPath string, verbose bool, run runFlags) error {
 	binaryPath, err := buildTranspiledBinary(filepath.Clean(filepath.Join(outDir, utils.DatagenDirName)))
 	if err != nil {
 		return nil
@@ -134,6 +100,7 @@ func invokeExecute(outDir, output, config, inputPath string, verbose bool) error
 	if strings.TrimSpace(output) != "" {
 		args = append(args, "-o", output)
 	}
+	args = append(args, run.args()...)
 	if verbose {
 		args = append(args, "-v")
 	}
@@ -145,25 +112,323 @@ func invokeExecute(outDir, output, config, inputPath string, verbose bool) error
 	return nil
 }
 
-func findAndTranspileDatagenModels(outDir, inputPath string) error {
-	slog.Debug(fmt.Sprintf("finding and transpiling datagen models from %s into %s", inputPath, outDir))
+// genFlags holds the flags of gen; they are forwarded to the generated
+// binary once validated.
+type genFlags struct {
+	count        int
+	tags         string
+	output       string
+	format       string
+	seed         int64
+	rowGroupSize int
+	jsonMode     string
+	verbose      bool
+	sql          sqlFlags
+	xml          xmlFlags
+	csv          csvFlags
+	out          outputFlags
+	run          runFlags
+}
 
-	dgDirData, err := GetDgDirStructure(inputPath, "")
+func getGenFlags(cmd *cobra.Command) (genFlags, error) {
+	var f genFlags
+	var err error
+	if f.count, err = cmd.Flags().GetInt("count"); err != nil {
+		return genFlags{}, fmt.Errorf("invalid value for --count: %w", err)
+	}
+	if f.tags, err = cmd.Flags().GetString("tags"); err != nil {
+		return genFlags{}, fmt.Errorf("invalid value for --tags: %w", err)
+	}
+	if f.output, err = cmd.Flags().GetString("output"); err != nil {
+		return genFlags{}, fmt.Errorf("invalid value for --output: %w", err)
+	}
+	if f.format, err = cmd.Flags().GetString("format"); err != nil {
+		return genFlags{}, fmt.Errorf("invalid value for --format: %w", err)
+	}
+	if f.seed, err = cmd.Flags().GetInt64("seed"); err != nil {
+		return genFlags{}, fmt.Errorf("invalid value for --seed: %w", err)
+	}
+	if f.rowGroupSize, err = cmd.Flags().GetInt("row-group-size"); err != nil {
+		return genFlags{}, fmt.Errorf("invalid value for --row-group-size: %w", err)
+	}
+	if f.rowGroupSize < 0 {
+		return genFlags{}, fmt.Errorf("invalid value for --row-group-size: must not be negative, got %d", f.rowGroupSize)
+	}
+	if f.jsonMode, err = cmd.Flags().GetString("json-mode"); err != nil {
+		return genFlags{}, fmt.Errorf("invalid value for --json-mode: %w", err)
+	}
+	if f.sql, err = getSQLFlags(cmd); err != nil {
+		return genFlags{}, err
+	}
+	if f.xml, err = getXMLFlags(cmd); err != nil {
+		return genFlags{}, err
+	}
+	if f.csv, err = getCSVFlags(cmd); err != nil {
+		return genFlags{}, err
+	}
+	if f.out, err = getOutputFlags(cmd); err != nil {
+		return genFlags{}, err
+	}
+	if f.run, err = getRunFlags(cmd); err != nil {
+		return genFlags{}, err
+	}
+	if f.verbose, err = cmd.Flags().GetBool("verbose"); err != nil {
+		return genFlags{}, fmt.Errorf("invalid value for --verbose: %w", err)
+	}
+	return f, nil
+}
+
+// args returns the arguments running gen on inputPath with the flags.
+func (f genFlags) args(inputPath string) []string {
+	args := []string{"gen", inputPath}
+	args = append(args, "-n", fmt.Sprintf("%d", f.count))
+	if strings.TrimSpace(f.tags) != "" {
+		args = append(args, "-t", f.tags)
+	}
+	if strings.TrimSpace(f.output) != "" {
+		args = append(args, "-o", f.output)
+	}
+	if strings.TrimSpace(f.format) != "" {
+		args = append(args, "-f", f.format)
+	}
+	if f.seed != 0 {
+		args = append(args, "--seed", fmt.Sprintf("%d", f.seed))
+	}
+	args = append(args, "--row-group-size", fmt.Sprintf("%d", f.rowGroupSize))
+	args = append(args, f.sql.args()...)
+	if strings.TrimSpace(f.jsonMode) != "" {
+		args = append(args, "--json-mode", f.jsonMode)
+	}
+	args = append(args, f.xml.args()...)
+	args = append(args, f.csv.args()...)
+	args = append(args, f.out.args()...)
+	args = append(args, f.run.args()...)
+	if f.verbose {
+		args = append(args, "-v")
+	}
+	return args
+}
+
+// runFlags holds the flags tuning how the generated binary generates
+// records; they are forwarded to it unchanged.
+type runFlags struct {
+	chunkSize   int
+	memoWindow  int
+	parallelism int
+}
+
+func getRunFlags(cmd *cobra.Command) (runFlags, error) {
+	var f runFlags
+	for _, flag := range []struct {
+		name  string
+		value *int
+	}{
+		{name: "chunk-size", value: &f.chunkSize},
+		{name: "memo-window", value: &f.memoWindow},
+		{name: "parallelism", value: &f.parallelism},
+	} {
+		v, err := cmd.Flags().GetInt(flag.name)
+		if err != nil {
+			return runFlags{}, fmt.Errorf("invalid value for --%s: %w", flag.name, err)
+		}
+		if v < 0 {
+			return runFlags{}, fmt.Errorf("invalid value for --%s: must not be negative, got %d", flag.name, v)
+		}
+		*flag.value = v
+	}
+	return f, nil
+}
+
+func (f runFlags) args() []string {
+	return []string{
+		"--chunk-size", fmt.Sprintf("%d", f.chunkSize),
+		"--memo-window", fmt.Sprintf("%d", f.memoWindow),
+		"--parallelism", fmt.Sprintf("%d", f.parallelism),
+	}
+}
+
+// sqlFlags holds the flags of the sql format of gen; they are forwarded to
+// the generated binary once validated.
+type sqlFlags struct {
+	dialect     codegen.Dialect
+	batchSize   int
+	createTable bool
+	truncate    bool
+}
+
+func getSQLFlags(cmd *cobra.Command) (sqlFlags, error) {
+	var f sqlFlags
+	dialectName, err := cmd.Flags().GetString("dialect")
 	if err != nil {
-		return fmt.Errorf("failed to read input\n  input_path: %s\n  cause: %w", inputPath, err)
+		return sqlFlags{}, fmt.Errorf("invalid value for --dialect: %w", err)
+	}
+	if f.dialect, err = codegen.ParseDialect(dialectName); err != nil {
+		return sqlFlags{}, fmt.Errorf("invalid value for --dialect: %w", err)
+	}
+	if f.batchSize, err = cmd.Flags().GetInt("batch-size"); err != nil {
+		return sqlFlags{}, fmt.Errorf("invalid value for --batch-size: %w", err)
 	}
+	if f.batchSize < 1 {
+		return sqlFlags{}, fmt.Errorf("invalid value for --batch-size: must be positive, got %d", f.batchSize)
+	}
+	if f.createTable, err = cmd.Flags().GetBool("create-table"); err != nil {
+		return sqlFlags{}, fmt.Errorf("invalid value for --create-table: %w", err)
+	}
+	if f.truncate, err = cmd.Flags().GetBool("truncate"); err != nil {
+		return sqlFlags{}, fmt.Errorf("invalid value for --truncate: %w", err)
+	}
+	return f, nil
+}
 
-	dgModelsCount := dgDirData.ModelCount()
-	slog.Debug(fmt.Sprintf("found %d datagen models in %s", dgModelsCount, inputPath))
+func (f sqlFlags) args() []string {
+	args := []string{
+		"--dialect", string(f.dialect),
+		"--batch-size", fmt.Sprintf("%d", f.batchSize),
+	}
+	if f.createTable {
+		args = append(args, "--create-table")
+	}
+	if f.truncate {
+		args = append(args, "--truncate")
+	}
+	return args
+}
 
-	if dgModelsCount == 0 {
-		slog.Warn(fmt.Sprintf("no .dg files found in %s", inputPath))
-		return fmt.Errorf("no .dg files found in %s", inputPath)
+// xmlFlags are the gen flags of the xml format.
+type xmlFlags struct {
+	root      string
+	namespace string
+}
+
+func getXMLFlags(cmd *cobra.Command) (xmlFlags, error) {
+	var f xmlFlags
+	var err error
+	if f.root, err = cmd.Flags().GetString("xml-root"); err != nil {
+		return xmlFlags{}, fmt.Errorf("invalid value for --xml-root: %w", err)
+	}
+	if f.namespace, err = cmd.Flags().GetString("xml-namespace"); err != nil {
+		return xmlFlags{}, fmt.Errorf("invalid value for --xml-namespace: %w", err)
 	}
+	return f, nil
+}
 
-	parsedAll, err := processDgDirData(dgDirData, outDir, []*codegen.DatagenParsed{})
+func (f xmlFlags) args() []string {
+	var args []string
+	if f.root != "" {
+		args = append(args, "--xml-root", f.root)
+	}
+	if f.namespace != "" {
+		args = append(args, "--xml-namespace", f.namespace)
+	}
+	return args
+}
+
+// csvFlags are the gen flags of the csv format.
+type csvFlags struct {
+	delimiter      string
+	header         bool
+	null           string
+	timeLayout     string
+	floatPrecision int
+}
+
+func getCSVFlags(cmd *cobra.Command) (csvFlags, error) {
+	var f csvFlags
+	var err error
+	if f.delimiter, err = cmd.Flags().GetString("csv-delimiter"); err != nil {
+		return csvFlags{}, fmt.Errorf("invalid value for --csv-delimiter: %w", err)
+	}
+	if f.header, err = cmd.Flags().GetBool("csv-header"); err != nil {
+		return csvFlags{}, fmt.Errorf("invalid value for --csv-header: %w", err)
+	}
+	if f.null, err = cmd.Flags().GetString("csv-null"); err != nil {
+		return csvFlags{}, fmt.Errorf("invalid value for --csv-null: %w", err)
+	}
+	if f.timeLayout, err = cmd.Flags().GetString("csv-time-layout"); err != nil {
+		return csvFlags{}, fmt.Errorf("invalid value for --csv-time-layout: %w", err)
+	}
+	if f.floatPrecision, err = cmd.Flags().GetInt("csv-float-precision"); err != nil {
+		return csvFlags{}, fmt.Errorf("invalid value for --csv-float-precision: %w", err)
+	}
+	return f, nil
+}
+
+func (f csvFlags) args() []string {
+	args := []string{
+		fmt.Sprintf("--csv-header=%t", f.header),
+		"--csv-float-precision", fmt.Sprintf("%d", f.floatPrecision),
+	}
+	if f.delimiter != "" {
+		args = append(args, "--csv-delimiter", f.delimiter)
+	}
+	if f.null != "" {
+		args = append(args, "--csv-null", f.null)
+	}
+	if f.timeLayout != "" {
+		args = append(args, "--csv-time-layout", f.timeLayout)
+	}
+	return args
+}
+
+// outputFlags are the gen flags compressing and splitting the output files.
+type outputFlags struct {
+	compress  string
+	splitRows int
+	splitSize string
+}
+
+func getOutputFlags(cmd *cobra.Command) (outputFlags, error) {
+	var f outputFlags
+	var err error
+	if f.compress, err = cmd.Flags().GetString("compress"); err != nil {
+		return outputFlags{}, fmt.Errorf("invalid value for --compress: %w", err)
+	}
+	if f.splitRows, err = cmd.Flags().GetInt("split-rows"); err != nil {
+		return outputFlags{}, fmt.Errorf("invalid value for --split-rows: %w", err)
+	}
+	if f.splitRows < 0 {
+		return outputFlags{}, fmt.Errorf("invalid value for --split-rows: must not be negative, got %d", f.splitRows)
+	}
+	if f.splitSize, err = cmd.Flags().GetString("split-size"); err != nil {
+		return outputFlags{}, fmt.Errorf("invalid value for --split-size: %w", err)
+	}
+	return f, nil
+}
+
+func (f outputFlags) args() []string {
+	var args []string
+	if f.compress != "" {
+		args = append(args, "--compress", f.compress)
+	}
+	if f.splitRows > 0 {
+		args = append(args, "--split-rows", fmt.Sprintf("%d", f.splitRows))
+	}
+	if f.splitSize != "" {
+		args = append(args, "--split-size", f.splitSize)
+	}
+	return args
+}
+
+// targetDir returns the directory the models are transpiled into: target
+// under the --output directory, or under the working directory when --output
+// is a URI other than file://, such as s3://bucket/prefix.
+func targetDir(output string) string {
+	scheme, rest, ok := strings.Cut(output, "://")
+	if !ok {
+		return filepath.Join(output, "target")
+	}
+	if strings.EqualFold(scheme, "file") {
+		return filepath.Join(rest, "target")
+	}
+	return filepath.Join(".", "target")
+}
+
+func findAndTranspileDatagenModels(outDir, inputPath string) error {
+	slog.Debug(fmt.Sprintf("finding and transpiling datagen models from %s into %s", inputPath, outDir))
+
+	dgDirData, parsedAll, err := findAndParseDatagenModels(outDir, inputPath)
 	if err != nil {
-		return fmt.Errorf("failed to process directory data\n  input_path: %s\n  cause: %w", inputPath, err)
+		return err
 	}
 
 	genDir := filepath.Clean(filepath.Join(outDir, utils.DatagenDirName))
@@ -175,11 +440,37 @@ func findAndTranspileDatagenModels(outDir, inputPath string) error {
 	if err := codegen.Codegen(parsedAll, genDir, dgDirData); err != nil {
 		return fmt.Errorf("code generation failed\n  output_dir: %s\n  cause: %w", outDir, err)
 	}
+	if err := validators.TypeCheck(parsedAll, genDir); err != nil {
+		return fmt.Errorf("type-checking generated code failed\n  output_dir: %s\n  cause: %w", outDir, err)
+	}
 
 	slog.Info(fmt.Sprintf("successfully transpiled %d datagen models into %s", len(parsedAll), outDir))
 	return nil
 }
 
+// findAndParseDatagenModels parses and validates every model found in the
+// input path.
+func findAndParseDatagenModels(outDir, inputPath string) (*utils.DgDir, []*codegen.DatagenParsed, error) {
+	dgDirData, err := GetDgDirStructure(inputPath, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read input\n  input_path: %s\n  cause: %w", inputPath, err)
+	}
+
+	dgModelsCount := dgDirData.ModelCount()
+	slog.Debug(fmt.Sprintf("found %d datagen models in %s", dgModelsCount, inputPath))
+
+	if dgModelsCount == 0 {
+		slog.Warn(fmt.Sprintf("no .dg files found in %s", inputPath))
+		return nil, nil, fmt.Errorf("no .dg files found in %s", inputPath)
+	}
+
+	parsedAll, err := processDgDirData(dgDirData, outDir, []*codegen.DatagenParsed{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to process directory data\n  input_path: %s\n  cause: %w", inputPath, err)
+	}
+	return dgDirData, parsedAll, nil
+}
+
 func processDgDirData(d *utils.DgDir, outDir string, accumulatedParsed []*codegen.DatagenParsed) ([]*codegen.DatagenParsed, error) {
 	if d == nil {
 		return accumulatedParsed, nil
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/elliotchance/orderedmap/v3"
 	"github.com/spf13/cobra"
@@ -27,7 +28,7 @@ func TestProcessDgDirData(t *testing.T) {
 			name:          "valid models directory",
 			inputPath:     filepath.Join("testdata", "valid"),
 			expectedError: false,
-			expectedCount: 10,
+			expectedCount: 11,
 			validateModels: func(t *testing.T, result []*codegen.DatagenParsed) {
 				modelNames := make(map[string]bool)
 				for _, parsed := range result {
@@ -37,7 +38,7 @@ func TestProcessDgDirData(t *testing.T) {
 				expectedModels := []string{
 					"simple", "minimal", "multiple_types", "with_metadata",
 					"with_misc", "with_builtin_functions", "nested", "with_conditionals",
-					"with_slices", "with_maps",
+					"with_slices", "with_maps", "with_columns",
 				}
 				for _, expected := range expectedModels {
 					assert.True(t, modelNames[expected], "expected model %s to be parsed", expected)
@@ -243,7 +244,26 @@ func TestBuildAndRunGenFlagParsing(t *testing.T) {
 				cmd.Flags().String("output", tmpDir, "")
 				cmd.Flags().String("format", "json", "")
 				cmd.Flags().Int64("seed", 0, "")
+				cmd.Flags().Int("row-group-size", 100000, "")
+				cmd.Flags().String("dialect", "mysql", "")
+				cmd.Flags().Int("batch-size", 1000, "")
+				cmd.Flags().Bool("create-table", false, "")
+				cmd.Flags().Bool("truncate", false, "")
+				cmd.Flags().String("json-mode", "jsonl", "")
+				cmd.Flags().String("xml-root", "records", "")
+				cmd.Flags().String("xml-namespace", "", "")
+				cmd.Flags().String("csv-delimiter", ",", "")
+				cmd.Flags().Bool("csv-header", true, "")
+				cmd.Flags().String("csv-null", "", "")
+				cmd.Flags().String("csv-time-layout", time.RFC3339Nano, "")
+				cmd.Flags().Int("csv-float-precision", -1, "")
+				cmd.Flags().String("compress", "none", "")
+				cmd.Flags().Int("split-rows", 0, "")
+				cmd.Flags().String("split-size", "", "")
 				cmd.Flags().Bool("noexec", true, "")
+				cmd.Flags().Int("chunk-size", 10000, "")
+				cmd.Flags().Int("memo-window", 0, "")
+				cmd.Flags().Int("parallelism", 1, "")
 				cmd.Flags().Bool("verbose", false, "")
 
 				return cmd, []string{file}
@@ -290,6 +310,9 @@ func TestBuildAndRunExecuteFlagParsing(t *testing.T) {
 				cmd.Flags().String("config", configFile, "")
 				cmd.Flags().String("output", tmpDir, "")
 				cmd.Flags().Bool("noexec", true, "")
+				cmd.Flags().Int("chunk-size", 10000, "")
+				cmd.Flags().Int("memo-window", 0, "")
+				cmd.Flags().Int("parallelism", 1, "")
 				cmd.Flags().Bool("verbose", false, "")
 
 				return cmd, []string{file}
@@ -380,13 +403,122 @@ func TestBuildAndRunGenErrorCases(t *testing.T) {
 				cmd.Flags().String("output", tmpDir, "")
 				cmd.Flags().String("format", "json", "")
 				cmd.Flags().Int64("seed", 0, "")
+				cmd.Flags().Int("row-group-size", 100000, "")
+				cmd.Flags().String("dialect", "mysql", "")
+				cmd.Flags().Int("batch-size", 1000, "")
+				cmd.Flags().Bool("create-table", false, "")
+				cmd.Flags().Bool("truncate", false, "")
+				cmd.Flags().String("json-mode", "jsonl", "")
+				cmd.Flags().String("xml-root", "records", "")
+				cmd.Flags().String("xml-namespace", "", "")
+				cmd.Flags().String("csv-delimiter", ",", "")
+				cmd.Flags().Bool("csv-header", true, "")
+				cmd.Flags().String("csv-null", "", "")
+				cmd.Flags().String("csv-time-layout", time.RFC3339Nano, "")
+				cmd.Flags().Int("csv-float-precision", -1, "")
+				cmd.Flags().String("compress", "none", "")
+				cmd.Flags().Int("split-rows", 0, "")
+				cmd.Flags().String("split-size", "", "")
 				cmd.Flags().Bool("noexec", true, "")
+				cmd.Flags().Int("chunk-size", 10000, "")
+				cmd.Flags().Int("memo-window", 0, "")
+				cmd.Flags().Int("parallelism", 1, "")
 				cmd.Flags().Bool("verbose", false, "")
 
 				return cmd, []string{file}
 			},
 			expectedError: true,
 		},
+		{
+			name: "negative row group size",
+			setupFunc: func(t *testing.T) (*cobra.Command, []string) {
+				tmpDir := t.TempDir()
+				file := filepath.Join("testdata", "valid", "simple.dg")
+
+				cmd := &cobra.Command{}
+				cmd.Flags().Int("count", 10, "")
+				cmd.Flags().String("tags", "", "")
+				cmd.Flags().String("output", tmpDir, "")
+				cmd.Flags().String("format", "parquet", "")
+				cmd.Flags().Int64("seed", 0, "")
+				cmd.Flags().Int("row-group-size", -1, "")
+				cmd.Flags().Bool("noexec", true, "")
+
+				return cmd, []string{file}
+			},
+			expectedError: true,
+			errorContains: "--row-group-size",
+		},
+		{
+			name: "unsupported dialect",
+			setupFunc: func(t *testing.T) (*cobra.Command, []string) {
+				tmpDir := t.TempDir()
+				file := filepath.Join("testdata", "valid", "simple.dg")
+
+				cmd := &cobra.Command{}
+				cmd.Flags().Int("count", 10, "")
+				cmd.Flags().String("tags", "", "")
+				cmd.Flags().String("output", tmpDir, "")
+				cmd.Flags().String("format", "sql", "")
+				cmd.Flags().Int64("seed", 0, "")
+				cmd.Flags().Int("row-group-size", 100000, "")
+				cmd.Flags().String("dialect", "oracle", "")
+				cmd.Flags().Int("batch-size", 1000, "")
+				cmd.Flags().Bool("create-table", false, "")
+				cmd.Flags().Bool("truncate", false, "")
+				cmd.Flags().String("json-mode", "jsonl", "")
+				cmd.Flags().String("xml-root", "records", "")
+				cmd.Flags().String("xml-namespace", "", "")
+				cmd.Flags().String("csv-delimiter", ",", "")
+				cmd.Flags().Bool("csv-header", true, "")
+				cmd.Flags().String("csv-null", "", "")
+				cmd.Flags().String("csv-time-layout", time.RFC3339Nano, "")
+				cmd.Flags().Int("csv-float-precision", -1, "")
+				cmd.Flags().String("compress", "none", "")
+				cmd.Flags().Int("split-rows", 0, "")
+				cmd.Flags().String("split-size", "", "")
+				cmd.Flags().Bool("noexec", true, "")
+
+				return cmd, []string{file}
+			},
+			expectedError: true,
+			errorContains: "--dialect",
+		},
+		{
+			name: "zero batch size",
+			setupFunc: func(t *testing.T) (*cobra.Command, []string) {
+				tmpDir := t.TempDir()
+				file := filepath.Join("testdata", "valid", "simple.dg")
+
+				cmd := &cobra.Command{}
+				cmd.Flags().Int("count", 10, "")
+				cmd.Flags().String("tags", "", "")
+				cmd.Flags().String("output", tmpDir, "")
+				cmd.Flags().String("format", "sql", "")
+				cmd.Flags().Int64("seed", 0, "")
+				cmd.Flags().Int("row-group-size", 100000, "")
+				cmd.Flags().String("dialect", "mysql", "")
+				cmd.Flags().Int("batch-size", 0, "")
+				cmd.Flags().Bool("create-table", false, "")
+				cmd.Flags().Bool("truncate", false, "")
+				cmd.Flags().String("json-mode", "jsonl", "")
+				cmd.Flags().String("xml-root", "records", "")
+				cmd.Flags().String("xml-namespace", "", "")
+				cmd.Flags().String("csv-delimiter", ",", "")
+				cmd.Flags().Bool("csv-header", true, "")
+				cmd.Flags().String("csv-null", "", "")
+				cmd.Flags().String("csv-time-layout", time.RFC3339Nano, "")
+				cmd.Flags().Int("csv-float-precision", -1, "")
+				cmd.Flags().String("compress", "none", "")
+				cmd.Flags().Int("split-rows", 0, "")
+				cmd.Flags().String("split-size", "", "")
+				cmd.Flags().Bool("noexec", true, "")
+
+				return cmd, []string{file}
+			},
+			expectedError: true,
+			errorContains: "--batch-size",
+		},
 	}
 
 	for _, tt := range tests {
@@ -442,6 +574,9 @@ func TestBuildAndRunExecuteErrorCases(t *testing.T) {
 				cmd.Flags().String("config", configFile, "")
 				cmd.Flags().String("output", tmpDir, "")
 				cmd.Flags().Bool("noexec", true, "")
+				cmd.Flags().Int("chunk-size", 10000, "")
+				cmd.Flags().Int("memo-window", 0, "")
+				cmd.Flags().Int("parallelism", 1, "")
 				cmd.Flags().Bool("verbose", false, "")
 
 				return cmd, []string{file}
@@ -506,49 +641,47 @@ func TestFindAndTranspileDatagenModelsEdgeCases(t *testing.T) {
 
 func TestInvokeGenArguments(t *testing.T) {
 	t.Run("with all optional flags", func(t *testing.T) {
-		outDir := t.TempDir()
-		inputPath := "/test/input.dg"
-
-		count := 100
-		tags := "prod,test"
-		output := "/output"
-		format := "xml"
-		seed := int64(999)
-		verbose := true
-
-		args := []string{"gen", inputPath}
-		args = append(args, "-n", "100")
-		if tags != "" {
-			args = append(args, "-t", tags)
-		}
-		if output != "" {
-			args = append(args, "-o", output)
-		}
-		if format != "" {
-			args = append(args, "-f", format)
-		}
-		if seed != 0 {
-			args = append(args, "--seed", "999")
+		flags := genFlags{
+			count:        100,
+			tags:         "prod,test",
+			output:       "/output",
+			format:       "xml",
+			seed:         999,
+			rowGroupSize: 5000,
+			jsonMode:     "array",
+			verbose:      true,
+			sql:          sqlFlags{dialect: codegen.DialectPostgres, batchSize: 500, truncate: true},
+			xml:          xmlFlags{root: "rows"},
+			csv:          csvFlags{header: true, floatPrecision: -1},
+			out:          outputFlags{compress: "gzip"},
+			run:          runFlags{chunkSize: 10, memoWindow: 20, parallelism: 2},
 		}
-		if verbose {
-			args = append(args, "-v")
-		}
-
-		expectedArgs := []string{"gen", inputPath, "-n", "100", "-t", "prod,test", "-o", "/output", "-f", "xml", "--seed", "999", "-v"}
-		assert.Equal(t, expectedArgs, args)
 
-		_ = outDir
-		_ = count
+		expectedArgs := []string{
+			"gen", "/test/input.dg", "-n", "100", "-t", "prod,test", "-o", "/output", "-f", "xml", "--seed", "999",
+			"--row-group-size", "5000",
+			"--dialect", "postgres", "--batch-size", "500", "--truncate",
+			"--json-mode", "array",
+			"--xml-root", "rows",
+			"--csv-header=true", "--csv-float-precision", "-1",
+			"--compress", "gzip",
+			"--chunk-size", "10", "--memo-window", "20", "--parallelism", "2",
+			"-v",
+		}
+		assert.Equal(t, expectedArgs, flags.args("/test/input.dg"))
 	})
 
 	t.Run("without optional flags", func(t *testing.T) {
-		inputPath := "/test/input.dg"
-
-		args := []string{"gen", inputPath}
-		args = append(args, "-n", "1")
-
-		expectedArgs := []string{"gen", inputPath, "-n", "1"}
-		assert.Equal(t, expectedArgs, args)
+		flags := genFlags{count: 1, sql: sqlFlags{dialect: codegen.DialectMySQL, batchSize: 1000}}
+
+		expectedArgs := []string{
+			"gen", "/test/input.dg", "-n", "1",
+			"--row-group-size", "0",
+			"--dialect", "mysql", "--batch-size", "1000",
+			"--csv-header=false", "--csv-float-precision", "0",
+			"--chunk-size", "0", "--memo-window", "0", "--parallelism", "0",
+		}
+		assert.Equal(t, expectedArgs, flags.args("/test/input.dg"))
 	})
 }
 
@@ -583,3 +716,129 @@ func TestInvokeExecuteArguments(t *testing.T) {
 		assert.Equal(t, expectedArgs, args)
 	})
 }
+
+func TestGetRunFlags(t *testing.T) {
+	newCmd := func(chunkSize, memoWindow, parallelism int) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("chunk-size", chunkSize, "")
+		cmd.Flags().Int("memo-window", memoWindow, "")
+		cmd.Flags().Int("parallelism", parallelism, "")
+		return cmd
+	}
+
+	t.Run("forwards values to the generated binary", func(t *testing.T) {
+		run, err := getRunFlags(newCmd(500, 2000, 4))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--chunk-size", "500", "--memo-window", "2000", "--parallelism", "4"}, run.args())
+	})
+
+	t.Run("zero values are forwarded", func(t *testing.T) {
+		run, err := getRunFlags(newCmd(0, 0, 0))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--chunk-size", "0", "--memo-window", "0", "--parallelism", "0"}, run.args())
+	})
+
+	t.Run("negative chunk size", func(t *testing.T) {
+		_, err := getRunFlags(newCmd(-1, 0, 1))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--chunk-size")
+	})
+
+	t.Run("negative memo window", func(t *testing.T) {
+		_, err := getRunFlags(newCmd(10, -5, 1))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--memo-window")
+	})
+
+	t.Run("negative parallelism", func(t *testing.T) {
+		_, err := getRunFlags(newCmd(10, 0, -2))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--parallelism")
+	})
+
+	t.Run("missing flags", func(t *testing.T) {
+		_, err := getRunFlags(&cobra.Command{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "chunk-size")
+	})
+}
+
+func TestGetCSVFlags(t *testing.T) {
+	newCmd := func(header bool, null string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("csv-delimiter", "|", "")
+		cmd.Flags().Bool("csv-header", header, "")
+		cmd.Flags().String("csv-null", null, "")
+		cmd.Flags().String("csv-time-layout", time.DateTime, "")
+		cmd.Flags().Int("csv-float-precision", 2, "")
+		return cmd
+	}
+
+	t.Run("forwards values to the generated binary", func(t *testing.T) {
+		csv, err := getCSVFlags(newCmd(false, `\N`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"--csv-header=false", "--csv-float-precision", "2",
+			"--csv-delimiter", "|", "--csv-null", `\N`, "--csv-time-layout", time.DateTime,
+		}, csv.args())
+	})
+
+	t.Run("empty null is not forwarded", func(t *testing.T) {
+		csv, err := getCSVFlags(newCmd(true, ""))
+		require.NoError(t, err)
+		assert.NotContains(t, csv.args(), "--csv-null")
+		assert.Contains(t, csv.args(), "--csv-header=true")
+	})
+
+	t.Run("missing flags", func(t *testing.T) {
+		_, err := getCSVFlags(&cobra.Command{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "csv-delimiter")
+	})
+}
+
+func TestGetOutputFlags(t *testing.T) {
+	newCmd := func(compress string, splitRows int, splitSize string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("compress", compress, "")
+		cmd.Flags().Int("split-rows", splitRows, "")
+		cmd.Flags().String("split-size", splitSize, "")
+		return cmd
+	}
+
+	t.Run("forwards values to the generated binary", func(t *testing.T) {
+		out, err := getOutputFlags(newCmd("gzip", 1000, "512MB"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--compress", "gzip", "--split-rows", "1000", "--split-size", "512MB"}, out.args())
+	})
+
+	t.Run("no split is not forwarded", func(t *testing.T) {
+		out, err := getOutputFlags(newCmd("none", 0, ""))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--compress", "none"}, out.args())
+	})
+
+	t.Run("negative split rows", func(t *testing.T) {
+		_, err := getOutputFlags(newCmd("none", -1, ""))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--split-rows")
+	})
+}
+
+func TestTargetDir(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{output: ".", expected: "target"},
+		{output: "out/data", expected: filepath.Join("out", "data", "target")},
+		{output: "file:///tmp/out", expected: filepath.Join("/tmp", "out", "target")},
+		{output: "s3://bucket/prefix", expected: "target"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			assert.Equal(t, tt.expected, targetDir(tt.output))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/importer"
+)
+
+// ImportModels scaffolds a .dg model for every table of the database --dsn
+// connects to, or of the statements in --from-ddl, in the --output
+// directory. Existing models are only overwritten with --force.
+func ImportModels(cmd *cobra.Command, _ []string) error {
+	dialectName, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return fmt.Errorf("invalid value for --from: %w", err)
+	}
+	dialect, err := codegen.ParseDialect(dialectName)
+	if err != nil {
+		return fmt.Errorf("invalid value for --from: %w", err)
+	}
+	if !slices.Contains(importer.Dialects, dialect) {
+		return fmt.Errorf("invalid value for --from: importing from %s is not supported", dialect)
+	}
+	dsn, err := cmd.Flags().GetString("dsn")
+	if err != nil {
+		return fmt.Errorf("invalid value for --dsn: %w", err)
+	}
+	ddlPath, err := cmd.Flags().GetString("from-ddl")
+	if err != nil {
+		return fmt.Errorf("invalid value for --from-ddl: %w", err)
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("invalid value for --output: %w", err)
+	}
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return fmt.Errorf("invalid value for --force: %w", err)
+	}
+
+	if (dsn == "") == (ddlPath == "") {
+		return errors.New("exactly one of --dsn or --from-ddl must be provided")
+	}
+
+	var tables []*importer.Table
+	source := ddlPath
+	if ddlPath != "" {
+		src, err := os.ReadFile(ddlPath) // #nosec G304 -- User-provided DDL file path is expected
+		if err != nil {
+			return fmt.Errorf("failed to read DDL\n  path: %s\n  cause: %w", ddlPath, err)
+		}
+		tables, err = importer.ParseDDL(string(src), dialect)
+		if err != nil {
+			return fmt.Errorf("failed to parse DDL\n  path: %s\n  cause: %w", ddlPath, err)
+		}
+	} else {
+		source = string(dialect)
+		tables, err = importer.Introspect(cmd.Context(), dialect, dsn)
+		if err != nil {
+			return err
+		}
+	}
+	if len(tables) == 0 {
+		return fmt.Errorf("no tables found\n  source: %s", source)
+	}
+
+	files, err := importer.Scaffold(tables)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(output) == "" {
+		output = "."
+	}
+	if !force {
+		for _, f := range files {
+			path := filepath.Join(output, f.Path)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("model already exists, use --force to overwrite it\n  path: %s", path)
+			}
+		}
+	}
+	for _, f := range files {
+		path := filepath.Join(output, f.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return fmt.Errorf("failed to create directory\n  path: %s\n  cause: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, f.Content, 0o600); err != nil {
+			return fmt.Errorf("failed to write model\n  path: %s\n  cause: %w", path, err)
+		}
+		slog.Debug(fmt.Sprintf("wrote model %s", path))
+	}
+	slog.Info(fmt.Sprintf("imported %d tables from %s into %s", len(files), source, output))
+	return nil
+}
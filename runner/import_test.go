@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newImportCmd(from, dsn, ddl, output string, force bool) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("from", from, "")
+	cmd.Flags().String("dsn", dsn, "")
+	cmd.Flags().String("from-ddl", ddl, "")
+	cmd.Flags().String("output", output, "")
+	cmd.Flags().Bool("force", force, "")
+	return cmd
+}
+
+func TestImportModels(t *testing.T) {
+	ddl := filepath.Join("testdata", "import", "postgres.sql")
+
+	t.Run("from ddl", func(t *testing.T) {
+		output := t.TempDir()
+
+		require.NoError(t, ImportModels(newImportCmd("postgres", "", ddl, output, false), nil))
+		assert.FileExists(t, filepath.Join(output, "accounts.dg"))
+		assert.FileExists(t, filepath.Join(output, "billing", "invoices.dg"))
+
+		cmd, out := newSchemaCmd("postgres", "")
+		require.NoError(t, BuildSchema(cmd, []string{output}))
+		assert.Contains(t, out.String(), "CREATE TABLE IF NOT EXISTS \"billing\".\"invoices\" (")
+		assert.Contains(t, out.String(), "FOREIGN KEY (\"account_id\") REFERENCES \"accounts\" (\"id\")")
+	})
+
+	t.Run("existing models", func(t *testing.T) {
+		output := t.TempDir()
+		existing := filepath.Join(output, "accounts.dg")
+		require.NoError(t, os.WriteFile(existing, []byte("model accounts {}"), 0o600))
+
+		err := ImportModels(newImportCmd("postgres", "", ddl, output, false), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "use --force to overwrite it")
+
+		require.NoError(t, ImportModels(newImportCmd("postgres", "", ddl, output, true), nil))
+		content, err := os.ReadFile(existing) // #nosec G304 -- Test file path constructed from a temp directory
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "fields {")
+	})
+
+	t.Run("source", func(t *testing.T) {
+		for _, cmd := range []*cobra.Command{
+			newImportCmd("postgres", "", "", t.TempDir(), false),
+			newImportCmd("postgres", "postgres://localhost/db", ddl, t.TempDir(), false),
+		} {
+			err := ImportModels(cmd, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "exactly one of --dsn or --from-ddl")
+		}
+	})
+
+	t.Run("unknown dialect", func(t *testing.T) {
+		err := ImportModels(newImportCmd("oracle", "", ddl, t.TempDir(), false), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid value for --from")
+
+		err = ImportModels(newImportCmd("sqlite", "", ddl, t.TempDir(), false), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "importing from sqlite is not supported")
+	})
+
+	t.Run("no tables", func(t *testing.T) {
+		empty := filepath.Join(t.TempDir(), "empty.sql")
+		require.NoError(t, os.WriteFile(empty, []byte("-- nothing here\n"), 0o600))
+
+		err := ImportModels(newImportCmd("mysql", "", empty, t.TempDir(), false), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no tables found")
+	})
+}
@@ -1,14 +1,33 @@
 package runner
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
 
 	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/utils"
 )
 
 func TestIntegrationTranspileValidModels(t *testing.T) {
@@ -157,6 +176,11 @@ func TestIntegrationInvalidModels(t *testing.T) {
 			modelFile:     "empty_model.dg",
 			expectedError: "model has no fields section",
 		},
+		{
+			name:          "type error in gen body",
+			modelFile:     "type_error.dg",
+			expectedError: `type_error.dg:11:14: gens.total_amount: cannot use "12.5" (untyped string constant) as float64 value`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -174,6 +198,499 @@ func TestIntegrationInvalidModels(t *testing.T) {
 	}
 }
 
+func TestIntegrationSQLiteSink(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	probe, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	if err := probe.Ping(); err != nil {
+		t.Skipf("sqlite driver unavailable: %v", err)
+	}
+	require.NoError(t, probe.Close())
+
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "db", "datagen.db")
+	configFile := filepath.Join(tmpDir, "config.json")
+	config := fmt.Sprintf(`{
+  "create_tables": true,
+  "clear_data": true,
+  "models": [
+    {"model_name": "users", "target_sinks": ["local"], "count": 10},
+    {"model_name": "orders", "target_sinks": ["local"], "count": 25}
+  ],
+  "sinks": [
+    {"sink_name": "local", "sink_type": "sqlite", "config": {"path": %q, "batch_size": 7}}
+  ]
+}`, dbPath)
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", configFile, "")
+	cmd.Flags().String("output", tmpDir, "")
+	cmd.Flags().Bool("noexec", false, "")
+	cmd.Flags().Int("chunk-size", 10000, "")
+	cmd.Flags().Int("memo-window", 0, "")
+	cmd.Flags().Int("parallelism", 1, "")
+	cmd.Flags().Bool("verbose", false, "")
+
+	// the second run clears the rows of the first one before loading again
+	for range 2 {
+		require.NoError(t, BuildAndRunExecute(cmd, []string{filepath.Join("testdata", "sqlite")}))
+
+		db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on")
+		require.NoError(t, err)
+
+		var users, orders, orphans int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM "users"`).Scan(&users))
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM "orders"`).Scan(&orders))
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM "orders" o LEFT JOIN "users" u ON o."user_id" = u."id" WHERE u."id" IS NULL`).Scan(&orphans))
+		assert.Equal(t, 10, users)
+		assert.Equal(t, 25, orders)
+		assert.Zero(t, orphans)
+
+		var tags string
+		require.NoError(t, db.QueryRow(`SELECT "tags" FROM "users" WHERE "id" = 3`).Scan(&tags))
+		assert.JSONEq(t, `["new","active"]`, tags)
+		require.NoError(t, db.Close())
+	}
+}
+
+// esStandIn is an Elasticsearch stand-in serving the requests of the
+// elasticsearch sink: the ping, index creation and deletion, and _bulk.
+type esStandIn struct {
+	mu       sync.Mutex
+	mappings map[string]json.RawMessage
+	docs     map[string]map[string]json.RawMessage
+	refresh  []string
+}
+
+func newESStandIn() *esStandIn {
+	return &esStandIn{mappings: map[string]json.RawMessage{}, docs: map[string]map[string]json.RawMessage{}}
+}
+
+func (s *esStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	index := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/":
+		fmt.Fprint(w, `{"version":{"number":"8.15.0"}}`)
+	case r.Method == http.MethodPost && r.URL.Path == "/_bulk":
+		s.refresh = append(s.refresh, r.URL.Query().Get("refresh"))
+		s.bulk(w, body)
+	case r.Method == http.MethodPut:
+		if _, ok := s.mappings[index]; ok {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"type":"resource_already_exists_exception","reason":"index already exists"},"status":400}`)
+			return
+		}
+		s.mappings[index] = body
+		s.docs[index] = map[string]json.RawMessage{}
+		fmt.Fprint(w, `{"acknowledged":true}`)
+	case r.Method == http.MethodDelete:
+		if _, ok := s.mappings[index]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`)
+			return
+		}
+		delete(s.mappings, index)
+		delete(s.docs, index)
+		fmt.Fprint(w, `{"acknowledged":true}`)
+	case r.Method == http.MethodPost && strings.HasSuffix(index, "/_delete_by_query"):
+		index = strings.TrimSuffix(index, "/_delete_by_query")
+		fmt.Fprintf(w, `{"deleted":%d}`, len(s.docs[index]))
+		s.docs[index] = map[string]json.RawMessage{}
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusBadRequest)
+	}
+}
+
+// bulk indexes the documents of an NDJSON body of index actions.
+func (s *esStandIn) bulk(w http.ResponseWriter, body []byte) {
+	type action struct {
+		Index struct {
+			Index string `json:"_index"`
+			ID    string `json:"_id"`
+		} `json:"index"`
+	}
+	var items []string
+	lines := bufio.NewScanner(bytes.NewReader(body))
+	lines.Buffer(nil, len(body)+1)
+	for lines.Scan() {
+		var a action
+		if err := json.Unmarshal(lines.Bytes(), &a); err != nil || !lines.Scan() {
+			http.Error(w, "malformed bulk body", http.StatusBadRequest)
+			return
+		}
+		if s.docs[a.Index.Index] == nil {
+			s.docs[a.Index.Index] = map[string]json.RawMessage{}
+		}
+		s.docs[a.Index.Index][a.Index.ID] = append(json.RawMessage(nil), lines.Bytes()...)
+		items = append(items, `{"index":{"status":201}}`)
+	}
+	fmt.Fprintf(w, `{"errors":false,"items":[%s]}`, strings.Join(items, ","))
+}
+
+func TestIntegrationElasticsearchSink(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	es := newESStandIn()
+	server := httptest.NewServer(es)
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.json")
+	config := fmt.Sprintf(`{
+  "create_tables": true,
+  "clear_data": true,
+  "models": [
+    {"model_name": "articles", "target_sinks": ["search"], "count": 25}
+  ],
+  "sinks": [
+    {"sink_name": "search", "sink_type": "elasticsearch", "config": {"url": %q, "id_field": "id", "refresh": "true", "create_index": true, "batch_size": 10}}
+  ]
+}`, server.URL)
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", configFile, "")
+	cmd.Flags().String("output", tmpDir, "")
+	cmd.Flags().Bool("noexec", false, "")
+	cmd.Flags().Int("chunk-size", 10000, "")
+	cmd.Flags().Int("memo-window", 0, "")
+	cmd.Flags().Int("parallelism", 1, "")
+	cmd.Flags().Bool("verbose", false, "")
+
+	// the second run recreates the index before indexing again
+	for range 2 {
+		require.NoError(t, BuildAndRunExecute(cmd, []string{filepath.Join("testdata", "elasticsearch")}))
+
+		es.mu.Lock()
+		assert.JSONEq(t, `{"mappings":{"properties":{
+			"id": {"type": "long"},
+			"title": {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}},
+			"body": {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}},
+			"tags": {"type": "keyword"},
+			"views": {"type": "long"},
+			"rating": {"type": "double"},
+			"published_at": {"type": "date"}
+		}}}`, string(es.mappings["articles"]))
+		require.Len(t, es.docs["articles"], 25)
+
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(es.docs["articles"]["3"], &doc))
+		assert.Equal(t, "article 3", doc["title"])
+		assert.Equal(t, []any{"news", "tech"}, doc["tags"])
+		assert.EqualValues(t, 300, doc["views"])
+		es.mu.Unlock()
+	}
+	assert.Equal(t, []string{"true", "true", "true", "true", "true", "true"}, es.refresh)
+}
+
+func TestIntegrationRedisSink(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	redis := miniredis.RunT(t)
+	require.NoError(t, redis.Set("other:1", "kept"))
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.json")
+	config := fmt.Sprintf(`{
+  "clear_data": true,
+  "models": [
+    {"model_name": "players", "target_sinks": ["cache", "profiles", "leaderboard", "recent"], "count": 10}
+  ],
+  "sinks": [
+    {"sink_name": "cache", "sink_type": "redis", "config": {"addr": %[1]q, "key": "player:{id}", "ttl": "1h", "batch_size": 3}},
+    {"sink_name": "profiles", "sink_type": "redis", "config": {"addr": %[1]q, "key": "profile:{id}", "type": "hash"}},
+    {"sink_name": "leaderboard", "sink_type": "redis", "config": {"addr": %[1]q, "key": "leaderboard", "type": "zset", "score_field": "score", "member_field": "name"}},
+    {"sink_name": "recent", "sink_type": "redis", "config": {"addr": %[1]q, "key": "recent:{country}", "type": "list", "member_field": "id"}}
+  ]
+}`, redis.Addr())
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", configFile, "")
+	cmd.Flags().String("output", tmpDir, "")
+	cmd.Flags().Bool("noexec", false, "")
+	cmd.Flags().Int("chunk-size", 10000, "")
+	cmd.Flags().Int("memo-window", 0, "")
+	cmd.Flags().Int("parallelism", 1, "")
+	cmd.Flags().Bool("verbose", false, "")
+
+	// the second run deletes the keys of the first one before writing again,
+	// so lists are not appended to twice
+	for range 2 {
+		require.NoError(t, BuildAndRunExecute(cmd, []string{filepath.Join("testdata", "redis")}))
+
+		assert.Len(t, redis.Keys(), 10+10+1+2+1)
+		cached, err := redis.Get("player:3")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":3,"name":"player_3","country":"us","score":30,"nickname":null}`, cached)
+		assert.Equal(t, time.Hour, redis.TTL("player:3"))
+
+		assert.Equal(t, "player_4", redis.HGet("profile:4", "name"))
+		assert.Equal(t, "40", redis.HGet("profile:4", "score"))
+		fields, err := redis.HKeys("profile:4")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"id", "name", "country", "score"}, fields)
+
+		score, err := redis.ZScore("leaderboard", "player_7")
+		require.NoError(t, err)
+		assert.Equal(t, 70.0, score)
+
+		recent, err := redis.List("recent:in")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0", "2", "4", "6", "8"}, recent)
+
+		kept, err := redis.Get("other:1")
+		require.NoError(t, err)
+		assert.Equal(t, "kept", kept)
+	}
+}
+
+// clickHouseStandIn is a ClickHouse stand-in serving the queries of the
+// clickhouse sink over HTTP, keeping the JSONEachRow rows inserted.
+type clickHouseStandIn struct {
+	mu      sync.Mutex
+	queries []string
+	rows    []map[string]any
+}
+
+func (s *clickHouseStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query().Get("query")
+	s.queries = append(s.queries, query)
+	switch {
+	case query == "SELECT 1":
+		fmt.Fprintln(w, "1")
+	case strings.HasPrefix(query, "CREATE TABLE"):
+	case strings.HasPrefix(query, "TRUNCATE TABLE"):
+		s.rows = nil
+	case strings.HasPrefix(query, "INSERT INTO") && strings.HasSuffix(query, "FORMAT JSONEachRow"):
+		decoder := json.NewDecoder(r.Body)
+		for decoder.More() {
+			var row map[string]any
+			if err := decoder.Decode(&row); err != nil {
+				w.Header().Set("X-ClickHouse-Exception-Code", "27")
+				http.Error(w, "Cannot parse input: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			s.rows = append(s.rows, row)
+		}
+	default:
+		w.Header().Set("X-ClickHouse-Exception-Code", "62")
+		http.Error(w, "Syntax error: "+query, http.StatusBadRequest)
+	}
+}
+
+func TestIntegrationClickHouseSink(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	ch := &clickHouseStandIn{}
+	server := httptest.NewServer(ch)
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.json")
+	config := fmt.Sprintf(`{
+  "create_tables": true,
+  "clear_data": true,
+  "models": [
+    {"model_name": "events", "target_sinks": ["analytics"], "count": 25}
+  ],
+  "sinks": [
+    {"sink_name": "analytics", "sink_type": "clickhouse", "config": {"url": %q, "batch_size": 10}}
+  ]
+}`, server.URL)
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", configFile, "")
+	cmd.Flags().String("output", tmpDir, "")
+	cmd.Flags().Bool("noexec", false, "")
+	cmd.Flags().Int("chunk-size", 10000, "")
+	cmd.Flags().Int("memo-window", 0, "")
+	cmd.Flags().Int("parallelism", 1, "")
+	cmd.Flags().Bool("verbose", false, "")
+
+	// the second run truncates the rows of the first one before loading again
+	for range 2 {
+		require.NoError(t, BuildAndRunExecute(cmd, []string{filepath.Join("testdata", "clickhouse")}))
+
+		ch.mu.Lock()
+		assert.Contains(t, ch.queries, "CREATE TABLE IF NOT EXISTS `events` (\n"+
+			"  `id` Int64,\n"+
+			"  `event_type` LowCardinality(String),\n"+
+			"  `tags` Array(LowCardinality(String)),\n"+
+			"  `attributes` Map(LowCardinality(String), Int64),\n"+
+			"  `device` String,\n"+
+			"  `at` DateTime64(6),\n"+
+			"  `payload` String\n"+
+			") ENGINE = MergeTree\nORDER BY tuple()")
+		assert.Contains(t, ch.queries, "TRUNCATE TABLE `events`")
+		assert.Contains(t, ch.queries, "INSERT INTO `events` (`id`, `event_type`, `tags`, `attributes`, `device`, `at`, `payload`) FORMAT JSONEachRow")
+		require.Len(t, ch.rows, 25)
+		assert.Equal(t, map[string]any{
+			"id":         3.0,
+			"event_type": "view",
+			"tags":       []any{"web", "beta"},
+			"attributes": map[string]any{"retries": 3.0},
+			"device":     `{"os":"linux","version":3}`,
+			"at":         "2024-01-01T00:00:03Z",
+			"payload":    "payload\t3",
+		}, ch.rows[3])
+		ch.queries = nil
+		ch.mu.Unlock()
+	}
+}
+
+func TestIntegrationKafkaSink(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, "orders"))
+	require.NoError(t, err)
+	defer cluster.Close()
+	brokers, err := json.Marshal(cluster.ListenAddrs())
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.json")
+	config := fmt.Sprintf(`{
+  "models": [
+    {"model_name": "orders", "target_sinks": ["events"], "count": 25}
+  ],
+  "sinks": [
+    {"sink_name": "events", "sink_type": "kafka", "config": {"topic": "orders", "bootstrap_servers": %s, "key": "customer", "batch_size": 10, "throttle": "300ms"}}
+  ]
+}`, brokers)
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", configFile, "")
+	cmd.Flags().String("output", tmpDir, "")
+	cmd.Flags().Bool("noexec", false, "")
+	cmd.Flags().Int("chunk-size", 10000, "")
+	cmd.Flags().Int("memo-window", 0, "")
+	cmd.Flags().Int("parallelism", 1, "")
+	cmd.Flags().Bool("verbose", false, "")
+
+	start := time.Now()
+	require.NoError(t, BuildAndRunExecute(cmd, []string{filepath.Join("testdata", "kafka")}))
+
+	consumer, err := kgo.NewClient(kgo.SeedBrokers(cluster.ListenAddrs()...), kgo.ConsumeTopics("orders"), kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()))
+	require.NoError(t, err)
+	defer consumer.Close()
+	var records []*kgo.Record
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for len(records) < 25 && ctx.Err() == nil {
+		fetches := consumer.PollFetches(ctx)
+		require.NoError(t, ctx.Err(), "timed out with %d of 25 messages", len(records))
+		require.Empty(t, fetches.Errors())
+		records = append(records, fetches.Records()...)
+	}
+	require.Len(t, records, 25)
+
+	for i, record := range records {
+		assert.Equal(t, fmt.Sprintf("customer_%d", i%3), string(record.Key))
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"customer":"customer_%d","amount":%v,"note":null}`, i, i%3, float64(i)*1.5), string(record.Value))
+	}
+
+	// batches of 10 are produced 300ms apart, so the messages of a batch are
+	// close in time and consecutive batches are not
+	var batches []int
+	for i, record := range records {
+		if i == 0 || record.Timestamp.Sub(records[i-1].Timestamp) >= 200*time.Millisecond {
+			batches = append(batches, 0)
+		}
+		batches[len(batches)-1]++
+	}
+	assert.Equal(t, []int{10, 10, 5}, batches)
+	assert.GreaterOrEqual(t, time.Since(start), 600*time.Millisecond, "the sink throttles between batches")
+}
+
+func TestIntegrationKafkaSinkUnknownKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.json")
+	// no broker listens on the address: the key is checked before connecting
+	config := `{
+  "models": [
+    {"model_name": "orders", "target_sinks": ["events"], "count": 5}
+  ],
+  "sinks": [
+    {"sink_name": "events", "sink_type": "kafka", "config": {"topic": "orders", "bootstrap_servers": ["127.0.0.1:1"], "key": "customer_id"}}
+  ]
+}`
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", configFile, "")
+	cmd.Flags().String("output", tmpDir, "")
+	cmd.Flags().Bool("noexec", false, "")
+	cmd.Flags().Int("chunk-size", 10000, "")
+	cmd.Flags().Int("memo-window", 0, "")
+	cmd.Flags().Int("parallelism", 1, "")
+	cmd.Flags().Bool("verbose", false, "")
+
+	// the errors of the generated binary are only written to stderr
+	stderr, err := os.CreateTemp(tmpDir, "stderr")
+	require.NoError(t, err)
+	defer stderr.Close()
+	saved := os.Stderr
+	os.Stderr = stderr
+	err = BuildAndRunExecute(cmd, []string{filepath.Join("testdata", "kafka")})
+	os.Stderr = saved
+	require.Error(t, err)
+
+	output, err := os.ReadFile(stderr.Name())
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `key field \"customer_id\" does not exist in model orders`)
+}
+
+// TestIntegrationGeneratedRuntime runs the tests of testdata/runtime in the
+// package generated for its models, covering the runtime of the generated
+// binary that stand-ins cannot reach.
+func TestIntegrationGeneratedRuntime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	runtimeDir := filepath.Join("testdata", "runtime")
+	outDir := t.TempDir()
+	require.NoError(t, findAndTranspileDatagenModels(outDir, runtimeDir))
+
+	genDir := filepath.Join(outDir, utils.DatagenDirName)
+	tests, err := filepath.Glob(filepath.Join(runtimeDir, "*_test.go"))
+	require.NoError(t, err)
+	require.NotEmpty(t, tests, "no runtime tests found")
+	for _, test := range tests {
+		src, err := os.ReadFile(test) // #nosec G304 -- Test file path constructed from known test directory
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(genDir, filepath.Base(test)), src, 0o600))
+	}
+
+	goTest := exec.Command("go", "test", "-count=1", ".")
+	goTest.Dir = genDir
+	output, err := goTest.CombinedOutput()
+	require.NoError(t, err, "runtime tests failed:\n%s", output)
+}
+
 func TestIntegrationUpdateGoldenFiles(t *testing.T) {
 	updateGolden := false
 	for _, arg := range os.Args {
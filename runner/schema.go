@@ -0,0 +1,51 @@
+package runner
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+)
+
+// BuildSchema writes the CREATE TABLE statements of the models found in the
+// input path to --output, or to stdout when it is empty. Nothing is built.
+func BuildSchema(cmd *cobra.Command, args []string) error {
+	inputPath := args[0]
+
+	dialectName, err := cmd.Flags().GetString("dialect")
+	if err != nil {
+		return fmt.Errorf("invalid value for --dialect: %w", err)
+	}
+	dialect, err := codegen.ParseDialect(dialectName)
+	if err != nil {
+		return fmt.Errorf("invalid value for --dialect: %w", err)
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("invalid value for --output: %w", err)
+	}
+
+	_, parsed, err := findAndParseDatagenModels("", inputPath)
+	if err != nil {
+		return err
+	}
+
+	schema, err := codegen.Schema(parsed, dialect)
+	if err != nil {
+		return fmt.Errorf("schema generation failed\n  input_path: %s\n  cause: %w", inputPath, err)
+	}
+
+	if strings.TrimSpace(output) == "" {
+		_, err := fmt.Fprint(cmd.OutOrStdout(), schema)
+		return err
+	}
+	if err := os.WriteFile(output, []byte(schema), 0o600); err != nil {
+		return fmt.Errorf("failed to write schema\n  path: %s\n  cause: %w", output, err)
+	}
+	slog.Info(fmt.Sprintf("wrote %s schema of %d models to %s", dialect, len(parsed), output))
+	return nil
+}
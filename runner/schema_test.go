@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSchemaCmd(dialect, output string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dialect", dialect, "")
+	cmd.Flags().String("output", output, "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func TestBuildSchema(t *testing.T) {
+	file := filepath.Join("testdata", "valid", "simple.dg")
+
+	t.Run("stdout", func(t *testing.T) {
+		cmd, out := newSchemaCmd("postgres", "")
+
+		require.NoError(t, BuildSchema(cmd, []string{file}))
+		assert.Equal(t, "CREATE TABLE IF NOT EXISTS \"simple\" (\n  \"id\" BIGINT NOT NULL,\n  \"name\" TEXT NOT NULL\n);\n", out.String())
+	})
+
+	t.Run("output file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "schema.sql")
+		cmd, out := newSchemaCmd("mysql", output)
+
+		require.NoError(t, BuildSchema(cmd, []string{file}))
+		assert.Empty(t, out.String())
+
+		content, err := os.ReadFile(output) // #nosec G304 -- Test file path constructed from a temp directory
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "CREATE TABLE IF NOT EXISTS `simple` (")
+	})
+
+	t.Run("whole directory", func(t *testing.T) {
+		cmd, out := newSchemaCmd("mysql", "")
+
+		require.NoError(t, BuildSchema(cmd, []string{filepath.Join("testdata", "valid")}))
+		assert.Contains(t, out.String(), "CREATE TABLE IF NOT EXISTS `with_maps` (")
+	})
+
+	t.Run("unknown dialect", func(t *testing.T) {
+		cmd, _ := newSchemaCmd("oracle", "")
+
+		err := BuildSchema(cmd, []string{file})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid value for --dialect")
+	})
+}
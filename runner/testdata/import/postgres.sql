@@ -0,0 +1,17 @@
+CREATE SCHEMA billing;
+CREATE TABLE public.accounts (
+    id uuid NOT NULL,
+    "Display Name" character varying(100),
+    lat double precision,
+    tags text[],
+    CONSTRAINT accounts_pkey PRIMARY KEY (id)
+);
+CREATE TABLE billing.invoices (
+    id bigserial PRIMARY KEY,
+    account_id uuid REFERENCES accounts,
+    total numeric(12,2),
+    issued_on date,
+    due time without time zone,
+    ext_ref integer REFERENCES missing(id)
+);
+ALTER TABLE ONLY billing.invoices ADD CONSTRAINT u UNIQUE (total);
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConnector connects to a database whose statements affect a fixed
+// number of rows, keeping the statements executed and the values of the
+// prepared ones.
+type fakeConnector struct {
+	affected   int64
+	statements []string
+	values     [][]driver.Value
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{c}, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ connector *fakeConnector }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.connector.statements = append(c.connector.statements, query)
+	return &fakeStmt{c.connector}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *fakeConn) Commit() error             { return nil }
+func (c *fakeConn) Rollback() error           { return nil }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.connector.statements = append(c.connector.statements, query)
+	return driver.RowsAffected(c.connector.affected), nil
+}
+
+type fakeStmt struct{ connector *fakeConnector }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.connector.values = append(s.connector.values, args)
+	return driver.RowsAffected(s.connector.affected), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) { return nil, driver.ErrSkip }
+
+func fakeTx(t *testing.T, affected int64) (*fakeConnector, *sql.Tx) {
+	t.Helper()
+	connector := &fakeConnector{affected: affected}
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { db.Close() })
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+	return connector, tx
+}
+
+func TestMySQLLoadDataSkippedRows(t *testing.T) {
+	rows := func() [][]any { return [][]any{{1, "a"}, {2, "b"}, {3, "c"}} }
+	columns := []string{"`id`", "`name`"}
+
+	for _, mode := range []__dgi_WriteMode{"", __dgi_WriteModeInsert} {
+		_, tx := fakeTx(t, 3)
+		if err := __dgi_mysqlLoadData(context.Background(), tx, "`accounts`", columns, mode, rows()); err != nil {
+			t.Errorf("write_mode %q: %v", mode, err)
+		}
+
+		// LOCAL files skip duplicate keys rather than failing
+		_, tx = fakeTx(t, 1)
+		err := __dgi_mysqlLoadData(context.Background(), tx, "`accounts`", columns, mode, rows())
+		if err == nil || !strings.Contains(err.Error(), "2 of 3 rows have keys already in the table") {
+			t.Errorf("write_mode %q: expected the skipped rows to fail the load, got %v", mode, err)
+		}
+	}
+
+	connector, tx := fakeTx(t, 1)
+	if err := __dgi_mysqlLoadData(context.Background(), tx, "`accounts`", columns, __dgi_WriteModeInsertIgnore, rows()); err != nil {
+		t.Errorf("insert_ignore skips rows on purpose, got %v", err)
+	}
+	if len(connector.statements) != 1 || !strings.HasPrefix(connector.statements[0], "LOAD DATA LOCAL INFILE 'Reader::datagen_") ||
+		!strings.Contains(connector.statements[0], "' IGNORE INTO TABLE `accounts` CHARACTER SET binary") {
+		t.Errorf("unexpected statements %q", connector.statements)
+	}
+}
+
+func TestInsertBatchSize(t *testing.T) {
+	for _, tt := range []struct {
+		batchSize, columns, maxPlaceholders, expected int
+	}{
+		{batchSize: 100, columns: 10, maxPlaceholders: __dgi_maxPlaceholders, expected: 100},
+		{batchSize: 6553, columns: 10, maxPlaceholders: __dgi_maxPlaceholders, expected: 6553},
+		{batchSize: 6554, columns: 10, maxPlaceholders: __dgi_maxPlaceholders, expected: 6553},
+		{batchSize: 0, columns: 10, maxPlaceholders: __dgi_maxPlaceholders, expected: 6553},
+		{batchSize: -1, columns: 7, maxPlaceholders: __dgi_sqliteMaxVariables, expected: 4680},
+		{batchSize: 0, columns: 0, maxPlaceholders: __dgi_maxPlaceholders, expected: 65535},
+		{batchSize: 10, columns: 70000, maxPlaceholders: __dgi_maxPlaceholders, expected: 1},
+	} {
+		got := __dgi_insertBatchSize(tt.batchSize, tt.columns, tt.maxPlaceholders)
+		if got != tt.expected {
+			t.Errorf("__dgi_insertBatchSize(%d, %d, %d) = %d, expected %d", tt.batchSize, tt.columns, tt.maxPlaceholders, got, tt.expected)
+		}
+		if got*max(tt.columns, 1) > tt.maxPlaceholders && got > 1 {
+			t.Errorf("__dgi_insertBatchSize(%d, %d, %d) = %d exceeds the placeholder limit", tt.batchSize, tt.columns, tt.maxPlaceholders, got)
+		}
+	}
+}
+
+type bulkAddress struct {
+	City string `json:"city"`
+}
+
+func TestMySQLLoadDataRows(t *testing.T) {
+	text := "a\tb\nc\\d\r\x00e"
+	rows := [][]any{
+		{
+			nil, (*string)(nil), &text,
+			time.Date(2024, 1, 2, 3, 4, 5, 600000000, time.FixedZone("CET", 3600)),
+			[]byte("x\ty"), true, false, uint64(math.MaxUint64), int8(-3), float32(1.5),
+			bulkAddress{City: "Tab\tCity"}, []string{"a", "b"}, []string(nil),
+		},
+		{"", `\N`, 0.1},
+	}
+
+	var b bytes.Buffer
+	if err := __dgi_mysqlLoadDataRows(&b, rows); err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Join([]string{
+		`\N`, `\N`, `a\tb\nc\\d\r\0e`,
+		`2024-01-02 02:04:05.6`,
+		`x\ty`, `1`, `0`, `18446744073709551615`, `-3`, `1.5`,
+		`{"city":"Tab\\tCity"}`, `["a","b"]`, `\N`,
+	}, "\t") + "\n" + strings.Join([]string{``, `\\N`, `0.1`}, "\t") + "\n"
+	if b.String() != expected {
+		t.Errorf("LOAD DATA rows\n  got:      %q\n  expected: %q", b.String(), expected)
+	}
+
+	if err := __dgi_mysqlLoadDataRows(&b, [][]any{{1, make(chan int)}}); err == nil || !strings.Contains(err.Error(), "column 2") {
+		t.Errorf("expected the column of values that cannot be loaded, got %v", err)
+	}
+}
+
+func TestPostgresCopy(t *testing.T) {
+	name := "ada"
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := [][]any{
+		{1, &name, []string{"a", "b"}, bulkAddress{City: "Paris"}, []byte{0, 1}, at, uint8(7)},
+		{2, (*string)(nil), []string(nil), &bulkAddress{}, []byte(nil), at, uint8(0)},
+	}
+
+	connector, tx := fakeTx(t, 1)
+	if err := __dgi_postgresCopy(context.Background(), tx, `"accounts"`, []string{`"id"`, `"name"`, `"tags"`, `"address"`, `"avatar"`, `"at"`, `"level"`}, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{`COPY "accounts" ("id","name","tags","address","avatar","at","level") FROM STDIN`}; !reflect.DeepEqual(connector.statements, expected) {
+		t.Errorf("statements %q, expected %q", connector.statements, expected)
+	}
+	expected := [][]driver.Value{
+		{int64(1), "ada", `["a","b"]`, `{"city":"Paris"}`, []byte{0, 1}, at, int64(7)},
+		{int64(2), nil, nil, `{"city":""}`, nil, at, int64(0)},
+		// the rows are sent by the statement run without values
+		{},
+	}
+	if !reflect.DeepEqual(connector.values, expected) {
+		t.Errorf("COPY values\n  got:      %#v\n  expected: %#v", connector.values, expected)
+	}
+}
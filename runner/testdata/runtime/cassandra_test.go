@@ -0,0 +1,157 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+const cassandraProto = 4
+
+func cqlNative(typ gocql.Type) gocql.TypeInfo {
+	return gocql.NewNativeType(cassandraProto, typ, "")
+}
+
+func cqlList(elem gocql.TypeInfo) gocql.TypeInfo {
+	return gocql.CollectionType{NativeType: gocql.NewNativeType(cassandraProto, gocql.TypeList, ""), Elem: elem}
+}
+
+func cqlMap(key, elem gocql.TypeInfo) gocql.TypeInfo {
+	return gocql.CollectionType{NativeType: gocql.NewNativeType(cassandraProto, gocql.TypeMap, ""), Key: key, Elem: elem}
+}
+
+// cassandraRoundTrip converts v, writes it to a column of type info as
+// gocql does and reads it back into out.
+func cassandraRoundTrip(t *testing.T, info gocql.TypeInfo, v, out any) any {
+	t.Helper()
+	value, err := __dgi_cassandraValue(reflect.ValueOf(v))
+	if err != nil {
+		t.Fatalf("%T: %v", v, err)
+	}
+	data, err := gocql.Marshal(info, value)
+	if err != nil {
+		t.Fatalf("%T as %s: %v", v, info, err)
+	}
+	if err := gocql.Unmarshal(info, data, out); err != nil {
+		t.Fatalf("%T as %s: %v", v, info, err)
+	}
+	return reflect.ValueOf(out).Elem().Interface()
+}
+
+type cassandraAddress struct {
+	City string `json:"city"`
+}
+
+func TestCassandraValue(t *testing.T) {
+	// unsigned integers fit the columns of the next larger type, uint64 a varint
+	var varint big.Int
+	got := cassandraRoundTrip(t, cqlNative(gocql.TypeVarint), uint64(math.MaxUint64), &varint).(big.Int)
+	if got.String() != "18446744073709551615" {
+		t.Errorf("uint64 as varint: %s", got.String())
+	}
+	var bigint int64
+	if got := cassandraRoundTrip(t, cqlNative(gocql.TypeBigInt), uint32(math.MaxUint32), &bigint); got != int64(math.MaxUint32) {
+		t.Errorf("uint32 as bigint: %v", got)
+	}
+	var smallint int16
+	if got := cassandraRoundTrip(t, cqlNative(gocql.TypeSmallInt), uint8(200), &smallint); got != int16(200) {
+		t.Errorf("uint8 as smallint: %v", got)
+	}
+	var tinyint int8
+	if got := cassandraRoundTrip(t, cqlNative(gocql.TypeTinyInt), int8(-3), &tinyint); got != int8(-3) {
+		t.Errorf("int8 as tinyint: %v", got)
+	}
+
+	// structs are written as JSON text, pointers as their value or null
+	var text string
+	if got := cassandraRoundTrip(t, cqlNative(gocql.TypeText), &cassandraAddress{City: "Lisbon"}, &text); got != `{"city":"Lisbon"}` {
+		t.Errorf("struct as text: %v", got)
+	}
+	if value, err := __dgi_cassandraValue(reflect.ValueOf((*string)(nil))); err != nil || value != nil {
+		t.Errorf("nil pointer: %v, %v", value, err)
+	}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	var timestamp time.Time
+	if got := cassandraRoundTrip(t, cqlNative(gocql.TypeTimestamp), at, &timestamp).(time.Time); !got.Equal(at) {
+		t.Errorf("time as timestamp: %v", got)
+	}
+
+	// collections convert their elements, nested ones included
+	var matrix [][]int32
+	if got := cassandraRoundTrip(t, cqlList(cqlList(cqlNative(gocql.TypeInt))), [][]int32{{1, 2}, {3}}, &matrix); !reflect.DeepEqual(got, [][]int32{{1, 2}, {3}}) {
+		t.Errorf("[][]int32 as list<frozen<list<int>>>: %v", got)
+	}
+	var attributes map[string][]float32
+	expected := map[string][]float32{"a": {1.5}, "b": {}}
+	if got := cassandraRoundTrip(t, cqlMap(cqlNative(gocql.TypeText), cqlList(cqlNative(gocql.TypeFloat))), map[string][]float32{"a": {1.5}, "b": {}}, &attributes); !reflect.DeepEqual(got, expected) {
+		t.Errorf("map[string][]float32 as map<text, frozen<list<float>>>: %v", got)
+	}
+	var addresses map[int32][]string
+	if got := cassandraRoundTrip(t, cqlMap(cqlNative(gocql.TypeInt), cqlList(cqlNative(gocql.TypeText))), map[uint16][]cassandraAddress{7: {{City: "Porto"}}}, &addresses); !reflect.DeepEqual(got, map[int32][]string{7: {`{"city":"Porto"}`}}) {
+		t.Errorf("map[uint16][]struct as map<int, frozen<list<text>>>: %v", got)
+	}
+	var avatar []byte
+	if got := cassandraRoundTrip(t, cqlNative(gocql.TypeBlob), []byte{0, 1, 2}, &avatar); !reflect.DeepEqual(got, []byte{0, 1, 2}) {
+		t.Errorf("[]byte as blob: %v", got)
+	}
+
+	if _, err := __dgi_cassandraValue(reflect.ValueOf(make(chan int))); err == nil {
+		t.Error("expected channels to be rejected")
+	}
+}
+
+func TestCassandraPartitions(t *testing.T) {
+	rows := [][]any{
+		{int64(1), "a"},
+		{int64(2), "b"},
+		{int64(1), "c"},
+		{"1", "d"},
+		{nil, "e"},
+		{int64(2), "f"},
+		{nil, "g"},
+	}
+
+	got := __dgi_cassandraPartitions(rows, 0)
+	expected := [][][]any{
+		{{int64(1), "a"}, {int64(1), "c"}},
+		{{int64(2), "b"}, {int64(2), "f"}},
+		// values of other types are other partitions, even when they print alike
+		{{"1", "d"}},
+		{{nil, "e"}, {nil, "g"}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("partitions\n  got:      %v\n  expected: %v", got, expected)
+	}
+
+	tags := [][]any{{"x", []any{"a", "b"}}, {"y", []any{"a", "b"}}, {"z", []any{"b"}}}
+	if got := __dgi_cassandraPartitions(tags, 1); len(got) != 2 || len(got[0]) != 2 || len(got[1]) != 1 {
+		t.Errorf("collections are partitioned by value, got %v", got)
+	}
+}
+
+func TestCassandraBatches(t *testing.T) {
+	partitions := [][][]any{
+		{{1, "a"}, {1, "b"}, {1, "c"}, {1, "d"}, {1, "e"}},
+		{{2, "f"}},
+	}
+
+	got := __dgi_cassandraBatches(partitions, 2)
+	expected := [][][]any{
+		{{1, "a"}, {1, "b"}},
+		{{1, "c"}, {1, "d"}},
+		{{1, "e"}},
+		{{2, "f"}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("batches\n  got:      %v\n  expected: %v", got, expected)
+	}
+
+	var config __dgi_CassandraConfig
+	if got := config.batchSize(); got != __dgi_cassandraBatchSize {
+		t.Errorf("default batch size = %d, expected %d", got, __dgi_cassandraBatchSize)
+	}
+}
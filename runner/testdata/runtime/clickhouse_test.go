@@ -0,0 +1,23 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClickHouseValueBytes(t *testing.T) {
+	got, err := __dgi_clickhouseValue(reflect.ValueOf([]byte("payload\t3")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "payload\t3" {
+		t.Errorf("bytes = %#v, expected the string they hold", got)
+	}
+
+	if _, err := __dgi_clickhouseValue(reflect.ValueOf([]byte{0xff, 0xfe})); err == nil {
+		t.Error("expected bytes that are not valid UTF-8 to be rejected")
+	}
+	if _, err := __dgi_clickhouseValue(reflect.ValueOf(map[string][]byte{"raw": {0xc3}})); err == nil {
+		t.Error("expected bytes that are not valid UTF-8 to be rejected within maps")
+	}
+}
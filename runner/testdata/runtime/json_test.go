@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedRecordsLimit(t *testing.T) {
+	children := __dgi_embeddedRecords(map[string]int{"accounts": __dgi_maxEmbeddedRecords + 1}, nil, 0, __dgi_newLinks(), __dgi_RunOptions{})
+	if _, err := children("accounts"); err == nil || !strings.Contains(err.Error(), "lower the count of accounts") {
+		t.Errorf("expected embedding too many records to fail, got %v", err)
+	}
+
+	children = __dgi_embeddedRecords(map[string]int{"accounts": 3}, nil, 0, __dgi_newLinks(), __dgi_RunOptions{ChunkSize: 2})
+	records, err := children("accounts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Errorf("expected 3 records, got %d", len(records))
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// countingGen generates iter*10, keeping the iters it was called for.
+type countingGen struct {
+	iters []int
+}
+
+func (g *countingGen) gen(i int) int {
+	g.iters = append(g.iters, i)
+	return i * 10
+}
+
+func TestMemoGetPure(t *testing.T) {
+	var g countingGen
+	memo := __dgi_NewMemo[int]("users", "id", 0, true)
+
+	// the first iter of a shard is generated directly, not the ones before it
+	for i := 5000; i < 5003; i++ {
+		if got := memo.Get(i, g.gen); got != i*10 {
+			t.Errorf("Get(%d) = %d", i, got)
+		}
+	}
+	memo.Get(5001, g.gen)
+	if expected := []int{5000, 5001, 5002}; !reflect.DeepEqual(g.iters, expected) {
+		t.Errorf("generated iters %v, expected %v", g.iters, expected)
+	}
+
+	// values before the run are generated again, leaving the run as it is
+	g.iters = nil
+	if got := memo.Get(7, g.gen); got != 70 {
+		t.Errorf("Get(7) = %d", got)
+	}
+	memo.Get(5002, g.gen)
+	if expected := []int{7}; !reflect.DeepEqual(g.iters, expected) {
+		t.Errorf("generated iters %v, expected %v", g.iters, expected)
+	}
+
+	// a later shard starts a new run
+	g.iters = nil
+	memo.Get(9000, g.gen)
+	memo.Get(9001, g.gen)
+	memo.Get(9000, g.gen)
+	if expected := []int{9000, 9001}; !reflect.DeepEqual(g.iters, expected) {
+		t.Errorf("generated iters %v, expected %v", g.iters, expected)
+	}
+}
+
+func TestMemoGetInOrder(t *testing.T) {
+	var g countingGen
+	memo := __dgi_NewMemo[int]("users", "name", 0, false)
+
+	// values that are not pure are generated in iter order
+	if got := memo.Get(3, g.gen); got != 30 {
+		t.Errorf("Get(3) = %d", got)
+	}
+	memo.Get(1, g.gen)
+	memo.Get(5, g.gen)
+	if expected := []int{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(g.iters, expected) {
+		t.Errorf("generated iters %v, expected %v", g.iters, expected)
+	}
+}
+
+func TestMemoGetPreviousIters(t *testing.T) {
+	// running totals read the previous value of their own field, which a
+	// deep first read fills in a loop rather than through nested reads
+	const last = 1_000_000
+	calls := 0
+	memo := __dgi_NewMemo[int]("orders", "total", 0, false)
+	var total func(i int) int
+	total = func(i int) int {
+		calls++
+		if i == 0 {
+			return 1
+		}
+		return memo.Get(i-1, total) + 1
+	}
+
+	if got := memo.Get(last, total); got != last+1 {
+		t.Errorf("Get(%d) = %d", last, got)
+	}
+	if got := memo.Get(40, total); got != 41 {
+		t.Errorf("Get(40) = %d", got)
+	}
+	if calls != last+1 {
+		t.Errorf("expected every value to be generated once, got %d calls", calls)
+	}
+}
+
+func TestMemoGetWindow(t *testing.T) {
+	var g countingGen
+	pure := __dgi_NewMemo[int]("users", "id", 10, true)
+	for i := range 10 + __dgi_memoSlack {
+		pure.Get(i, g.gen)
+	}
+
+	// pure values that left the window are generated again
+	g.iters = nil
+	if got := pure.Get(3, g.gen); got != 30 {
+		t.Errorf("Get(3) = %d", got)
+	}
+	if expected := []int{3}; !reflect.DeepEqual(g.iters, expected) {
+		t.Errorf("generated iters %v, expected %v", g.iters, expected)
+	}
+
+	// other values cannot be read anymore
+	memo := __dgi_NewMemo[int]("users", "name", 10, false)
+	for i := range 10 + __dgi_memoSlack {
+		memo.Get(i, g.gen)
+	}
+	defer func() {
+		var evicted *__dgi_MemoEvictedError
+		if err, _ := recover().(error); !errors.As(err, &evicted) {
+			t.Fatalf("expected a *__dgi_MemoEvictedError, got %v", err)
+		}
+		if evicted.Model != "users" || evicted.Field != "name" || evicted.Iter != 3 || evicted.Oldest != __dgi_memoSlack {
+			t.Errorf("unexpected error %+v", evicted)
+		}
+	}()
+	memo.Get(3, g.gen)
+}
+
+func TestGenerateShardEvicted(t *testing.T) {
+	memo := __dgi_NewMemo[int]("users", "name", 10, false)
+	var g countingGen
+	gen := func(i int) __dgi_Record {
+		memo.Get(i, g.gen)
+		if i == 10+__dgi_memoSlack {
+			memo.Get(0, g.gen)
+		}
+		return nil
+	}
+
+	_, err := __dgi_generateShard(gen, __dgi_Shard{Model: "users", Count: 2000, Start: 0, End: 2000})
+	var evicted *__dgi_MemoEvictedError
+	if !errors.As(err, &evicted) {
+		t.Fatalf("expected a *__dgi_MemoEvictedError, got %v", err)
+	}
+}
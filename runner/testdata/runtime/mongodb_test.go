@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMongoDBDocuments(t *testing.T) {
+	generator := __init___datagen_accountsGenerator(0)
+	records := []*__datagen_accounts{generator.Gen(0).(*__datagen_accounts), generator.Gen(4).(*__datagen_accounts)}
+
+	documents := Documents___datagen_accounts_mongodb(records)
+	if len(documents) != 2 {
+		t.Fatalf("expected a document per record, got %d", len(documents))
+	}
+
+	// encoded as the client encodes them, with the json tags of structs
+	var buf bytes.Buffer
+	writer, err := bsonrw.NewBSONValueWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoder, err := bson.NewEncoder(writer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoder.UseJSONStructTags()
+	if err := encoder.Encode(documents[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	var got bson.D
+	if err := bson.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	expected := bson.D{
+		{Key: "_id", Value: int32(4)},
+		{Key: "name", Value: "account_4"},
+		{Key: "nickname", Value: nil},
+		{Key: "tags", Value: primitive.A{"new", "tier_1"}},
+		{Key: "limits", Value: bson.D{{Key: "daily", Value: int32(400)}}},
+		{Key: "address", Value: bson.D{{Key: "city", Value: "Lisbon"}}},
+		{Key: "opened_at", Value: primitive.NewDateTimeFromTime(time.Date(2024, 1, 1, 0, 0, 4, 0, time.UTC))},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("document\n  got:      %#v\n  expected: %#v", got, expected)
+	}
+}
+
+func TestMongoDBCollections(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Disconnect(context.Background())
+
+	config := &__dgi_MongoDBConfig{URI: "mongodb://localhost:1", Database: "datagen", Collections: map[string]string{"shop.accounts": "customers"}}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := Collection___datagen_accounts_mongodb(client, "shop.accounts", config); got.Name() != "customers" || got.Database().Name() != "datagen" {
+		t.Errorf("mapped model inserted into %s.%s, expected datagen.customers", got.Database().Name(), got.Name())
+	}
+	if got := Collection___datagen_accounts_mongodb(client, "accounts", config).Name(); got != "accounts" {
+		t.Errorf("models without a collection are inserted into %s, expected the model name", got)
+	}
+
+	config.Collections["accounts"] = ""
+	if err := config.Validate(); err == nil {
+		t.Error("expected empty collections to be rejected")
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// __dgi_maxPlaceholders is the most parameters MySQL and Postgres take in a
+// statement.
+const __dgi_maxPlaceholders = 65535
+
+// __dgi_sqliteMaxVariables is the most parameters SQLite takes in a
+// statement.
+const __dgi_sqliteMaxVariables = 32766
+
+// __dgi_insertBatchSize returns the rows per INSERT statement of a table of
+// columns columns: batchSize, or as many rows as fit when it is not set,
+// capped so that statements stay under maxPlaceholders parameters.
+func __dgi_insertBatchSize(batchSize, columns, maxPlaceholders int) int {
+	limit := max(maxPlaceholders/max(columns, 1), 1)
+	if batchSize <= 0 || batchSize > limit {
+		return limit
+	}
+	return batchSize
+}
+
+var __dgi_sinkTimeType = reflect.TypeOf(time.Time{})
+
+// __dgi_sinkValue converts v to a value the SQL drivers take, following
+// pointers and writing slices, maps and structs as JSON, as they are stored
+// in JSON columns.
+func __dgi_sinkValue(v any) (any, error) {
+	if _, ok := v.(driver.Valuer); ok {
+		return v, nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Bytes(), nil
+		}
+	case reflect.Array:
+	case reflect.Struct:
+		if rv.Type() == __dgi_sinkTimeType {
+			return rv.Interface(), nil
+		}
+	default:
+		return rv.Interface(), nil
+	}
+
+	data, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// __dgi_sinkValues converts the values of a row with __dgi_sinkValue, in
+// place.
+func __dgi_sinkValues(row []any) error {
+	for i, v := range row {
+		value, err := __dgi_sinkValue(v)
+		if err != nil {
+			return fmt.Errorf("column %d: %w", i+1, err)
+		}
+		row[i] = value
+	}
+	return nil
+}
+
+// __dgi_postgresCopy loads rows into table with the COPY protocol, which
+// takes no parameters and needs no statement per batch.
+func __dgi_postgresCopy(ctx context.Context, tx *sql.Tx, table string, columns []string, rows [][]any) error {
+	stmt, err := tx.PrepareContext(ctx, "COPY "+table+" ("+strings.Join(columns, ",")+") FROM STDIN")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		if err := __dgi_sinkValues(row); err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return err
+		}
+	}
+	// the rows are sent once the statement is executed without values
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return err
+	}
+	return stmt.Close()
+}
+
+var __dgi_loadDataReaders atomic.Int64
+
+// __dgi_mysqlLoadDataModes are the write modes LOAD DATA supports, with the
+// keyword giving them. Rows whose keys are already in the table are skipped
+// without one, as LOCAL files cannot be stopped halfway through, so inserts
+// fail once the file is loaded when rows were skipped.
+var __dgi_mysqlLoadDataModes = map[__dgi_WriteMode]string{
+	"":                          "",
+	__dgi_WriteModeInsert:       "",
+	__dgi_WriteModeInsertIgnore: " IGNORE",
+	__dgi_WriteModeReplace:      " REPLACE",
+}
+
+// __dgi_mysqlLoadData loads rows into table with LOAD DATA LOCAL INFILE,
+// streaming them to the server as tab-separated text while they are encoded.
+func __dgi_mysqlLoadData(ctx context.Context, tx *sql.Tx, table string, columns []string, mode __dgi_WriteMode, rows [][]any) error {
+	keyword, ok := __dgi_mysqlLoadDataModes[mode]
+	if !ok {
+		return fmt.Errorf("LOAD DATA does not support write_mode %s", mode)
+	}
+
+	name := fmt.Sprintf("datagen_%d", __dgi_loadDataReaders.Add(1))
+	reader, writer := io.Pipe()
+	mysql.RegisterReaderHandler(name, func() io.Reader { return reader })
+	defer mysql.DeregisterReaderHandler(name)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		writer.CloseWithError(__dgi_mysqlLoadDataRows(writer, rows))
+	}()
+
+	result, err := tx.ExecContext(ctx, "LOAD DATA LOCAL INFILE 'Reader::"+name+"'"+keyword+" INTO TABLE "+table+
+		" CHARACTER SET binary FIELDS TERMINATED BY '\\t' ESCAPED BY '\\\\' LINES TERMINATED BY '\\n' ("+strings.Join(columns, ",")+")")
+	// unblocks the encoding of rows the server did not read
+	reader.Close()
+	<-done
+	if err != nil || keyword != "" {
+		return err
+	}
+	loaded, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if skipped := int64(len(rows)) - loaded; skipped > 0 {
+		return fmt.Errorf("%d of %d rows have keys already in the table, use write_mode insert_ignore to skip them", skipped, len(rows))
+	}
+	return nil
+}
+
+// __dgi_mysqlLoadDataRows writes rows in the default format of LOAD DATA:
+// tab-separated fields, newline-terminated lines, backslash escapes and \N
+// for NULL.
+func __dgi_mysqlLoadDataRows(w io.Writer, rows [][]any) error {
+	b := bufio.NewWriter(w)
+	for _, row := range rows {
+		for i, v := range row {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			if err := __dgi_mysqlLoadDataField(b, v); err != nil {
+				return fmt.Errorf("column %d: %w", i+1, err)
+			}
+		}
+		if err := b.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
+
+var __dgi_mysqlLoadDataEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`, "\x00", `\0`)
+
+// __dgi_mysqlLoadDataField writes a value as a field of LOAD DATA, with times
+// in UTC as the driver sends them.
+func __dgi_mysqlLoadDataField(b *bufio.Writer, v any) error {
+	value, err := __dgi_sinkValue(v)
+	if err != nil {
+		return err
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		if value, err = valuer.Value(); err != nil {
+			return err
+		}
+	}
+	if value == nil {
+		b.WriteString(`\N`)
+		return nil
+	}
+	if t, ok := value.(time.Time); ok {
+		b.WriteString(t.UTC().Format("2006-01-02 15:04:05.999999"))
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.WriteString(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		b.WriteString(strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()))
+	case reflect.String:
+		__dgi_mysqlLoadDataEscaper.WriteString(b, rv.String())
+	case reflect.Slice:
+		// __dgi_sinkValue leaves no slice but bytes
+		__dgi_mysqlLoadDataEscaper.WriteString(b, string(rv.Bytes()))
+	default:
+		return fmt.Errorf("cannot load %T", value)
+	}
+	return nil
+}
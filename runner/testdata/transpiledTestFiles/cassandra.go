@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// __dgi_cassandraColumn is a column of a Cassandra table, with its CQL type.
+type __dgi_cassandraColumn struct {
+	name string
+	typ  string
+}
+
+// __dgi_cassandraTable is the table records of a model are written to.
+type __dgi_cassandraTable struct {
+	// name is the quoted name of the table
+	name    string
+	columns []__dgi_cassandraColumn
+	// keys name the primary key of the table, the first being its partition
+	// key and the others its clustering columns
+	keys []string
+}
+
+// __dgi_newCassandraSession connects to the cluster of config, using its
+// keyspace.
+func __dgi_newCassandraSession(config *__dgi_CassandraConfig) (*gocql.Session, error) {
+	cluster := gocql.NewCluster(config.Hosts...)
+	cluster.Keyspace = config.Keyspace
+	cluster.Consistency = config.consistency()
+	if config.Port > 0 {
+		cluster.Port = config.Port
+	}
+	if config.Username != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: config.Username, Password: config.Password}
+	}
+	if d, err := time.ParseDuration(config.Timeout); err == nil && d > 0 {
+		cluster.Timeout = d
+		cluster.ConnectTimeout = d
+	}
+	return cluster.CreateSession()
+}
+
+// __dgi_cqlIdent quotes a table or column name for CQL, keeping its case.
+func __dgi_cqlIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// partitionKey returns the index of the partition key among the columns of
+// the table.
+func (t *__dgi_cassandraTable) partitionKey() (int, error) {
+	if len(t.keys) == 0 {
+		return 0, fmt.Errorf("table %s has no primary key: set key_columns of the model", t.name)
+	}
+	i := slices.IndexFunc(t.columns, func(c __dgi_cassandraColumn) bool { return c.name == t.keys[0] })
+	if i < 0 {
+		return 0, fmt.Errorf("key column %q is not a column of table %s", t.keys[0], t.name)
+	}
+	return i, nil
+}
+
+// createStatement returns the CREATE TABLE statement of the table, which
+// leaves an existing table alone.
+func (t *__dgi_cassandraTable) createStatement() (string, error) {
+	if _, err := t.partitionKey(); err != nil {
+		return "", err
+	}
+	lines := make([]string, 0, len(t.columns)+1)
+	for _, c := range t.columns {
+		lines = append(lines, "  "+__dgi_cqlIdent(c.name)+" "+c.typ)
+	}
+	keys := make([]string, 0, len(t.keys))
+	for _, key := range t.keys {
+		if !slices.ContainsFunc(t.columns, func(c __dgi_cassandraColumn) bool { return c.name == key }) {
+			return "", fmt.Errorf("key column %q is not a column of table %s", key, t.name)
+		}
+		keys = append(keys, __dgi_cqlIdent(key))
+	}
+	keys[0] = "(" + keys[0] + ")"
+	lines = append(lines, "  PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+	return "CREATE TABLE IF NOT EXISTS " + t.name + " (\n" + strings.Join(lines, ",\n") + "\n)", nil
+}
+
+// insertStatement returns the INSERT statement writing a row of the table.
+func (t *__dgi_cassandraTable) insertStatement() string {
+	columns := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		columns[i] = __dgi_cqlIdent(c.name)
+	}
+	return "INSERT INTO " + t.name + " (" + strings.Join(columns, ",") + ") VALUES (" +
+		strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+}
+
+// __dgi_cassandraInsert writes rows to table, each with a prepared statement,
+// or in unlogged batches of the rows of a partition, at most
+// config.batchSize() each, when config.UnloggedBatches is set, running up to
+// config.concurrency() of them at once.
+func __dgi_cassandraInsert(ctx context.Context, session *gocql.Session, config *__dgi_CassandraConfig, table *__dgi_cassandraTable, rows [][]any) error {
+	for _, row := range rows {
+		if err := __dgi_cassandraValues(row); err != nil {
+			return err
+		}
+	}
+	stmt := table.insertStatement()
+
+	var groups [][][]any
+	if config.UnloggedBatches {
+		key, err := table.partitionKey()
+		if err != nil {
+			return err
+		}
+		groups = __dgi_cassandraBatches(__dgi_cassandraPartitions(rows, key), config.batchSize())
+	} else {
+		groups = make([][][]any, len(rows))
+		for i, row := range rows {
+			groups[i] = [][]any{row}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	work := make(chan [][]any)
+	for range min(config.concurrency(), len(groups)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range work {
+				if err := __dgi_cassandraWrite(ctx, session, stmt, group); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+loop:
+	for _, group := range groups {
+		select {
+		case work <- group:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(work)
+	wg.Wait()
+	return firstErr
+}
+
+// __dgi_cassandraWrite writes a single row with stmt, or several rows of one
+// partition in an unlogged batch.
+func __dgi_cassandraWrite(ctx context.Context, session *gocql.Session, stmt string, rows [][]any) error {
+	if len(rows) == 1 {
+		return session.Query(stmt, rows[0]...).WithContext(ctx).Exec()
+	}
+	batch := session.NewBatch(gocql.UnloggedBatch).WithContext(ctx)
+	for _, row := range rows {
+		batch.Query(stmt, row...)
+	}
+	return session.ExecuteBatch(batch)
+}
+
+// __dgi_cassandraPartitions groups rows by the value of their column key, in
+// the order partitions first appear.
+func __dgi_cassandraPartitions(rows [][]any, key int) [][][]any {
+	var groups [][][]any
+	index := map[string]int{}
+	for _, row := range rows {
+		data, _ := json.Marshal(row[key])
+		k := fmt.Sprintf("%T:%s", row[key], data)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], row)
+	}
+	return groups
+}
+
+// __dgi_cassandraBatches splits partitions into batches of at most size rows.
+func __dgi_cassandraBatches(partitions [][][]any, size int) [][][]any {
+	var batches [][][]any
+	for _, rows := range partitions {
+		for len(rows) > size {
+			batches = append(batches, rows[:size])
+			rows = rows[size:]
+		}
+		batches = append(batches, rows)
+	}
+	return batches
+}
+
+// __dgi_cassandraValues converts the values of a row with
+// __dgi_cassandraValue, in place.
+func __dgi_cassandraValues(row []any) error {
+	for i, v := range row {
+		value, err := __dgi_cassandraValue(reflect.ValueOf(v))
+		if err != nil {
+			return fmt.Errorf("column %d: %w", i+1, err)
+		}
+		row[i] = value
+	}
+	return nil
+}
+
+var __dgi_cassandraTimeType = reflect.TypeOf(time.Time{})
+
+// __dgi_cassandraValue converts v to a value gocql writes to its CQL column,
+// following pointers, converting the elements of lists and maps and writing
+// structs as JSON text.
+func __dgi_cassandraValue(v reflect.Value) (any, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	case reflect.Float32:
+		return float32(v.Float()), nil
+	case reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), nil
+		}
+		list := make([]any, v.Len())
+		for i := range list {
+			elem, err := __dgi_cassandraValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = elem
+		}
+		return list, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		m := make(map[any]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := __dgi_cassandraValue(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			elem, err := __dgi_cassandraValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			m[key] = elem
+		}
+		return m, nil
+	case reflect.Struct:
+		if v.Type() == __dgi_cassandraTimeType {
+			return v.Interface(), nil
+		}
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+	return nil, fmt.Errorf("cannot write %s to Cassandra", v.Type())
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+)
+
+type __dgi_CassandraConfig struct {
+	Hosts          []string          `json:"hosts"`
+	Port           int               `json:"port,omitempty"`
+	Keyspace       string            `json:"keyspace"`
+	Username       string            `json:"username,omitempty"`
+	Password       string            `json:"password,omitempty"`
+	// Consistency is the consistency level rows are written with, such as
+	// one or local_quorum, quorum by default.
+	Consistency    string            `json:"consistency,omitempty"`
+	// Tables maps model names to the tables they are loaded into, optionally
+	// prefixed with a keyspace, rather than the tables their metadata names.
+	Tables         map[string]string `json:"tables,omitempty"`
+	// UnloggedBatches writes the rows of a partition in unlogged batches
+	// rather than one prepared statement per row.
+	UnloggedBatches bool             `json:"unlogged_batches,omitempty"`
+	// Concurrency is the most statements or batches run at once.
+	Concurrency    int               `json:"concurrency,omitempty"`
+	// BatchSize is the most rows written at a time, and so the most rows of
+	// an unlogged batch, __dgi_cassandraBatchSize by default.
+	BatchSize      int               `json:"batch_size,omitempty"`
+	Timeout        string            `json:"timeout,omitempty"`
+	Throttle       string            `json:"throttle,omitempty"`
+}
+
+func (c *__dgi_CassandraConfig) consistency() gocql.Consistency {
+	if c.Consistency == "" {
+		return gocql.Quorum
+	}
+	consistency, _ := gocql.ParseConsistencyWrapper(strings.ToUpper(c.Consistency))
+	return consistency
+}
+
+// __dgi_cassandraBatchSize is the default batch size, which keeps the unlogged
+// batches of most tables under the size Cassandra fails batches at.
+const __dgi_cassandraBatchSize = 20
+
+func (c *__dgi_CassandraConfig) batchSize() int {
+	if c.BatchSize <= 0 {
+		return __dgi_cassandraBatchSize
+	}
+	return c.BatchSize
+}
+
+func (c *__dgi_CassandraConfig) concurrency() int {
+	if c.Concurrency <= 0 {
+		return 16
+	}
+	return c.Concurrency
+}
+
+// table returns the quoted name of the table of a model, mapped in Tables or
+// the quoted name defaultTable its metadata gives.
+func (c *__dgi_CassandraConfig) table(modelName, defaultTable string) string {
+	name, ok := c.Tables[modelName]
+	if !ok {
+		return defaultTable
+	}
+	if keyspace, table, ok := strings.Cut(name, "."); ok {
+		return __dgi_cqlIdent(keyspace) + "." + __dgi_cqlIdent(table)
+	}
+	return __dgi_cqlIdent(name)
+}
+
+func (c *__dgi_CassandraConfig) Validate() error {
+	if len(c.Hosts) == 0 || c.Keyspace == "" {
+		return errors.New("cassandra: hosts and keyspace are required")
+	}
+	if c.Consistency != "" {
+		if _, err := gocql.ParseConsistencyWrapper(strings.ToUpper(c.Consistency)); err != nil {
+			return fmt.Errorf("cassandra: unsupported consistency %q", c.Consistency)
+		}
+	}
+	for model, table := range c.Tables {
+		if table == "" || strings.HasPrefix(table, ".") || strings.HasSuffix(table, ".") {
+			return fmt.Errorf("cassandra: invalid table %q for model %q", table, model)
+		}
+	}
+	return nil
+}
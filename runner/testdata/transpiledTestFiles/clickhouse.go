@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// __dgi_chClient runs queries through the HTTP interface of a ClickHouse
+// server.
+type __dgi_chClient struct {
+	config *__dgi_ClickHouseConfig
+	base   string
+	http   *http.Client
+}
+
+// __dgi_chError is an error response of the server.
+type __dgi_chError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *__dgi_chError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("status %d: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("status %d: code %s: %s", e.Status, e.Code, e.Message)
+}
+
+// __dgi_newCHClient returns a client of the server of config, once it runs a
+// query with its credentials.
+func __dgi_newCHClient(config *__dgi_ClickHouseConfig) (*__dgi_chClient, error) {
+	timeout := 30 * time.Second
+	if d, err := time.ParseDuration(config.Timeout); err == nil && d > 0 {
+		timeout = d
+	}
+	c := &__dgi_chClient{config: config, base: strings.TrimSuffix(config.URL, "/"), http: &http.Client{Timeout: timeout}}
+	if err := c.exec("SELECT 1", nil); err != nil {
+		return nil, fmt.Errorf("ping server: %w", err)
+	}
+	return c, nil
+}
+
+// exec runs query, reading the rows it inserts from body. Times are parsed
+// whatever their format, and nulls are the default of columns that cannot
+// hold them, such as arrays and maps.
+func (c *__dgi_chClient) exec(query string, body []byte) error {
+	params := url.Values{}
+	params.Set("query", query)
+	if c.config.Database != "" {
+		params.Set("database", c.config.Database)
+	}
+	params.Set("date_time_input_format", "best_effort")
+	params.Set("input_format_null_as_default", "1")
+
+	req, err := http.NewRequest(http.MethodPost, c.base+"/?"+params.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &__dgi_chError{Status: resp.StatusCode, Code: resp.Header.Get("X-ClickHouse-Exception-Code"), Message: strings.TrimSpace(string(data))}
+	}
+	return nil
+}
+
+// insert inserts rows into table, as JSONEachRow objects keyed by column.
+func (c *__dgi_chClient) insert(table string, names, columns []string, rows [][]any) error {
+	var b bytes.Buffer
+	fields := make([]__dgi_JSONField, len(names))
+	for _, row := range rows {
+		for i, v := range row {
+			value, err := __dgi_clickhouseValue(reflect.ValueOf(v))
+			if err != nil {
+				return fmt.Errorf("column %s: %w", names[i], err)
+			}
+			fields[i] = __dgi_JSONField{Key: names[i], Value: value}
+		}
+		line, err := __dgi_marshalJSONObject(fields)
+		if err != nil {
+			return err
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return c.exec("INSERT INTO "+table+" ("+strings.Join(columns, ", ")+") FORMAT JSONEachRow", b.Bytes())
+}
+
+// close releases the connections of the client.
+func (c *__dgi_chClient) close() {
+	c.http.CloseIdleConnections()
+}
+
+var __dgi_clickhouseTimeType = reflect.TypeOf(time.Time{})
+
+// __dgi_clickhouseValue converts v to the value JSONEachRow reads into its
+// column: structs become JSON text, as they are stored in String columns,
+// within slices and maps too, byte slices become the String they hold rather
+// than base64, and everything else is left to encoding/json. JSON text cannot
+// hold bytes that are not valid UTF-8, so byte slices holding them are
+// rejected rather than stored with their invalid bytes replaced.
+func __dgi_clickhouseValue(v reflect.Value) (any, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == __dgi_clickhouseTimeType {
+			return v.Interface(), nil
+		}
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return v.Interface(), nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			if !utf8.Valid(v.Bytes()) {
+				return nil, errors.New("bytes are not valid UTF-8, which String columns are written as")
+			}
+			return string(v.Bytes()), nil
+		}
+		values := make([]any, v.Len())
+		for i := range values {
+			value, err := __dgi_clickhouseValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		values := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, err := __dgi_clickhouseValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			values[fmt.Sprint(iter.Key().Interface())] = value
+		}
+		return values, nil
+	}
+	return v.Interface(), nil
+}
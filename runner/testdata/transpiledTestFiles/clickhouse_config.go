@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+type __dgi_ClickHouseConfig struct {
+	// URL is the address of the HTTP interface of the server, such as
+	// http://localhost:8123.
+	URL            string `json:"url"`
+	// Database is the database of tables whose model metadata names no
+	// schema, the default database of the user when it is not set.
+	Database       string `json:"database,omitempty"`
+	Username       string `json:"username,omitempty"`
+	Password       string `json:"password,omitempty"`
+	BatchSize      int    `json:"batch_size,omitempty"`
+	Timeout        string `json:"timeout,omitempty"`
+	Throttle       string `json:"throttle,omitempty"`
+}
+
+func (c *__dgi_ClickHouseConfig) Validate() error {
+	if c.URL == "" {
+		return errors.New("clickhouse: url is required")
+	}
+	if u, err := url.Parse(c.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("clickhouse: url must be an http or https URL, got %q", c.URL)
+	}
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/brianvoe/gofakeit/v7"
 	"log/slog"
+	"maps"
 	"math/rand"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -16,14 +18,30 @@ func __dgi_getModelGenCount(metadata __dgi_Metadata, flagCount int) int {
 	return metadata.Count
 }
 
-func __dgi_runGenCommand(flagCount int, flagTags, flagOutput, flagFormat string, flagSeed int64) error {
+// __dgi_RunOptions holds the flags shared by gen and execute that tune how
+// records are generated.
+type __dgi_RunOptions struct {
+	ChunkSize   int
+	MemoWindow  int
+	Parallelism int
+}
+
+// workerFactory returns a factory creating generators that share links.
+func (o __dgi_RunOptions) workerFactory(links *__dgi_Links) __dgi_WorkerFactory {
+	return func() (*__dgi_DataGenGenerators, map[string]__dgi_RecordGenerator) {
+		return __dgi_initGeneratorsAndModels(o.MemoWindow, links)
+	}
+}
+
+func __dgi_runGenCommand(flagCount int, flagTags, flagOutput, flagFormat string, flagSeed int64, flagRowGroupSize int, sqlOpts __dgi_SQLOptions, jsonOpts __dgi_JSONOptions, xmlOpts __dgi_XMLOptions, csvOpts __dgi_CSVOptions, outOpts __dgi_OutputOptions, opts __dgi_RunOptions) error {
 	if flagSeed != 0 {
 		if err := __dgi_setDatagenSeed(flagSeed); err != nil {
 			return fmt.Errorf("error setting seed: %v", err)
 		}
 	}
 
-	datagen, models := __dgi_initGeneratorsAndModels()
+	links := __dgi_newLinks()
+	datagen, models := __dgi_initGeneratorsAndModels(opts.MemoWindow, links)
 	allMetadata := __dgi_getModelsMetadata(datagen)
 
 	selected := make(map[string]int)
@@ -47,22 +65,25 @@ func __dgi_runGenCommand(flagCount int, flagTags, flagOutput, flagFormat string,
 		}
 	}
 
-	writers := map[string]__dgi_OutputWriter{
-		__dgi_FormatCSV:    __dgi_writeCSV,
-		__dgi_FormatJSON:   __dgi_writeJSON,
-		__dgi_FormatXML:    __dgi_writeXML,
-		__dgi_FormatStdout: __dgi_writeStdout,
+	writers := map[string]__dgi_OutputWriterFactory{
+		__dgi_FormatCSV:      __dgi_newCSVWriterFactory(csvOpts),
+		__dgi_FormatJSON:     __dgi_newJSONWriterFactory(jsonOpts, __dgi_embeddedRecords(selected, allMetadata, flagCount, links, opts)),
+		__dgi_FormatXML:      __dgi_newXMLWriterFactory(xmlOpts),
+		__dgi_FormatParquet:  __dgi_newParquetWriterFactory(flagRowGroupSize),
+		__dgi_FormatAvro:     __dgi_newAvroWriter,
+		__dgi_FormatProtobuf: __dgi_newProtobufWriter,
+		__dgi_FormatSQL:      __dgi_newSQLWriterFactory(sqlOpts),
+		__dgi_FormatStdout:   __dgi_newStdoutWriter,
 	}
 
 	if flagFormat == "" {
 		flagFormat = __dgi_FormatStdout
 	}
 
-	w, ok := writers[flagFormat]
+	newWriter, ok := writers[flagFormat]
 	if !ok {
-		return fmt.Errorf("--format must be one of %s", strings.Join([]string{__dgi_FormatCSV, __dgi_FormatJSON, __dgi_FormatXML, __dgi_FormatStdout}, ", "))
+		return fmt.Errorf("--format must be one of %s", strings.Join([]string{__dgi_FormatCSV, __dgi_FormatJSON, __dgi_FormatXML, __dgi_FormatParquet, __dgi_FormatAvro, __dgi_FormatProtobuf, __dgi_FormatSQL, __dgi_FormatStdout}, ", "))
 	}
-	writeFn := func(name string, records []__dgi_Record) error { return w(name, records, flagOutput) }
 
 	selectedNames := make([]string, 0, len(selected))
 	for name := range selected {
@@ -71,36 +92,135 @@ func __dgi_runGenCommand(flagCount int, flagTags, flagOutput, flagFormat string,
 
 	sort.Strings(selectedNames)
 
+	if err := outOpts.validate(flagFormat); err != nil {
+		return err
+	}
+	outputs, err := __dgi_newOutputFiles(flagOutput, outOpts)
+	if err != nil {
+		return err
+	}
+
+	if flagFormat == __dgi_FormatCSV {
+		if err := csvOpts.validate(); err != nil {
+			return err
+		}
+	}
+
+	if flagFormat == __dgi_FormatJSON {
+		if err := jsonOpts.validate(); err != nil {
+			return err
+		}
+	}
+
+	if flagFormat == __dgi_FormatXML {
+		if err := xmlOpts.validate(); err != nil {
+			return err
+		}
+	}
+
+	if flagFormat == __dgi_FormatSQL {
+		if err := sqlOpts.validate(); err != nil {
+			return err
+		}
+		// referenced models are written first so that the statements can be
+		// applied in one go
+		sorted, err := links.TopologicalSort()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("cannot perform topological sort, writing models by name: %s", err.Error()))
+		} else {
+			sorted = slices.DeleteFunc(sorted, func(name string) bool {
+				_, ok := selected[name]
+				return !ok
+			})
+			if len(sorted) == len(selectedNames) {
+				selectedNames = sorted
+			}
+		}
+	}
+
 	slog.Info(fmt.Sprintf("generating data for %d models in %s format", len(selectedNames), flagFormat))
 
 	for _, name := range selectedNames {
-		count, _ := selected[name]
-		gen, ok := models[name]
-		if !ok {
+		if _, ok := models[name]; !ok {
 			return fmt.Errorf("unknown model: %s", name)
 		}
+	}
+
+	var w __dgi_OutputWriter
+	shards := __dgi_shardModels(selectedNames, selected, opts.ChunkSize)
+	err = __dgi_generateShards(shards, opts.Parallelism, opts.workerFactory(links), func(shard __dgi_Shard, records []__dgi_Record) error {
+		if shard.First() {
+			slog.Debug(fmt.Sprintf("generating %d records for %s in chunks of %d", shard.Count, shard.Model, opts.ChunkSize))
+			var err error
+			if w, err = outputs.newWriter(newWriter, shard.Model); err != nil {
+				return fmt.Errorf("error in writing records for model %s: %w", shard.Model, err)
+			}
+		}
 
-		slog.Debug(fmt.Sprintf("generating %d records for %s", count, name))
-		records := make([]__dgi_Record, 0, count)
-		for i := 0; i < count; i++ {
-			records = append(records, gen(i))
+		err := w.Write(records)
+		if shard.Last() && err == nil {
+			err = w.Close()
+			w = nil
+		}
+		if err != nil {
+			return fmt.Errorf("error in writing records for model %s: %w", shard.Model, err)
+		}
+		if shard.Last() {
+			slog.Info(fmt.Sprintf("generated and wrote %d records for %s", shard.Count, shard.Model))
 		}
+		return nil
+	})
+	if err != nil {
+		// the files of the model that failed are dropped rather than left
+		// half written
+		outputs.abort()
+		return err
+	}
+	return outputs.commit()
+}
+
+// __dgi_maxEmbeddedRecords is the most records of a model that are embedded
+// in JSON documents. Every one of them is kept in memory, along with its
+// document, while the documents embedding them are written.
+const __dgi_maxEmbeddedRecords = 1_000_000
 
-		if err := writeFn(name, records); err != nil {
-			return fmt.Errorf("error in writing records for model %s: %w", name, err)
+// __dgi_embeddedRecords returns the records of the models embedded in JSON
+// documents, which are generated once each, as many as gen writes of them.
+func __dgi_embeddedRecords(selected map[string]int, allMetadata map[string]__dgi_Metadata, flagCount int, links *__dgi_Links, opts __dgi_RunOptions) __dgi_JSONChildren {
+	generated := map[string][]__dgi_Record{}
+	return func(model string) ([]__dgi_Record, error) {
+		if records, ok := generated[model]; ok {
+			return records, nil
 		}
-		slog.Info(fmt.Sprintf("generated and wrote %d records for %s", count, name))
+		count, ok := selected[model]
+		if !ok {
+			count = __dgi_getModelGenCount(allMetadata[model], flagCount)
+		}
+		if count > __dgi_maxEmbeddedRecords {
+			return nil, fmt.Errorf("%d records are too many to embed, as embedded records are kept in memory: at most %d can be, lower the count of %s", count, __dgi_maxEmbeddedRecords, model)
+		}
+		var records []__dgi_Record
+		shards := __dgi_shardModels([]string{model}, map[string]int{model: count}, opts.ChunkSize)
+		err := __dgi_generateShards(shards, opts.Parallelism, opts.workerFactory(links), func(_ __dgi_Shard, chunk []__dgi_Record) error {
+			records = append(records, chunk...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		generated[model] = records
+		return records, nil
 	}
-	return nil
 }
 
-func __dgi_runExecuteCommand(flagConfig, flagOutput string) error {
+func __dgi_runExecuteCommand(flagConfig, flagOutput string, opts __dgi_RunOptions) error {
 	if strings.TrimSpace(flagConfig) == "" {
 		return fmt.Errorf("config file path not provided")
 	}
 
 	slog.Debug(fmt.Sprintf("loading configuration from %s", flagConfig))
-	datagen, models := __dgi_initGeneratorsAndModels()
+	links := __dgi_newLinks()
+	datagen, models := __dgi_initGeneratorsAndModels(opts.MemoWindow, links)
 	var modelsToLoad []string
 	allMetadata := __dgi_getModelsMetadata(datagen)
 
@@ -134,42 +254,32 @@ func __dgi_runExecuteCommand(flagConfig, flagOutput string) error {
 	}
 
 	slog.Info(fmt.Sprintf("preparing to load data into sinks for %d models", len(modelsToLoad)))
-	allData := map[string][]__dgi_Record{}
+	counts := map[string]int{}
 
 	for _, name := range modelsToLoad {
-		gen := models[name]
-
 		count := __dgi_getRecordCount(cfg, name, allMetadata[name])
 
 		if count == 0 {
 			slog.Info(fmt.Sprintf("skipping %s with zero count", name))
 			continue
 		}
-
-		datagen.__links.StartGen(name)
-
-		slog.Debug(fmt.Sprintf("generating %d records for %s for sink loading", count, name))
-		records := make([]__dgi_Record, 0, count)
-		for i := 0; i < count; i++ {
-			records = append(records, gen(i))
-		}
-
-		allData[name] = records
-
-		datagen.__links.EndGen(name)
-		slog.Debug(fmt.Sprintf("generated %d records for %s for sink loading", len(records), name))
+		counts[name] = count
 	}
 
+	// records are streamed straight into the sinks, so referenced models have to be
+	// generated and loaded before the models referencing them
 	topologicallySorted, err := datagen.__links.TopologicalSort()
 	if err != nil {
 		slog.Warn(fmt.Sprintf("cannot perform topological sort, loading anyway: %s", err.Error()))
+		topologicallySorted = slices.Sorted(maps.Keys(counts))
 	} else {
 		slog.Debug(fmt.Sprintf("topological sort completed: %v", topologicallySorted))
 	}
-	return __dgi_orchestrateSinks(topologicallySorted, allData, cfg)
+	return __dgi_orchestrateSinks(topologicallySorted, opts.workerFactory(links), counts, cfg, opts)
 }
 
 func __dgi_setDatagenSeed(seed int64) error {
+	__dgi_setRootSeed(uint64(seed))
 	rand.Seed(seed)
 	return gofakeit.Seed(seed)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -12,22 +13,33 @@ import (
 type __dgi_SinkType string
 
 const (
-	__dgi_SinkTypeMySQL    __dgi_SinkType = "mysql"
-	__dgi_SinkTypePostgres __dgi_SinkType = "postgres"
-	__dgi_SinkTypeKafka    __dgi_SinkType = "kafka"
+	__dgi_SinkTypeMySQL         __dgi_SinkType = "mysql"
+	__dgi_SinkTypePostgres      __dgi_SinkType = "postgres"
+	__dgi_SinkTypeSQLite        __dgi_SinkType = "sqlite"
+	__dgi_SinkTypeMongoDB       __dgi_SinkType = "mongodb"
+	__dgi_SinkTypeElasticsearch __dgi_SinkType = "elasticsearch"
+	__dgi_SinkTypeRedis         __dgi_SinkType = "redis"
+	__dgi_SinkTypeClickHouse    __dgi_SinkType = "clickhouse"
+	__dgi_SinkTypeCassandra     __dgi_SinkType = "cassandra"
+	__dgi_SinkTypeKafka         __dgi_SinkType = "kafka"
 )
 
 type __dgi_Config struct {
-	ClearData bool              `json:"clear_data,omitempty"`
-	Models    []__dgi_ModelSpec `json:"models"`
-	Sinks     []__dgi_SinkSpec  `json:"sinks"`
-	Seed      int64             `json:"seed,omitempty"`
+	CreateTables bool              `json:"create_tables,omitempty"`
+	ClearData    bool              `json:"clear_data,omitempty"`
+	Models       []__dgi_ModelSpec `json:"models"`
+	Sinks        []__dgi_SinkSpec  `json:"sinks"`
+	Seed         int64             `json:"seed,omitempty"`
 }
 
 type __dgi_ModelSpec struct {
 	ModelName   string   `json:"model_name"`
 	TargetSinks []string `json:"target_sinks"`
 	Count       *int     `json:"count,omitempty"`
+	// WriteMode is how SQL sinks write rows whose keys are already in the
+	// table, matched on KeyColumns or on the primary key of the table.
+	WriteMode  __dgi_WriteMode `json:"write_mode,omitempty"`
+	KeyColumns []string        `json:"key_columns,omitempty"`
 }
 
 type __dgi_SinkSpec struct {
@@ -90,6 +102,14 @@ func (c *__dgi_Config) Validate(models map[string]__dgi_RecordGenerator) error {
 			return fmt.Errorf("duplicate model_name: %s", m.ModelName)
 		}
 		modelsSet[m.ModelName] = struct{}{}
+		if err := m.WriteMode.validate(); err != nil {
+			return fmt.Errorf("model %q: %w", m.ModelName, err)
+		}
+		for i, key := range m.KeyColumns {
+			if key == "" || slices.Contains(m.KeyColumns[:i], key) {
+				return fmt.Errorf("model %q: key_columns must be distinct column names, got %q", m.ModelName, m.KeyColumns)
+			}
+		}
 	}
 	sinksSet := make(map[string]struct{})
 	for _, s := range c.Sinks {
@@ -119,6 +139,62 @@ func (c *__dgi_Config) Validate(models map[string]__dgi_RecordGenerator) error {
 			if err := sc.Validate(); err != nil {
 				return fmt.Errorf("sink %q (postgres): %w", s.SinkName, err)
 			}
+		case __dgi_SinkTypeSQLite:
+			var sc __dgi_SQLiteConfig
+			if err := s.ConfigInto(&sc); err != nil {
+				return fmt.Errorf("sink %q (sqlite): %w", s.SinkName, err)
+			}
+			if err := sc.Validate(); err != nil {
+				return fmt.Errorf("sink %q (sqlite): %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeMongoDB:
+			var sc __dgi_MongoDBConfig
+			if err := s.ConfigInto(&sc); err != nil {
+				return fmt.Errorf("sink %q (mongodb): %w", s.SinkName, err)
+			}
+			if err := sc.Validate(); err != nil {
+				return fmt.Errorf("sink %q (mongodb): %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeElasticsearch:
+			var sc __dgi_ElasticsearchConfig
+			if err := s.ConfigInto(&sc); err != nil {
+				return fmt.Errorf("sink %q (elasticsearch): %w", s.SinkName, err)
+			}
+			if err := sc.Validate(); err != nil {
+				return fmt.Errorf("sink %q (elasticsearch): %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeRedis:
+			var sc __dgi_RedisConfig
+			if err := s.ConfigInto(&sc); err != nil {
+				return fmt.Errorf("sink %q (redis): %w", s.SinkName, err)
+			}
+			if err := sc.Validate(); err != nil {
+				return fmt.Errorf("sink %q (redis): %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeClickHouse:
+			var sc __dgi_ClickHouseConfig
+			if err := s.ConfigInto(&sc); err != nil {
+				return fmt.Errorf("sink %q (clickhouse): %w", s.SinkName, err)
+			}
+			if err := sc.Validate(); err != nil {
+				return fmt.Errorf("sink %q (clickhouse): %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeCassandra:
+			var sc __dgi_CassandraConfig
+			if err := s.ConfigInto(&sc); err != nil {
+				return fmt.Errorf("sink %q (cassandra): %w", s.SinkName, err)
+			}
+			if err := sc.Validate(); err != nil {
+				return fmt.Errorf("sink %q (cassandra): %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeKafka:
+			var sc __dgi_KafkaConfig
+			if err := s.ConfigInto(&sc); err != nil {
+				return fmt.Errorf("sink %q (kafka): %w", s.SinkName, err)
+			}
+			if err := sc.Validate(); err != nil {
+				return fmt.Errorf("sink %q (kafka): %w", s.SinkName, err)
+			}
 		default:
 			return fmt.Errorf("sink %q: unsupported sink_type %q", s.SinkName, s.SinkType)
 		}
@@ -149,6 +225,15 @@ func (c *__dgi_Config) SinkSpecsForModel(modelName string) ([]*__dgi_SinkSpec, e
 	return nil, fmt.Errorf("unknown model %q", modelName)
 }
 
+func (c *__dgi_Config) findModelByName(name string) *__dgi_ModelSpec {
+	for i := range c.Models {
+		if c.Models[i].ModelName == name {
+			return &c.Models[i]
+		}
+	}
+	return nil
+}
+
 func (c *__dgi_Config) findSinkByName(name string) *__dgi_SinkSpec {
 	for i := range c.Sinks {
 		if c.Sinks[i].SinkName == name {
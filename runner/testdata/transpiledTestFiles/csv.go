@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// __dgi_CSVOptions holds the flags of the csv format.
+type __dgi_CSVOptions struct {
+	Delimiter      string
+	Header         bool
+	Null           string
+	TimeLayout     string
+	FloatPrecision int
+}
+
+// delimiter returns the rune separating the cells of a row, reading \t as a
+// tab.
+func (o __dgi_CSVOptions) delimiter() rune {
+	if o.Delimiter == `\t` {
+		return '\t'
+	}
+	r, _ := utf8.DecodeRuneInString(o.Delimiter)
+	return r
+}
+
+func (o __dgi_CSVOptions) validate() error {
+	d := o.delimiter()
+	if o.Delimiter != `\t` && utf8.RuneCountInString(o.Delimiter) != 1 {
+		return fmt.Errorf("--csv-delimiter must be a single character, got %q", o.Delimiter)
+	}
+	if d == '"' || d == '\r' || d == '\n' || d == utf8.RuneError {
+		return fmt.Errorf("--csv-delimiter cannot be %q", o.Delimiter)
+	}
+	if strings.ContainsAny(o.Null, "\"\r\n"+string(d)) {
+		return fmt.Errorf("--csv-null cannot hold quotes, line breaks or the delimiter, got %q", o.Null)
+	}
+	if o.TimeLayout == "" {
+		return fmt.Errorf("--csv-time-layout must not be empty")
+	}
+	if o.FloatPrecision < -1 {
+		return fmt.Errorf("--csv-float-precision must be -1 or more, got %d", o.FloatPrecision)
+	}
+	return nil
+}
+
+var __dgi_csvTimeType = reflect.TypeOf(time.Time{})
+
+// __dgi_csvCell renders v as the text of a cell, and false for nil values,
+// which are written as the null token. Slices, maps and structs are written
+// as JSON, and bytes in base64.
+func __dgi_csvCell(opts __dgi_CSVOptions, v reflect.Value) (string, bool, error) {
+	if !v.IsValid() {
+		return "", false, nil
+	}
+	if v.Type() == __dgi_csvTimeType {
+		return v.Interface().(time.Time).Format(opts.TimeLayout), true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return "", false, nil
+		}
+		return __dgi_csvCell(opts, v.Elem())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			return "NaN", true, nil
+		case math.IsInf(f, 1):
+			return "Infinity", true, nil
+		case math.IsInf(f, -1):
+			return "-Infinity", true, nil
+		case opts.FloatPrecision >= 0:
+			return strconv.FormatFloat(f, 'f', opts.FloatPrecision, v.Type().Bits()), true, nil
+		}
+		return strconv.FormatFloat(f, 'g', -1, v.Type().Bits()), true, nil
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return "", false, nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), true, nil
+		}
+	case reflect.Array, reflect.Struct:
+	default:
+		return "", false, fmt.Errorf("cannot write %s as a CSV cell", v.Type())
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+// __dgi_csvWriter writes the records of a model as rows of delimited cells,
+// after a row of column names. Cells holding the delimiter, quotes or line
+// breaks are quoted, as are values reading as the null token, so that nil
+// and empty values stay apart.
+type __dgi_csvWriter struct {
+	name          string
+	opts          __dgi_CSVOptions
+	delimiter     rune
+	file          *__dgi_OutputFile
+	writer        *bufio.Writer
+	headerWritten bool
+	count         int
+}
+
+// __dgi_newCSVWriterFactory returns a factory of CSV writers, whose cells
+// follow opts.
+func __dgi_newCSVWriterFactory(opts __dgi_CSVOptions) __dgi_OutputWriterFactory {
+	return func(name string, create __dgi_OutputFileFactory) (__dgi_OutputWriter, error) {
+		csvFile, err := create(__dgi_FormatCSV, false)
+		if err != nil {
+			return nil, fmt.Errorf("error creating CSV file for %s: %v", name, err)
+		}
+		return &__dgi_csvWriter{
+			name:      name,
+			opts:      opts,
+			delimiter: opts.delimiter(),
+			file:      csvFile,
+			writer:    bufio.NewWriter(csvFile),
+		}, nil
+	}
+}
+
+// writeCell writes a cell, quoting it when it cannot be read back as is.
+func (w *__dgi_csvWriter) writeCell(s string, null bool) {
+	if null {
+		w.writer.WriteString(w.opts.Null)
+		return
+	}
+	if s != w.opts.Null && !strings.ContainsAny(s, "\"\r\n") && !strings.ContainsRune(s, w.delimiter) {
+		w.writer.WriteString(s)
+		return
+	}
+	w.writer.WriteByte('"')
+	w.writer.WriteString(strings.ReplaceAll(s, `"`, `""`))
+	w.writer.WriteByte('"')
+}
+
+func (w *__dgi_csvWriter) Write(records []__dgi_Record) error {
+	for _, record := range records {
+		if w.opts.Header && !w.headerWritten {
+			for i, column := range record.CSVHeaders() {
+				if i > 0 {
+					w.writer.WriteRune(w.delimiter)
+				}
+				w.writeCell(column, false)
+			}
+			if err := w.writer.WriteByte('\n'); err != nil {
+				return fmt.Errorf("error writing CSV headers for %s: %w", w.name, err)
+			}
+		}
+		w.headerWritten = true
+
+		for i, value := range record.ToCSV() {
+			cell, ok, err := __dgi_csvCell(w.opts, reflect.ValueOf(value))
+			if err != nil {
+				return fmt.Errorf("error writing CSV row for %s: %w", w.name, err)
+			}
+			if i > 0 {
+				w.writer.WriteRune(w.delimiter)
+			}
+			w.writeCell(cell, !ok)
+		}
+		if err := w.writer.WriteByte('\n'); err != nil {
+			return fmt.Errorf("error writing CSV row for %s: %w", w.name, err)
+		}
+	}
+	w.count += len(records)
+	return nil
+}
+
+func (w *__dgi_csvWriter) Close() error {
+	defer w.file.Discard()
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("error flushing CSV file for %s: %w", w.name, err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("error closing CSV file for %s: %w", w.name, err)
+	}
+	slog.Info(fmt.Sprintf("generated CSV file %s with %d records", w.file.Name(), w.count))
+	return nil
+}
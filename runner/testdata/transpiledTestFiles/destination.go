@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// __dgi_Destination stores the output files of a run, under the location
+// given with --output. Files only show up at their path once committed, so
+// that failed runs leave no partial files behind.
+type __dgi_Destination interface {
+	// Resolve returns the path of the file of the model name with the
+	// extension ext.
+	Resolve(name, ext string) (string, error)
+	// Create starts writing the file at path.
+	Create(path string) (__dgi_Upload, error)
+}
+
+// __dgi_Upload is a file being written to a destination.
+type __dgi_Upload interface {
+	io.Writer
+	// Commit makes the file show up at its path, replacing any file there.
+	Commit() error
+	// Abort drops what was written. It does nothing once committed.
+	Abort() error
+}
+
+// __dgi_newDestination returns the destination of the --output location: a
+// local path, a file:// URI, or an s3://bucket/prefix URI.
+func __dgi_newDestination(outPath string) (__dgi_Destination, error) {
+	scheme, rest, ok := strings.Cut(outPath, "://")
+	if !ok {
+		return __dgi_localDestination{root: outPath}, nil
+	}
+	switch strings.ToLower(scheme) {
+	case "file":
+		return __dgi_localDestination{root: rest}, nil
+	case "s3":
+		return __dgi_newS3Destination(rest)
+	}
+	return nil, fmt.Errorf("unsupported output location %s, expected a path, file:// or s3://", outPath)
+}
+
+// __dgi_localDestination writes files to the local file system, each to a
+// hidden temporary file renamed to its path once committed.
+type __dgi_localDestination struct {
+	root string
+}
+
+func (d __dgi_localDestination) Resolve(name, ext string) (string, error) {
+	return __dgi_resolveOutputFilePath(d.root, name, ext)
+}
+
+func (d __dgi_localDestination) Create(path string) (__dgi_Upload, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating output directory: %v", err)
+	}
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("error creating output file: %v", err)
+	}
+	return &__dgi_localUpload{path: path, file: file}, nil
+}
+
+type __dgi_localUpload struct {
+	path string
+	file *os.File
+	done bool
+}
+
+func (u *__dgi_localUpload) Write(p []byte) (int, error) {
+	return u.file.Write(p)
+}
+
+func (u *__dgi_localUpload) Commit() error {
+	if u.done {
+		return nil
+	}
+	u.done = true
+	if err := u.file.Chmod(0644); err != nil {
+		u.file.Close()
+		os.Remove(u.file.Name())
+		return err
+	}
+	if err := u.file.Close(); err != nil {
+		os.Remove(u.file.Name())
+		return err
+	}
+	if err := os.Rename(u.file.Name(), u.path); err != nil {
+		os.Remove(u.file.Name())
+		return err
+	}
+	return nil
+}
+
+func (u *__dgi_localUpload) Abort() error {
+	if u.done {
+		return nil
+	}
+	u.done = true
+	u.file.Close()
+	return os.Remove(u.file.Name())
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// __dgi_esClient calls the REST API of an Elasticsearch or OpenSearch
+// cluster.
+type __dgi_esClient struct {
+	config *__dgi_ElasticsearchConfig
+	base   string
+	http   *http.Client
+}
+
+// __dgi_esError is an error response of the cluster.
+type __dgi_esError struct {
+	Status int
+	Type   string
+	Reason string
+}
+
+func (e *__dgi_esError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("status %d: %s", e.Status, e.Reason)
+	}
+	return fmt.Sprintf("status %d: %s: %s", e.Status, e.Type, e.Reason)
+}
+
+// __dgi_newESClient returns a client of the cluster of config, once it
+// answers.
+func __dgi_newESClient(config *__dgi_ElasticsearchConfig) (*__dgi_esClient, error) {
+	timeout := 30 * time.Second
+	if d, err := time.ParseDuration(config.Timeout); err == nil && d > 0 {
+		timeout = d
+	}
+	c := &__dgi_esClient{config: config, base: strings.TrimSuffix(config.URL, "/"), http: &http.Client{Timeout: timeout}}
+	if _, err := c.do(http.MethodGet, "/", nil); err != nil {
+		return nil, fmt.Errorf("ping cluster: %w", err)
+	}
+	return c, nil
+}
+
+// do sends a request with a JSON or NDJSON body and returns the body of the
+// response, or an __dgi_esError when it is not successful.
+func (c *__dgi_esClient) do(method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, c.base+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		contentType := "application/json"
+		if strings.HasPrefix(path, "/_bulk") {
+			contentType = "application/x-ndjson"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	switch {
+	case c.config.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+c.config.APIKey)
+	case c.config.Username != "":
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, __dgi_esResponseError(resp.StatusCode, data)
+	}
+	return data, nil
+}
+
+// __dgi_esResponseError reads the error document of a response, whose error
+// is an object with a type and reason, or a string.
+func __dgi_esResponseError(status int, data []byte) error {
+	var doc struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(data, &doc); err == nil && len(doc.Error) > 0 {
+		var cause struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal(doc.Error, &cause); err == nil {
+			return &__dgi_esError{Status: status, Type: cause.Type, Reason: cause.Reason}
+		}
+		var reason string
+		if err := json.Unmarshal(doc.Error, &reason); err == nil {
+			return &__dgi_esError{Status: status, Reason: reason}
+		}
+	}
+	return &__dgi_esError{Status: status, Reason: strings.TrimSpace(string(data))}
+}
+
+// bulk sends the NDJSON body of a _bulk request, failing when any of its
+// actions fails.
+func (c *__dgi_esClient) bulk(body []byte) error {
+	path := "/_bulk"
+	if c.config.Refresh != "" {
+		path += "?refresh=" + url.QueryEscape(c.config.Refresh)
+	}
+	data, err := c.do(http.MethodPost, path, body)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("reading bulk response: %w", err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+	failed := 0
+	var first error
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error == nil {
+				continue
+			}
+			failed++
+			if first == nil {
+				first = &__dgi_esError{Status: result.Status, Type: result.Error.Type, Reason: result.Error.Reason}
+			}
+		}
+	}
+	return fmt.Errorf("%d of %d documents failed, first: %w", failed, len(resp.Items), first)
+}
+
+// createIndex creates index with the given settings and mappings, unless it
+// already exists.
+func (c *__dgi_esClient) createIndex(index, body string) error {
+	_, err := c.do(http.MethodPut, "/"+url.PathEscape(index), []byte(body))
+	if e, ok := err.(*__dgi_esError); ok && e.Type == "resource_already_exists_exception" {
+		return nil
+	}
+	return err
+}
+
+// deleteIndex deletes index, if it exists.
+func (c *__dgi_esClient) deleteIndex(index string) error {
+	_, err := c.do(http.MethodDelete, "/"+url.PathEscape(index), nil)
+	if e, ok := err.(*__dgi_esError); ok && e.Status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// deleteDocuments deletes every document of index, if it exists, keeping
+// its mapping.
+func (c *__dgi_esClient) deleteDocuments(index string) error {
+	_, err := c.do(http.MethodPost, "/"+url.PathEscape(index)+"/_delete_by_query?refresh=true&conflicts=proceed", []byte(`{"query":{"match_all":{}}}`))
+	if e, ok := err.(*__dgi_esError); ok && e.Status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// close releases the connections of the client.
+func (c *__dgi_esClient) close() {
+	c.http.CloseIdleConnections()
+}
+
+// __dgi_esBulkAction returns the action line indexing a document into index,
+// with id as its _id unless it is empty.
+func __dgi_esBulkAction(index, id string) ([]byte, error) {
+	type meta struct {
+		Index string `json:"_index"`
+		ID    string `json:"_id,omitempty"`
+	}
+	return json.Marshal(map[string]meta{"index": {Index: index, ID: id}})
+}
+
+// __dgi_esID formats the value of the id field of a document.
+func __dgi_esID(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			break
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || ((rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil()) {
+		return "", fmt.Errorf("id is null")
+	}
+	if t, ok := rv.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339Nano), nil
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct, reflect.Array:
+		data, err := json.Marshal(rv.Interface())
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return fmt.Sprint(rv.Interface()), nil
+}
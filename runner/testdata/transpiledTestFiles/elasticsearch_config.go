@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+type __dgi_ElasticsearchConfig struct {
+	// URL is the address of the cluster, such as http://localhost:9200.
+	URL            string `json:"url"`
+	Username       string `json:"username,omitempty"`
+	Password       string `json:"password,omitempty"`
+	APIKey         string `json:"api_key,omitempty"`
+	// Index is the index every model of the sink is loaded into, the name of
+	// the model in lower case by default.
+	Index          string `json:"index,omitempty"`
+	// IDField is the field whose value is the _id of documents, which the
+	// cluster generates when it is not set.
+	IDField        string `json:"id_field,omitempty"`
+	// Refresh is the refresh parameter of bulk requests: true, false or
+	// wait_for.
+	Refresh        string `json:"refresh,omitempty"`
+	// CreateIndex makes clear_data delete the index and create it again with
+	// the mapping of the model, rather than delete its documents.
+	CreateIndex    bool   `json:"create_index,omitempty"`
+	BatchSize      int    `json:"batch_size,omitempty"`
+	Timeout        string `json:"timeout,omitempty"`
+	Throttle       string `json:"throttle,omitempty"`
+}
+
+func (c *__dgi_ElasticsearchConfig) Validate() error {
+	if c.URL == "" {
+		return errors.New("elasticsearch: url is required")
+	}
+	if u, err := url.Parse(c.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("elasticsearch: url must be an http or https URL, got %q", c.URL)
+	}
+	switch c.Refresh {
+	case "", "true", "false", "wait_for":
+	default:
+		return fmt.Errorf("elasticsearch: unsupported refresh %q (expected \"true\", \"false\" or \"wait_for\")", c.Refresh)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// layouts of the json format
+const (
+	__dgi_JSONModeLines  = "jsonl"
+	__dgi_JSONModeArray  = "array"
+	__dgi_JSONModePretty = "pretty"
+)
+
+// __dgi_JSONOptions holds the flags of the json format.
+type __dgi_JSONOptions struct {
+	Mode string
+}
+
+func (o __dgi_JSONOptions) validate() error {
+	switch o.Mode {
+	case __dgi_JSONModeLines, __dgi_JSONModeArray, __dgi_JSONModePretty:
+		return nil
+	}
+	return fmt.Errorf("--json-mode must be one of %s", strings.Join([]string{__dgi_JSONModeLines, __dgi_JSONModeArray, __dgi_JSONModePretty}, ", "))
+}
+
+// __dgi_JSONField is a value written under Key in a JSON object.
+type __dgi_JSONField struct {
+	Key   string
+	Value any
+}
+
+// __dgi_JSONEmbed nests the records of Model whose ChildValue equals Value
+// under Key in the JSON document of a record.
+type __dgi_JSONEmbed struct {
+	Key        string
+	Model      string
+	Value      any
+	ChildValue func(r __dgi_Record) any
+}
+
+// __dgi_JSONChildren returns every record of a model, for the models nested
+// in the JSON documents of another.
+type __dgi_JSONChildren func(model string) ([]__dgi_Record, error)
+
+// __dgi_marshalJSONObject marshals fields as a JSON object, keeping their
+// order.
+func __dgi_marshalJSONObject(fields []__dgi_JSONField) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling %s: %w", field.Key, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// __dgi_jsonWriter writes the records of a model as JSON Lines, or as one
+// JSON array that is indented in the pretty mode. The records of embedded
+// models are generated when the first record comes in, and kept in memory.
+type __dgi_jsonWriter struct {
+	name     string
+	opts     __dgi_JSONOptions
+	file     *__dgi_OutputFile
+	writer   *bufio.Writer
+	children __dgi_JSONChildren
+	// embedded maps the key of every embedded model to the documents of its
+	// records, by the value they reference
+	embedded map[string]map[any][]string
+	count    int
+}
+
+// __dgi_newJSONWriterFactory returns a factory of JSON writers, which get
+// the records of embedded models from children.
+func __dgi_newJSONWriterFactory(opts __dgi_JSONOptions, children __dgi_JSONChildren) __dgi_OutputWriterFactory {
+	return func(name string, create __dgi_OutputFileFactory) (__dgi_OutputWriter, error) {
+		jsonFile, err := create(__dgi_FormatJSON, false)
+		if err != nil {
+			return nil, fmt.Errorf("error creating JSON file for %s: %v", name, err)
+		}
+		return &__dgi_jsonWriter{
+			name:     name,
+			opts:     opts,
+			file:     jsonFile,
+			writer:   bufio.NewWriter(jsonFile),
+			children: children,
+		}, nil
+	}
+}
+
+// embed indexes the records of the models embedded by record.
+func (w *__dgi_jsonWriter) embed(record __dgi_Record) error {
+	w.embedded = map[string]map[any][]string{}
+	for _, e := range record.JSONEmbeds() {
+		children, err := w.children(e.Model)
+		if err != nil {
+			return fmt.Errorf("error generating %s to embed in %s: %w", e.Model, w.name, err)
+		}
+		index := map[any][]string{}
+		for _, child := range children {
+			value := e.ChildValue(child)
+			index[value] = append(index[value], child.ToJSON())
+		}
+		w.embedded[e.Key] = index
+		slog.Debug(fmt.Sprintf("embedding %d records of %s in %s", len(children), e.Model, w.name))
+	}
+	return nil
+}
+
+// document returns the JSON document of record, with the records of the
+// embedded models referencing it.
+func (w *__dgi_jsonWriter) document(record __dgi_Record) string {
+	doc := record.ToJSON()
+	embeds := record.JSONEmbeds()
+	if len(embeds) == 0 || doc == "" {
+		return doc
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(doc, "}"))
+	for _, e := range embeds {
+		key, _ := json.Marshal(e.Key)
+		b.WriteByte(',')
+		b.Write(key)
+		b.WriteString(":[")
+		b.WriteString(strings.Join(w.embedded[e.Key][e.Value], ","))
+		b.WriteByte(']')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func (w *__dgi_jsonWriter) Write(records []__dgi_Record) error {
+	if len(records) > 0 && w.embedded == nil {
+		if err := w.embed(records[0]); err != nil {
+			return err
+		}
+	}
+
+	for _, record := range records {
+		doc := w.document(record)
+		var err error
+		switch w.opts.Mode {
+		case __dgi_JSONModeLines:
+			_, err = fmt.Fprintln(w.writer, doc)
+		default:
+			sep := ",\n  "
+			if w.count == 0 {
+				sep = "[\n  "
+			}
+			if w.opts.Mode == __dgi_JSONModePretty {
+				var indented bytes.Buffer
+				if err := json.Indent(&indented, []byte(doc), "  ", "  "); err == nil {
+					doc = indented.String()
+				}
+			}
+			_, err = w.writer.WriteString(sep + doc)
+		}
+		if err != nil {
+			return fmt.Errorf("error writing JSON row for %s: %w", w.name, err)
+		}
+		w.count++
+	}
+	return nil
+}
+
+func (w *__dgi_jsonWriter) Close() error {
+	defer w.file.Discard()
+	if w.opts.Mode != __dgi_JSONModeLines {
+		end := "\n]\n"
+		if w.count == 0 {
+			end = "[]\n"
+		}
+		if _, err := w.writer.WriteString(end); err != nil {
+			return fmt.Errorf("error writing JSON file for %s: %w", w.name, err)
+		}
+	}
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("error flushing JSON file for %s: %w", w.name, err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("error closing JSON file for %s: %w", w.name, err)
+	}
+	slog.Info(fmt.Sprintf("generated JSON file %s with %d records", w.file.Name(), w.count))
+	return nil
+}
@@ -2,22 +2,38 @@ package main
 
 import (
 	"errors"
+	"fmt"
+)
+
+const (
+	__dgi_KafkaSerializerString = "string"
+	__dgi_KafkaSerializerJSON   = "json"
 )
 
 type __dgi_KafkaConfig struct {
-	Topic               string   `json:"topic"`
-	Key                 string   `json:"key,omitempty"`
-	IncludeKeyInMessage string   `json:"include_key_in_message,omitempty"`
-	BootstrapServers    []string `json:"bootstrap_servers"`
-	KeySerializer       string   `json:"key_serializer"`
-	ValueSerializer     string   `json:"value_serializer"`
-	BatchSize           int      `json:"batch_size,omitempty"`
-	Throttle          int      `json:"throttle,omitempty"`
+	Topic            string   `json:"topic"`
+	Key              string   `json:"key,omitempty"`
+	BootstrapServers []string `json:"bootstrap_servers"`
+	KeySerializer    string   `json:"key_serializer"`
+	ValueSerializer  string   `json:"value_serializer"`
+	BatchSize        int      `json:"batch_size,omitempty"`
+	Timeout          string   `json:"timeout,omitempty"`
+	Throttle         string   `json:"throttle,omitempty"`
 }
 
 func (c *__dgi_KafkaConfig) Validate() error {
 	if c.Topic == "" || len(c.BootstrapServers) == 0 {
 		return errors.New("kafka: topic and bootstrap_servers are required")
 	}
+	switch c.KeySerializer {
+	case "", __dgi_KafkaSerializerString, __dgi_KafkaSerializerJSON:
+	default:
+		return fmt.Errorf("kafka: unsupported key_serializer %q (expected %q or %q)", c.KeySerializer, __dgi_KafkaSerializerString, __dgi_KafkaSerializerJSON)
+	}
+	switch c.ValueSerializer {
+	case "", __dgi_KafkaSerializerJSON:
+	default:
+		return fmt.Errorf("kafka: unsupported value_serializer %q (expected %q)", c.ValueSerializer, __dgi_KafkaSerializerJSON)
+	}
 	return nil
 }
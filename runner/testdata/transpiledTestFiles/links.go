@@ -9,10 +9,12 @@ import (
 	"sort"
 )
 
+// __dgi_Links records which models reference which other models. It is
+// shared by all workers; the model a worker is generating is tracked on the
+// worker's own __dgi_DataGenGenerators.
 type __dgi_Links struct {
-	mu       sync.Mutex
-	data     map[string]map[string]struct{}
-	curModel string
+	mu   sync.RWMutex
+	data map[string]map[string]struct{}
 }
 
 type __dgi_Stack struct {
@@ -55,100 +57,63 @@ func (l *__dgi_Links) TopologicalSort() ([]string, error) {
 
 slog.Debug(fmt.Sprintf("performing topological sort for %d models", len(allModels)))
 	finalStack := &__dgi_Stack{}
-	curStack := &__dgi_Stack{}
 	visited := map[string]struct{}{}
 	for _, model := range allModels {
 		if _, ok := visited[model]; ok {
 			continue
 		}
-
-		curStack.Push(model)
-		if err := l.dfs(curStack, visited); err != nil {
-		   return nil, fmt.Errorf("topological sort failed: %w", err)
-		}
-
-		for !curStack.IsEmpty() {
-		    elem, err := curStack.Pop()
-		    if err != nil {
-		       return nil, fmt.Errorf("popping from stack: %w", err)
-		    }
-
-		    finalStack.Push(elem)
-		}
+		l.dfs(model, visited, finalStack)
 	}
 
 slog.Debug(fmt.Sprintf("topological sort completed: %v", finalStack.data))
     return finalStack.data, nil
 }
 
-func (l *__dgi_Links) dfs(curStack *__dgi_Stack, visited map[string]struct{}) error {
-	if curStack.IsEmpty() {
-		return nil
-	}
+// dfs pushes every model reachable from model onto finalStack after the models
+// it depends on, visiting dependencies in sorted order to keep runs reproducible
+func (l *__dgi_Links) dfs(model string, visited map[string]struct{}, finalStack *__dgi_Stack) {
+	visited[model] = struct{}{}
 
-	elem, err := curStack.Peek()
-	if err != nil {
-	   return fmt.Errorf("error while creating links: %w", err)
+	deps := make([]string, 0, len(l.data[model]))
+	for key := range l.data[model] {
+		deps = append(deps, key)
 	}
+	sort.Strings(deps)
 
-	visited[elem] = struct{}{}
-	for key, _ := range l.data[elem] {
+	for _, key := range deps {
 		if _, ok := visited[key]; ok {
 			continue
 		}
-		curStack.Push(key)
-		if err := l.dfs(curStack, visited); err != nil {
-		   return err
-		}
+		l.dfs(key, visited, finalStack)
 	}
-	return nil
+	finalStack.Push(model)
 }
 
-func (l *__dgi_Links) StartGen(model string) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-slog.Debug(fmt.Sprintf("starting generation tracking for %s", model))
-	l.curModel = model
-	if _, ok := l.data[l.curModel]; !ok {
-		l.data[l.curModel] = map[string]struct{}{}
+func (l *__dgi_Links) AcceptSignal(from, to string) {
+	l.mu.RLock()
+	_, ok := l.data[from][to]
+	l.mu.RUnlock()
+	if ok {
+		return
 	}
-}
 
-func (l *__dgi_Links) EndGen(model string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-slog.Debug(fmt.Sprintf("ending generation tracking for %s", model))
-	l.curModel = ""
-}
 
-func (l *__dgi_Links) AcceptSignal(model string) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-slog.Debug(fmt.Sprintf("recording dependency from %s to %s", l.curModel, model))
-	if _, ok := l.data[l.curModel]; !ok {
-		l.data[l.curModel] = map[string]struct{}{}
+slog.Debug(fmt.Sprintf("recording dependency from %s to %s", from, to))
+	if _, ok := l.data[from]; !ok {
+		l.data[from] = map[string]struct{}{}
 	}
-
-	v := l.data[l.curModel]
-	v[model] = struct{}{}
+	l.data[from][to] = struct{}{}
 }
 
 func (l *__dgi_Links) PrettyPrint() {
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 
 	var sb strings.Builder
 	sb.WriteString("Links {\n")
 
-	// Print current model
-	sb.WriteString("  curModel: ")
-	if l.curModel != "" {
-		sb.WriteString(fmt.Sprintf("%q\n", l.curModel))
-	} else {
-		sb.WriteString("nil\n")
-	}
-
 	// Print data map
 	sb.WriteString("  data: {\n")
 	for src, dstMap := range l.data {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -40,6 +41,15 @@ func main() {
 		flagFormat string
 		flagSeed   int64
 		flagConfig string
+
+		flagRowGroupSize int
+		sqlOpts          __dgi_SQLOptions
+		jsonOpts         __dgi_JSONOptions
+		xmlOpts          __dgi_XMLOptions
+		csvOpts          __dgi_CSVOptions
+		outOpts          __dgi_OutputOptions
+
+		runOpts __dgi_RunOptions
 	)
 
 	genCmd := &cobra.Command{
@@ -47,7 +57,7 @@ func main() {
 		Short: "Generate data for models",
 		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return __dgi_runGenCommand(flagCount, flagTags, flagOutput, flagFormat, flagSeed)
+			return __dgi_runGenCommand(flagCount, flagTags, flagOutput, flagFormat, flagSeed, flagRowGroupSize, sqlOpts, jsonOpts, xmlOpts, csvOpts, outOpts, runOpts)
 		},
 	}
 
@@ -56,19 +66,41 @@ func main() {
 		Short: "Load data to relevant sinks",
 		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return __dgi_runExecuteCommand(flagConfig, flagOutput)
+			return __dgi_runExecuteCommand(flagConfig, flagOutput, runOpts)
 		},
 	}
 
 	genCmd.Flags().IntVarP(&flagCount, "count", "n", -1, "number of records to generate for all the models")
 	genCmd.Flags().StringVarP(&flagTags, "tags", "t", "", "comma-separated key=value tags to filter models")
 	genCmd.Flags().StringVarP(&flagOutput, "output", "o", ".", "output directory or file path")
-	genCmd.Flags().StringVarP(&flagFormat, "format", "f", "", strings.Join([]string{__dgi_FormatCSV, __dgi_FormatJSON, __dgi_FormatXML, __dgi_FormatStdout}, "|"))
+	genCmd.Flags().StringVarP(&flagFormat, "format", "f", "", strings.Join([]string{__dgi_FormatCSV, __dgi_FormatJSON, __dgi_FormatXML, __dgi_FormatParquet, __dgi_FormatAvro, __dgi_FormatProtobuf, __dgi_FormatSQL, __dgi_FormatStdout}, "|"))
 	genCmd.Flags().Int64VarP(&flagSeed, "seed", "s", 0, "deterministic seed for random data generation (0=non-deterministic)")
+	genCmd.Flags().IntVar(&flagRowGroupSize, "row-group-size", 100000, "number of records per Parquet row group (0=one row group per file)")
+	genCmd.Flags().StringVar(&sqlOpts.Dialect, "dialect", __dgi_DialectMySQL, strings.Join([]string{__dgi_DialectMySQL, __dgi_DialectPostgres, __dgi_DialectSQLite}, "|"))
+	genCmd.Flags().IntVar(&sqlOpts.BatchSize, "batch-size", 1000, "number of rows per INSERT statement of the sql format")
+	genCmd.Flags().BoolVar(&sqlOpts.CreateTable, "create-table", false, "start the SQL of each model with its CREATE TABLE statement")
+	genCmd.Flags().BoolVar(&sqlOpts.Truncate, "truncate", false, "empty the table of each model before the SQL inserts its records")
+	genCmd.Flags().StringVar(&jsonOpts.Mode, "json-mode", __dgi_JSONModeLines, "layout of the json format: "+strings.Join([]string{__dgi_JSONModeLines, __dgi_JSONModeArray, __dgi_JSONModePretty}, "|"))
+	genCmd.Flags().StringVar(&xmlOpts.Root, "xml-root", "records", "root element of the documents of the xml format")
+	genCmd.Flags().StringVar(&xmlOpts.Namespace, "xml-namespace", "", "default namespace of the documents of the xml format")
+	genCmd.Flags().StringVar(&csvOpts.Delimiter, "csv-delimiter", ",", "cell delimiter of the csv format, a single character or \\t for tabs")
+	genCmd.Flags().BoolVar(&csvOpts.Header, "csv-header", true, "start the files of the csv format with a row of column names")
+	genCmd.Flags().StringVar(&csvOpts.Null, "csv-null", "", "text of nil values in the csv format")
+	genCmd.Flags().StringVar(&csvOpts.TimeLayout, "csv-time-layout", time.RFC3339Nano, "Go layout of times in the csv format")
+	genCmd.Flags().IntVar(&csvOpts.FloatPrecision, "csv-float-precision", -1, "number of decimals of floats in the csv format (-1=shortest exact)")
+	genCmd.Flags().StringVar(&outOpts.Compress, "compress", __dgi_CompressNone, "compression of the output files: "+strings.Join([]string{__dgi_CompressNone, __dgi_CompressGzip, __dgi_CompressZstd}, "|"))
+	genCmd.Flags().IntVar(&outOpts.SplitRows, "split-rows", 0, "maximum number of records per part file, with a manifest of the parts (0=no split)")
+	genCmd.Flags().StringVar(&outOpts.SplitSize, "split-size", "", "size past which part files are closed, such as 512MB, with a manifest of the parts")
 
 	executeCmd.Flags().StringVarP(&flagConfig, "config", "c", "config.json", "path to config file")
 	executeCmd.Flags().StringVarP(&flagOutput, "output", "o", ".", "output directory or file path")
 
+	for _, cmd := range []*cobra.Command{genCmd, executeCmd} {
+		cmd.Flags().IntVar(&runOpts.ChunkSize, "chunk-size", 10000, "number of records generated and written per chunk (0=buffer all records of a model)")
+		cmd.Flags().IntVar(&runOpts.MemoWindow, "memo-window", 0, "number of values kept for fields referenced by other fields (0=keep all)")
+		cmd.Flags().IntVar(&runOpts.Parallelism, "parallelism", 1, "number of workers generating records concurrently (0=one per CPU)")
+	}
+
 	rootCmd.AddCommand(genCmd)
 	rootCmd.AddCommand(executeCmd)
 
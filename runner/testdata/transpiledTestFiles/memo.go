@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+)
+
+// __dgi_memoSlack is how many values a bounded memo may hold beyond its
+// window before the oldest ones are released.
+const __dgi_memoSlack = 1024
+
+// __dgi_memoCurrentRow is the window of fields that are only read for the row
+// being generated; their memo holds a single value.
+const __dgi_memoCurrentRow = -1
+
+// __dgi_Memo caches the values generated for a single field over a run of
+// consecutive iters. A window of 0 keeps the whole run, otherwise only its
+// last window values stay readable.
+//
+// The values of a pure field only depend on their iter, so one that is not
+// memoized is generated on its own, and a value further ahead, such as the
+// first of a shard, starts a new run. Other fields may draw from streams
+// whose values depend on the order they are drawn in, so their values are
+// generated in iter order and one that has left the window cannot be read
+// anymore.
+type __dgi_Memo[T any] struct {
+	model  string
+	field  string
+	window int
+	pure   bool
+	base   int
+	vals   []T
+}
+
+func __dgi_NewMemo[T any](model, field string, window int, pure bool) *__dgi_Memo[T] {
+	return &__dgi_Memo[T]{model: model, field: field, window: window, pure: pure}
+}
+
+// Get returns the value for iter, generating it when it is not memoized.
+// Reading a value of a field that is not pure after it has left the window
+// panics with a *__dgi_MemoEvictedError, which __dgi_generateShard turns into
+// an error.
+func (m *__dgi_Memo[T]) Get(iter int, gen func(i int) T) T {
+	if m.window == __dgi_memoCurrentRow {
+		if len(m.vals) == 0 || iter != m.base {
+			m.vals = append(m.vals[:0], gen(iter))
+			m.base = iter
+		}
+		return m.vals[0]
+	}
+
+	next := m.base + len(m.vals)
+	if iter >= m.base && iter < next {
+		return m.vals[iter-m.base]
+	}
+
+	if m.pure && iter != next {
+		val := gen(iter)
+		if iter > next || len(m.vals) == 0 {
+			m.vals = append(m.vals[:0], val)
+			m.base = iter
+		}
+		return val
+	}
+
+	if iter < m.base {
+		panic(&__dgi_MemoEvictedError{Model: m.model, Field: m.field, Iter: iter, Window: m.window, Oldest: m.base})
+	}
+
+	// gen may read values of the field itself, filling part of the run
+	for next <= iter {
+		val := gen(next)
+		if next == m.base+len(m.vals) {
+			m.vals = append(m.vals, val)
+		}
+		if m.window > 0 && len(m.vals) >= m.window+__dgi_memoSlack {
+			drop := len(m.vals) - m.window
+			m.vals = append(make([]T, 0, m.window+__dgi_memoSlack), m.vals[drop:]...)
+			m.base += drop
+		}
+		next = m.base + len(m.vals)
+	}
+	return m.vals[iter-m.base]
+}
+
+type __dgi_MemoEvictedError struct {
+	Model  string
+	Field  string
+	Iter   int
+	Window int
+	Oldest int
+}
+
+func (e *__dgi_MemoEvictedError) Error() string {
+	return fmt.Sprintf("%s.%s(%d) is no longer memoized: the memo window of %d only keeps values from iter %d onwards, increase --memo-window or set it to 0 to keep every value",
+		e.Model, e.Field, e.Iter, e.Window, e.Oldest)
+}
@@ -386,25 +386,19 @@ type __datagen_minimalGenerator struct {
 }
 
 type __datagen_minimalDataHolder struct {
-	id []int
+	id *__dgi_Memo[int]
 }
 
 func (cg *__datagen_minimalGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_minimalGenerator) __gen_id(iter int) int {
+func (self *__datagen_minimalGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -414,16 +408,18 @@ func (cg *__datagen_minimalGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_minimalGenerator() *__datagen_minimalGenerator {
-	all := &__datagen_minimalDataHolder{}
+func __init___datagen_minimalGenerator(memoWindow int) *__datagen_minimalGenerator {
+	all := &__datagen_minimalDataHolder{
+		id: __dgi_NewMemo[int]("minimal", "id", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_minimalGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	return cg
 }
 
-func (e *__datagen_minimal) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
+func (e *__datagen_minimal) ToCSV() []any {
+	return []any{
+		e.id,
 	}
 }
 
@@ -434,8 +430,8 @@ func (e *__datagen_minimal) CSVHeaders() []string {
 }
 
 func (e *__datagen_minimal) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id": e.id,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -444,20 +440,69 @@ func (e *__datagen_minimal) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_minimal) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName xml.Name `xml:"minimal"`
-		Xml_id  int      `xml:"id"`
+func (e *__datagen_minimal) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_minimal) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("minimal", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+	})
+}
+
+func (e *__datagen_minimal) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id int `parquet:"id"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id: e.id,
+	return &__dgi_parquetRow{
+		Parquet_id: e.id,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_minimal) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id)
+}
+
+func (e *__datagen_minimal) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"minimal\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_minimal) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id)
+}
+
+func (e *__datagen_minimal) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage minimal {\n  int64 id = 1;\n}\n", nil
+}
+
+func (e *__datagen_minimal) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `minimal` (`id`) VALUES",
+			Truncate:         "DELETE FROM `minimal`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `minimal` (\n  `id` BIGINT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"minimal\" (\"id\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"minimal\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"minimal\" (\n  \"id\" BIGINT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"minimal\" (\"id\") VALUES",
+			Truncate:         "DELETE FROM \"minimal\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"minimal\" (\n  \"id\" INTEGER NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_minimal) SQLValues() []any {
+	return []any{e.id}
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Load___datagen_minimal_clickhouse inserts a single batch of records in one JSONEachRow insert, using the provided connection.
+func Load___datagen_minimal_clickhouse(records []*__datagen_minimal, conn *__dgi_chClient) error {
+	if len(records) == 0 {
+		return nil
+	}
+	names := []string{
+		"id",
+	}
+	columns := []string{
+		"`id`",
+	}
+	if err := conn.insert("`minimal`", names, columns, Rows___datagen_minimal_clickhouse(records)); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_minimal_clickhouse returns the values of the columns of records, in order.
+func Rows___datagen_minimal_clickhouse(records []*__datagen_minimal) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+		})
+	}
+	return rows
+}
+
+// Truncate___datagen_minimal_clickhouse truncates the model's table using the provided connection.
+func Truncate___datagen_minimal_clickhouse(conn *__dgi_chClient) error {
+	if err := conn.exec("TRUNCATE TABLE `minimal`", nil); err != nil {
+		return fmt.Errorf("truncate failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_minimal_clickhouse_table creates the model's table unless it already exists.
+func Create___datagen_minimal_clickhouse_table(conn *__dgi_chClient) error {
+	if err := conn.exec("CREATE TABLE IF NOT EXISTS `minimal` (\n  `id` Int64\n) ENGINE = MergeTree\nORDER BY tuple()", nil); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
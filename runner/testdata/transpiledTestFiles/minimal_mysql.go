@@ -7,8 +7,8 @@ import (
 	"strings"
 )
 
-// Load___datagen_minimal_mysql executes a single batch of records using the provided transaction.
-func Load___datagen_minimal_mysql(records []*__datagen_minimal, tx *sql.Tx) error {
+// Load___datagen_minimal_mysql executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_minimal_mysql(records []*__datagen_minimal, tx *sql.Tx, stmt __dgi_writeStatement) error {
 	if len(records) == 0 {
 		return nil
 	}
@@ -16,12 +16,7 @@ func Load___datagen_minimal_mysql(records []*__datagen_minimal, tx *sql.Tx) erro
 	ctx := context.Background()
 
 	var b strings.Builder
-	columns := []string{
-		"`id`",
-	}
-	b.WriteString("INSERT INTO minimal (")
-	b.WriteString(strings.Join(columns, ","))
-	b.WriteString(") VALUES ")
+	b.WriteString(stmt.prefix)
 
 	placeholderGroup := "(" + strings.Repeat("?,", 1)
 	placeholderGroup = placeholderGroup[:len(placeholderGroup)-1] + ")"
@@ -31,12 +26,15 @@ func Load___datagen_minimal_mysql(records []*__datagen_minimal, tx *sql.Tx) erro
 		}
 		b.WriteString(placeholderGroup)
 	}
+	b.WriteString(stmt.suffix)
 	sqlStmt := b.String()
 
 	var args []interface{}
-	for _, record := range records {
-		args = append(args, record.id)
-
+	for _, row := range Rows___datagen_minimal_mysql(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
 	}
 
 	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
@@ -46,11 +44,60 @@ func Load___datagen_minimal_mysql(records []*__datagen_minimal, tx *sql.Tx) erro
 	return nil
 }
 
+// LoadData___datagen_minimal_mysql loads a single batch of records with LOAD DATA LOCAL INFILE, using the provided transaction.
+func LoadData___datagen_minimal_mysql(records []*__datagen_minimal, tx *sql.Tx, mode __dgi_WriteMode) error {
+	if len(records) == 0 {
+		return nil
+	}
+	columns := []string{
+		"`id`",
+	}
+	if err := __dgi_mysqlLoadData(context.Background(), tx, "`minimal`", columns, mode, Rows___datagen_minimal_mysql(records)); err != nil {
+		return fmt.Errorf("load data failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_minimal_mysql returns the values of the columns of records, in order.
+func Rows___datagen_minimal_mysql(records []*__datagen_minimal) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_minimal_mysql returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_minimal_mysql(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+	}
+	columns := []string{
+		"`id`",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectMySQL, "`minimal`", names, columns, keys, mode)
+}
+
 // Truncate___datagen_minimal_mysql() truncates the model's table using the shared connection.
 func Truncate___datagen_minimal_mysql(tx *sql.Tx) error {
 	ctx := context.Background()
-	if _, err := tx.ExecContext(ctx, "DELETE FROM minimal;"); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `minimal`;"); err != nil {
 		return fmt.Errorf("delete failed with error : %w", err)
 	}
 	return nil
 }
+
+// Create___datagen_minimal_mysql_table creates the model's table unless it already exists.
+func Create___datagen_minimal_mysql_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `minimal` (\n  `id` BIGINT NOT NULL\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
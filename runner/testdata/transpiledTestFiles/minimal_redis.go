@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Load___datagen_minimal_redis writes a single batch of records to their keys in one pipeline.
+func Load___datagen_minimal_redis(records []*__datagen_minimal, client *redis.Client, config *__dgi_RedisConfig, key *__dgi_redisKey) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	pipe := client.Pipeline()
+	for _, record := range records {
+		value := func(field string) (any, error) {
+			return Value___datagen_minimal_redis(record, field)
+		}
+		k, err := key.render(value)
+		if err != nil {
+			return fmt.Errorf("rendering key failed with error : %w", err)
+		}
+		err = __dgi_redisWrite(ctx, pipe, config, k, []__dgi_JSONField{
+			{Key: "id", Value: record.id},
+		}, value)
+		if err != nil {
+			return fmt.Errorf("writing key %s failed with error : %w", k, err)
+		}
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("pipeline failed with error : %w", err)
+	}
+	return nil
+}
+
+// Value___datagen_minimal_redis returns the value of a field of a record, read by key templates, scores and members.
+func Value___datagen_minimal_redis(record *__datagen_minimal, field string) (any, error) {
+	switch field {
+	case "id":
+		return record.id, nil
+	default:
+		return nil, fmt.Errorf("field %q does not exist in model minimal", field)
+	}
+}
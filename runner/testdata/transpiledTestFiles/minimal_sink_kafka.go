@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// __datagen_minimal_kafkaSink streams __datagen_minimal data to a Kafka topic
+type __datagen_minimal_kafkaSink struct {
+	modelName     string
+	config        *__dgi_KafkaConfig
+	client        *kgo.Client
+	total         int
+	totalProduced int
+}
+
+// Open_kafka___datagen_minimal_sink creates the Kafka client __datagen_minimal data is produced with, once the key
+// field the config names is known to exist
+func Open_kafka___datagen_minimal_sink(modelName string, total int, config *__dgi_KafkaConfig) (*__datagen_minimal_kafkaSink, error) {
+	if _, err := Key___datagen_minimal_kafka(&__datagen_minimal{}, config); err != nil {
+		return nil, fmt.Errorf("✘ [Kafka] %s: FAILED\n   └─ Messages produced: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("initializing Kafka client for %s with %d records", modelName, total))
+	client, err := Open___datagen_minimal_kafka_client(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [Kafka] %s: FAILED\n   └─ Messages produced: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("producing %s to topic %s with batch size %d", modelName, config.Topic, config.BatchSize))
+	return &__datagen_minimal_kafkaSink{modelName: modelName, config: config, client: client, total: total}, nil
+}
+
+// Load produces a chunk of __datagen_minimal records in batches of config.BatchSize
+func (s *__datagen_minimal_kafkaSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_minimal, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_minimal))
+	}
+
+	batchSize := s.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(records)
+	}
+
+	for i := 0; i < len(records); i += batchSize {
+		end := i + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[i:end]
+
+		slog.Debug(fmt.Sprintf("producing batch starting at %d of size %d for %s to Kafka", s.totalProduced, len(batch), s.modelName))
+		if err := Load___datagen_minimal_kafka(batch, s.client, s.config); err != nil {
+			return fmt.Errorf("✘ [Kafka] %s: FAILED\n   └─ Messages produced: %d/%d\n   └─ Error: %v\n",
+				s.modelName, s.totalProduced, s.total, err)
+		}
+
+		s.totalProduced += len(batch)
+
+		if s.config.Throttle != "" && s.totalProduced < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
+				time.Sleep(throttleDuration)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit closes the Kafka client; every message has already been acknowledged by ProduceSync
+func (s *__datagen_minimal_kafkaSink) Commit() error {
+	s.client.Close()
+	slog.Info(fmt.Sprintf("successfully produced %d/%d messages for %s to Kafka topic %s", s.totalProduced, s.total, s.modelName, s.config.Topic))
+	return nil
+}
+
+// Abort closes the Kafka client; messages that were already produced stay on the topic
+func (s *__datagen_minimal_kafkaSink) Abort() {
+	s.client.Close()
+}
@@ -1,15 +1,12 @@
 package main
 
-import (
-	"sync"
-)
-
 type __dgi_DataGenGenerators struct {
 	minimal                func() *__datagen_minimalGenerator
 	multiple_types         func() *__datagen_multiple_typesGenerator
 	nested                 func() *__datagen_nestedGenerator
 	simple                 func() *__datagen_simpleGenerator
 	with_builtin_functions func() *__datagen_with_builtin_functionsGenerator
+	with_columns           func() *__datagen_with_columnsGenerator
 	with_conditionals      func() *__datagen_with_conditionalsGenerator
 	with_maps              func() *__datagen_with_mapsGenerator
 	with_metadata          func() *__datagen_with_metadataGenerator
@@ -17,6 +14,8 @@ type __dgi_DataGenGenerators struct {
 	with_slices            func() *__datagen_with_slicesGenerator
 
 	__links *__dgi_Links
+	// __curModel is the model this set of generators is generating records for
+	__curModel string
 }
 
 type __dgi_Metadata struct {
@@ -26,76 +25,105 @@ type __dgi_Metadata struct {
 
 func minimalFunc(model *__datagen_minimalGenerator, tail string) func() *__datagen_minimalGenerator {
 	return func() *__datagen_minimalGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func multiple_typesFunc(model *__datagen_multiple_typesGenerator, tail string) func() *__datagen_multiple_typesGenerator {
 	return func() *__datagen_multiple_typesGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func nestedFunc(model *__datagen_nestedGenerator, tail string) func() *__datagen_nestedGenerator {
 	return func() *__datagen_nestedGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func simpleFunc(model *__datagen_simpleGenerator, tail string) func() *__datagen_simpleGenerator {
 	return func() *__datagen_simpleGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func with_builtin_functionsFunc(model *__datagen_with_builtin_functionsGenerator, tail string) func() *__datagen_with_builtin_functionsGenerator {
 	return func() *__datagen_with_builtin_functionsGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
+		return model
+	}
+}
+func with_columnsFunc(model *__datagen_with_columnsGenerator, tail string) func() *__datagen_with_columnsGenerator {
+	return func() *__datagen_with_columnsGenerator {
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func with_conditionalsFunc(model *__datagen_with_conditionalsGenerator, tail string) func() *__datagen_with_conditionalsGenerator {
 	return func() *__datagen_with_conditionalsGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func with_mapsFunc(model *__datagen_with_mapsGenerator, tail string) func() *__datagen_with_mapsGenerator {
 	return func() *__datagen_with_mapsGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func with_metadataFunc(model *__datagen_with_metadataGenerator, tail string) func() *__datagen_with_metadataGenerator {
 	return func() *__datagen_with_metadataGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func with_miscFunc(model *__datagen_with_miscGenerator, tail string) func() *__datagen_with_miscGenerator {
 	return func() *__datagen_with_miscGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 func with_slicesFunc(model *__datagen_with_slicesGenerator, tail string) func() *__datagen_with_slicesGenerator {
 	return func() *__datagen_with_slicesGenerator {
-		model.datagen.__links.AcceptSignal(tail)
+		model.datagen.__links.AcceptSignal(model.datagen.__curModel, tail)
 		return model
 	}
 }
 
-func __dgi_initGeneratorsAndModels() (*__dgi_DataGenGenerators, map[string]__dgi_RecordGenerator) {
-	minimalGenerator := __init___datagen_minimalGenerator()
-	multiple_typesGenerator := __init___datagen_multiple_typesGenerator()
-	nestedGenerator := __init___datagen_nestedGenerator()
-	simpleGenerator := __init___datagen_simpleGenerator()
-	with_builtin_functionsGenerator := __init___datagen_with_builtin_functionsGenerator()
-	with_conditionalsGenerator := __init___datagen_with_conditionalsGenerator()
-	with_mapsGenerator := __init___datagen_with_mapsGenerator()
-	with_metadataGenerator := __init___datagen_with_metadataGenerator()
-	with_miscGenerator := __init___datagen_with_miscGenerator()
-	with_slicesGenerator := __init___datagen_with_slicesGenerator()
+// __dgi_newLinks returns links seeded with the references found in gen functions at compile time
+func __dgi_newLinks() *__dgi_Links {
+	return &__dgi_Links{
+		data: map[string]map[string]struct{}{
+			"minimal":                {},
+			"multiple_types":         {},
+			"nested":                 {},
+			"simple":                 {},
+			"with_builtin_functions": {},
+			"with_columns":           {},
+			"with_conditionals":      {},
+			"with_maps":              {},
+			"with_metadata":          {},
+			"with_misc":              {},
+			"with_slices":            {},
+		},
+	}
+}
+
+// __dgi_initGeneratorsAndModels returns a fresh set of generators. Every worker
+// generating records owns such a set, so memoized values are never shared
+// between goroutines; links are shared by all of them.
+func __dgi_initGeneratorsAndModels(memoWindow int, links *__dgi_Links) (*__dgi_DataGenGenerators, map[string]__dgi_RecordGenerator) {
+	minimalGenerator := __init___datagen_minimalGenerator(memoWindow)
+	multiple_typesGenerator := __init___datagen_multiple_typesGenerator(memoWindow)
+	nestedGenerator := __init___datagen_nestedGenerator(memoWindow)
+	simpleGenerator := __init___datagen_simpleGenerator(memoWindow)
+	with_builtin_functionsGenerator := __init___datagen_with_builtin_functionsGenerator(memoWindow)
+	with_columnsGenerator := __init___datagen_with_columnsGenerator(memoWindow)
+	with_conditionalsGenerator := __init___datagen_with_conditionalsGenerator(memoWindow)
+	with_mapsGenerator := __init___datagen_with_mapsGenerator(memoWindow)
+	with_metadataGenerator := __init___datagen_with_metadataGenerator(memoWindow)
+	with_miscGenerator := __init___datagen_with_miscGenerator(memoWindow)
+	with_slicesGenerator := __init___datagen_with_slicesGenerator(memoWindow)
 
 	// Construct directory instances bottom-up so children are available
 
@@ -105,22 +133,21 @@ func __dgi_initGeneratorsAndModels() (*__dgi_DataGenGenerators, map[string]__dgi
 		nested:                 nestedFunc(nestedGenerator, "nested"),
 		simple:                 simpleFunc(simpleGenerator, "simple"),
 		with_builtin_functions: with_builtin_functionsFunc(with_builtin_functionsGenerator, "with_builtin_functions"),
+		with_columns:           with_columnsFunc(with_columnsGenerator, "with_columns"),
 		with_conditionals:      with_conditionalsFunc(with_conditionalsGenerator, "with_conditionals"),
 		with_maps:              with_mapsFunc(with_mapsGenerator, "with_maps"),
 		with_metadata:          with_metadataFunc(with_metadataGenerator, "with_metadata"),
 		with_misc:              with_miscFunc(with_miscGenerator, "with_misc"),
 		with_slices:            with_slicesFunc(with_slicesGenerator, "with_slices"),
 
-		__links: &__dgi_Links{
-			mu:   sync.Mutex{},
-			data: map[string]map[string]struct{}{},
-		},
+		__links: links,
 	}
 	minimalGenerator.datagen = datagen
 	multiple_typesGenerator.datagen = datagen
 	nestedGenerator.datagen = datagen
 	simpleGenerator.datagen = datagen
 	with_builtin_functionsGenerator.datagen = datagen
+	with_columnsGenerator.datagen = datagen
 	with_conditionalsGenerator.datagen = datagen
 	with_mapsGenerator.datagen = datagen
 	with_metadataGenerator.datagen = datagen
@@ -134,6 +161,7 @@ func __dgi_initGeneratorsAndModels() (*__dgi_DataGenGenerators, map[string]__dgi
 		"nested":                 nestedGenerator.Gen,
 		"simple":                 simpleGenerator.Gen,
 		"with_builtin_functions": with_builtin_functionsGenerator.Gen,
+		"with_columns":           with_columnsGenerator.Gen,
 		"with_conditionals":      with_conditionalsGenerator.Gen,
 		"with_maps":              with_mapsGenerator.Gen,
 		"with_metadata":          with_metadataGenerator.Gen,
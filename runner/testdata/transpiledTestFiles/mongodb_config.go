@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	__dgi_MongoDBClearDelete = "delete"
+	__dgi_MongoDBClearDrop   = "drop"
+)
+
+type __dgi_MongoDBConfig struct {
+	URI            string            `json:"uri"`
+	Database       string            `json:"database"`
+	// Collections maps model names to the collections they are inserted
+	// into, rather than collections named after the models.
+	Collections    map[string]string `json:"collections,omitempty"`
+	BatchSize      int               `json:"batch_size,omitempty"`
+	// Ordered stops a batch at the first document that fails to insert,
+	// unless set to false.
+	Ordered        *bool             `json:"ordered,omitempty"`
+	// ClearMode is how clear_data empties a collection: delete its documents,
+	// keeping its indexes, or drop it.
+	ClearMode      string            `json:"clear_mode,omitempty"`
+	Timeout        string            `json:"timeout,omitempty"`
+	Throttle       string            `json:"throttle,omitempty"`
+}
+
+func (c *__dgi_MongoDBConfig) ordered() bool {
+	return c.Ordered == nil || *c.Ordered
+}
+
+// collection returns the collection of a model, mapped in Collections or
+// defaultCollection.
+func (c *__dgi_MongoDBConfig) collection(modelName, defaultCollection string) string {
+	if name, ok := c.Collections[modelName]; ok {
+		return name
+	}
+	return defaultCollection
+}
+
+func (c *__dgi_MongoDBConfig) Validate() error {
+	if c.URI == "" || c.Database == "" {
+		return errors.New("mongodb: uri and database are required")
+	}
+	switch c.ClearMode {
+	case "", __dgi_MongoDBClearDelete, __dgi_MongoDBClearDrop:
+	default:
+		return fmt.Errorf("mongodb: unsupported clear_mode %q (expected %q or %q)", c.ClearMode, __dgi_MongoDBClearDelete, __dgi_MongoDBClearDrop)
+	}
+	for model, collection := range c.Collections {
+		if collection == "" {
+			return fmt.Errorf("mongodb: invalid collection %q for model %q", collection, model)
+		}
+	}
+	return nil
+}
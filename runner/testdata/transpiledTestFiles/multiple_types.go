@@ -392,85 +392,62 @@ type __datagen_multiple_typesGenerator struct {
 }
 
 type __datagen_multiple_typesDataHolder struct {
-	id     []int
-	score  []float64
-	name   []string
-	active []bool
+	id     *__dgi_Memo[int]
+	score  *__dgi_Memo[float64]
+	name   *__dgi_Memo[string]
+	active *__dgi_Memo[bool]
 }
 
 func (cg *__datagen_multiple_typesGenerator) __gen_wrapper_active() func(iter int) bool {
+	gen := func(i int) bool {
+		return cg.__gen_active(nil, i)
+	}
 	return func(iter int) bool {
-		if iter < len(cg.all.active) {
-			return cg.all.active[iter]
-		}
-
-		for i := len(cg.all.active); i <= iter; i++ {
-			val := cg.__gen_active(i)
-			cg.all.active = append(cg.all.active, val)
-		}
-
-		return cg.all.active[iter]
+		return cg.all.active.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_multiple_typesGenerator) __gen_active(iter int) bool {
+func (self *__datagen_multiple_typesGenerator) __gen_active(__dgi_r *__dgi_Rand, iter int) bool {
 	return iter%2 == 0
 }
 
 func (cg *__datagen_multiple_typesGenerator) __gen_wrapper_name() func(iter int) string {
+	gen := func(i int) string {
+		return cg.__gen_name(nil, i)
+	}
 	return func(iter int) string {
-		if iter < len(cg.all.name) {
-			return cg.all.name[iter]
-		}
-
-		for i := len(cg.all.name); i <= iter; i++ {
-			val := cg.__gen_name(i)
-			cg.all.name = append(cg.all.name, val)
-		}
-
-		return cg.all.name[iter]
+		return cg.all.name.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_multiple_typesGenerator) __gen_name(iter int) string {
+func (self *__datagen_multiple_typesGenerator) __gen_name(__dgi_r *__dgi_Rand, iter int) string {
 	return fmt.Sprintf("user_%d", iter)
 }
 
 func (cg *__datagen_multiple_typesGenerator) __gen_wrapper_score() func(iter int) float64 {
+	key := __dgi_streamKey("multiple_types", "score")
+	gen := func(i int) float64 {
+		return cg.__gen_score(__dgi_fieldRand(key, i), i)
+	}
 	return func(iter int) float64 {
-		if iter < len(cg.all.score) {
-			return cg.all.score[iter]
-		}
-
-		for i := len(cg.all.score); i <= iter; i++ {
-			val := cg.__gen_score(i)
-			cg.all.score = append(cg.all.score, val)
-		}
-
-		return cg.all.score[iter]
+		return cg.all.score.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_multiple_typesGenerator) __gen_score(iter int) float64 {
-	return FloatBetween(0.0, 100.0)
+func (self *__datagen_multiple_typesGenerator) __gen_score(__dgi_r *__dgi_Rand, iter int) float64 {
+	return FloatBetween(__dgi_r, 0.0, 100.0)
 }
 
 func (cg *__datagen_multiple_typesGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_multiple_typesGenerator) __gen_id(iter int) int {
+func (self *__datagen_multiple_typesGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -483,8 +460,13 @@ func (cg *__datagen_multiple_typesGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_multiple_typesGenerator() *__datagen_multiple_typesGenerator {
-	all := &__datagen_multiple_typesDataHolder{}
+func __init___datagen_multiple_typesGenerator(memoWindow int) *__datagen_multiple_typesGenerator {
+	all := &__datagen_multiple_typesDataHolder{
+		id:     __dgi_NewMemo[int]("multiple_types", "id", __dgi_memoCurrentRow, false),
+		score:  __dgi_NewMemo[float64]("multiple_types", "score", __dgi_memoCurrentRow, false),
+		name:   __dgi_NewMemo[string]("multiple_types", "name", __dgi_memoCurrentRow, false),
+		active: __dgi_NewMemo[bool]("multiple_types", "active", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_multiple_typesGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.score = cg.__gen_wrapper_score()
@@ -493,12 +475,12 @@ func __init___datagen_multiple_typesGenerator() *__datagen_multiple_typesGenerat
 	return cg
 }
 
-func (e *__datagen_multiple_types) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.score),
-		fmt.Sprintf("%v", e.name),
-		fmt.Sprintf("%v", e.active),
+func (e *__datagen_multiple_types) ToCSV() []any {
+	return []any{
+		e.id,
+		e.score,
+		e.name,
+		e.active,
 	}
 }
 
@@ -512,11 +494,11 @@ func (e *__datagen_multiple_types) CSVHeaders() []string {
 }
 
 func (e *__datagen_multiple_types) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":     e.id,
-		"score":  e.score,
-		"name":   e.name,
-		"active": e.active,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "score", Value: e.score},
+		{Key: "name", Value: e.name},
+		{Key: "active", Value: e.active},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -525,26 +507,78 @@ func (e *__datagen_multiple_types) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_multiple_types) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName    xml.Name `xml:"multiple_types"`
-		Xml_id     int      `xml:"id"`
-		Xml_score  float64  `xml:"score"`
-		Xml_name   string   `xml:"name"`
-		Xml_active bool     `xml:"active"`
+func (e *__datagen_multiple_types) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_multiple_types) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("multiple_types", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "score", Attr: false, Value: e.score},
+		{Name: "name", Attr: false, Value: e.name},
+		{Name: "active", Attr: false, Value: e.active},
+	})
+}
+
+func (e *__datagen_multiple_types) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id     int     `parquet:"id"`
+		Parquet_score  float64 `parquet:"score"`
+		Parquet_name   string  `parquet:"name"`
+		Parquet_active bool    `parquet:"active"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:     e.id,
-		Xml_score:  e.score,
-		Xml_name:   e.name,
-		Xml_active: e.active,
+	return &__dgi_parquetRow{
+		Parquet_id:     e.id,
+		Parquet_score:  e.score,
+		Parquet_name:   e.name,
+		Parquet_active: e.active,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_multiple_types) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.score, e.name, e.active)
+}
+
+func (e *__datagen_multiple_types) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"multiple_types\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"score\",\n      \"type\": \"double\"\n    },\n    {\n      \"name\": \"name\",\n      \"type\": \"string\"\n    },\n    {\n      \"name\": \"active\",\n      \"type\": \"boolean\"\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_multiple_types) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.score, e.name, e.active)
+}
+
+func (e *__datagen_multiple_types) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage multiple_types {\n  int64 id = 1;\n  double score = 2;\n  string name = 3;\n  bool active = 4;\n}\n", nil
+}
+
+func (e *__datagen_multiple_types) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `multiple_types` (`id`, `score`, `name`, `active`) VALUES",
+			Truncate:         "DELETE FROM `multiple_types`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `multiple_types` (\n  `id` BIGINT NOT NULL,\n  `score` DOUBLE NOT NULL,\n  `name` TEXT NOT NULL,\n  `active` BOOLEAN NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"multiple_types\" (\"id\", \"score\", \"name\", \"active\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"multiple_types\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"multiple_types\" (\n  \"id\" BIGINT NOT NULL,\n  \"score\" DOUBLE PRECISION NOT NULL,\n  \"name\" TEXT NOT NULL,\n  \"active\" BOOLEAN NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"multiple_types\" (\"id\", \"score\", \"name\", \"active\") VALUES",
+			Truncate:         "DELETE FROM \"multiple_types\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"multiple_types\" (\n  \"id\" INTEGER NOT NULL,\n  \"score\" REAL NOT NULL,\n  \"name\" TEXT NOT NULL,\n  \"active\" BOOLEAN NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_multiple_types) SQLValues() []any {
+	return []any{e.id, e.score, e.name, e.active}
 }
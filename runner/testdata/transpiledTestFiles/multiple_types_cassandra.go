@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+)
+
+// Table___datagen_multiple_types_cassandra returns the table the records of the model are written to, as mapped in config,
+// keyed on keys, or on the primary key of the table when there are none.
+func Table___datagen_multiple_types_cassandra(modelName string, config *__dgi_CassandraConfig, keys []string) (*__dgi_cassandraTable, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	columns := []__dgi_cassandraColumn{
+		{name: "id", typ: "bigint"},
+		{name: "score", typ: "double"},
+		{name: "name", typ: "text"},
+		{name: "active", typ: "boolean"},
+	}
+	return &__dgi_cassandraTable{name: config.table(modelName, "\"multiple_types\""), columns: columns, keys: keys}, nil
+}
+
+// Load___datagen_multiple_types_cassandra writes a single batch of records to table, using the provided session.
+func Load___datagen_multiple_types_cassandra(records []*__datagen_multiple_types, session *gocql.Session, config *__dgi_CassandraConfig, table *__dgi_cassandraTable) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := __dgi_cassandraInsert(context.Background(), session, config, table, Rows___datagen_multiple_types_cassandra(records)); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_multiple_types_cassandra returns the values of the columns of records, in order.
+func Rows___datagen_multiple_types_cassandra(records []*__datagen_multiple_types) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.score,
+			record.name,
+			record.active,
+		})
+	}
+	return rows
+}
+
+// Truncate___datagen_multiple_types_cassandra truncates the model's table using the provided session.
+func Truncate___datagen_multiple_types_cassandra(session *gocql.Session, table *__dgi_cassandraTable) error {
+	if err := session.Query("TRUNCATE TABLE " + table.name).Exec(); err != nil {
+		return fmt.Errorf("truncate failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_multiple_types_cassandra_table creates the model's table unless it already exists.
+func Create___datagen_multiple_types_cassandra_table(session *gocql.Session, table *__dgi_cassandraTable) error {
+	stmt, err := table.createStatement()
+	if err != nil {
+		return err
+	}
+	if err := session.Query(stmt).Exec(); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Load___datagen_multiple_types_clickhouse inserts a single batch of records in one JSONEachRow insert, using the provided connection.
+func Load___datagen_multiple_types_clickhouse(records []*__datagen_multiple_types, conn *__dgi_chClient) error {
+	if len(records) == 0 {
+		return nil
+	}
+	names := []string{
+		"id",
+		"score",
+		"name",
+		"active",
+	}
+	columns := []string{
+		"`id`",
+		"`score`",
+		"`name`",
+		"`active`",
+	}
+	if err := conn.insert("`multiple_types`", names, columns, Rows___datagen_multiple_types_clickhouse(records)); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_multiple_types_clickhouse returns the values of the columns of records, in order.
+func Rows___datagen_multiple_types_clickhouse(records []*__datagen_multiple_types) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.score,
+			record.name,
+			record.active,
+		})
+	}
+	return rows
+}
+
+// Truncate___datagen_multiple_types_clickhouse truncates the model's table using the provided connection.
+func Truncate___datagen_multiple_types_clickhouse(conn *__dgi_chClient) error {
+	if err := conn.exec("TRUNCATE TABLE `multiple_types`", nil); err != nil {
+		return fmt.Errorf("truncate failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_multiple_types_clickhouse_table creates the model's table unless it already exists.
+func Create___datagen_multiple_types_clickhouse_table(conn *__dgi_chClient) error {
+	if err := conn.exec("CREATE TABLE IF NOT EXISTS `multiple_types` (\n  `id` Int64,\n  `score` Float64,\n  `name` String,\n  `active` Bool\n) ENGINE = MergeTree\nORDER BY tuple()", nil); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
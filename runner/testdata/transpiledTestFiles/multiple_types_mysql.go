@@ -7,8 +7,8 @@ import (
 	"strings"
 )
 
-// Load___datagen_multiple_types_mysql executes a single batch of records using the provided transaction.
-func Load___datagen_multiple_types_mysql(records []*__datagen_multiple_types, tx *sql.Tx) error {
+// Load___datagen_multiple_types_mysql executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_multiple_types_mysql(records []*__datagen_multiple_types, tx *sql.Tx, stmt __dgi_writeStatement) error {
 	if len(records) == 0 {
 		return nil
 	}
@@ -16,15 +16,7 @@ func Load___datagen_multiple_types_mysql(records []*__datagen_multiple_types, tx
 	ctx := context.Background()
 
 	var b strings.Builder
-	columns := []string{
-		"`id`",
-		"`score`",
-		"`name`",
-		"`active`",
-	}
-	b.WriteString("INSERT INTO multiple_types (")
-	b.WriteString(strings.Join(columns, ","))
-	b.WriteString(") VALUES ")
+	b.WriteString(stmt.prefix)
 
 	placeholderGroup := "(" + strings.Repeat("?,", 4)
 	placeholderGroup = placeholderGroup[:len(placeholderGroup)-1] + ")"
@@ -34,15 +26,15 @@ func Load___datagen_multiple_types_mysql(records []*__datagen_multiple_types, tx
 		}
 		b.WriteString(placeholderGroup)
 	}
+	b.WriteString(stmt.suffix)
 	sqlStmt := b.String()
 
 	var args []interface{}
-	for _, record := range records {
-		args = append(args, record.id)
-		args = append(args, record.score)
-		args = append(args, record.name)
-		args = append(args, record.active)
-
+	for _, row := range Rows___datagen_multiple_types_mysql(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
 	}
 
 	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
@@ -52,11 +44,72 @@ func Load___datagen_multiple_types_mysql(records []*__datagen_multiple_types, tx
 	return nil
 }
 
+// LoadData___datagen_multiple_types_mysql loads a single batch of records with LOAD DATA LOCAL INFILE, using the provided transaction.
+func LoadData___datagen_multiple_types_mysql(records []*__datagen_multiple_types, tx *sql.Tx, mode __dgi_WriteMode) error {
+	if len(records) == 0 {
+		return nil
+	}
+	columns := []string{
+		"`id`",
+		"`score`",
+		"`name`",
+		"`active`",
+	}
+	if err := __dgi_mysqlLoadData(context.Background(), tx, "`multiple_types`", columns, mode, Rows___datagen_multiple_types_mysql(records)); err != nil {
+		return fmt.Errorf("load data failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_multiple_types_mysql returns the values of the columns of records, in order.
+func Rows___datagen_multiple_types_mysql(records []*__datagen_multiple_types) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.score,
+			record.name,
+			record.active,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_multiple_types_mysql returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_multiple_types_mysql(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+		"score",
+		"name",
+		"active",
+	}
+	columns := []string{
+		"`id`",
+		"`score`",
+		"`name`",
+		"`active`",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectMySQL, "`multiple_types`", names, columns, keys, mode)
+}
+
 // Truncate___datagen_multiple_types_mysql() truncates the model's table using the shared connection.
 func Truncate___datagen_multiple_types_mysql(tx *sql.Tx) error {
 	ctx := context.Background()
-	if _, err := tx.ExecContext(ctx, "DELETE FROM multiple_types;"); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `multiple_types`;"); err != nil {
 		return fmt.Errorf("delete failed with error : %w", err)
 	}
 	return nil
 }
+
+// Create___datagen_multiple_types_mysql_table creates the model's table unless it already exists.
+func Create___datagen_multiple_types_mysql_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `multiple_types` (\n  `id` BIGINT NOT NULL,\n  `score` DOUBLE NOT NULL,\n  `name` TEXT NOT NULL,\n  `active` BOOLEAN NOT NULL\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
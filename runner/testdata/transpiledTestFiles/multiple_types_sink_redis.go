@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// __datagen_multiple_types_redisSink writes __datagen_multiple_types data to the Redis keys of a key template in pipelined batches
+type __datagen_multiple_types_redisSink struct {
+	modelName    string
+	config       *__dgi_RedisConfig
+	key          *__dgi_redisKey
+	client       *redis.Client
+	total        int
+	totalWritten int
+}
+
+// Open_redis___datagen_multiple_types_sink connects to the Redis server __datagen_multiple_types data is written to, once the fields
+// the config reads are known to exist
+func Open_redis___datagen_multiple_types_sink(modelName string, total int, config *__dgi_RedisConfig) (*__datagen_multiple_types_redisSink, error) {
+	key, err := __dgi_parseRedisKey(config.Key)
+	if err == nil {
+		err = __dgi_checkRedisFields(config, key, func(field string) (any, error) {
+			return Value___datagen_multiple_types_redis(&__datagen_multiple_types{}, field)
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("✘ [Redis] %s: FAILED\n   └─ Records written: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("initializing Redis client for %s with %d records", modelName, total))
+	client, err := __dgi_newRedisClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [Redis] %s: FAILED\n   └─ Records written: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("writing %s to keys %s as %s with batch size %d", modelName, config.Key, config.dataType(), config.BatchSize))
+	return &__datagen_multiple_types_redisSink{modelName: modelName, config: config, key: key, client: client, total: total}, nil
+}
+
+// Load writes a chunk of __datagen_multiple_types records in pipelines of config.BatchSize records, 1000 by default
+func (s *__datagen_multiple_types_redisSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_multiple_types, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_multiple_types))
+	}
+
+	batchSize := s.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	for i := 0; i < len(records); i += batchSize {
+		end := i + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[i:end]
+
+		slog.Debug(fmt.Sprintf("writing batch starting at %d of size %d for %s to Redis", s.totalWritten, len(batch), s.modelName))
+		if err := Load___datagen_multiple_types_redis(batch, s.client, s.config, s.key); err != nil {
+			return fmt.Errorf("✘ [Redis] %s: FAILED\n   └─ Records written: %d/%d\n   └─ Error: %v\n",
+				s.modelName, s.totalWritten, s.total, err)
+		}
+
+		s.totalWritten += len(batch)
+
+		if s.config.Throttle != "" && s.totalWritten < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
+				time.Sleep(throttleDuration)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit closes the client; every pipeline has already been executed
+func (s *__datagen_multiple_types_redisSink) Commit() error {
+	s.close()
+	slog.Info(fmt.Sprintf("successfully wrote %d/%d records for %s to Redis", s.totalWritten, s.total, s.modelName))
+	return nil
+}
+
+// Abort closes the client; keys that were already written stay
+func (s *__datagen_multiple_types_redisSink) Abort() {
+	s.close()
+}
+
+func (s *__datagen_multiple_types_redisSink) close() {
+	if err := s.client.Close(); err != nil {
+		slog.Warn(fmt.Sprintf("failed to close Redis client for %s: %s", s.modelName, err.Error()))
+	}
+}
+
+// Clear_redis___datagen_multiple_types_data clears __datagen_multiple_types data from Redis, deleting the keys that start
+// with the literal prefix of the key template
+func Clear_redis___datagen_multiple_types_data(modelName string, config *__dgi_RedisConfig) error {
+	key, err := __dgi_parseRedisKey(config.Key)
+	if err != nil {
+		return err
+	}
+	pattern, err := key.pattern()
+	if err != nil {
+		return fmt.Errorf("cannot clear keys %s of model %s: %w", config.Key, modelName, err)
+	}
+
+	slog.Debug(fmt.Sprintf("initializing Redis client for clearing data for %s", modelName))
+	client, err := __dgi_newRedisClient(config)
+	if err != nil {
+		return fmt.Errorf("Redis connection failed: %w", err)
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			slog.Warn(fmt.Sprintf("failed to close Redis client: %s", err.Error()))
+		}
+	}()
+
+	deleted, err := __dgi_redisClear(context.Background(), client, pattern)
+	if err != nil {
+		return fmt.Errorf("failed to delete keys %s for model %s: %w", pattern, modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("successfully cleared %d keys for %s from Redis", deleted, modelName))
+	return nil
+}
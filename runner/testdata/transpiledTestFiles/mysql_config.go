@@ -14,6 +14,9 @@ type __dgi_MySQLConfig struct {
 	Timeout        string `json:"timeout,omitempty"`
 	WriteTimeout   string `json:"write_timeout,omitempty"`
 	Throttle       string `json:"throttle,omitempty"`
+	// LoadData loads records with LOAD DATA LOCAL INFILE rather than INSERT
+	// statements, which needs local_infile on the server.
+	LoadData       bool   `json:"load_data,omitempty"`
 }
 
 func (c *__dgi_MySQLConfig) Validate() error {
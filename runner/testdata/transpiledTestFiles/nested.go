@@ -393,45 +393,33 @@ type __datagen_nestedGenerator struct {
 }
 
 type __datagen_nestedDataHolder struct {
-	id   []int
-	user []UserInfo
+	id   *__dgi_Memo[int]
+	user *__dgi_Memo[UserInfo]
 }
 
 func (cg *__datagen_nestedGenerator) __gen_wrapper_user() func(iter int) UserInfo {
+	gen := func(i int) UserInfo {
+		return cg.__gen_user(nil, i)
+	}
 	return func(iter int) UserInfo {
-		if iter < len(cg.all.user) {
-			return cg.all.user[iter]
-		}
-
-		for i := len(cg.all.user); i <= iter; i++ {
-			val := cg.__gen_user(i)
-			cg.all.user = append(cg.all.user, val)
-		}
-
-		return cg.all.user[iter]
+		return cg.all.user.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_nestedGenerator) __gen_user(iter int) UserInfo {
+func (self *__datagen_nestedGenerator) __gen_user(__dgi_r *__dgi_Rand, iter int) UserInfo {
 	return UserInfo{Name: fmt.Sprintf("user%d", iter), Email: fmt.Sprintf("user%d@test.com", iter)}
 }
 
 func (cg *__datagen_nestedGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_nestedGenerator) __gen_id(iter int) int {
+func (self *__datagen_nestedGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -442,18 +430,21 @@ func (cg *__datagen_nestedGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_nestedGenerator() *__datagen_nestedGenerator {
-	all := &__datagen_nestedDataHolder{}
+func __init___datagen_nestedGenerator(memoWindow int) *__datagen_nestedGenerator {
+	all := &__datagen_nestedDataHolder{
+		id:   __dgi_NewMemo[int]("nested", "id", __dgi_memoCurrentRow, false),
+		user: __dgi_NewMemo[UserInfo]("nested", "user", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_nestedGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.user = cg.__gen_wrapper_user()
 	return cg
 }
 
-func (e *__datagen_nested) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.user),
+func (e *__datagen_nested) ToCSV() []any {
+	return []any{
+		e.id,
+		e.user,
 	}
 }
 
@@ -465,9 +456,9 @@ func (e *__datagen_nested) CSVHeaders() []string {
 }
 
 func (e *__datagen_nested) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":   e.id,
-		"user": e.user,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "user", Value: e.user},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -476,22 +467,72 @@ func (e *__datagen_nested) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_nested) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName  xml.Name `xml:"nested"`
-		Xml_id   int      `xml:"id"`
-		Xml_user UserInfo `xml:"user"`
+func (e *__datagen_nested) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_nested) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("nested", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "user", Attr: false, Value: e.user},
+	})
+}
+
+func (e *__datagen_nested) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id   int      `parquet:"id"`
+		Parquet_user UserInfo `parquet:"user"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:   e.id,
-		Xml_user: e.user,
+	return &__dgi_parquetRow{
+		Parquet_id:   e.id,
+		Parquet_user: e.user,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_nested) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.user)
+}
+
+func (e *__datagen_nested) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"nested\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"user\",\n      \"type\": {\n        \"type\": \"record\",\n        \"name\": \"UserInfo\",\n        \"fields\": [\n          {\n            \"name\": \"Name\",\n            \"type\": \"string\"\n          },\n          {\n            \"name\": \"Email\",\n            \"type\": \"string\"\n          }\n        ]\n      }\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_nested) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.user)
+}
+
+func (e *__datagen_nested) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage nested {\n  int64 id = 1;\n  UserInfo user = 2;\n}\n\nmessage UserInfo {\n  string Name = 1;\n  string Email = 2;\n}\n", nil
+}
+
+func (e *__datagen_nested) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `nested` (`id`, `user`) VALUES",
+			Truncate:         "DELETE FROM `nested`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `nested` (\n  `id` BIGINT NOT NULL,\n  `user` JSON NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"nested\" (\"id\", \"user\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"nested\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"nested\" (\n  \"id\" BIGINT NOT NULL,\n  \"user\" JSONB NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"nested\" (\"id\", \"user\") VALUES",
+			Truncate:         "DELETE FROM \"nested\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"nested\" (\n  \"id\" INTEGER NOT NULL,\n  \"user\" TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_nested) SQLValues() []any {
+	return []any{e.id, e.user}
 }
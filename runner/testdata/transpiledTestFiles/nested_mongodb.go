@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Load___datagen_nested_mongodb inserts a single batch of records into the collection, in order unless ordered is false.
+func Load___datagen_nested_mongodb(records []*__datagen_nested, collection *mongo.Collection, ordered bool) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	if _, err := collection.InsertMany(ctx, Documents___datagen_nested_mongodb(records), options.InsertMany().SetOrdered(ordered)); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+	return nil
+}
+
+// Documents___datagen_nested_mongodb returns records as documents keyed by column, leaving maps, slices and structs
+// to be encoded as embedded documents and arrays.
+func Documents___datagen_nested_mongodb(records []*__datagen_nested) []interface{} {
+	documents := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		documents = append(documents, bson.D{
+			{Key: "id", Value: record.id},
+			{Key: "user", Value: record.user},
+		})
+	}
+	return documents
+}
+
+// Collection___datagen_nested_mongodb returns the collection of the model in the configured database, as mapped in config.
+func Collection___datagen_nested_mongodb(client *mongo.Client, modelName string, config *__dgi_MongoDBConfig) *mongo.Collection {
+	return client.Database(config.Database).Collection(config.collection(modelName, "nested"))
+}
+
+// Truncate___datagen_nested_mongodb empties the collection of the model, deleting its documents or dropping it
+// as the clear mode of the config says.
+func Truncate___datagen_nested_mongodb(collection *mongo.Collection, config *__dgi_MongoDBConfig) error {
+	ctx := context.Background()
+	if config.ClearMode == __dgi_MongoDBClearDrop {
+		if err := collection.Drop(ctx); err != nil {
+			return fmt.Errorf("drop failed with error : %w", err)
+		}
+		return nil
+	}
+	if _, err := collection.DeleteMany(ctx, bson.D{}); err != nil {
+		return fmt.Errorf("delete failed with error : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// __datagen_nested_clickhouseSink streams __datagen_nested data into ClickHouse with batch inserts over HTTP
+type __datagen_nested_clickhouseSink struct {
+	modelName     string
+	config        *__dgi_ClickHouseConfig
+	conn          *__dgi_chClient
+	total         int
+	totalInserted int
+}
+
+// Open_clickhouse___datagen_nested_sink connects to ClickHouse for __datagen_nested data to be inserted
+func Open_clickhouse___datagen_nested_sink(modelName string, total int, config *__dgi_ClickHouseConfig) (*__datagen_nested_clickhouseSink, error) {
+	slog.Debug(fmt.Sprintf("initializing ClickHouse connection for %s with %d records", modelName, total))
+	conn, err := Open___datagen_nested_clickhouse_connection(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [ClickHouse] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("inserting %s into ClickHouse with batch size %d", modelName, config.BatchSize))
+	return &__datagen_nested_clickhouseSink{modelName: modelName, config: config, conn: conn, total: total}, nil
+}
+
+// Load inserts a chunk of __datagen_nested records in batches of config.BatchSize, or all at once when it is not set,
+// as ClickHouse prefers few large inserts
+func (s *__datagen_nested_clickhouseSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_nested, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_nested))
+	}
+
+	batchSize := s.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = max(len(records), 1)
+	}
+
+	for i := 0; i < len(records); i += batchSize {
+		end := i + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[i:end]
+
+		slog.Debug(fmt.Sprintf("loading batch starting at %d of size %d for %s into ClickHouse", s.totalInserted, len(batch), s.modelName))
+		if err := Load___datagen_nested_clickhouse(batch, s.conn); err != nil {
+			return fmt.Errorf("✘ [ClickHouse] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
+				s.modelName, s.totalInserted, s.total, err)
+		}
+
+		s.totalInserted += len(batch)
+
+		if s.config.Throttle != "" && s.totalInserted < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
+				time.Sleep(throttleDuration)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit closes the ClickHouse connection; every batch has already been inserted
+func (s *__datagen_nested_clickhouseSink) Commit() error {
+	s.conn.close()
+	slog.Info(fmt.Sprintf("successfully loaded %d/%d rows for %s into ClickHouse", s.totalInserted, s.total, s.modelName))
+	return nil
+}
+
+// Abort closes the ClickHouse connection; ClickHouse has no transactions, so batches already inserted stay
+func (s *__datagen_nested_clickhouseSink) Abort() {
+	s.conn.close()
+}
+
+// Clear_clickhouse___datagen_nested_data clears __datagen_nested data from ClickHouse
+func Clear_clickhouse___datagen_nested_data(modelName string, config *__dgi_ClickHouseConfig) error {
+	slog.Debug(fmt.Sprintf("initializing ClickHouse connection for clearing data for %s", modelName))
+	if err := Init___datagen_nested_clickhouse_connection(config); err != nil {
+		return fmt.Errorf("ClickHouse connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_nested_clickhouse_connection()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to close ClickHouse connection: %s", err.Error()))
+		}
+	}()
+
+	conn, err := Get___datagen_nested_clickhouse_connection()
+	if err != nil {
+		return fmt.Errorf("failed to get ClickHouse connection: %w", err)
+	}
+
+	if err := Truncate___datagen_nested_clickhouse(conn); err != nil {
+		return fmt.Errorf("failed to truncate table for model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("successfully cleared data for %s from ClickHouse", modelName))
+	return nil
+}
+
+// Create_clickhouse___datagen_nested_table creates the table __datagen_nested data is loaded into in ClickHouse, unless it already exists
+func Create_clickhouse___datagen_nested_table(modelName string, config *__dgi_ClickHouseConfig) error {
+	slog.Debug(fmt.Sprintf("initializing ClickHouse connection for creating the table of %s", modelName))
+	if err := Init___datagen_nested_clickhouse_connection(config); err != nil {
+		return fmt.Errorf("ClickHouse connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_nested_clickhouse_connection()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to close ClickHouse connection: %s", err.Error()))
+		}
+	}()
+
+	conn, err := Get___datagen_nested_clickhouse_connection()
+	if err != nil {
+		return fmt.Errorf("failed to get ClickHouse connection: %w", err)
+	}
+
+	if err := Create___datagen_nested_clickhouse_table(conn); err != nil {
+		return fmt.Errorf("failed to create table for model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("table for %s is ready in ClickHouse", modelName))
+	return nil
+}
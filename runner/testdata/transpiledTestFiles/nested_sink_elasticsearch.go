@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// __datagen_nested_elasticsearchSink indexes __datagen_nested data into an Elasticsearch index with the _bulk API
+type __datagen_nested_elasticsearchSink struct {
+	modelName    string
+	config       *__dgi_ElasticsearchConfig
+	client       *__dgi_esClient
+	index        string
+	total        int
+	totalIndexed int
+}
+
+// Open_elasticsearch___datagen_nested_sink connects to the cluster __datagen_nested data is indexed into
+func Open_elasticsearch___datagen_nested_sink(modelName string, total int, config *__dgi_ElasticsearchConfig) (*__datagen_nested_elasticsearchSink, error) {
+	slog.Debug(fmt.Sprintf("initializing Elasticsearch client for %s with %d records", modelName, total))
+	client, err := __dgi_newESClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [Elasticsearch] %s: FAILED\n   └─ Documents indexed: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	index := Index___datagen_nested_elasticsearch(config)
+	slog.Debug(fmt.Sprintf("indexing %s into index %s with batch size %d", modelName, index, config.BatchSize))
+	return &__datagen_nested_elasticsearchSink{modelName: modelName, config: config, client: client, index: index, total: total}, nil
+}
+
+// Load indexes a chunk of __datagen_nested records in _bulk requests of config.BatchSize documents, 1000 by default
+func (s *__datagen_nested_elasticsearchSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_nested, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_nested))
+	}
+
+	batchSize := s.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	for i := 0; i < len(records); i += batchSize {
+		end := i + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[i:end]
+
+		slog.Debug(fmt.Sprintf("indexing batch starting at %d of size %d for %s into Elasticsearch", s.totalIndexed, len(batch), s.modelName))
+		if err := Load___datagen_nested_elasticsearch(batch, s.client, s.index); err != nil {
+			return fmt.Errorf("✘ [Elasticsearch] %s: FAILED\n   └─ Documents indexed: %d/%d\n   └─ Error: %v\n",
+				s.modelName, s.totalIndexed, s.total, err)
+		}
+
+		s.totalIndexed += len(batch)
+
+		if s.config.Throttle != "" && s.totalIndexed < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
+				time.Sleep(throttleDuration)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit closes the client; every document has already been acknowledged by its _bulk request
+func (s *__datagen_nested_elasticsearchSink) Commit() error {
+	s.client.close()
+	slog.Info(fmt.Sprintf("successfully indexed %d/%d documents for %s into Elasticsearch index %s", s.totalIndexed, s.total, s.modelName, s.index))
+	return nil
+}
+
+// Abort closes the client; documents that were already indexed stay in the index
+func (s *__datagen_nested_elasticsearchSink) Abort() {
+	s.client.close()
+}
+
+// Clear_elasticsearch___datagen_nested_data clears __datagen_nested data from Elasticsearch, deleting the documents
+// of the index, or the index itself to create it again with the mapping of the model when config.CreateIndex is set
+func Clear_elasticsearch___datagen_nested_data(modelName string, config *__dgi_ElasticsearchConfig) error {
+	slog.Debug(fmt.Sprintf("initializing Elasticsearch client for clearing data for %s", modelName))
+	client, err := __dgi_newESClient(config)
+	if err != nil {
+		return fmt.Errorf("Elasticsearch connection failed: %w", err)
+	}
+	defer client.close()
+
+	index := Index___datagen_nested_elasticsearch(config)
+	if !config.CreateIndex {
+		if err := client.deleteDocuments(index); err != nil {
+			return fmt.Errorf("failed to delete documents of index %s for model %s: %w", index, modelName, err)
+		}
+		slog.Info(fmt.Sprintf("successfully cleared data for %s from Elasticsearch", modelName))
+		return nil
+	}
+
+	mapping, err := Mapping___datagen_nested_elasticsearch()
+	if err != nil {
+		return fmt.Errorf("failed to create index %s for model %s: %w", index, modelName, err)
+	}
+	if err := client.deleteIndex(index); err != nil {
+		return fmt.Errorf("failed to delete index %s for model %s: %w", index, modelName, err)
+	}
+	if err := client.createIndex(index, mapping); err != nil {
+		return fmt.Errorf("failed to create index %s for model %s: %w", index, modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("successfully recreated index %s for %s in Elasticsearch", index, modelName))
+	return nil
+}
+
+// Create_elasticsearch___datagen_nested_index creates the index __datagen_nested data is indexed into with the mapping
+// of the model, unless it already exists
+func Create_elasticsearch___datagen_nested_index(modelName string, config *__dgi_ElasticsearchConfig) error {
+	slog.Debug(fmt.Sprintf("initializing Elasticsearch client for creating the index of %s", modelName))
+	client, err := __dgi_newESClient(config)
+	if err != nil {
+		return fmt.Errorf("Elasticsearch connection failed: %w", err)
+	}
+	defer client.close()
+
+	index := Index___datagen_nested_elasticsearch(config)
+	mapping, err := Mapping___datagen_nested_elasticsearch()
+	if err != nil {
+		return fmt.Errorf("failed to create index %s for model %s: %w", index, modelName, err)
+	}
+	if err := client.createIndex(index, mapping); err != nil {
+		return fmt.Errorf("failed to create index %s for model %s: %w", index, modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("index for %s is ready in Elasticsearch", modelName))
+	return nil
+}
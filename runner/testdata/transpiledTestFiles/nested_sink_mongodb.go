@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// __datagen_nested_mongodbSink inserts __datagen_nested data into a MongoDB collection
+type __datagen_nested_mongodbSink struct {
+	modelName     string
+	config        *__dgi_MongoDBConfig
+	client        *mongo.Client
+	collection    *mongo.Collection
+	total         int
+	totalInserted int
+}
+
+// Open_mongodb___datagen_nested_sink connects the MongoDB client __datagen_nested data is inserted with
+func Open_mongodb___datagen_nested_sink(modelName string, total int, config *__dgi_MongoDBConfig) (*__datagen_nested_mongodbSink, error) {
+	slog.Debug(fmt.Sprintf("initializing MongoDB client for %s with %d records", modelName, total))
+	client, err := Open___datagen_nested_mongodb_client(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [MongoDB] %s: FAILED\n   └─ Documents inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	collection := Collection___datagen_nested_mongodb(client, modelName, config)
+	slog.Debug(fmt.Sprintf("inserting %s into collection %s.%s with batch size %d", modelName, config.Database, collection.Name(), config.BatchSize))
+	return &__datagen_nested_mongodbSink{modelName: modelName, config: config, client: client, collection: collection, total: total}, nil
+}
+
+// Load inserts a chunk of __datagen_nested records in batches of config.BatchSize
+func (s *__datagen_nested_mongodbSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_nested, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_nested))
+	}
+
+	batchSize := s.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = max(len(records), 1)
+	}
+
+	for i := 0; i < len(records); i += batchSize {
+		end := i + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[i:end]
+
+		slog.Debug(fmt.Sprintf("inserting batch starting at %d of size %d for %s into MongoDB", s.totalInserted, len(batch), s.modelName))
+		if err := Load___datagen_nested_mongodb(batch, s.collection, s.config.ordered()); err != nil {
+			return fmt.Errorf("✘ [MongoDB] %s: FAILED\n   └─ Documents inserted: %d/%d\n   └─ Error: %v\n",
+				s.modelName, s.totalInserted, s.total, err)
+		}
+
+		s.totalInserted += len(batch)
+
+		if s.config.Throttle != "" && s.totalInserted < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
+				time.Sleep(throttleDuration)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit disconnects the MongoDB client; every document has already been acknowledged by InsertMany
+func (s *__datagen_nested_mongodbSink) Commit() error {
+	s.close()
+	slog.Info(fmt.Sprintf("successfully inserted %d/%d documents for %s into MongoDB collection %s", s.totalInserted, s.total, s.modelName, s.collection.Name()))
+	return nil
+}
+
+// Abort disconnects the MongoDB client; documents that were already inserted stay in the collection
+func (s *__datagen_nested_mongodbSink) Abort() {
+	s.close()
+}
+
+func (s *__datagen_nested_mongodbSink) close() {
+	if err := s.client.Disconnect(context.Background()); err != nil {
+		slog.Warn(fmt.Sprintf("failed to disconnect MongoDB client for %s: %s", s.modelName, err.Error()))
+	}
+}
+
+// Clear_mongodb___datagen_nested_data clears __datagen_nested data from MongoDB
+func Clear_mongodb___datagen_nested_data(modelName string, config *__dgi_MongoDBConfig) error {
+	slog.Debug(fmt.Sprintf("initializing MongoDB client for clearing data for %s", modelName))
+	if err := Init___datagen_nested_mongodb_client(config); err != nil {
+		return fmt.Errorf("MongoDB connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_nested_mongodb_client()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to disconnect MongoDB client: %s", err.Error()))
+		}
+	}()
+
+	client, err := Get___datagen_nested_mongodb_client()
+	if err != nil {
+		return fmt.Errorf("failed to get MongoDB client: %w", err)
+	}
+
+	collection := Collection___datagen_nested_mongodb(client, modelName, config)
+	if err := Truncate___datagen_nested_mongodb(collection, config); err != nil {
+		return fmt.Errorf("failed to clear collection for model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("successfully cleared data for %s from MongoDB", modelName))
+	return nil
+}
@@ -8,47 +8,67 @@ import (
 	"time"
 )
 
-// Sink_postgres___datagen_nested_data loads __datagen_nested data into Postgres
-func Sink_postgres___datagen_nested_data(modelName string, records []*__datagen_nested, config *__dgi_PostgresConfig) error {
-	slog.Debug(fmt.Sprintf("initializing Postgres connection for %s with %d records", modelName, len(records)))
-	if err := Init___datagen_nested_postgres_connection(config); err != nil {
-		return fmt.Errorf("✘ [Postgres] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
-			modelName, len(records), err)
-	}
-	defer func() {
-		err := Close___datagen_nested_postgres_connection()
-		if err != nil {
-			slog.Warn(fmt.Sprintf("failed to close DB connection for %s: %s", modelName, err.Error()))
-		}
-	}()
+// __datagen_nested_postgresSink streams __datagen_nested data into Postgres within a single transaction
+type __datagen_nested_postgresSink struct {
+	modelName string
+	config    *__dgi_PostgresConfig
+	stmt      __dgi_writeStatement
+	// useCopy is set when records are loaded with COPY rather than INSERT
+	useCopy       bool
+	db            *sql.DB
+	tx            *sql.Tx
+	total         int
+	totalInserted int
+}
 
-	db, err := Get___datagen_nested_postgres_connection()
+// Open_postgres___datagen_nested_sink connects to Postgres and starts the transaction __datagen_nested data is loaded in,
+// with the statement of the model's write mode
+func Open_postgres___datagen_nested_sink(modelName string, total int, config *__dgi_PostgresConfig, mode __dgi_WriteMode, keys []string) (*__datagen_nested_postgresSink, error) {
+	stmt, err := Statement___datagen_nested_postgres(mode, keys)
 	if err != nil {
-		return fmt.Errorf("✘ [Postgres] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
-			modelName, len(records), err)
+		return nil, fmt.Errorf("✘ [Postgres] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
 	}
 
-	batchSize := config.BatchSize
-	if batchSize <= 0 {
-		batchSize = len(records)
+	slog.Debug(fmt.Sprintf("initializing Postgres connection for %s with %d records", modelName, total))
+	db, err := Open___datagen_nested_postgres_connection(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [Postgres] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
 	}
 
-	totalInserted := 0
-
-	slog.Debug(fmt.Sprintf("starting Postgres transaction for %s with batch size %d", modelName, batchSize))
+	slog.Debug(fmt.Sprintf("starting Postgres transaction for %s with batch size %d", modelName, config.BatchSize))
 	tx, err := db.Begin()
 	if err != nil {
-		return fmt.Errorf("✘ [Postgres] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
-			modelName, totalInserted, len(records), err)
+		if closeErr := db.Close(); closeErr != nil {
+			slog.Warn(fmt.Sprintf("failed to close DB connection for %s: %s", modelName, closeErr.Error()))
+		}
+		return nil, fmt.Errorf("✘ [Postgres] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
 	}
 
-	defer func() {
-		if err := tx.Rollback(); err != nil {
-			if !errors.Is(err, sql.ErrTxDone) {
-				slog.Error(fmt.Sprintf("error rolling back transaction for %s: %s", modelName, err.Error()))
-			}
-		}
-	}()
+	// COPY cannot resolve conflicts, so other write modes insert
+	useCopy := config.copies() && (mode == "" || mode == __dgi_WriteModeInsert)
+	if config.copies() && !useCopy {
+		slog.Debug(fmt.Sprintf("loading %s into Postgres with INSERT statements for write_mode %s", modelName, mode))
+	}
+	return &__datagen_nested_postgresSink{modelName: modelName, config: config, stmt: stmt, useCopy: useCopy, db: db, tx: tx, total: total}, nil
+}
+
+// Load loads a chunk of __datagen_nested records in batches of config.BatchSize, with COPY or with INSERT statements
+// kept under the parameter limit of Postgres
+func (s *__datagen_nested_postgresSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_nested, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_nested))
+	}
+
+	batchSize := s.config.BatchSize
+	if !s.useCopy {
+		batchSize = __dgi_insertBatchSize(batchSize, 2, __dgi_maxPlaceholders)
+	} else if batchSize <= 0 {
+		batchSize = max(len(records), 1)
+	}
 
 	for i := 0; i < len(records); i += batchSize {
 		end := i + batchSize
@@ -57,31 +77,58 @@ func Sink_postgres___datagen_nested_data(modelName string, records []*__datagen_
 		}
 		batch := records[i:end]
 
-		slog.Debug(fmt.Sprintf("loading batch starting at %d of size %d for %s into Postgres", i, len(batch), modelName))
-		if err := Load___datagen_nested_postgres(batch, tx); err != nil {
+		slog.Debug(fmt.Sprintf("loading batch starting at %d of size %d for %s into Postgres", s.totalInserted, len(batch), s.modelName))
+		var err error
+		if s.useCopy {
+			err = Copy___datagen_nested_postgres(batch, s.tx)
+		} else {
+			err = Load___datagen_nested_postgres(batch, s.tx, s.stmt)
+		}
+		if err != nil {
 			return fmt.Errorf("✘ [Postgres] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
-				modelName, totalInserted, len(records), err)
+				s.modelName, s.totalInserted, s.total, err)
 		}
 
-		totalInserted += len(batch)
+		s.totalInserted += len(batch)
 
-		if config.Throttle != "" && end < len(records) {
-			if throttleDuration, err := time.ParseDuration(config.Throttle); err == nil {
-				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, modelName))
+		if s.config.Throttle != "" && s.totalInserted < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
 				time.Sleep(throttleDuration)
 			}
 		}
 	}
+	return nil
+}
 
-	if err := tx.Commit(); err != nil {
+// Commit commits the transaction and closes the Postgres connection
+func (s *__datagen_nested_postgresSink) Commit() error {
+	defer s.close()
+	if err := s.tx.Commit(); err != nil {
 		return fmt.Errorf("✘ [Postgres] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
-			modelName, totalInserted, len(records), err)
+			s.modelName, s.totalInserted, s.total, err)
 	}
 
-	slog.Info(fmt.Sprintf("successfully loaded %d/%d rows for %s into Postgres", totalInserted, len(records), modelName))
+	slog.Info(fmt.Sprintf("successfully loaded %d/%d rows for %s into Postgres", s.totalInserted, s.total, s.modelName))
 	return nil
 }
 
+// Abort rolls back the transaction and closes the Postgres connection
+func (s *__datagen_nested_postgresSink) Abort() {
+	defer s.close()
+	if err := s.tx.Rollback(); err != nil {
+		if !errors.Is(err, sql.ErrTxDone) {
+			slog.Error(fmt.Sprintf("error rolling back transaction for %s: %s", s.modelName, err.Error()))
+		}
+	}
+}
+
+func (s *__datagen_nested_postgresSink) close() {
+	if err := s.db.Close(); err != nil {
+		slog.Warn(fmt.Sprintf("failed to close DB connection for %s: %s", s.modelName, err.Error()))
+	}
+}
+
 // Clear_postgres___datagen_nested_data clears __datagen_nested data from Postgres
 func Clear_postgres___datagen_nested_data(modelName string, config *__dgi_PostgresConfig) error {
 	slog.Debug(fmt.Sprintf("initializing Postgres connection for clearing data for %s", modelName))
@@ -126,3 +173,30 @@ func Clear_postgres___datagen_nested_data(modelName string, config *__dgi_Postgr
 	slog.Info(fmt.Sprintf("successfully cleared data for %s from Postgres", modelName))
 	return nil
 }
+
+// Create_postgres___datagen_nested_table creates the table __datagen_nested data is loaded into in Postgres, unless it already exists
+func Create_postgres___datagen_nested_table(modelName string, config *__dgi_PostgresConfig) error {
+	slog.Debug(fmt.Sprintf("initializing Postgres connection for creating the table of %s", modelName))
+	if err := Init___datagen_nested_postgres_connection(config); err != nil {
+		return fmt.Errorf("Postgres connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_nested_postgres_connection()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to close DB connection: %s", err.Error()))
+		}
+	}()
+
+	db, err := Get___datagen_nested_postgres_connection()
+	if err != nil {
+		return fmt.Errorf("failed to get Postgres connection: %w", err)
+	}
+
+	if err := Create___datagen_nested_postgres_table(db); err != nil {
+		return fmt.Errorf("failed to create table for model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("table for %s is ready in Postgres", modelName))
+	return nil
+}
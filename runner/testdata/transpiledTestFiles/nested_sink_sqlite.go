@@ -0,0 +1,184 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// __datagen_nested_sqliteSink writes __datagen_nested data to a SQLite database file within a single transaction
+type __datagen_nested_sqliteSink struct {
+	modelName     string
+	config        *__dgi_SQLiteConfig
+	stmt          __dgi_writeStatement
+	db            *sql.DB
+	tx            *sql.Tx
+	total         int
+	totalInserted int
+}
+
+// Open_sqlite___datagen_nested_sink opens the SQLite database and starts the transaction __datagen_nested data is loaded in,
+// with the statement of the model's write mode
+func Open_sqlite___datagen_nested_sink(modelName string, total int, config *__dgi_SQLiteConfig, mode __dgi_WriteMode, keys []string) (*__datagen_nested_sqliteSink, error) {
+	stmt, err := Statement___datagen_nested_sqlite(mode, keys)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [SQLite] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("initializing SQLite connection for %s with %d records", modelName, total))
+	db, err := Open___datagen_nested_sqlite_connection(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [SQLite] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("starting SQLite transaction for %s with batch size %d", modelName, config.BatchSize))
+	tx, err := db.Begin()
+	if err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			slog.Warn(fmt.Sprintf("failed to close DB connection for %s: %s", modelName, closeErr.Error()))
+		}
+		return nil, fmt.Errorf("✘ [SQLite] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	return &__datagen_nested_sqliteSink{modelName: modelName, config: config, stmt: stmt, db: db, tx: tx, total: total}, nil
+}
+
+// Load loads a chunk of __datagen_nested records in batches of config.BatchSize, with INSERT statements kept under
+// the parameter limit of SQLite
+func (s *__datagen_nested_sqliteSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_nested, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_nested))
+	}
+
+	batchSize := __dgi_insertBatchSize(s.config.BatchSize, 2, __dgi_sqliteMaxVariables)
+
+	for i := 0; i < len(records); i += batchSize {
+		end := i + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[i:end]
+
+		slog.Debug(fmt.Sprintf("loading batch starting at %d of size %d for %s into SQLite", s.totalInserted, len(batch), s.modelName))
+		if err := Load___datagen_nested_sqlite(batch, s.tx, s.stmt); err != nil {
+			return fmt.Errorf("✘ [SQLite] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
+				s.modelName, s.totalInserted, s.total, err)
+		}
+
+		s.totalInserted += len(batch)
+
+		if s.config.Throttle != "" && s.totalInserted < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
+				time.Sleep(throttleDuration)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit commits the transaction and closes the SQLite connection
+func (s *__datagen_nested_sqliteSink) Commit() error {
+	defer s.close()
+	if err := s.tx.Commit(); err != nil {
+		return fmt.Errorf("✘ [SQLite] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
+			s.modelName, s.totalInserted, s.total, err)
+	}
+
+	slog.Info(fmt.Sprintf("successfully loaded %d/%d rows for %s into SQLite", s.totalInserted, s.total, s.modelName))
+	return nil
+}
+
+// Abort rolls back the transaction and closes the SQLite connection
+func (s *__datagen_nested_sqliteSink) Abort() {
+	defer s.close()
+	if err := s.tx.Rollback(); err != nil {
+		if !errors.Is(err, sql.ErrTxDone) {
+			slog.Error(fmt.Sprintf("error rolling back transaction for %s: %s", s.modelName, err.Error()))
+		}
+	}
+}
+
+func (s *__datagen_nested_sqliteSink) close() {
+	if err := s.db.Close(); err != nil {
+		slog.Warn(fmt.Sprintf("failed to close DB connection for %s: %s", s.modelName, err.Error()))
+	}
+}
+
+// Clear_sqlite___datagen_nested_data clears __datagen_nested data from SQLite
+func Clear_sqlite___datagen_nested_data(modelName string, config *__dgi_SQLiteConfig) error {
+	slog.Debug(fmt.Sprintf("initializing SQLite connection for clearing data for %s", modelName))
+	if err := Init___datagen_nested_sqlite_connection(config); err != nil {
+		return fmt.Errorf("SQLite connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_nested_sqlite_connection()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to close DB connection: %s", err.Error()))
+		}
+	}()
+
+	db, err := Get___datagen_nested_sqlite_connection()
+	if err != nil {
+		return fmt.Errorf("failed to get SQLite connection: %w", err)
+	}
+
+	slog.Debug(fmt.Sprintf("starting SQLite transaction for clearing data for %s", modelName))
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction for clearing model %s: %w", modelName, err)
+	}
+
+	if err := Truncate___datagen_nested_sqlite(tx); err != nil {
+		return fmt.Errorf("failed to truncate table for model %s: %w", modelName, err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if !errors.Is(err, sql.ErrTxDone) {
+				slog.Error(fmt.Sprintf("error rolling back transaction for %s: %s", modelName, err.Error()))
+			}
+		}
+	}()
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction for clearing model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("successfully cleared data for %s from SQLite", modelName))
+	return nil
+}
+
+// Create_sqlite___datagen_nested_table creates the table __datagen_nested data is loaded into in SQLite, unless it already exists
+func Create_sqlite___datagen_nested_table(modelName string, config *__dgi_SQLiteConfig) error {
+	slog.Debug(fmt.Sprintf("initializing SQLite connection for creating the table of %s", modelName))
+	if err := Init___datagen_nested_sqlite_connection(config); err != nil {
+		return fmt.Errorf("SQLite connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_nested_sqlite_connection()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to close DB connection: %s", err.Error()))
+		}
+	}()
+
+	db, err := Get___datagen_nested_sqlite_connection()
+	if err != nil {
+		return fmt.Errorf("failed to get SQLite connection: %w", err)
+	}
+
+	if err := Create___datagen_nested_sqlite_table(db); err != nil {
+		return fmt.Errorf("failed to create table for model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("table for %s is ready in SQLite", modelName))
+	return nil
+}
@@ -0,0 +1,482 @@
+package main
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressions of the output files
+const (
+	__dgi_CompressNone = "none"
+	__dgi_CompressGzip = "gzip"
+	__dgi_CompressZstd = "zstd"
+)
+
+// __dgi_compressExts maps compressions to the extensions they add to files
+var __dgi_compressExts = map[string]string{
+	__dgi_CompressGzip: "gz",
+	__dgi_CompressZstd: "zst",
+}
+
+// __dgi_OutputOptions holds the flags compressing and splitting the files of
+// every format.
+type __dgi_OutputOptions struct {
+	Compress  string
+	SplitRows int
+	SplitSize string
+	// splitBytes is SplitSize in bytes, set by validate
+	splitBytes int64
+}
+
+func (o *__dgi_OutputOptions) validate(format string) error {
+	switch o.Compress {
+	case "", __dgi_CompressNone, __dgi_CompressGzip, __dgi_CompressZstd:
+	default:
+		return fmt.Errorf("--compress must be one of %s", strings.Join([]string{__dgi_CompressNone, __dgi_CompressGzip, __dgi_CompressZstd}, ", "))
+	}
+	if o.SplitRows < 0 {
+		return fmt.Errorf("--split-rows must not be negative, got %d", o.SplitRows)
+	}
+	if strings.TrimSpace(o.SplitSize) != "" {
+		size, err := __dgi_parseSize(o.SplitSize)
+		if err != nil {
+			return fmt.Errorf("--split-size: %w", err)
+		}
+		o.splitBytes = size
+	}
+	if format == __dgi_FormatStdout && (o.compressed() || o.split()) {
+		return fmt.Errorf("--compress, --split-rows and --split-size do not apply to the %s format", __dgi_FormatStdout)
+	}
+	return nil
+}
+
+func (o __dgi_OutputOptions) compressed() bool {
+	return o.Compress != "" && o.Compress != __dgi_CompressNone
+}
+
+func (o __dgi_OutputOptions) split() bool {
+	return o.SplitRows > 0 || o.splitBytes > 0
+}
+
+// __dgi_parseSize parses a positive size such as 512MB, in bytes or in B, KB,
+// MB, GB or TB, which are powers of 1024.
+func __dgi_parseSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		bytes  int64
+	}{{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}}
+
+	text, scale := strings.ToUpper(strings.TrimSpace(s)), int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(text, unit.suffix) {
+			text, scale = strings.TrimSpace(strings.TrimSuffix(text, unit.suffix)), unit.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || n <= 0 || n > (1<<63-1)/scale {
+		return 0, fmt.Errorf("invalid size %q, expected a positive number of B, KB, MB, GB or TB", s)
+	}
+	return n * scale, nil
+}
+
+// __dgi_OutputFile is a file a writer writes a model to, compressed as the
+// flags ask, which shows up at its path once closed. Its size and checksum
+// are those of the bytes written to the destination.
+type __dgi_OutputFile struct {
+	path   string
+	ext    string
+	files  *__dgi_outputFiles
+	upload __dgi_Upload
+	// shared is set for appendable files, which are committed with the run
+	shared     bool
+	closed     bool
+	out        io.Writer
+	compressor io.WriteCloser
+	size       int64
+	hash       hash.Hash
+}
+
+// __dgi_rawOutput writes to the destination, counting and hashing the bytes.
+type __dgi_rawOutput struct {
+	f *__dgi_OutputFile
+}
+
+func (r __dgi_rawOutput) Write(p []byte) (int, error) {
+	n, err := r.f.upload.Write(p)
+	r.f.size += int64(n)
+	r.f.hash.Write(p[:n])
+	return n, err
+}
+
+func (f *__dgi_OutputFile) Write(p []byte) (int, error) {
+	return f.out.Write(p)
+}
+
+// Name returns the path of the file.
+func (f *__dgi_OutputFile) Name() string {
+	return f.path
+}
+
+// Sibling returns the path of a file named as this one with the extension
+// ext, for the schemas written next to it.
+func (f *__dgi_OutputFile) Sibling(ext string) string {
+	return strings.TrimSuffix(f.path, "."+f.ext) + "." + ext
+}
+
+// WriteSibling writes data, uncompressed, to the file of Sibling(ext).
+func (f *__dgi_OutputFile) WriteSibling(ext string, data []byte) error {
+	return f.files.writeFile(f.Sibling(ext), data)
+}
+
+// Size returns the number of bytes written to the destination so far.
+func (f *__dgi_OutputFile) Size() int64 {
+	return f.size
+}
+
+// Checksum returns the SHA-256 of the bytes written to the destination, in
+// hex.
+func (f *__dgi_OutputFile) Checksum() string {
+	return hex.EncodeToString(f.hash.Sum(nil))
+}
+
+// Close flushes the compressed stream and commits the file, or drops it when
+// the stream cannot be flushed. Closing it again does nothing.
+func (f *__dgi_OutputFile) Close() error {
+	if f.closed {
+		return nil
+	}
+	if f.compressor != nil {
+		if err := f.compressor.Close(); err != nil {
+			f.Discard()
+			return err
+		}
+	}
+	f.closed = true
+	if f.shared {
+		return nil
+	}
+	delete(f.files.pending, f.upload)
+	return f.upload.Commit()
+}
+
+// Discard drops the file of a writer that got no records or failed to write
+// its end, so that no truncated file is committed. It does nothing once the
+// file is closed. Appendable files are left to the other models writing to
+// them, and dropped with the run when it fails.
+func (f *__dgi_OutputFile) Discard() error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	if f.shared {
+		return nil
+	}
+	delete(f.files.pending, f.upload)
+	return f.upload.Abort()
+}
+
+// __dgi_OutputFileFactory creates the file of a writer with the extension of
+// its format. Appendable files that another model of the run already wrote
+// to are appended to.
+type __dgi_OutputFileFactory func(ext string, appendable bool) (*__dgi_OutputFile, error)
+
+// __dgi_sharedUpload is an appendable file, which several models of a run
+// may write to.
+type __dgi_sharedUpload struct {
+	path   string
+	upload __dgi_Upload
+	size   int64
+}
+
+func (u *__dgi_sharedUpload) Write(p []byte) (int, error) {
+	n, err := u.upload.Write(p)
+	u.size += int64(n)
+	return n, err
+}
+
+func (u *__dgi_sharedUpload) Commit() error {
+	// nothing is left behind when no model wrote to the file
+	if u.size == 0 {
+		return u.upload.Abort()
+	}
+	return u.upload.Commit()
+}
+
+func (u *__dgi_sharedUpload) Abort() error {
+	return u.upload.Abort()
+}
+
+// __dgi_outputFiles creates the output files of a run in its destination.
+// Files of writers that do not finish are dropped by abort, and appendable
+// files are committed by commit once every model is written.
+type __dgi_outputFiles struct {
+	dest    __dgi_Destination
+	opts    __dgi_OutputOptions
+	shared  []*__dgi_sharedUpload
+	pending map[__dgi_Upload]struct{}
+}
+
+func __dgi_newOutputFiles(outPath string, opts __dgi_OutputOptions) (*__dgi_outputFiles, error) {
+	dest, err := __dgi_newDestination(outPath)
+	if err != nil {
+		return nil, err
+	}
+	return &__dgi_outputFiles{dest: dest, opts: opts, pending: map[__dgi_Upload]struct{}{}}, nil
+}
+
+// ext returns the extension of the files of a format, compression included.
+func (o *__dgi_outputFiles) ext(format string) string {
+	if o.opts.compressed() {
+		return format + "." + __dgi_compressExts[o.opts.Compress]
+	}
+	return format
+}
+
+// create starts the file at path, whose extension ext includes the
+// compression.
+func (o *__dgi_outputFiles) create(path, ext string, appendable bool) (*__dgi_OutputFile, error) {
+	f := &__dgi_OutputFile{path: path, ext: ext, files: o, shared: appendable, hash: sha256.New()}
+	if appendable {
+		for _, shared := range o.shared {
+			if shared.path == path {
+				f.upload = shared
+			}
+		}
+	}
+	if f.upload == nil {
+		upload, err := o.dest.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		if appendable {
+			shared := &__dgi_sharedUpload{path: path, upload: upload}
+			o.shared = append(o.shared, shared)
+			f.upload = shared
+		} else {
+			o.pending[upload] = struct{}{}
+			f.upload = upload
+		}
+	}
+
+	f.out = __dgi_rawOutput{f}
+	switch o.opts.Compress {
+	case __dgi_CompressGzip:
+		f.compressor = gzip.NewWriter(f.out)
+	case __dgi_CompressZstd:
+		encoder, err := zstd.NewWriter(f.out)
+		if err != nil {
+			f.Discard()
+			return nil, fmt.Errorf("error creating zstd stream: %v", err)
+		}
+		f.compressor = encoder
+	}
+	if f.compressor != nil {
+		f.out = f.compressor
+	}
+	return f, nil
+}
+
+// writeFile writes data to the file at path.
+func (o *__dgi_outputFiles) writeFile(path string, data []byte) error {
+	upload, err := o.dest.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := upload.Write(data); err != nil {
+		upload.Abort()
+		return err
+	}
+	return upload.Commit()
+}
+
+// commit commits the appendable files of the run.
+func (o *__dgi_outputFiles) commit() error {
+	var errs []error
+	for _, shared := range o.shared {
+		if err := shared.Commit(); err != nil {
+			errs = append(errs, fmt.Errorf("error committing %s: %w", shared.path, err))
+		}
+	}
+	o.shared = nil
+	return errors.Join(errs...)
+}
+
+// abort drops every file that is not committed yet.
+func (o *__dgi_outputFiles) abort() {
+	for upload := range o.pending {
+		upload.Abort()
+	}
+	for _, shared := range o.shared {
+		shared.Abort()
+	}
+	o.pending, o.shared = map[__dgi_Upload]struct{}{}, nil
+}
+
+// newWriter returns a writer of the model name, whose files are split in
+// parts when the flags ask for it.
+func (o *__dgi_outputFiles) newWriter(factory __dgi_OutputWriterFactory, name string) (__dgi_OutputWriter, error) {
+	if !o.opts.split() {
+		return factory(name, func(ext string, appendable bool) (*__dgi_OutputFile, error) {
+			fullExt := o.ext(ext)
+			path, err := o.dest.Resolve(name, fullExt)
+			if err != nil {
+				return nil, err
+			}
+			return o.create(path, fullExt, appendable)
+		})
+	}
+	return &__dgi_splitWriter{name: name, files: o, factory: factory}, nil
+}
+
+// __dgi_ManifestPart is a part file of a model in its manifest. Path is
+// relative to the manifest.
+type __dgi_ManifestPart struct {
+	Path   string `json:"path"`
+	Rows   int    `json:"rows"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// __dgi_Manifest lists the part files of a model.
+type __dgi_Manifest struct {
+	Model       string               `json:"model"`
+	Format      string               `json:"format"`
+	Compression string               `json:"compression"`
+	Rows        int                  `json:"rows"`
+	Parts       []__dgi_ManifestPart `json:"parts"`
+}
+
+// __dgi_splitWriter writes the records of a model to numbered part files of
+// at most SplitRows records, closing a part once it grows past SplitSize.
+// Every part is written by a writer of its own, so that each one is a whole
+// file of the format. A manifest of the parts is written on Close.
+type __dgi_splitWriter struct {
+	name     string
+	files    *__dgi_outputFiles
+	factory  __dgi_OutputWriterFactory
+	writer   __dgi_OutputWriter
+	file     *__dgi_OutputFile
+	format   string
+	manifest string
+	rows     int
+	parts    []__dgi_ManifestPart
+}
+
+// openPart starts the next part file.
+func (w *__dgi_splitWriter) openPart() error {
+	part := len(w.parts) + 1
+	writer, err := w.factory(w.name, func(ext string, _ bool) (*__dgi_OutputFile, error) {
+		fullExt := w.files.ext(ext)
+		path, err := w.files.dest.Resolve(w.name, fullExt)
+		if err != nil {
+			return nil, err
+		}
+		base := strings.TrimSuffix(path, "."+fullExt)
+		w.format, w.manifest = ext, base+".manifest.json"
+		file, err := w.files.create(fmt.Sprintf("%s-%05d.%s", base, part, fullExt), fullExt, false)
+		w.file = file
+		return file, err
+	})
+	if err != nil {
+		return err
+	}
+	w.writer, w.rows = writer, 0
+	return nil
+}
+
+// closePart closes the current part file and adds it to the manifest.
+func (w *__dgi_splitWriter) closePart() error {
+	err := w.writer.Close()
+	w.writer = nil
+	if err != nil {
+		return err
+	}
+	if w.file == nil {
+		return nil
+	}
+	w.parts = append(w.parts, __dgi_ManifestPart{
+		Path:   filepath.Base(w.file.Name()),
+		Rows:   w.rows,
+		Bytes:  w.file.Size(),
+		SHA256: w.file.Checksum(),
+	})
+	w.file = nil
+	return nil
+}
+
+func (w *__dgi_splitWriter) Write(records []__dgi_Record) error {
+	opts := w.files.opts
+	for len(records) > 0 {
+		if w.writer == nil {
+			if err := w.openPart(); err != nil {
+				return err
+			}
+		}
+		n := len(records)
+		if opts.SplitRows > 0 {
+			n = min(n, opts.SplitRows-w.rows)
+		}
+		if opts.splitBytes > 0 {
+			// the size is checked after every record
+			n = 1
+		}
+		if err := w.writer.Write(records[:n]); err != nil {
+			return err
+		}
+		w.rows += n
+		records = records[n:]
+
+		full := opts.SplitRows > 0 && w.rows >= opts.SplitRows
+		if opts.splitBytes > 0 && w.file != nil && w.file.Size() >= opts.splitBytes {
+			full = true
+		}
+		if full {
+			if err := w.closePart(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *__dgi_splitWriter) Close() error {
+	if w.writer != nil {
+		if err := w.closePart(); err != nil {
+			return err
+		}
+	}
+	if len(w.parts) == 0 {
+		slog.Info(fmt.Sprintf("no records for %s, no part files generated", w.name))
+		return nil
+	}
+
+	manifest := __dgi_Manifest{Model: w.name, Format: w.format, Compression: w.files.opts.Compress, Parts: w.parts}
+	if manifest.Compression == "" {
+		manifest.Compression = __dgi_CompressNone
+	}
+	for _, part := range w.parts {
+		manifest.Rows += part.Rows
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling manifest for %s: %w", w.name, err)
+	}
+	if err := w.files.writeFile(w.manifest, append(data, '\n')); err != nil {
+		return fmt.Errorf("error writing manifest for %s: %w", w.name, err)
+	}
+	slog.Info(fmt.Sprintf("generated manifest %s of %d parts with %d records", w.manifest, len(w.parts), manifest.Rows))
+	return nil
+}
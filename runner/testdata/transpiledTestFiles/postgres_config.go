@@ -13,6 +13,13 @@ type __dgi_PostgresConfig struct {
 	BatchSize      int    `json:"batch_size,omitempty"`
 	Timeout        string `json:"timeout,omitempty"`
 	Throttle       string `json:"throttle,omitempty"`
+	// Copy loads records with COPY rather than INSERT statements, unless set
+	// to false.
+	Copy           *bool  `json:"copy,omitempty"`
+}
+
+func (c *__dgi_PostgresConfig) copies() bool {
+	return c.Copy == nil || *c.Copy
 }
 
 func (c *__dgi_PostgresConfig) Validate() error {
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Protobuf wire types
+const (
+	__dgi_protoVarint  = 0
+	__dgi_protoFixed64 = 1
+	__dgi_protoBytes   = 2
+	__dgi_protoFixed32 = 5
+)
+
+// __dgi_protoEncode appends the Protobuf encoding of a message made of
+// values to b, numbering fields from 1 in order. The encoding follows the
+// schema codegen derives from the field types: nil pointers are left out,
+// slices are repeated fields, maps map fields, times
+// google.protobuf.Timestamp messages and structs messages of their exported
+// fields.
+func __dgi_protoEncode(b []byte, values ...any) ([]byte, error) {
+	var err error
+	for i, v := range values {
+		if b, err = __dgi_protoAppendField(b, i+1, reflect.ValueOf(v)); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func __dgi_protoAppendTag(b []byte, num, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(num)<<3|uint64(wireType))
+}
+
+func __dgi_protoAppendBytes(b []byte, num int, data []byte) []byte {
+	b = __dgi_protoAppendTag(b, num, __dgi_protoBytes)
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// __dgi_protoPackable reports whether values of kind k are packed when
+// repeated, and the wire type each of them has.
+func __dgi_protoPackable(k reflect.Kind) (int, bool) {
+	switch k {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return __dgi_protoVarint, true
+	case reflect.Float32:
+		return __dgi_protoFixed32, true
+	case reflect.Float64:
+		return __dgi_protoFixed64, true
+	}
+	return 0, false
+}
+
+// __dgi_protoAppendScalar appends a scalar value without its tag.
+func __dgi_protoAppendScalar(b []byte, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendUvarint(b, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return binary.AppendUvarint(b, v.Uint())
+	case reflect.Float32:
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Float()))
+	}
+	return b
+}
+
+func __dgi_protoAppendField(b []byte, num int, v reflect.Value) ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("cannot encode untyped nil in Protobuf")
+	}
+	if v.Type() == __dgi_timeType {
+		t := v.Interface().(time.Time)
+		var ts []byte
+		ts = binary.AppendUvarint(__dgi_protoAppendTag(ts, 1, __dgi_protoVarint), uint64(t.Unix()))
+		ts = binary.AppendUvarint(__dgi_protoAppendTag(ts, 2, __dgi_protoVarint), uint64(t.Nanosecond()))
+		return __dgi_protoAppendBytes(b, num, ts), nil
+	}
+	if wireType, ok := __dgi_protoPackable(v.Kind()); ok {
+		return __dgi_protoAppendScalar(__dgi_protoAppendTag(b, num, wireType), v), nil
+	}
+
+	var err error
+	switch v.Kind() {
+	case reflect.String:
+		return __dgi_protoAppendBytes(b, num, []byte(v.String())), nil
+	case reflect.Pointer:
+		if v.IsNil() {
+			return b, nil
+		}
+		return __dgi_protoAppendField(b, num, v.Elem())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return __dgi_protoAppendBytes(b, num, v.Bytes()), nil
+		}
+		if v.Len() == 0 {
+			return b, nil
+		}
+		if _, ok := __dgi_protoPackable(v.Type().Elem().Kind()); ok {
+			var packed []byte
+			for i := 0; i < v.Len(); i++ {
+				packed = __dgi_protoAppendScalar(packed, v.Index(i))
+			}
+			return __dgi_protoAppendBytes(b, num, packed), nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if b, err = __dgi_protoAppendField(b, num, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Map:
+		// keys are sorted so that seeded runs write the same bytes
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, key := range keys {
+			var entry []byte
+			if entry, err = __dgi_protoAppendField(entry, 1, key); err != nil {
+				return nil, err
+			}
+			if entry, err = __dgi_protoAppendField(entry, 2, v.MapIndex(key)); err != nil {
+				return nil, err
+			}
+			b = __dgi_protoAppendBytes(b, num, entry)
+		}
+		return b, nil
+	case reflect.Struct:
+		var msg []byte
+		fieldNum := 0
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			fieldNum++
+			if msg, err = __dgi_protoAppendField(msg, fieldNum, v.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+		return __dgi_protoAppendBytes(b, num, msg), nil
+	}
+	return nil, fmt.Errorf("cannot encode %s in Protobuf", v.Type())
+}
+
+// __dgi_protobufWriter writes the records of a model as length-delimited
+// Protobuf messages, each preceded by its size as a varint, and the message
+// definition to a .proto file next to them. The definition comes with the
+// first record, so models without records get neither.
+type __dgi_protobufWriter struct {
+	name   string
+	file   *__dgi_OutputFile
+	writer *bufio.Writer
+	count  int
+}
+
+func __dgi_newProtobufWriter(name string, create __dgi_OutputFileFactory) (__dgi_OutputWriter, error) {
+	pbFile, err := create(__dgi_ProtobufExt, false)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Protobuf file for %s: %v", name, err)
+	}
+	return &__dgi_protobufWriter{name: name, file: pbFile}, nil
+}
+
+func (w *__dgi_protobufWriter) Write(records []__dgi_Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if w.writer == nil {
+		schema, err := records[0].ProtoSchema()
+		if err != nil {
+			return err
+		}
+		if err := w.file.WriteSibling("proto", []byte(schema)); err != nil {
+			return fmt.Errorf("error writing Protobuf schema for %s: %w", w.name, err)
+		}
+		w.writer = bufio.NewWriter(w.file)
+	}
+
+	var size []byte
+	for _, record := range records {
+		msg, err := record.ToProto()
+		if err != nil {
+			return fmt.Errorf("error encoding Protobuf message for %s: %w", w.name, err)
+		}
+		size = binary.AppendUvarint(size[:0], uint64(len(msg)))
+		if _, err := w.writer.Write(size); err != nil {
+			return fmt.Errorf("error writing Protobuf message for %s: %w", w.name, err)
+		}
+		if _, err := w.writer.Write(msg); err != nil {
+			return fmt.Errorf("error writing Protobuf message for %s: %w", w.name, err)
+		}
+	}
+	w.count += len(records)
+	return nil
+}
+
+func (w *__dgi_protobufWriter) Close() error {
+	if w.writer == nil {
+		if err := w.file.Discard(); err != nil {
+			return fmt.Errorf("error removing empty Protobuf file for %s: %w", w.name, err)
+		}
+		slog.Info(fmt.Sprintf("no records for %s, no Protobuf file generated", w.name))
+		return nil
+	}
+	defer w.file.Discard()
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("error flushing Protobuf file for %s: %w", w.name, err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("error closing Protobuf file for %s: %w", w.name, err)
+	}
+	slog.Info(fmt.Sprintf("generated Protobuf file %s with %d records", w.file.Name(), w.count))
+	return nil
+}
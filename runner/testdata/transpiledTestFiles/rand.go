@@ -0,0 +1,89 @@
+package main
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// __dgi_rootSeed is the seed every random stream is derived from. It is
+// random unless a seed is given through --seed or the execute config.
+var __dgi_rootSeed = __dgi_randomSeed()
+
+// __dgi_sharedRand serves misc code that is not passed the stream of a field,
+// such as methods and package level variables. It is safe for concurrent use,
+// but its values depend on the order it is drawn from.
+var __dgi_sharedRand = __dgi_newSharedRand(__dgi_rootSeed)
+
+// __dgi_Rand is the random stream stdlib helpers draw from.
+type __dgi_Rand struct {
+	rand  *rand.Rand
+	faker *gofakeit.Faker
+}
+
+func __dgi_newRand(src rand.Source) *__dgi_Rand {
+	return &__dgi_Rand{rand: rand.New(src), faker: gofakeit.NewFaker(src, false)}
+}
+
+// __dgi_streamKey identifies the random stream of a field, independently of
+// the seed.
+func __dgi_streamKey(model, field string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(model))
+	h.Write([]byte{0})
+	h.Write([]byte(field))
+	return h.Sum64()
+}
+
+// __dgi_fieldRand returns the stream a gen function draws from for the value
+// at iter. It only depends on the seed, the stream key and iter, so a value
+// comes out the same whichever worker generates it and in whatever order.
+func __dgi_fieldRand(key uint64, iter int) *__dgi_Rand {
+	stream := __dgi_mix(__dgi_rootSeed ^ key)
+	return __dgi_newRand(rand.NewPCG(stream, __dgi_mix(stream+uint64(iter))))
+}
+
+// __dgi_callsRand returns the stream the calls arguments of a field draw
+// from. Every worker evaluates them for its own generators, so they have to
+// come out the same each time.
+func __dgi_callsRand(key uint64) *__dgi_Rand {
+	return __dgi_fieldRand(key, -1)
+}
+
+func __dgi_setRootSeed(seed uint64) {
+	__dgi_rootSeed = seed
+	__dgi_sharedRand = __dgi_newSharedRand(seed)
+}
+
+func __dgi_newSharedRand(seed uint64) *__dgi_Rand {
+	return __dgi_newRand(&__dgi_lockedSource{src: rand.NewPCG(__dgi_mix(seed), __dgi_mix(^seed))})
+}
+
+func __dgi_randomSeed() uint64 {
+	var b [8]byte
+	_, _ = crand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// __dgi_mix is the splitmix64 finalizer, used to spread related seeds apart.
+func __dgi_mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+type __dgi_lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *__dgi_lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// __dgi_redisKey is a parsed key template: literals surround the values of
+// fields, so there is one more literal than there are fields.
+type __dgi_redisKey struct {
+	literals []string
+	fields   []string
+}
+
+// __dgi_parseRedisKey parses a key template whose {field} placeholders name
+// fields of the model.
+func __dgi_parseRedisKey(tmpl string) (*__dgi_redisKey, error) {
+	k := &__dgi_redisKey{}
+	rest := tmpl
+	for {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			k.literals = append(k.literals, rest)
+			return k, nil
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("key %q has an unopened }", tmpl)
+		}
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end < 0 || rest[open+1+end] != '}' {
+			return nil, fmt.Errorf("key %q has an unclosed {", tmpl)
+		}
+		field := strings.TrimSpace(rest[open+1 : open+1+end])
+		if field == "" {
+			return nil, fmt.Errorf("key %q has an empty placeholder", tmpl)
+		}
+		k.literals = append(k.literals, rest[:open])
+		k.fields = append(k.fields, field)
+		rest = rest[open+2+end:]
+	}
+}
+
+// render returns the key of a record, reading the values of its fields with
+// value.
+func (k *__dgi_redisKey) render(value func(field string) (any, error)) (string, error) {
+	var b strings.Builder
+	for i, field := range k.fields {
+		b.WriteString(k.literals[i])
+		v, err := value(field)
+		if err != nil {
+			return "", err
+		}
+		s, ok, err := __dgi_redisValue(v)
+		if err != nil {
+			return "", fmt.Errorf("key field %s: %w", field, err)
+		}
+		if !ok {
+			return "", fmt.Errorf("key field %s is null", field)
+		}
+		b.WriteString(s)
+	}
+	b.WriteString(k.literals[len(k.fields)])
+	return b.String(), nil
+}
+
+var __dgi_redisGlobEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+// pattern returns the SCAN pattern matching every key of the template: the
+// key itself without placeholders, or the literal before the first one
+// followed by anything.
+func (k *__dgi_redisKey) pattern() (string, error) {
+	prefix := __dgi_redisGlobEscaper.Replace(k.literals[0])
+	if len(k.fields) == 0 {
+		return prefix, nil
+	}
+	if prefix == "" {
+		return "", errors.New("key starts with a placeholder, so its keys cannot be told apart from others")
+	}
+	return prefix + "*", nil
+}
+
+// __dgi_checkRedisFields checks that the fields the config reads exist,
+// reading them from an empty record with value.
+func __dgi_checkRedisFields(config *__dgi_RedisConfig, key *__dgi_redisKey, value func(field string) (any, error)) error {
+	for _, field := range append([]string{config.ScoreField, config.MemberField}, key.fields...) {
+		if field == "" {
+			continue
+		}
+		if _, err := value(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// __dgi_newRedisClient returns a client of the server of config, once it
+// answers.
+func __dgi_newRedisClient(config *__dgi_RedisConfig) (*redis.Client, error) {
+	timeout := 5 * time.Second
+	if d, err := time.ParseDuration(config.Timeout); err == nil && d > 0 {
+		timeout = d
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr:         config.Addr,
+		Username:     config.Username,
+		Password:     config.Password,
+		DB:           config.DB,
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("ping server: %w", err)
+	}
+	return client, nil
+}
+
+// __dgi_redisWrite queues the commands writing a record to key as the
+// structure of config: fields are the fields of its JSON document and value
+// reads the fields of the record scoring it or giving its member.
+func __dgi_redisWrite(ctx context.Context, pipe redis.Pipeliner, config *__dgi_RedisConfig, key string, fields []__dgi_JSONField, value func(field string) (any, error)) error {
+	ttl := config.ttl()
+	switch config.dataType() {
+	case __dgi_RedisTypeString:
+		document, err := __dgi_marshalJSONObject(fields)
+		if err != nil {
+			return fmt.Errorf("encoding document: %w", err)
+		}
+		pipe.Set(ctx, key, document, ttl)
+		return nil
+	case __dgi_RedisTypeHash:
+		values := make([]any, 0, 2*len(fields))
+		for _, f := range fields {
+			s, ok, err := __dgi_redisValue(f.Value)
+			if err != nil {
+				return fmt.Errorf("hash field %s: %w", f.Key, err)
+			}
+			// hashes cannot hold nulls, so null fields are left out
+			if ok {
+				values = append(values, f.Key, s)
+			}
+		}
+		if len(values) > 0 {
+			pipe.HSet(ctx, key, values...)
+		}
+	case __dgi_RedisTypeZSet, __dgi_RedisTypeList:
+		member, err := __dgi_redisMember(config, fields, value)
+		if err != nil {
+			return err
+		}
+		if config.dataType() == __dgi_RedisTypeList {
+			pipe.RPush(ctx, key, member)
+			break
+		}
+		v, err := value(config.ScoreField)
+		if err != nil {
+			return err
+		}
+		score, err := __dgi_redisScore(v)
+		if err != nil {
+			return fmt.Errorf("score field %s: %w", config.ScoreField, err)
+		}
+		pipe.ZAdd(ctx, key, redis.Z{Score: score, Member: member})
+	}
+	if ttl > 0 {
+		pipe.PExpire(ctx, key, ttl)
+	}
+	return nil
+}
+
+// __dgi_redisMember returns the member of a record in a sorted set or list:
+// the value of the member field, or its JSON document.
+func __dgi_redisMember(config *__dgi_RedisConfig, fields []__dgi_JSONField, value func(field string) (any, error)) (string, error) {
+	if config.MemberField == "" {
+		document, err := __dgi_marshalJSONObject(fields)
+		if err != nil {
+			return "", fmt.Errorf("encoding document: %w", err)
+		}
+		return string(document), nil
+	}
+	v, err := value(config.MemberField)
+	if err != nil {
+		return "", err
+	}
+	member, ok, err := __dgi_redisValue(v)
+	if err != nil {
+		return "", fmt.Errorf("member field %s: %w", config.MemberField, err)
+	}
+	if !ok {
+		return "", fmt.Errorf("member field %s is null", config.MemberField)
+	}
+	return member, nil
+}
+
+// __dgi_redisClear deletes the keys matching pattern, scanning them in
+// batches, and returns how many were deleted.
+func __dgi_redisClear(ctx context.Context, client *redis.Client, pattern string) (int64, error) {
+	var deleted int64
+	var cursor uint64
+	for {
+		keys, next, err := client.Scan(ctx, cursor, pattern, 1000).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("scan %s: %w", pattern, err)
+		}
+		if len(keys) > 0 {
+			n, err := client.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("delete keys: %w", err)
+			}
+			deleted += n
+		}
+		if next == 0 {
+			return deleted, nil
+		}
+		cursor = next
+	}
+}
+
+var __dgi_redisTimeType = reflect.TypeOf(time.Time{})
+
+// __dgi_redisValue formats a value as a string, following pointers, with
+// times in RFC 3339 and slices, maps and structs as JSON. It reports false
+// for nulls.
+func __dgi_redisValue(v any) (string, bool, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return "", false, nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return "", false, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		if rv.IsNil() {
+			return "", false, nil
+		}
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes()), true, nil
+		}
+	case reflect.Array:
+	case reflect.Struct:
+		if rv.Type() == __dgi_redisTimeType {
+			return rv.Interface().(time.Time).Format(time.RFC3339Nano), true, nil
+		}
+	default:
+		return fmt.Sprint(rv.Interface()), true, nil
+	}
+
+	data, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+// __dgi_redisScore converts a numeric value to the score of a sorted set
+// member.
+func __dgi_redisScore(v any) (float64, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return 0, errors.New("score is null")
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	}
+	if rv.IsValid() && rv.Type() == __dgi_redisTimeType {
+		return float64(rv.Interface().(time.Time).Unix()), nil
+	}
+	return 0, fmt.Errorf("cannot score %T", v)
+}
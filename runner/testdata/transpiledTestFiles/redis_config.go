@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	__dgi_RedisTypeString = "string"
+	__dgi_RedisTypeHash   = "hash"
+	__dgi_RedisTypeZSet   = "zset"
+	__dgi_RedisTypeList   = "list"
+)
+
+type __dgi_RedisConfig struct {
+	// Addr is the host:port of the server, such as localhost:6379.
+	Addr           string `json:"addr"`
+	Username       string `json:"username,omitempty"`
+	Password       string `json:"password,omitempty"`
+	DB             int    `json:"db,omitempty"`
+	// Key is the template of the keys records are written to, whose {field}
+	// placeholders are replaced by the values of the fields of each record,
+	// such as user:{id}.
+	Key            string `json:"key"`
+	// Type is the structure records are written as: a string holding their
+	// JSON document, a hash of their fields, or a member of the sorted set or
+	// list of their key.
+	Type           string `json:"type,omitempty"`
+	// ScoreField is the field scoring the members of sorted sets.
+	ScoreField     string `json:"score_field,omitempty"`
+	// MemberField is the field whose value is the member of sorted sets and
+	// lists, which is the JSON document of the record when it is not set.
+	MemberField    string `json:"member_field,omitempty"`
+	// TTL is the time to live of the keys written, which never expire when it
+	// is not set.
+	TTL            string `json:"ttl,omitempty"`
+	BatchSize      int    `json:"batch_size,omitempty"`
+	Timeout        string `json:"timeout,omitempty"`
+	Throttle       string `json:"throttle,omitempty"`
+}
+
+func (c *__dgi_RedisConfig) dataType() string {
+	if c.Type == "" {
+		return __dgi_RedisTypeString
+	}
+	return c.Type
+}
+
+// ttl returns the time to live of the keys written, or zero when they never
+// expire.
+func (c *__dgi_RedisConfig) ttl() time.Duration {
+	d, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+func (c *__dgi_RedisConfig) Validate() error {
+	if c.Addr == "" || c.Key == "" {
+		return errors.New("redis: addr and key are required")
+	}
+	if _, err := __dgi_parseRedisKey(c.Key); err != nil {
+		return fmt.Errorf("redis: %w", err)
+	}
+	switch c.dataType() {
+	case __dgi_RedisTypeString, __dgi_RedisTypeHash, __dgi_RedisTypeList:
+		if c.ScoreField != "" {
+			return fmt.Errorf("redis: score_field is only used by type %q", __dgi_RedisTypeZSet)
+		}
+	case __dgi_RedisTypeZSet:
+		if c.ScoreField == "" {
+			return fmt.Errorf("redis: score_field is required by type %q", __dgi_RedisTypeZSet)
+		}
+	default:
+		return fmt.Errorf("redis: unsupported type %q (expected %q, %q, %q or %q)", c.Type,
+			__dgi_RedisTypeString, __dgi_RedisTypeHash, __dgi_RedisTypeZSet, __dgi_RedisTypeList)
+	}
+	if c.MemberField != "" && c.dataType() != __dgi_RedisTypeZSet && c.dataType() != __dgi_RedisTypeList {
+		return fmt.Errorf("redis: member_field is only used by types %q and %q", __dgi_RedisTypeZSet, __dgi_RedisTypeList)
+	}
+	if c.TTL != "" {
+		if d, err := time.ParseDuration(c.TTL); err != nil || d <= 0 {
+			return fmt.Errorf("redis: ttl must be a positive duration, got %q", c.TTL)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// __dgi_s3PartSize is the size of the parts of multipart uploads; files
+// smaller than a part are uploaded in one request
+const __dgi_s3PartSize = 8 << 20
+
+// __dgi_s3Client signs requests to an S3-compatible API with AWS Signature
+// Version 4. Buckets are addressed by path on custom endpoints, such as
+// MinIO, and by host on AWS.
+type __dgi_s3Client struct {
+	endpoint     *url.URL
+	region       string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	http         *http.Client
+}
+
+// __dgi_newS3Client returns a client configured from the standard AWS
+// environment variables.
+func __dgi_newS3Client() (*__dgi_s3Client, error) {
+	c := &__dgi_s3Client{
+		region:       __dgi_firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"),
+		accessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		http:         &http.Client{Timeout: 5 * time.Minute},
+	}
+	if c.region == "" {
+		c.region = "us-east-1"
+	}
+	if c.accessKey == "" || c.secretKey == "" {
+		return nil, fmt.Errorf("s3 output requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	if endpoint := __dgi_firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"); endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+		}
+		c.endpoint = u
+	}
+	return c, nil
+}
+
+func __dgi_firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// __dgi_s3Escape escapes s as AWS expects in canonical requests, keeping
+// slashes when escaping paths.
+func __dgi_s3Escape(s string, path bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case c == '/' && path:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func __dgi_hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// newRequest returns a signed request on the object key of bucket, or on the
+// bucket when key is empty.
+func (c *__dgi_s3Client) newRequest(method, bucket, key string, query url.Values, body []byte, now time.Time) (*http.Request, error) {
+	u := &url.URL{Scheme: "https", Host: bucket + ".s3." + c.region + ".amazonaws.com", Path: "/" + key}
+	if c.endpoint != nil {
+		u = &url.URL{Scheme: c.endpoint.Scheme, Host: c.endpoint.Host, Path: strings.TrimSuffix(c.endpoint.Path, "/") + "/" + bucket + "/" + key}
+	}
+	u.RawPath = __dgi_s3Escape(u.Path, true)
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		params = append(params, __dgi_s3Escape(k, false)+"="+__dgi_s3Escape(query.Get(k), false))
+	}
+	u.RawQuery = strings.Join(params, "&")
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	c.sign(req, body, now)
+	return req, nil
+}
+
+// sign signs req, whose query is already in canonical order, and its body.
+func (c *__dgi_s3Client) sign(req *http.Request, body []byte, now time.Time) {
+	payload := sha256.Sum256(body)
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payload[:]))
+	if c.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{req.Method, req.URL.EscapedPath(), req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, hex.EncodeToString(payload[:])}, "\n")
+	hashed := sha256.Sum256([]byte(canonical))
+	scope := amzDate[:8] + "/" + c.region + "/s3/aws4_request"
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	signingKey := __dgi_hmacSHA256([]byte("AWS4"+c.secretKey), amzDate[:8])
+	signingKey = __dgi_hmacSHA256(signingKey, c.region)
+	signingKey = __dgi_hmacSHA256(signingKey, "s3")
+	signingKey = __dgi_hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(__dgi_hmacSHA256(signingKey, toSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", c.accessKey, scope, signedHeaders, signature))
+}
+
+// __dgi_s3Error is the error document of the S3 API.
+type __dgi_s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// do sends a request and returns the response body, turning error statuses
+// and error documents into errors.
+func (c *__dgi_s3Client) do(method, bucket, key string, query url.Values, body []byte) (http.Header, []byte, error) {
+	req, err := c.newRequest(method, bucket, key, query, body, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var s3Err __dgi_s3Error
+	// CompleteMultipartUpload reports errors in 200 responses
+	failed := resp.StatusCode >= 300 || (bytes.Contains(data, []byte("<Error>")) && xml.Unmarshal(data, &s3Err) == nil && s3Err.Code != "")
+	if !failed {
+		return resp.Header, data, nil
+	}
+	if s3Err.Code == "" {
+		xml.Unmarshal(data, &s3Err)
+	}
+	if s3Err.Code != "" {
+		return nil, nil, fmt.Errorf("%s s3://%s/%s: %s: %s", method, bucket, key, s3Err.Code, s3Err.Message)
+	}
+	return nil, nil, fmt.Errorf("%s s3://%s/%s: %s", method, bucket, key, resp.Status)
+}
+
+// __dgi_s3Destination writes files as objects under a prefix of a bucket.
+// Objects only show up once uploaded in full.
+type __dgi_s3Destination struct {
+	bucket string
+	prefix string
+	client *__dgi_s3Client
+}
+
+// __dgi_newS3Destination returns the destination of bucket/prefix.
+func __dgi_newS3Destination(location string) (*__dgi_s3Destination, error) {
+	bucket, prefix, _ := strings.Cut(location, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid output location s3://%s, expected s3://bucket/prefix", location)
+	}
+	client, err := __dgi_newS3Client()
+	if err != nil {
+		return nil, err
+	}
+	return &__dgi_s3Destination{bucket: bucket, prefix: prefix, client: client}, nil
+}
+
+// Resolve returns the URI of the object of a model: the prefix itself when
+// it ends with the extension, and an object under it otherwise.
+func (d *__dgi_s3Destination) Resolve(name, ext string) (string, error) {
+	key := d.prefix
+	if !strings.HasSuffix(strings.ToLower(key), "."+strings.ToLower(ext)) {
+		key = path.Join(key, name+"."+ext)
+	}
+	return "s3://" + d.bucket + "/" + strings.TrimPrefix(key, "/"), nil
+}
+
+func (d *__dgi_s3Destination) Create(uri string) (__dgi_Upload, error) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(uri, "s3://"), "/")
+	if bucket != d.bucket || key == "" {
+		return nil, fmt.Errorf("invalid object %s for the bucket %s", uri, d.bucket)
+	}
+	return &__dgi_s3Upload{client: d.client, bucket: bucket, key: key}, nil
+}
+
+// __dgi_s3Upload uploads an object in one request, or in parts of
+// __dgi_s3PartSize once it outgrows one.
+type __dgi_s3Upload struct {
+	client   *__dgi_s3Client
+	bucket   string
+	key      string
+	buf      []byte
+	uploadID string
+	etags    []string
+	done     bool
+}
+
+func (u *__dgi_s3Upload) Write(p []byte) (int, error) {
+	u.buf = append(u.buf, p...)
+	for len(u.buf) >= __dgi_s3PartSize {
+		if err := u.uploadPart(u.buf[:__dgi_s3PartSize]); err != nil {
+			return 0, err
+		}
+		u.buf = append(u.buf[:0], u.buf[__dgi_s3PartSize:]...)
+	}
+	return len(p), nil
+}
+
+func (u *__dgi_s3Upload) uploadPart(part []byte) error {
+	if u.uploadID == "" {
+		_, data, err := u.client.do(http.MethodPost, u.bucket, u.key, url.Values{"uploads": {""}}, nil)
+		if err != nil {
+			return err
+		}
+		var started struct {
+			UploadID string `xml:"UploadId"`
+		}
+		if err := xml.Unmarshal(data, &started); err != nil || started.UploadID == "" {
+			return fmt.Errorf("error starting the upload of s3://%s/%s: no upload id", u.bucket, u.key)
+		}
+		u.uploadID = started.UploadID
+	}
+	query := url.Values{"partNumber": {strconv.Itoa(len(u.etags) + 1)}, "uploadId": {u.uploadID}}
+	header, _, err := u.client.do(http.MethodPut, u.bucket, u.key, query, part)
+	if err != nil {
+		return err
+	}
+	u.etags = append(u.etags, header.Get("ETag"))
+	return nil
+}
+
+func (u *__dgi_s3Upload) Commit() error {
+	if u.done {
+		return nil
+	}
+	u.done = true
+	if u.uploadID == "" {
+		_, _, err := u.client.do(http.MethodPut, u.bucket, u.key, nil, u.buf)
+		return err
+	}
+	if len(u.buf) > 0 {
+		if err := u.uploadPart(u.buf); err != nil {
+			u.abort()
+			return err
+		}
+	}
+
+	type part struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+	complete := struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []part   `xml:"Part"`
+	}{}
+	for i, etag := range u.etags {
+		complete.Parts = append(complete.Parts, part{PartNumber: i + 1, ETag: etag})
+	}
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		u.abort()
+		return err
+	}
+	if _, _, err := u.client.do(http.MethodPost, u.bucket, u.key, url.Values{"uploadId": {u.uploadID}}, body); err != nil {
+		u.abort()
+		return err
+	}
+	return nil
+}
+
+func (u *__dgi_s3Upload) Abort() error {
+	if u.done {
+		return nil
+	}
+	u.done = true
+	return u.abort()
+}
+
+// abort drops the parts uploaded so far.
+func (u *__dgi_s3Upload) abort() error {
+	u.buf = nil
+	if u.uploadID == "" {
+		return nil
+	}
+	_, _, err := u.client.do(http.MethodDelete, u.bucket, u.key, url.Values{"uploadId": {u.uploadID}}, nil)
+	return err
+}
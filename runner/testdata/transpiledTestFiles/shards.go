@@ -0,0 +1,150 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+)
+
+// __dgi_Shard is a range of iters of a single model, generated by one worker.
+type __dgi_Shard struct {
+	Model string
+	Count int
+	Start int
+	End   int
+}
+
+// First reports whether the shard holds the first records of its model.
+func (s __dgi_Shard) First() bool {
+	return s.Start == 0
+}
+
+// Last reports whether the shard holds the last records of its model.
+func (s __dgi_Shard) Last() bool {
+	return s.End == s.Count
+}
+
+// __dgi_WorkerFactory returns the generators a worker owns for the whole run.
+type __dgi_WorkerFactory func() (*__dgi_DataGenGenerators, map[string]__dgi_RecordGenerator)
+
+// __dgi_shardModels splits the models, in the given order, into shards of at
+// most chunkSize records. Shard boundaries only depend on the counts and the
+// chunk size, never on the number of workers. A chunkSize of 0 or less puts
+// each model in a single shard, and a model with no records still gets one
+// empty shard.
+func __dgi_shardModels(models []string, counts map[string]int, chunkSize int) []__dgi_Shard {
+	var shards []__dgi_Shard
+	for _, name := range models {
+		count := counts[name]
+		size := chunkSize
+		if size <= 0 || size > count {
+			size = count
+		}
+
+		if count == 0 {
+			shards = append(shards, __dgi_Shard{Model: name})
+			continue
+		}
+		for start := 0; start < count; start += size {
+			shards = append(shards, __dgi_Shard{Model: name, Count: count, Start: start, End: min(start+size, count)})
+		}
+	}
+	return shards
+}
+
+// __dgi_workerCount resolves the --parallelism flag, where 0 means one worker
+// per CPU.
+func __dgi_workerCount(parallelism int) int {
+	if parallelism <= 0 {
+		return runtime.NumCPU()
+	}
+	return parallelism
+}
+
+// __dgi_generateShards generates shards on parallelism workers and hands them
+// to emit one at a time, in the order they were given. Every value is derived
+// from its own random stream, so the emitted records do not depend on the
+// number of workers or on which worker generated a shard. At most two shards
+// per worker are generated ahead of the one being emitted. Generation stops at
+// the first error, from a shard or from emit.
+func __dgi_generateShards(shards []__dgi_Shard, parallelism int, newWorker __dgi_WorkerFactory, emit func(shard __dgi_Shard, records []__dgi_Record) error) error {
+	type result struct {
+		records []__dgi_Record
+		err     error
+	}
+
+	workers := __dgi_workerCount(parallelism)
+	results := make([]chan result, len(shards))
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+
+	ahead := make(chan struct{}, 2*workers)
+	next := make(chan int)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(next)
+		for i := range shards {
+			select {
+			case ahead <- struct{}{}:
+			case <-done:
+				return
+			}
+			select {
+			case next <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			datagen, models := newWorker()
+			for i := range next {
+				datagen.__curModel = shards[i].Model
+				records, err := __dgi_generateShard(models[shards[i].Model], shards[i])
+				results[i] <- result{records: records, err: err}
+			}
+		}()
+	}
+
+	var err error
+	for i, shard := range shards {
+		res := <-results[i]
+		<-ahead
+		if err = res.err; err != nil {
+			break
+		}
+		if err = emit(shard, res.records); err != nil {
+			break
+		}
+	}
+
+	close(done)
+	wg.Wait()
+	return err
+}
+
+// __dgi_generateShard generates the records of a shard, turning a read of a
+// value that has left its memo window into an error.
+func __dgi_generateShard(gen __dgi_RecordGenerator, shard __dgi_Shard) (records []__dgi_Record, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			evicted, ok := r.(*__dgi_MemoEvictedError)
+			if !ok {
+				panic(r)
+			}
+			err = evicted
+		}
+	}()
+
+	records = make([]__dgi_Record, 0, shard.End-shard.Start)
+	for i := shard.Start; i < shard.End; i++ {
+		records = append(records, gen(i))
+	}
+	return records, nil
+}
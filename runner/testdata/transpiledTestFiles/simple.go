@@ -388,45 +388,33 @@ type __datagen_simpleGenerator struct {
 }
 
 type __datagen_simpleDataHolder struct {
-	id   []int
-	name []string
+	id   *__dgi_Memo[int]
+	name *__dgi_Memo[string]
 }
 
 func (cg *__datagen_simpleGenerator) __gen_wrapper_name() func(iter int) string {
+	gen := func(i int) string {
+		return cg.__gen_name(nil, i)
+	}
 	return func(iter int) string {
-		if iter < len(cg.all.name) {
-			return cg.all.name[iter]
-		}
-
-		for i := len(cg.all.name); i <= iter; i++ {
-			val := cg.__gen_name(i)
-			cg.all.name = append(cg.all.name, val)
-		}
-
-		return cg.all.name[iter]
+		return cg.all.name.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_simpleGenerator) __gen_name(iter int) string {
+func (self *__datagen_simpleGenerator) __gen_name(__dgi_r *__dgi_Rand, iter int) string {
 	return "test_user"
 }
 
 func (cg *__datagen_simpleGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_simpleGenerator) __gen_id(iter int) int {
+func (self *__datagen_simpleGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -437,18 +425,21 @@ func (cg *__datagen_simpleGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_simpleGenerator() *__datagen_simpleGenerator {
-	all := &__datagen_simpleDataHolder{}
+func __init___datagen_simpleGenerator(memoWindow int) *__datagen_simpleGenerator {
+	all := &__datagen_simpleDataHolder{
+		id:   __dgi_NewMemo[int]("simple", "id", __dgi_memoCurrentRow, false),
+		name: __dgi_NewMemo[string]("simple", "name", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_simpleGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.name = cg.__gen_wrapper_name()
 	return cg
 }
 
-func (e *__datagen_simple) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.name),
+func (e *__datagen_simple) ToCSV() []any {
+	return []any{
+		e.id,
+		e.name,
 	}
 }
 
@@ -460,9 +451,9 @@ func (e *__datagen_simple) CSVHeaders() []string {
 }
 
 func (e *__datagen_simple) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":   e.id,
-		"name": e.name,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "name", Value: e.name},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -471,22 +462,72 @@ func (e *__datagen_simple) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_simple) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName  xml.Name `xml:"simple"`
-		Xml_id   int      `xml:"id"`
-		Xml_name string   `xml:"name"`
+func (e *__datagen_simple) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_simple) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("simple", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "name", Attr: false, Value: e.name},
+	})
+}
+
+func (e *__datagen_simple) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id   int    `parquet:"id"`
+		Parquet_name string `parquet:"name"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:   e.id,
-		Xml_name: e.name,
+	return &__dgi_parquetRow{
+		Parquet_id:   e.id,
+		Parquet_name: e.name,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_simple) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.name)
+}
+
+func (e *__datagen_simple) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"simple\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"name\",\n      \"type\": \"string\"\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_simple) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.name)
+}
+
+func (e *__datagen_simple) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage simple {\n  int64 id = 1;\n  string name = 2;\n}\n", nil
+}
+
+func (e *__datagen_simple) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `simple` (`id`, `name`) VALUES",
+			Truncate:         "DELETE FROM `simple`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `simple` (\n  `id` BIGINT NOT NULL,\n  `name` TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"simple\" (\"id\", \"name\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"simple\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"simple\" (\n  \"id\" BIGINT NOT NULL,\n  \"name\" TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"simple\" (\"id\", \"name\") VALUES",
+			Truncate:         "DELETE FROM \"simple\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"simple\" (\n  \"id\" INTEGER NOT NULL,\n  \"name\" TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_simple) SQLValues() []any {
+	return []any{e.id, e.name}
 }
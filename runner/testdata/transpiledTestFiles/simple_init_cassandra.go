@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+)
+
+var __datagen_simple_cassandra_session *gocql.Session
+
+// Init___datagen_simple_cassandra_session initializes a shared Cassandra session for __datagen_simple.
+func Init___datagen_simple_cassandra_session(req *__dgi_CassandraConfig) error {
+	if _, err := Get___datagen_simple_cassandra_session(); err == nil {
+		return nil
+	}
+
+	session, err := Open___datagen_simple_cassandra_session(req)
+	if err != nil {
+		return err
+	}
+
+	__datagen_simple_cassandra_session = session
+	return nil
+}
+
+// Open___datagen_simple_cassandra_session opens a new Cassandra session for __datagen_simple that is owned by the caller.
+func Open___datagen_simple_cassandra_session(req *__dgi_CassandraConfig) (*gocql.Session, error) {
+	session, err := __dgi_newCassandraSession(req)
+	if err != nil {
+		return nil, fmt.Errorf("open session: %w", err)
+	}
+	return session, nil
+}
+
+// Get___datagen_simple_cassandra_session returns the shared Cassandra session or an error if not initialized.
+func Get___datagen_simple_cassandra_session() (*gocql.Session, error) {
+	if __datagen_simple_cassandra_session == nil {
+		return nil, fmt.Errorf("cassandra session for __datagen_simple is not initialized")
+	}
+	return __datagen_simple_cassandra_session, nil
+}
+
+// Close___datagen_simple_cassandra_session closes the shared Cassandra session for __datagen_simple if initialized.
+func Close___datagen_simple_cassandra_session() error {
+	if __datagen_simple_cassandra_session == nil {
+		return nil
+	}
+	__datagen_simple_cassandra_session.Close()
+	__datagen_simple_cassandra_session = nil
+	return nil
+}
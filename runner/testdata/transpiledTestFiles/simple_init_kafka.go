@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Open___datagen_simple_kafka_client opens a new Kafka producer client for __datagen_simple that is owned by the caller.
+func Open___datagen_simple_kafka_client(req *__dgi_KafkaConfig) (*kgo.Client, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(req.BootstrapServers...),
+		kgo.DefaultProduceTopic(req.Topic),
+		kgo.AllowAutoTopicCreation(),
+	}
+	// Optional timeout: accept duration strings; ignore if empty or invalid
+	timeout := 10 * time.Second
+	if d, err := time.ParseDuration(req.Timeout); err == nil && d > 0 {
+		timeout = d
+		opts = append(opts, kgo.DialTimeout(d), kgo.ProduceRequestTimeout(d))
+	}
+
+	cl, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := cl.Ping(ctx); err != nil {
+		cl.Close()
+		return nil, fmt.Errorf("ping brokers: %w", err)
+	}
+
+	return cl, nil
+}
@@ -8,8 +8,8 @@ import (
 	"strings"
 )
 
-// Load___datagen_simple_postgres executes a single batch of records using the provided transaction.
-func Load___datagen_simple_postgres(records []*__datagen_simple, tx *sql.Tx) error {
+// Load___datagen_simple_postgres executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_simple_postgres(records []*__datagen_simple, tx *sql.Tx, stmt __dgi_writeStatement) error {
 	if len(records) == 0 {
 		slog.Warn(fmt.Sprintf("no records to insert for model %s", "simple"))
 		return nil
@@ -18,13 +18,7 @@ func Load___datagen_simple_postgres(records []*__datagen_simple, tx *sql.Tx) err
 	ctx := context.Background()
 
 	var b strings.Builder
-	columns := []string{
-		"\"id\"",
-		"\"name\"",
-	}
-	b.WriteString("INSERT INTO \"simple\" (")
-	b.WriteString(strings.Join(columns, ","))
-	b.WriteString(") VALUES ")
+	b.WriteString(stmt.prefix)
 
 	// Build placeholders for Postgres ($1, $2, ... format)
 	placeholderCount := 0
@@ -42,13 +36,15 @@ func Load___datagen_simple_postgres(records []*__datagen_simple, tx *sql.Tx) err
 		}
 		b.WriteString(")")
 	}
+	b.WriteString(stmt.suffix)
 	sqlStmt := b.String()
 
 	var args []interface{}
-	for _, record := range records {
-		args = append(args, record.id)
-		args = append(args, record.name)
-
+	for _, row := range Rows___datagen_simple_postgres(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
 	}
 
 	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
@@ -58,11 +54,65 @@ func Load___datagen_simple_postgres(records []*__datagen_simple, tx *sql.Tx) err
 	return nil
 }
 
-// Truncate___datagen_simple_postgres() truncates the model's table using the shared connection.
+// Copy___datagen_simple_postgres loads a single batch of records with COPY, using the provided transaction.
+func Copy___datagen_simple_postgres(records []*__datagen_simple, tx *sql.Tx) error {
+	if len(records) == 0 {
+		return nil
+	}
+	columns := []string{
+		"\"id\"",
+		"\"name\"",
+	}
+	if err := __dgi_postgresCopy(context.Background(), tx, "\"simple\"", columns, Rows___datagen_simple_postgres(records)); err != nil {
+		return fmt.Errorf("copy failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_simple_postgres returns the values of the columns of records, in order.
+func Rows___datagen_simple_postgres(records []*__datagen_simple) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.name,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_simple_postgres returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_simple_postgres(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+		"name",
+	}
+	columns := []string{
+		"\"id\"",
+		"\"name\"",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectPostgres, "\"simple\"", names, columns, keys, mode)
+}
+
+// Truncate___datagen_simple_postgres() empties the model's table using the shared connection. Rows are deleted
+// rather than truncated with CASCADE, so that tables referencing it outside the run are never emptied.
 func Truncate___datagen_simple_postgres(tx *sql.Tx) error {
 	ctx := context.Background()
-	if _, err := tx.ExecContext(ctx, "TRUNCATE TABLE \"simple\" RESTART IDENTITY CASCADE;"); err != nil {
-		return fmt.Errorf("truncate failed with error : %w", err)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM \"simple\";"); err != nil {
+		return fmt.Errorf("delete failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_simple_postgres_table creates the model's table unless it already exists.
+func Create___datagen_simple_postgres_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS \"simple\" (\n  \"id\" BIGINT NOT NULL,\n  \"name\" TEXT NOT NULL\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
 	}
 	return nil
 }
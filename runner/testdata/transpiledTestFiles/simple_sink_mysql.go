@@ -6,82 +6,140 @@ import (
 	"fmt"
 	"log/slog"
 	"time"
+
+	"github.com/go-sql-driver/mysql"
 )
 
-// Sink_mysql___datagen_simple_data loads __datagen_simple data into MySQL
-func Sink_mysql___datagen_simple_data(modelName string, records []*__datagen_simple, config *__dgi_MySQLConfig) error {
-	slog.Debug(fmt.Sprintf("initializing MySQL connection for %s with %d records", modelName, len(records)))
-	if err := Init___datagen_simple_mysql_connection(config); err != nil {
-		return fmt.Errorf("✘ [MySQL] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
-			modelName, len(records), err)
-	}
-	defer func() {
-		err := Close___datagen_simple_mysql_connection()
-		if err != nil {
-			slog.Warn(fmt.Sprintf("failed to close DB connection for %s: %s", modelName, err.Error()))
-		}
-	}()
+// __datagen_simple_mysqlSink streams __datagen_simple data into MySQL within a single transaction
+type __datagen_simple_mysqlSink struct {
+	modelName string
+	config    *__dgi_MySQLConfig
+	stmt      __dgi_writeStatement
+	mode      __dgi_WriteMode
+	// loadData is set when records are loaded with LOAD DATA rather than INSERT
+	loadData      bool
+	db            *sql.DB
+	tx            *sql.Tx
+	total         int
+	totalInserted int
+}
 
-	db, err := Get___datagen_simple_mysql_connection()
+// Open_mysql___datagen_simple_sink connects to MySQL and starts the transaction __datagen_simple data is loaded in,
+// with the statement of the model's write mode
+func Open_mysql___datagen_simple_sink(modelName string, total int, config *__dgi_MySQLConfig, mode __dgi_WriteMode, keys []string) (*__datagen_simple_mysqlSink, error) {
+	stmt, err := Statement___datagen_simple_mysql(mode, keys)
 	if err != nil {
-		return fmt.Errorf("✘ [MySQL] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
-			modelName, len(records), err)
+		return nil, fmt.Errorf("✘ [MySQL] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
 	}
 
-	batchSize := config.BatchSize
-	if batchSize <= 0 {
-		batchSize = len(records)
+	slog.Debug(fmt.Sprintf("initializing MySQL connection for %s with %d records", modelName, total))
+	db, err := Open___datagen_simple_mysql_connection(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [MySQL] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
 	}
 
-	totalInserted := 0
-
-	slog.Debug(fmt.Sprintf("starting MySQL transaction for %s with batch size %d", modelName, batchSize))
+	slog.Debug(fmt.Sprintf("starting MySQL transaction for %s with batch size %d", modelName, config.BatchSize))
 	tx, err := db.Begin()
 	if err != nil {
-		return fmt.Errorf("✘ [MySQL] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
-			modelName, totalInserted, len(records), err)
+		if closeErr := db.Close(); closeErr != nil {
+			slog.Warn(fmt.Sprintf("failed to close DB connection for %s: %s", modelName, closeErr.Error()))
+		}
+		return nil, fmt.Errorf("✘ [MySQL] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
 	}
 
-	defer func() {
-		if err := tx.Rollback(); err != nil {
-			if !errors.Is(err, sql.ErrTxDone) {
-				slog.Error(fmt.Sprintf("error rolling back transaction for %s: %s", modelName, err.Error()))
-			}
-		}
-	}()
+	_, supported := __dgi_mysqlLoadDataModes[mode]
+	if config.LoadData && !supported {
+		slog.Debug(fmt.Sprintf("loading %s into MySQL with INSERT statements for write_mode %s", modelName, mode))
+	}
+	return &__datagen_simple_mysqlSink{modelName: modelName, config: config, stmt: stmt, mode: mode, loadData: config.LoadData && supported, db: db, tx: tx, total: total}, nil
+}
 
-	for i := 0; i < len(records); i += batchSize {
+// Load loads a chunk of __datagen_simple records in batches of config.BatchSize, with LOAD DATA or with INSERT
+// statements kept under the placeholder limit of MySQL
+func (s *__datagen_simple_mysqlSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_simple, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_simple))
+	}
+
+	batchSize := s.config.BatchSize
+	if !s.loadData {
+		batchSize = __dgi_insertBatchSize(batchSize, 2, __dgi_maxPlaceholders)
+	} else if batchSize <= 0 {
+		batchSize = max(len(records), 1)
+	}
+
+	for i := 0; i < len(records); {
 		end := i + batchSize
 		if end > len(records) {
 			end = len(records)
 		}
 		batch := records[i:end]
 
-		slog.Debug(fmt.Sprintf("loading batch starting at %d of size %d for %s into MySQL", i, len(batch), modelName))
-		if err := Load___datagen_simple_mysql(batch, tx); err != nil {
+		slog.Debug(fmt.Sprintf("loading batch starting at %d of size %d for %s into MySQL", s.totalInserted, len(batch), s.modelName))
+		var err error
+		if s.loadData {
+			err = LoadData___datagen_simple_mysql(batch, s.tx, s.mode)
+			var mysqlErr *mysql.MySQLError
+			// servers refuse LOCAL files unless local_infile is on
+			if errors.As(err, &mysqlErr) && (mysqlErr.Number == 1148 || mysqlErr.Number == 3948) {
+				slog.Warn(fmt.Sprintf("LOAD DATA LOCAL INFILE is disabled on the server, loading %s with INSERT statements: %s", s.modelName, mysqlErr.Message))
+				s.loadData = false
+				batchSize = __dgi_insertBatchSize(s.config.BatchSize, 2, __dgi_maxPlaceholders)
+				continue
+			}
+		} else {
+			err = Load___datagen_simple_mysql(batch, s.tx, s.stmt)
+		}
+		if err != nil {
 			return fmt.Errorf("✘ [MySQL] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
-				modelName, totalInserted, len(records), err)
+				s.modelName, s.totalInserted, s.total, err)
 		}
 
-		totalInserted += len(batch)
+		s.totalInserted += len(batch)
+		i = end
 
-		if config.Throttle != "" && end < len(records) {
-			if throttleDuration, err := time.ParseDuration(config.Throttle); err == nil {
-				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, modelName))
+		if s.config.Throttle != "" && s.totalInserted < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
 				time.Sleep(throttleDuration)
 			}
 		}
 	}
+	return nil
+}
 
-	if err := tx.Commit(); err != nil {
+// Commit commits the transaction and closes the MySQL connection
+func (s *__datagen_simple_mysqlSink) Commit() error {
+	defer s.close()
+	if err := s.tx.Commit(); err != nil {
 		return fmt.Errorf("✘ [MySQL] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
-			modelName, totalInserted, len(records), err)
+			s.modelName, s.totalInserted, s.total, err)
 	}
 
-	slog.Info(fmt.Sprintf("successfully loaded %d/%d rows for %s into MySQL", totalInserted, len(records), modelName))
+	slog.Info(fmt.Sprintf("successfully loaded %d/%d rows for %s into MySQL", s.totalInserted, s.total, s.modelName))
 	return nil
 }
 
+// Abort rolls back the transaction and closes the MySQL connection
+func (s *__datagen_simple_mysqlSink) Abort() {
+	defer s.close()
+	if err := s.tx.Rollback(); err != nil {
+		if !errors.Is(err, sql.ErrTxDone) {
+			slog.Error(fmt.Sprintf("error rolling back transaction for %s: %s", s.modelName, err.Error()))
+		}
+	}
+}
+
+func (s *__datagen_simple_mysqlSink) close() {
+	if err := s.db.Close(); err != nil {
+		slog.Warn(fmt.Sprintf("failed to close DB connection for %s: %s", s.modelName, err.Error()))
+	}
+}
+
 // Clear_mysql___datagen_simple_data clears __datagen_simple data from MySQL
 func Clear_mysql___datagen_simple_data(modelName string, config *__dgi_MySQLConfig) error {
 	slog.Debug(fmt.Sprintf("initializing MySQL connection for clearing data for %s", modelName))
@@ -126,3 +184,30 @@ func Clear_mysql___datagen_simple_data(modelName string, config *__dgi_MySQLConf
 	slog.Info(fmt.Sprintf("successfully cleared data for %s from MySQL", modelName))
 	return nil
 }
+
+// Create_mysql___datagen_simple_table creates the table __datagen_simple data is loaded into in MySQL, unless it already exists
+func Create_mysql___datagen_simple_table(modelName string, config *__dgi_MySQLConfig) error {
+	slog.Debug(fmt.Sprintf("initializing MySQL connection for creating the table of %s", modelName))
+	if err := Init___datagen_simple_mysql_connection(config); err != nil {
+		return fmt.Errorf("MySQL connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_simple_mysql_connection()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to close DB connection: %s", err.Error()))
+		}
+	}()
+
+	db, err := Get___datagen_simple_mysql_connection()
+	if err != nil {
+		return fmt.Errorf("failed to get MySQL connection: %w", err)
+	}
+
+	if err := Create___datagen_simple_mysql_table(db); err != nil {
+		return fmt.Errorf("failed to create table for model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("table for %s is ready in MySQL", modelName))
+	return nil
+}
@@ -6,28 +6,60 @@ import (
 	"slices"
 )
 
-func __dgi_orchestrateSinks(topologicallySorted []string, allData map[string][]__dgi_Record, cfg *__dgi_Config) error {
+// __dgi_ModelSink receives the records of a single model chunk by chunk.
+// Commit finalizes everything loaded so far, Abort discards it where the sink allows.
+type __dgi_ModelSink interface {
+	Load(records []__dgi_Record) error
+	Commit() error
+	Abort()
+}
+
+func __dgi_orchestrateSinks(topologicallySorted []string, newWorker __dgi_WorkerFactory, counts map[string]int, cfg *__dgi_Config, opts __dgi_RunOptions) error {
+	if cfg.CreateTables {
+		slog.Info("creating missing tables in sinks")
+		if err := __dgi_createAllTables(topologicallySorted, counts, cfg); err != nil {
+			return fmt.Errorf("error in creating tables: %w", err)
+		}
+	}
+
 	if cfg.ClearData {
 		slog.Info("clearing existing data from sinks")
-		if err := __dgi_clearAllData(topologicallySorted, allData, cfg); err != nil {
+		if err := __dgi_clearAllData(topologicallySorted, counts, cfg); err != nil {
 			return fmt.Errorf("error in clearing data: %w", err)
 		}
 	}
 
 	slog.Info("loading data into sinks")
-	return __dgi_loadAllData(topologicallySorted, allData, cfg)
+	return __dgi_loadAllData(topologicallySorted, newWorker, counts, cfg, opts)
 }
 
-func __dgi_clearAllData(topologicallySorted []string, allData map[string][]__dgi_Record, cfg *__dgi_Config) error {
+// createAllTables creates the tables in topological order, so the tables
+// foreign keys point to exist first
+func __dgi_createAllTables(topologicallySorted []string, counts map[string]int, cfg *__dgi_Config) error {
+	slog.Debug(fmt.Sprintf("creating tables in topological order: %v", topologicallySorted))
+	for _, name := range topologicallySorted {
+		if _, ok := counts[name]; !ok {
+			continue
+		}
+
+		if err := __dgi_createModelTables(name, cfg); err != nil {
+			return fmt.Errorf("error creating tables for model %s: %w", name, err)
+		}
+	}
+	slog.Info("table creation completed successfully")
+	return nil
+}
+
+func __dgi_clearAllData(topologicallySorted []string, counts map[string]int, cfg *__dgi_Config) error {
 	reversedTopologicallySorted := slices.Clone(topologicallySorted)
 	slices.Reverse(reversedTopologicallySorted)
 	slog.Debug(fmt.Sprintf("clearing data in reverse topological order: %v", reversedTopologicallySorted))
 	for _, name := range reversedTopologicallySorted {
-		if _, ok := allData[name]; !ok {
+		if _, ok := counts[name]; !ok {
 			continue
 		}
 
-		if err := __dgi_clearModelSinks(name, allData[name], cfg); err != nil {
+		if err := __dgi_clearModelSinks(name, cfg); err != nil {
 			return fmt.Errorf("error clearing sinks for model %s: %w", name, err)
 		}
 	}
@@ -35,24 +67,51 @@ func __dgi_clearAllData(topologicallySorted []string, allData map[string][]__dgi
 	return nil
 }
 
-func __dgi_loadAllData(topologicallySorted []string, allData map[string][]__dgi_Record, cfg *__dgi_Config) error {
+// loadAllData generates the models on parallel workers but loads them one at
+// a time, in topological order, so referenced rows are committed first
+func __dgi_loadAllData(topologicallySorted []string, newWorker __dgi_WorkerFactory, counts map[string]int, cfg *__dgi_Config, opts __dgi_RunOptions) error {
 	slog.Debug(fmt.Sprintf("loading data in topological order: %v", topologicallySorted))
+	toLoad := make([]string, 0, len(counts))
 	for _, name := range topologicallySorted {
-		records, ok := allData[name]
-		if !ok {
-			continue
+		if _, ok := counts[name]; ok {
+			toLoad = append(toLoad, name)
+		}
+	}
+
+	var sink *__dgi_modelSinks
+	shards := __dgi_shardModels(toLoad, counts, opts.ChunkSize)
+	err := __dgi_generateShards(shards, opts.Parallelism, newWorker, func(shard __dgi_Shard, records []__dgi_Record) error {
+		if shard.First() {
+			var err error
+			if sink, err = __dgi_openModelSinks(shard.Model, shard.Count, opts.ChunkSize, cfg); err != nil {
+				return err
+			}
 		}
 
-		if err := __dgi_loadModelSinks(name, records, cfg); err != nil {
-			return fmt.Errorf("%q, skipping further models", err)
+		if err := sink.Load(records); err != nil {
+			sink.Abort()
+			sink = nil
+			return err
 		}
+		if shard.Last() {
+			err := sink.Commit()
+			sink = nil
+			return err
+		}
+		return nil
+	})
+	if sink != nil {
+		sink.Abort()
+	}
+	if err != nil {
+		return fmt.Errorf("%q, skipping further models", err)
 	}
 	slog.Info("data loading completed successfully")
 	return nil
 }
 
 // clearModelSinks routes model records to configured sinks per config.json
-func __dgi_clearModelSinks(modelName string, records []__dgi_Record, cfg *__dgi_Config) error {
+func __dgi_clearModelSinks(modelName string, cfg *__dgi_Config) error {
 	sinks, err := cfg.SinkSpecsForModel(modelName)
 	if err != nil {
 		return fmt.Errorf("error while getting sink specs for model %s: %w", modelName, err)
@@ -71,6 +130,38 @@ func __dgi_clearModelSinks(modelName string, records []__dgi_Record, cfg *__dgi_
 			if err != nil {
 				return fmt.Errorf("error while clearing Postgres sink %s: %w", s.SinkName, err)
 			}
+		case __dgi_SinkTypeSQLite:
+			err := __dgi_clearSqliteSink(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while clearing SQLite sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeMongoDB:
+			err := __dgi_clearMongodbSink(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while clearing MongoDB sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeElasticsearch:
+			err := __dgi_clearElasticsearchSink(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while clearing Elasticsearch sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeRedis:
+			err := __dgi_clearRedisSink(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while clearing Redis sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeClickHouse:
+			err := __dgi_clearClickhouseSink(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while clearing ClickHouse sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeCassandra:
+			err := __dgi_clearCassandraSink(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while clearing Cassandra sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeKafka:
+			slog.Warn(fmt.Sprintf("clear_data is not supported for Kafka sink %s, skipping %s", s.SinkName, modelName))
 		default:
 			return fmt.Errorf("unsupported sink_type %q for model %q", s.SinkType, modelName)
 		}
@@ -78,26 +169,51 @@ func __dgi_clearModelSinks(modelName string, records []__dgi_Record, cfg *__dgi_
 	return nil
 }
 
-// loadModelSinks routes model records to configured sinks per config.json
-func __dgi_loadModelSinks(modelName string, records []__dgi_Record, cfg *__dgi_Config) error {
+// createModelTables creates the table of a model in every SQL sink configured for it
+func __dgi_createModelTables(modelName string, cfg *__dgi_Config) error {
 	sinks, err := cfg.SinkSpecsForModel(modelName)
 	if err != nil {
 		return fmt.Errorf("error while getting sink specs for model %s: %w", modelName, err)
 	}
 
-	slog.Debug(fmt.Sprintf("loading %s to %d sinks with %d records", modelName, len(sinks), len(records)))
 	for _, s := range sinks {
 		switch s.SinkType {
 		case __dgi_SinkTypeMySQL:
-			err := __dgi_loadMysqlSink(s, modelName, records)
+			err := __dgi_createMysqlTable(s, modelName)
 			if err != nil {
-				return fmt.Errorf("error in loading MySQL sink %s: %w", s.SinkName, err)
+				return fmt.Errorf("error while creating table in MySQL sink %s: %w", s.SinkName, err)
 			}
 		case __dgi_SinkTypePostgres:
-			err := __dgi_loadPostgresSink(s, modelName, records)
+			err := __dgi_createPostgresTable(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while creating table in Postgres sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeSQLite:
+			err := __dgi_createSqliteTable(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while creating table in SQLite sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeMongoDB:
+			slog.Debug(fmt.Sprintf("MongoDB sink %s creates the collection of %s on the first insert", s.SinkName, modelName))
+		case __dgi_SinkTypeElasticsearch:
+			err := __dgi_createElasticsearchIndex(s, modelName)
 			if err != nil {
-				return fmt.Errorf("error in loading Postgres sink %s: %w", s.SinkName, err)
+				return fmt.Errorf("error while creating index in Elasticsearch sink %s: %w", s.SinkName, err)
 			}
+		case __dgi_SinkTypeRedis:
+			slog.Debug(fmt.Sprintf("Redis sink %s creates the keys of %s as they are written", s.SinkName, modelName))
+		case __dgi_SinkTypeClickHouse:
+			err := __dgi_createClickhouseTable(s, modelName)
+			if err != nil {
+				return fmt.Errorf("error while creating table in ClickHouse sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeCassandra:
+			err := __dgi_createCassandraTable(s, cfg.findModelByName(modelName))
+			if err != nil {
+				return fmt.Errorf("error while creating table in Cassandra sink %s: %w", s.SinkName, err)
+			}
+		case __dgi_SinkTypeKafka:
+			slog.Warn(fmt.Sprintf("create_tables is not supported for Kafka sink %s, skipping %s", s.SinkName, modelName))
 		default:
 			return fmt.Errorf("unsupported sink_type %q for model %q", s.SinkType, modelName)
 		}
@@ -105,89 +221,172 @@ func __dgi_loadModelSinks(modelName string, records []__dgi_Record, cfg *__dgi_C
 	return nil
 }
 
-func __dgi_loadMysqlSink(sinkSpec *__dgi_SinkSpec, modelName string, records []__dgi_Record) error {
-	var sc __dgi_MySQLConfig
-	if err := sinkSpec.ConfigInto(&sc); err != nil {
-		return fmt.Errorf("mysql sink %q config: %w", sinkSpec.SinkName, err)
-	}
+// __dgi_modelSinks loads the records of a model into every sink configured
+// for it in config.json
+type __dgi_modelSinks struct {
+	specs []*__dgi_SinkSpec
+	sinks []__dgi_ModelSink
+}
 
-	if sc.BatchSize <= 0 {
-		sc.BatchSize = len(records)
+func __dgi_openModelSinks(modelName string, count, chunkSize int, cfg *__dgi_Config) (*__dgi_modelSinks, error) {
+	specs, err := cfg.SinkSpecsForModel(modelName)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting sink specs for model %s: %w", modelName, err)
 	}
 
-	switch modelName {
-	case "minimal":
-		typed := make([]*__datagen_minimal, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_minimal))
+	slog.Debug(fmt.Sprintf("loading %s to %d sinks with %d records in chunks of %d", modelName, len(specs), count, chunkSize))
+	model := cfg.findModelByName(modelName)
+	m := &__dgi_modelSinks{specs: specs, sinks: make([]__dgi_ModelSink, 0, len(specs))}
+	for _, s := range specs {
+		sink, err := __dgi_openModelSink(s, model, count)
+		if err != nil {
+			m.Abort()
+			return nil, err
 		}
+		m.sinks = append(m.sinks, sink)
+	}
+	return m, nil
+}
 
-		return Sink_mysql___datagen_minimal_data(modelName, typed, &sc)
-	case "multiple_types":
-		typed := make([]*__datagen_multiple_types, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_multiple_types))
+func (m *__dgi_modelSinks) Load(records []__dgi_Record) error {
+	for i, sink := range m.sinks {
+		if err := sink.Load(records); err != nil {
+			return fmt.Errorf("error in loading %s sink %s: %w", m.specs[i].SinkType, m.specs[i].SinkName, err)
 		}
+	}
+	return nil
+}
 
-		return Sink_mysql___datagen_multiple_types_data(modelName, typed, &sc)
-	case "nested":
-		typed := make([]*__datagen_nested, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_nested))
+func (m *__dgi_modelSinks) Commit() error {
+	for i, sink := range m.sinks {
+		if err := sink.Commit(); err != nil {
+			for _, rest := range m.sinks[i+1:] {
+				rest.Abort()
+			}
+			return fmt.Errorf("error in loading %s sink %s: %w", m.specs[i].SinkType, m.specs[i].SinkName, err)
 		}
+	}
+	return nil
+}
 
-		return Sink_mysql___datagen_nested_data(modelName, typed, &sc)
-	case "simple":
-		typed := make([]*__datagen_simple, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_simple))
-		}
+func (m *__dgi_modelSinks) Abort() {
+	for _, sink := range m.sinks {
+		sink.Abort()
+	}
+}
 
-		return Sink_mysql___datagen_simple_data(modelName, typed, &sc)
-	case "with_builtin_functions":
-		typed := make([]*__datagen_with_builtin_functions, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_builtin_functions))
+func __dgi_openModelSink(s *__dgi_SinkSpec, model *__dgi_ModelSpec, count int) (__dgi_ModelSink, error) {
+	modelName := model.ModelName
+	switch s.SinkType {
+	case __dgi_SinkTypeMySQL:
+		sink, err := __dgi_openMysqlSink(s, model, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading MySQL sink %s: %w", s.SinkName, err)
 		}
-
-		return Sink_mysql___datagen_with_builtin_functions_data(modelName, typed, &sc)
-	case "with_conditionals":
-		typed := make([]*__datagen_with_conditionals, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_conditionals))
+		return sink, nil
+	case __dgi_SinkTypePostgres:
+		sink, err := __dgi_openPostgresSink(s, model, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading Postgres sink %s: %w", s.SinkName, err)
 		}
-
-		return Sink_mysql___datagen_with_conditionals_data(modelName, typed, &sc)
-	case "with_maps":
-		typed := make([]*__datagen_with_maps, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_maps))
+		return sink, nil
+	case __dgi_SinkTypeSQLite:
+		sink, err := __dgi_openSqliteSink(s, model, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading SQLite sink %s: %w", s.SinkName, err)
 		}
-
-		return Sink_mysql___datagen_with_maps_data(modelName, typed, &sc)
-	case "with_metadata":
-		typed := make([]*__datagen_with_metadata, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_metadata))
+		return sink, nil
+	case __dgi_SinkTypeMongoDB:
+		if model.WriteMode != "" && model.WriteMode != __dgi_WriteModeInsert {
+			slog.Warn(fmt.Sprintf("write_mode %s is not supported for MongoDB sink %s, inserting %s", model.WriteMode, s.SinkName, modelName))
 		}
-
-		return Sink_mysql___datagen_with_metadata_data(modelName, typed, &sc)
-	case "with_misc":
-		typed := make([]*__datagen_with_misc, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_misc))
+		sink, err := __dgi_openMongodbSink(s, modelName, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading MongoDB sink %s: %w", s.SinkName, err)
 		}
-
-		return Sink_mysql___datagen_with_misc_data(modelName, typed, &sc)
-	case "with_slices":
-		typed := make([]*__datagen_with_slices, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_slices))
+		return sink, nil
+	case __dgi_SinkTypeElasticsearch:
+		if model.WriteMode != "" && model.WriteMode != __dgi_WriteModeInsert {
+			slog.Warn(fmt.Sprintf("write_mode %s is not supported for Elasticsearch sink %s, indexing %s", model.WriteMode, s.SinkName, modelName))
+		}
+		sink, err := __dgi_openElasticsearchSink(s, modelName, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading Elasticsearch sink %s: %w", s.SinkName, err)
+		}
+		return sink, nil
+	case __dgi_SinkTypeRedis:
+		if model.WriteMode != "" && model.WriteMode != __dgi_WriteModeInsert {
+			slog.Warn(fmt.Sprintf("write_mode %s is not supported for Redis sink %s, writing %s", model.WriteMode, s.SinkName, modelName))
+		}
+		sink, err := __dgi_openRedisSink(s, modelName, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading Redis sink %s: %w", s.SinkName, err)
+		}
+		return sink, nil
+	case __dgi_SinkTypeClickHouse:
+		if model.WriteMode != "" && model.WriteMode != __dgi_WriteModeInsert {
+			slog.Warn(fmt.Sprintf("write_mode %s is not supported for ClickHouse sink %s, inserting %s", model.WriteMode, s.SinkName, modelName))
+		}
+		sink, err := __dgi_openClickhouseSink(s, modelName, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading ClickHouse sink %s: %w", s.SinkName, err)
+		}
+		return sink, nil
+	case __dgi_SinkTypeCassandra:
+		if model.WriteMode != "" && model.WriteMode != __dgi_WriteModeInsert {
+			slog.Warn(fmt.Sprintf("write_mode %s is not supported for Cassandra sink %s, inserting %s", model.WriteMode, s.SinkName, modelName))
+		}
+		sink, err := __dgi_openCassandraSink(s, model, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading Cassandra sink %s: %w", s.SinkName, err)
 		}
+		return sink, nil
+	case __dgi_SinkTypeKafka:
+		if model.WriteMode != "" && model.WriteMode != __dgi_WriteModeInsert {
+			slog.Warn(fmt.Sprintf("write_mode %s is not supported for Kafka sink %s, appending %s", model.WriteMode, s.SinkName, modelName))
+		}
+		sink, err := __dgi_openKafkaSink(s, modelName, count)
+		if err != nil {
+			return nil, fmt.Errorf("error in loading Kafka sink %s: %w", s.SinkName, err)
+		}
+		return sink, nil
+	default:
+		return nil, fmt.Errorf("unsupported sink_type %q for model %q", s.SinkType, modelName)
+	}
+}
 
-		return Sink_mysql___datagen_with_slices_data(modelName, typed, &sc)
+func __dgi_openMysqlSink(sinkSpec *__dgi_SinkSpec, model *__dgi_ModelSpec, count int) (__dgi_ModelSink, error) {
+	modelName := model.ModelName
+	var sc __dgi_MySQLConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("mysql sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Open_mysql___datagen_minimal_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "multiple_types":
+		return Open_mysql___datagen_multiple_types_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "nested":
+		return Open_mysql___datagen_nested_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "simple":
+		return Open_mysql___datagen_simple_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_builtin_functions":
+		return Open_mysql___datagen_with_builtin_functions_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_columns":
+		return Open_mysql___datagen_with_columns_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_conditionals":
+		return Open_mysql___datagen_with_conditionals_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_maps":
+		return Open_mysql___datagen_with_maps_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_metadata":
+		return Open_mysql___datagen_with_metadata_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_misc":
+		return Open_mysql___datagen_with_misc_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_slices":
+		return Open_mysql___datagen_with_slices_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	default:
-		return fmt.Errorf("mysql sink not implemented for model %q", modelName)
+		return nil, fmt.Errorf("mysql sink not implemented for model %q", modelName)
 	}
 }
 
@@ -208,6 +407,8 @@ func __dgi_clearMysqlSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
 		return Clear_mysql___datagen_simple_data(modelName, &sc)
 	case "with_builtin_functions":
 		return Clear_mysql___datagen_with_builtin_functions_data(modelName, &sc)
+	case "with_columns":
+		return Clear_mysql___datagen_with_columns_data(modelName, &sc)
 	case "with_conditionals":
 		return Clear_mysql___datagen_with_conditionals_data(modelName, &sc)
 	case "with_maps":
@@ -223,89 +424,72 @@ func __dgi_clearMysqlSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
 	}
 }
 
-func __dgi_loadPostgresSink(sinkSpec *__dgi_SinkSpec, modelName string, records []__dgi_Record) error {
-	var sc __dgi_PostgresConfig
+func __dgi_createMysqlTable(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_MySQLConfig
 	if err := sinkSpec.ConfigInto(&sc); err != nil {
-		return fmt.Errorf("postgres sink %q config: %w", sinkSpec.SinkName, err)
+		return fmt.Errorf("mysql sink %q config: %w", sinkSpec.SinkName, err)
 	}
 
-	if sc.BatchSize <= 0 {
-		sc.BatchSize = len(records)
+	switch modelName {
+	case "minimal":
+		return Create_mysql___datagen_minimal_table(modelName, &sc)
+	case "multiple_types":
+		return Create_mysql___datagen_multiple_types_table(modelName, &sc)
+	case "nested":
+		return Create_mysql___datagen_nested_table(modelName, &sc)
+	case "simple":
+		return Create_mysql___datagen_simple_table(modelName, &sc)
+	case "with_builtin_functions":
+		return Create_mysql___datagen_with_builtin_functions_table(modelName, &sc)
+	case "with_columns":
+		return Create_mysql___datagen_with_columns_table(modelName, &sc)
+	case "with_conditionals":
+		return Create_mysql___datagen_with_conditionals_table(modelName, &sc)
+	case "with_maps":
+		return Create_mysql___datagen_with_maps_table(modelName, &sc)
+	case "with_metadata":
+		return Create_mysql___datagen_with_metadata_table(modelName, &sc)
+	case "with_misc":
+		return Create_mysql___datagen_with_misc_table(modelName, &sc)
+	case "with_slices":
+		return Create_mysql___datagen_with_slices_table(modelName, &sc)
+	default:
+		return fmt.Errorf("mysql sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_openPostgresSink(sinkSpec *__dgi_SinkSpec, model *__dgi_ModelSpec, count int) (__dgi_ModelSink, error) {
+	modelName := model.ModelName
+	var sc __dgi_PostgresConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("postgres sink %q config: %w", sinkSpec.SinkName, err)
 	}
 
 	switch modelName {
 	case "minimal":
-		typed := make([]*__datagen_minimal, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_minimal))
-		}
-
-		return Sink_postgres___datagen_minimal_data(modelName, typed, &sc)
+		return Open_postgres___datagen_minimal_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "multiple_types":
-		typed := make([]*__datagen_multiple_types, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_multiple_types))
-		}
-
-		return Sink_postgres___datagen_multiple_types_data(modelName, typed, &sc)
+		return Open_postgres___datagen_multiple_types_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "nested":
-		typed := make([]*__datagen_nested, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_nested))
-		}
-
-		return Sink_postgres___datagen_nested_data(modelName, typed, &sc)
+		return Open_postgres___datagen_nested_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "simple":
-		typed := make([]*__datagen_simple, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_simple))
-		}
-
-		return Sink_postgres___datagen_simple_data(modelName, typed, &sc)
+		return Open_postgres___datagen_simple_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "with_builtin_functions":
-		typed := make([]*__datagen_with_builtin_functions, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_builtin_functions))
-		}
-
-		return Sink_postgres___datagen_with_builtin_functions_data(modelName, typed, &sc)
+		return Open_postgres___datagen_with_builtin_functions_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_columns":
+		return Open_postgres___datagen_with_columns_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "with_conditionals":
-		typed := make([]*__datagen_with_conditionals, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_conditionals))
-		}
-
-		return Sink_postgres___datagen_with_conditionals_data(modelName, typed, &sc)
+		return Open_postgres___datagen_with_conditionals_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "with_maps":
-		typed := make([]*__datagen_with_maps, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_maps))
-		}
-
-		return Sink_postgres___datagen_with_maps_data(modelName, typed, &sc)
+		return Open_postgres___datagen_with_maps_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "with_metadata":
-		typed := make([]*__datagen_with_metadata, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_metadata))
-		}
-
-		return Sink_postgres___datagen_with_metadata_data(modelName, typed, &sc)
+		return Open_postgres___datagen_with_metadata_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "with_misc":
-		typed := make([]*__datagen_with_misc, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_misc))
-		}
-
-		return Sink_postgres___datagen_with_misc_data(modelName, typed, &sc)
+		return Open_postgres___datagen_with_misc_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	case "with_slices":
-		typed := make([]*__datagen_with_slices, 0, len(records))
-		for _, r := range records {
-			typed = append(typed, r.(*__datagen_with_slices))
-		}
-
-		return Sink_postgres___datagen_with_slices_data(modelName, typed, &sc)
+		return Open_postgres___datagen_with_slices_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
 	default:
-		return fmt.Errorf("postgres sink not implemented for model %q", modelName)
+		return nil, fmt.Errorf("postgres sink not implemented for model %q", modelName)
 	}
 }
 
@@ -326,6 +510,8 @@ func __dgi_clearPostgresSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
 		return Clear_postgres___datagen_simple_data(modelName, &sc)
 	case "with_builtin_functions":
 		return Clear_postgres___datagen_with_builtin_functions_data(modelName, &sc)
+	case "with_columns":
+		return Clear_postgres___datagen_with_columns_data(modelName, &sc)
 	case "with_conditionals":
 		return Clear_postgres___datagen_with_conditionals_data(modelName, &sc)
 	case "with_maps":
@@ -341,6 +527,621 @@ func __dgi_clearPostgresSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
 	}
 }
 
+func __dgi_createPostgresTable(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_PostgresConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("postgres sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Create_postgres___datagen_minimal_table(modelName, &sc)
+	case "multiple_types":
+		return Create_postgres___datagen_multiple_types_table(modelName, &sc)
+	case "nested":
+		return Create_postgres___datagen_nested_table(modelName, &sc)
+	case "simple":
+		return Create_postgres___datagen_simple_table(modelName, &sc)
+	case "with_builtin_functions":
+		return Create_postgres___datagen_with_builtin_functions_table(modelName, &sc)
+	case "with_columns":
+		return Create_postgres___datagen_with_columns_table(modelName, &sc)
+	case "with_conditionals":
+		return Create_postgres___datagen_with_conditionals_table(modelName, &sc)
+	case "with_maps":
+		return Create_postgres___datagen_with_maps_table(modelName, &sc)
+	case "with_metadata":
+		return Create_postgres___datagen_with_metadata_table(modelName, &sc)
+	case "with_misc":
+		return Create_postgres___datagen_with_misc_table(modelName, &sc)
+	case "with_slices":
+		return Create_postgres___datagen_with_slices_table(modelName, &sc)
+	default:
+		return fmt.Errorf("postgres sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_openSqliteSink(sinkSpec *__dgi_SinkSpec, model *__dgi_ModelSpec, count int) (__dgi_ModelSink, error) {
+	modelName := model.ModelName
+	var sc __dgi_SQLiteConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("sqlite sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Open_sqlite___datagen_minimal_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "multiple_types":
+		return Open_sqlite___datagen_multiple_types_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "nested":
+		return Open_sqlite___datagen_nested_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "simple":
+		return Open_sqlite___datagen_simple_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_builtin_functions":
+		return Open_sqlite___datagen_with_builtin_functions_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_columns":
+		return Open_sqlite___datagen_with_columns_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_conditionals":
+		return Open_sqlite___datagen_with_conditionals_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_maps":
+		return Open_sqlite___datagen_with_maps_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_metadata":
+		return Open_sqlite___datagen_with_metadata_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_misc":
+		return Open_sqlite___datagen_with_misc_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	case "with_slices":
+		return Open_sqlite___datagen_with_slices_sink(modelName, count, &sc, model.WriteMode, model.KeyColumns)
+	default:
+		return nil, fmt.Errorf("sqlite sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_clearSqliteSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_SQLiteConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("sqlite sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Clear_sqlite___datagen_minimal_data(modelName, &sc)
+	case "multiple_types":
+		return Clear_sqlite___datagen_multiple_types_data(modelName, &sc)
+	case "nested":
+		return Clear_sqlite___datagen_nested_data(modelName, &sc)
+	case "simple":
+		return Clear_sqlite___datagen_simple_data(modelName, &sc)
+	case "with_builtin_functions":
+		return Clear_sqlite___datagen_with_builtin_functions_data(modelName, &sc)
+	case "with_columns":
+		return Clear_sqlite___datagen_with_columns_data(modelName, &sc)
+	case "with_conditionals":
+		return Clear_sqlite___datagen_with_conditionals_data(modelName, &sc)
+	case "with_maps":
+		return Clear_sqlite___datagen_with_maps_data(modelName, &sc)
+	case "with_metadata":
+		return Clear_sqlite___datagen_with_metadata_data(modelName, &sc)
+	case "with_misc":
+		return Clear_sqlite___datagen_with_misc_data(modelName, &sc)
+	case "with_slices":
+		return Clear_sqlite___datagen_with_slices_data(modelName, &sc)
+	default:
+		return fmt.Errorf("sqlite sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_createSqliteTable(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_SQLiteConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("sqlite sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Create_sqlite___datagen_minimal_table(modelName, &sc)
+	case "multiple_types":
+		return Create_sqlite___datagen_multiple_types_table(modelName, &sc)
+	case "nested":
+		return Create_sqlite___datagen_nested_table(modelName, &sc)
+	case "simple":
+		return Create_sqlite___datagen_simple_table(modelName, &sc)
+	case "with_builtin_functions":
+		return Create_sqlite___datagen_with_builtin_functions_table(modelName, &sc)
+	case "with_columns":
+		return Create_sqlite___datagen_with_columns_table(modelName, &sc)
+	case "with_conditionals":
+		return Create_sqlite___datagen_with_conditionals_table(modelName, &sc)
+	case "with_maps":
+		return Create_sqlite___datagen_with_maps_table(modelName, &sc)
+	case "with_metadata":
+		return Create_sqlite___datagen_with_metadata_table(modelName, &sc)
+	case "with_misc":
+		return Create_sqlite___datagen_with_misc_table(modelName, &sc)
+	case "with_slices":
+		return Create_sqlite___datagen_with_slices_table(modelName, &sc)
+	default:
+		return fmt.Errorf("sqlite sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_openMongodbSink(sinkSpec *__dgi_SinkSpec, modelName string, count int) (__dgi_ModelSink, error) {
+	var sc __dgi_MongoDBConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("mongodb sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Open_mongodb___datagen_minimal_sink(modelName, count, &sc)
+	case "multiple_types":
+		return Open_mongodb___datagen_multiple_types_sink(modelName, count, &sc)
+	case "nested":
+		return Open_mongodb___datagen_nested_sink(modelName, count, &sc)
+	case "simple":
+		return Open_mongodb___datagen_simple_sink(modelName, count, &sc)
+	case "with_builtin_functions":
+		return Open_mongodb___datagen_with_builtin_functions_sink(modelName, count, &sc)
+	case "with_columns":
+		return Open_mongodb___datagen_with_columns_sink(modelName, count, &sc)
+	case "with_conditionals":
+		return Open_mongodb___datagen_with_conditionals_sink(modelName, count, &sc)
+	case "with_maps":
+		return Open_mongodb___datagen_with_maps_sink(modelName, count, &sc)
+	case "with_metadata":
+		return Open_mongodb___datagen_with_metadata_sink(modelName, count, &sc)
+	case "with_misc":
+		return Open_mongodb___datagen_with_misc_sink(modelName, count, &sc)
+	case "with_slices":
+		return Open_mongodb___datagen_with_slices_sink(modelName, count, &sc)
+	default:
+		return nil, fmt.Errorf("mongodb sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_clearMongodbSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_MongoDBConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("mongodb sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Clear_mongodb___datagen_minimal_data(modelName, &sc)
+	case "multiple_types":
+		return Clear_mongodb___datagen_multiple_types_data(modelName, &sc)
+	case "nested":
+		return Clear_mongodb___datagen_nested_data(modelName, &sc)
+	case "simple":
+		return Clear_mongodb___datagen_simple_data(modelName, &sc)
+	case "with_builtin_functions":
+		return Clear_mongodb___datagen_with_builtin_functions_data(modelName, &sc)
+	case "with_columns":
+		return Clear_mongodb___datagen_with_columns_data(modelName, &sc)
+	case "with_conditionals":
+		return Clear_mongodb___datagen_with_conditionals_data(modelName, &sc)
+	case "with_maps":
+		return Clear_mongodb___datagen_with_maps_data(modelName, &sc)
+	case "with_metadata":
+		return Clear_mongodb___datagen_with_metadata_data(modelName, &sc)
+	case "with_misc":
+		return Clear_mongodb___datagen_with_misc_data(modelName, &sc)
+	case "with_slices":
+		return Clear_mongodb___datagen_with_slices_data(modelName, &sc)
+	default:
+		return fmt.Errorf("mongodb sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_openElasticsearchSink(sinkSpec *__dgi_SinkSpec, modelName string, count int) (__dgi_ModelSink, error) {
+	var sc __dgi_ElasticsearchConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("elasticsearch sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Open_elasticsearch___datagen_minimal_sink(modelName, count, &sc)
+	case "multiple_types":
+		return Open_elasticsearch___datagen_multiple_types_sink(modelName, count, &sc)
+	case "nested":
+		return Open_elasticsearch___datagen_nested_sink(modelName, count, &sc)
+	case "simple":
+		return Open_elasticsearch___datagen_simple_sink(modelName, count, &sc)
+	case "with_builtin_functions":
+		return Open_elasticsearch___datagen_with_builtin_functions_sink(modelName, count, &sc)
+	case "with_columns":
+		return Open_elasticsearch___datagen_with_columns_sink(modelName, count, &sc)
+	case "with_conditionals":
+		return Open_elasticsearch___datagen_with_conditionals_sink(modelName, count, &sc)
+	case "with_maps":
+		return Open_elasticsearch___datagen_with_maps_sink(modelName, count, &sc)
+	case "with_metadata":
+		return Open_elasticsearch___datagen_with_metadata_sink(modelName, count, &sc)
+	case "with_misc":
+		return Open_elasticsearch___datagen_with_misc_sink(modelName, count, &sc)
+	case "with_slices":
+		return Open_elasticsearch___datagen_with_slices_sink(modelName, count, &sc)
+	default:
+		return nil, fmt.Errorf("elasticsearch sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_clearElasticsearchSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_ElasticsearchConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("elasticsearch sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Clear_elasticsearch___datagen_minimal_data(modelName, &sc)
+	case "multiple_types":
+		return Clear_elasticsearch___datagen_multiple_types_data(modelName, &sc)
+	case "nested":
+		return Clear_elasticsearch___datagen_nested_data(modelName, &sc)
+	case "simple":
+		return Clear_elasticsearch___datagen_simple_data(modelName, &sc)
+	case "with_builtin_functions":
+		return Clear_elasticsearch___datagen_with_builtin_functions_data(modelName, &sc)
+	case "with_columns":
+		return Clear_elasticsearch___datagen_with_columns_data(modelName, &sc)
+	case "with_conditionals":
+		return Clear_elasticsearch___datagen_with_conditionals_data(modelName, &sc)
+	case "with_maps":
+		return Clear_elasticsearch___datagen_with_maps_data(modelName, &sc)
+	case "with_metadata":
+		return Clear_elasticsearch___datagen_with_metadata_data(modelName, &sc)
+	case "with_misc":
+		return Clear_elasticsearch___datagen_with_misc_data(modelName, &sc)
+	case "with_slices":
+		return Clear_elasticsearch___datagen_with_slices_data(modelName, &sc)
+	default:
+		return fmt.Errorf("elasticsearch sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_createElasticsearchIndex(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_ElasticsearchConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("elasticsearch sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Create_elasticsearch___datagen_minimal_index(modelName, &sc)
+	case "multiple_types":
+		return Create_elasticsearch___datagen_multiple_types_index(modelName, &sc)
+	case "nested":
+		return Create_elasticsearch___datagen_nested_index(modelName, &sc)
+	case "simple":
+		return Create_elasticsearch___datagen_simple_index(modelName, &sc)
+	case "with_builtin_functions":
+		return Create_elasticsearch___datagen_with_builtin_functions_index(modelName, &sc)
+	case "with_columns":
+		return Create_elasticsearch___datagen_with_columns_index(modelName, &sc)
+	case "with_conditionals":
+		return Create_elasticsearch___datagen_with_conditionals_index(modelName, &sc)
+	case "with_maps":
+		return Create_elasticsearch___datagen_with_maps_index(modelName, &sc)
+	case "with_metadata":
+		return Create_elasticsearch___datagen_with_metadata_index(modelName, &sc)
+	case "with_misc":
+		return Create_elasticsearch___datagen_with_misc_index(modelName, &sc)
+	case "with_slices":
+		return Create_elasticsearch___datagen_with_slices_index(modelName, &sc)
+	default:
+		return fmt.Errorf("elasticsearch sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_openRedisSink(sinkSpec *__dgi_SinkSpec, modelName string, count int) (__dgi_ModelSink, error) {
+	var sc __dgi_RedisConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("redis sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Open_redis___datagen_minimal_sink(modelName, count, &sc)
+	case "multiple_types":
+		return Open_redis___datagen_multiple_types_sink(modelName, count, &sc)
+	case "nested":
+		return Open_redis___datagen_nested_sink(modelName, count, &sc)
+	case "simple":
+		return Open_redis___datagen_simple_sink(modelName, count, &sc)
+	case "with_builtin_functions":
+		return Open_redis___datagen_with_builtin_functions_sink(modelName, count, &sc)
+	case "with_columns":
+		return Open_redis___datagen_with_columns_sink(modelName, count, &sc)
+	case "with_conditionals":
+		return Open_redis___datagen_with_conditionals_sink(modelName, count, &sc)
+	case "with_maps":
+		return Open_redis___datagen_with_maps_sink(modelName, count, &sc)
+	case "with_metadata":
+		return Open_redis___datagen_with_metadata_sink(modelName, count, &sc)
+	case "with_misc":
+		return Open_redis___datagen_with_misc_sink(modelName, count, &sc)
+	case "with_slices":
+		return Open_redis___datagen_with_slices_sink(modelName, count, &sc)
+	default:
+		return nil, fmt.Errorf("redis sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_clearRedisSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_RedisConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("redis sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Clear_redis___datagen_minimal_data(modelName, &sc)
+	case "multiple_types":
+		return Clear_redis___datagen_multiple_types_data(modelName, &sc)
+	case "nested":
+		return Clear_redis___datagen_nested_data(modelName, &sc)
+	case "simple":
+		return Clear_redis___datagen_simple_data(modelName, &sc)
+	case "with_builtin_functions":
+		return Clear_redis___datagen_with_builtin_functions_data(modelName, &sc)
+	case "with_columns":
+		return Clear_redis___datagen_with_columns_data(modelName, &sc)
+	case "with_conditionals":
+		return Clear_redis___datagen_with_conditionals_data(modelName, &sc)
+	case "with_maps":
+		return Clear_redis___datagen_with_maps_data(modelName, &sc)
+	case "with_metadata":
+		return Clear_redis___datagen_with_metadata_data(modelName, &sc)
+	case "with_misc":
+		return Clear_redis___datagen_with_misc_data(modelName, &sc)
+	case "with_slices":
+		return Clear_redis___datagen_with_slices_data(modelName, &sc)
+	default:
+		return fmt.Errorf("redis sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_openClickhouseSink(sinkSpec *__dgi_SinkSpec, modelName string, count int) (__dgi_ModelSink, error) {
+	var sc __dgi_ClickHouseConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("clickhouse sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Open_clickhouse___datagen_minimal_sink(modelName, count, &sc)
+	case "multiple_types":
+		return Open_clickhouse___datagen_multiple_types_sink(modelName, count, &sc)
+	case "nested":
+		return Open_clickhouse___datagen_nested_sink(modelName, count, &sc)
+	case "simple":
+		return Open_clickhouse___datagen_simple_sink(modelName, count, &sc)
+	case "with_builtin_functions":
+		return Open_clickhouse___datagen_with_builtin_functions_sink(modelName, count, &sc)
+	case "with_columns":
+		return Open_clickhouse___datagen_with_columns_sink(modelName, count, &sc)
+	case "with_conditionals":
+		return Open_clickhouse___datagen_with_conditionals_sink(modelName, count, &sc)
+	case "with_maps":
+		return Open_clickhouse___datagen_with_maps_sink(modelName, count, &sc)
+	case "with_metadata":
+		return Open_clickhouse___datagen_with_metadata_sink(modelName, count, &sc)
+	case "with_misc":
+		return Open_clickhouse___datagen_with_misc_sink(modelName, count, &sc)
+	case "with_slices":
+		return Open_clickhouse___datagen_with_slices_sink(modelName, count, &sc)
+	default:
+		return nil, fmt.Errorf("clickhouse sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_clearClickhouseSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_ClickHouseConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("clickhouse sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Clear_clickhouse___datagen_minimal_data(modelName, &sc)
+	case "multiple_types":
+		return Clear_clickhouse___datagen_multiple_types_data(modelName, &sc)
+	case "nested":
+		return Clear_clickhouse___datagen_nested_data(modelName, &sc)
+	case "simple":
+		return Clear_clickhouse___datagen_simple_data(modelName, &sc)
+	case "with_builtin_functions":
+		return Clear_clickhouse___datagen_with_builtin_functions_data(modelName, &sc)
+	case "with_columns":
+		return Clear_clickhouse___datagen_with_columns_data(modelName, &sc)
+	case "with_conditionals":
+		return Clear_clickhouse___datagen_with_conditionals_data(modelName, &sc)
+	case "with_maps":
+		return Clear_clickhouse___datagen_with_maps_data(modelName, &sc)
+	case "with_metadata":
+		return Clear_clickhouse___datagen_with_metadata_data(modelName, &sc)
+	case "with_misc":
+		return Clear_clickhouse___datagen_with_misc_data(modelName, &sc)
+	case "with_slices":
+		return Clear_clickhouse___datagen_with_slices_data(modelName, &sc)
+	default:
+		return fmt.Errorf("clickhouse sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_createClickhouseTable(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_ClickHouseConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("clickhouse sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Create_clickhouse___datagen_minimal_table(modelName, &sc)
+	case "multiple_types":
+		return Create_clickhouse___datagen_multiple_types_table(modelName, &sc)
+	case "nested":
+		return Create_clickhouse___datagen_nested_table(modelName, &sc)
+	case "simple":
+		return Create_clickhouse___datagen_simple_table(modelName, &sc)
+	case "with_builtin_functions":
+		return Create_clickhouse___datagen_with_builtin_functions_table(modelName, &sc)
+	case "with_columns":
+		return Create_clickhouse___datagen_with_columns_table(modelName, &sc)
+	case "with_conditionals":
+		return Create_clickhouse___datagen_with_conditionals_table(modelName, &sc)
+	case "with_maps":
+		return Create_clickhouse___datagen_with_maps_table(modelName, &sc)
+	case "with_metadata":
+		return Create_clickhouse___datagen_with_metadata_table(modelName, &sc)
+	case "with_misc":
+		return Create_clickhouse___datagen_with_misc_table(modelName, &sc)
+	case "with_slices":
+		return Create_clickhouse___datagen_with_slices_table(modelName, &sc)
+	default:
+		return fmt.Errorf("clickhouse sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_openCassandraSink(sinkSpec *__dgi_SinkSpec, model *__dgi_ModelSpec, count int) (__dgi_ModelSink, error) {
+	modelName := model.ModelName
+	var sc __dgi_CassandraConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("cassandra sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Open_cassandra___datagen_minimal_sink(modelName, count, &sc, model.KeyColumns)
+	case "multiple_types":
+		return Open_cassandra___datagen_multiple_types_sink(modelName, count, &sc, model.KeyColumns)
+	case "nested":
+		return Open_cassandra___datagen_nested_sink(modelName, count, &sc, model.KeyColumns)
+	case "simple":
+		return Open_cassandra___datagen_simple_sink(modelName, count, &sc, model.KeyColumns)
+	case "with_builtin_functions":
+		return Open_cassandra___datagen_with_builtin_functions_sink(modelName, count, &sc, model.KeyColumns)
+	case "with_columns":
+		return Open_cassandra___datagen_with_columns_sink(modelName, count, &sc, model.KeyColumns)
+	case "with_conditionals":
+		return Open_cassandra___datagen_with_conditionals_sink(modelName, count, &sc, model.KeyColumns)
+	case "with_maps":
+		return Open_cassandra___datagen_with_maps_sink(modelName, count, &sc, model.KeyColumns)
+	case "with_metadata":
+		return Open_cassandra___datagen_with_metadata_sink(modelName, count, &sc, model.KeyColumns)
+	case "with_misc":
+		return Open_cassandra___datagen_with_misc_sink(modelName, count, &sc, model.KeyColumns)
+	case "with_slices":
+		return Open_cassandra___datagen_with_slices_sink(modelName, count, &sc, model.KeyColumns)
+	default:
+		return nil, fmt.Errorf("cassandra sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_clearCassandraSink(sinkSpec *__dgi_SinkSpec, modelName string) error {
+	var sc __dgi_CassandraConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("cassandra sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Clear_cassandra___datagen_minimal_data(modelName, &sc)
+	case "multiple_types":
+		return Clear_cassandra___datagen_multiple_types_data(modelName, &sc)
+	case "nested":
+		return Clear_cassandra___datagen_nested_data(modelName, &sc)
+	case "simple":
+		return Clear_cassandra___datagen_simple_data(modelName, &sc)
+	case "with_builtin_functions":
+		return Clear_cassandra___datagen_with_builtin_functions_data(modelName, &sc)
+	case "with_columns":
+		return Clear_cassandra___datagen_with_columns_data(modelName, &sc)
+	case "with_conditionals":
+		return Clear_cassandra___datagen_with_conditionals_data(modelName, &sc)
+	case "with_maps":
+		return Clear_cassandra___datagen_with_maps_data(modelName, &sc)
+	case "with_metadata":
+		return Clear_cassandra___datagen_with_metadata_data(modelName, &sc)
+	case "with_misc":
+		return Clear_cassandra___datagen_with_misc_data(modelName, &sc)
+	case "with_slices":
+		return Clear_cassandra___datagen_with_slices_data(modelName, &sc)
+	default:
+		return fmt.Errorf("cassandra sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_createCassandraTable(sinkSpec *__dgi_SinkSpec, model *__dgi_ModelSpec) error {
+	modelName := model.ModelName
+	var sc __dgi_CassandraConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return fmt.Errorf("cassandra sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Create_cassandra___datagen_minimal_table(modelName, &sc, model.KeyColumns)
+	case "multiple_types":
+		return Create_cassandra___datagen_multiple_types_table(modelName, &sc, model.KeyColumns)
+	case "nested":
+		return Create_cassandra___datagen_nested_table(modelName, &sc, model.KeyColumns)
+	case "simple":
+		return Create_cassandra___datagen_simple_table(modelName, &sc, model.KeyColumns)
+	case "with_builtin_functions":
+		return Create_cassandra___datagen_with_builtin_functions_table(modelName, &sc, model.KeyColumns)
+	case "with_columns":
+		return Create_cassandra___datagen_with_columns_table(modelName, &sc, model.KeyColumns)
+	case "with_conditionals":
+		return Create_cassandra___datagen_with_conditionals_table(modelName, &sc, model.KeyColumns)
+	case "with_maps":
+		return Create_cassandra___datagen_with_maps_table(modelName, &sc, model.KeyColumns)
+	case "with_metadata":
+		return Create_cassandra___datagen_with_metadata_table(modelName, &sc, model.KeyColumns)
+	case "with_misc":
+		return Create_cassandra___datagen_with_misc_table(modelName, &sc, model.KeyColumns)
+	case "with_slices":
+		return Create_cassandra___datagen_with_slices_table(modelName, &sc, model.KeyColumns)
+	default:
+		return fmt.Errorf("cassandra sink not implemented for model %q", modelName)
+	}
+}
+
+func __dgi_openKafkaSink(sinkSpec *__dgi_SinkSpec, modelName string, count int) (__dgi_ModelSink, error) {
+	var sc __dgi_KafkaConfig
+	if err := sinkSpec.ConfigInto(&sc); err != nil {
+		return nil, fmt.Errorf("kafka sink %q config: %w", sinkSpec.SinkName, err)
+	}
+
+	switch modelName {
+	case "minimal":
+		return Open_kafka___datagen_minimal_sink(modelName, count, &sc)
+	case "multiple_types":
+		return Open_kafka___datagen_multiple_types_sink(modelName, count, &sc)
+	case "nested":
+		return Open_kafka___datagen_nested_sink(modelName, count, &sc)
+	case "simple":
+		return Open_kafka___datagen_simple_sink(modelName, count, &sc)
+	case "with_builtin_functions":
+		return Open_kafka___datagen_with_builtin_functions_sink(modelName, count, &sc)
+	case "with_columns":
+		return Open_kafka___datagen_with_columns_sink(modelName, count, &sc)
+	case "with_conditionals":
+		return Open_kafka___datagen_with_conditionals_sink(modelName, count, &sc)
+	case "with_maps":
+		return Open_kafka___datagen_with_maps_sink(modelName, count, &sc)
+	case "with_metadata":
+		return Open_kafka___datagen_with_metadata_sink(modelName, count, &sc)
+	case "with_misc":
+		return Open_kafka___datagen_with_misc_sink(modelName, count, &sc)
+	case "with_slices":
+		return Open_kafka___datagen_with_slices_sink(modelName, count, &sc)
+	default:
+		return nil, fmt.Errorf("kafka sink not implemented for model %q", modelName)
+	}
+}
+
 func __dgi_getRecordCount(cfg *__dgi_Config, modelName string, metadata __dgi_Metadata) int {
 	for _, m := range cfg.Models {
 		if m.ModelName == modelName {
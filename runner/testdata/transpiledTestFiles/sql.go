@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQL dialects of the sql format
+const (
+	__dgi_DialectMySQL    = "mysql"
+	__dgi_DialectPostgres = "postgres"
+	__dgi_DialectSQLite   = "sqlite"
+)
+
+// __dgi_SQLTable holds the statements writing the records of a model to its
+// table in one dialect. Insert ends with VALUES, and CreateTableError tells
+// why there is no CreateTable.
+type __dgi_SQLTable struct {
+	Insert           string
+	Truncate         string
+	CreateTable      string
+	CreateTableError string
+}
+
+// __dgi_SQLOptions holds the flags of the sql format.
+type __dgi_SQLOptions struct {
+	Dialect     string
+	BatchSize   int
+	CreateTable bool
+	Truncate    bool
+}
+
+func (o __dgi_SQLOptions) validate() error {
+	switch o.Dialect {
+	case __dgi_DialectMySQL, __dgi_DialectPostgres, __dgi_DialectSQLite:
+	default:
+		return fmt.Errorf("--dialect must be one of %s", strings.Join([]string{__dgi_DialectMySQL, __dgi_DialectPostgres, __dgi_DialectSQLite}, ", "))
+	}
+	if o.BatchSize < 1 {
+		return fmt.Errorf("--batch-size must be positive, got %d", o.BatchSize)
+	}
+	return nil
+}
+
+var __dgi_sqlTimeType = reflect.TypeOf(time.Time{})
+
+// __dgi_sqlLiteral renders v as a literal of dialect. Slices, maps and
+// structs are written as JSON, and times in UTC.
+func __dgi_sqlLiteral(dialect string, v reflect.Value) (string, error) {
+	if !v.IsValid() {
+		return "NULL", nil
+	}
+	if v.Type() == __dgi_sqlTimeType {
+		return "'" + v.Interface().(time.Time).UTC().Format("2006-01-02 15:04:05.999999") + "'", nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return "NULL", nil
+		}
+		return __dgi_sqlLiteral(dialect, v.Elem())
+	case reflect.Bool:
+		if dialect == __dgi_DialectSQLite {
+			if v.Bool() {
+				return "1", nil
+			}
+			return "0", nil
+		}
+		if v.Bool() {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			// only Postgres has literals for these, which it reads from strings
+			if dialect != __dgi_DialectPostgres {
+				return "", fmt.Errorf("cannot write %v in %s", f, dialect)
+			}
+			switch {
+			case math.IsNaN(f):
+				return "'NaN'", nil
+			case f > 0:
+				return "'Infinity'", nil
+			}
+			return "'-Infinity'", nil
+		}
+		return strconv.FormatFloat(f, 'g', -1, v.Type().Bits()), nil
+	case reflect.String:
+		return __dgi_sqlString(dialect, v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			return "NULL", nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if dialect == __dgi_DialectPostgres {
+				return `'\x` + hex.EncodeToString(v.Bytes()) + "'", nil
+			}
+			return "X'" + hex.EncodeToString(v.Bytes()) + "'", nil
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return "NULL", nil
+		}
+	case reflect.Array, reflect.Struct:
+	default:
+		return "", fmt.Errorf("cannot write %s in %s", v.Type(), dialect)
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return "", err
+	}
+	return __dgi_sqlString(dialect, string(data))
+}
+
+// __dgi_sqlString quotes s as a string literal of dialect. MySQL also treats
+// backslashes as escapes, and Postgres and SQLite strings cannot hold NUL.
+func __dgi_sqlString(dialect, s string) (string, error) {
+	if dialect == __dgi_DialectMySQL {
+		s = strings.NewReplacer(`\`, `\\`, "'", "''", "\x00", `\0`, "\x1a", `\Z`).Replace(s)
+		return "'" + s + "'", nil
+	}
+	if strings.ContainsRune(s, 0) {
+		return "", fmt.Errorf("cannot write a string holding NUL in %s", dialect)
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'", nil
+}
+
+// __dgi_sqlWriter writes the records of a model as INSERT statements of
+// BatchSize rows, after the optional CREATE TABLE and TRUNCATE preambles. The
+// statements come with the first record, so models without records get
+// nothing.
+type __dgi_sqlWriter struct {
+	name   string
+	opts   __dgi_SQLOptions
+	file   *__dgi_OutputFile
+	writer *bufio.Writer
+	insert string
+	rows   []string
+	count  int
+}
+
+// __dgi_newSQLWriterFactory returns a factory of SQL writers. Models written
+// to the same file, when the output is a .sql file, follow each other in it.
+func __dgi_newSQLWriterFactory(opts __dgi_SQLOptions) __dgi_OutputWriterFactory {
+	return func(name string, create __dgi_OutputFileFactory) (__dgi_OutputWriter, error) {
+		file, err := create(__dgi_FormatSQL, true)
+		if err != nil {
+			return nil, fmt.Errorf("error creating SQL file for %s: %v", name, err)
+		}
+		return &__dgi_sqlWriter{name: name, opts: opts, file: file}, nil
+	}
+}
+
+func (w *__dgi_sqlWriter) writeHeader(table __dgi_SQLTable) error {
+	w.writer = bufio.NewWriter(w.file)
+	w.insert = table.Insert
+	if w.opts.CreateTable {
+		if table.CreateTableError != "" {
+			return fmt.Errorf("cannot derive the table of the model: %s", table.CreateTableError)
+		}
+		if _, err := fmt.Fprintf(w.writer, "%s\n\n", table.CreateTable); err != nil {
+			return fmt.Errorf("error writing SQL for %s: %w", w.name, err)
+		}
+	}
+	if w.opts.Truncate {
+		if _, err := fmt.Fprintf(w.writer, "%s\n\n", table.Truncate); err != nil {
+			return fmt.Errorf("error writing SQL for %s: %w", w.name, err)
+		}
+	}
+	return nil
+}
+
+func (w *__dgi_sqlWriter) Write(records []__dgi_Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if w.writer == nil {
+		table, err := records[0].SQLTable(w.opts.Dialect)
+		if err != nil {
+			return err
+		}
+		if err := w.writeHeader(table); err != nil {
+			return err
+		}
+	}
+
+	for _, record := range records {
+		values := record.SQLValues()
+		literals := make([]string, len(values))
+		for i, value := range values {
+			literal, err := __dgi_sqlLiteral(w.opts.Dialect, reflect.ValueOf(value))
+			if err != nil {
+				return fmt.Errorf("error writing SQL row for %s: %w", w.name, err)
+			}
+			literals[i] = literal
+		}
+		w.rows = append(w.rows, "("+strings.Join(literals, ", ")+")")
+		if len(w.rows) == w.opts.BatchSize {
+			if err := w.flushRows(); err != nil {
+				return err
+			}
+		}
+	}
+	w.count += len(records)
+	return nil
+}
+
+// flushRows writes the pending rows as one INSERT statement.
+func (w *__dgi_sqlWriter) flushRows() error {
+	if len(w.rows) == 0 {
+		return nil
+	}
+	_, err := fmt.Fprintf(w.writer, "%s\n  %s;\n\n", w.insert, strings.Join(w.rows, ",\n  "))
+	w.rows = w.rows[:0]
+	if err != nil {
+		return fmt.Errorf("error writing SQL for %s: %w", w.name, err)
+	}
+	return nil
+}
+
+func (w *__dgi_sqlWriter) Close() error {
+	if w.writer == nil {
+		if err := w.file.Discard(); err != nil {
+			return fmt.Errorf("error removing empty SQL file for %s: %w", w.name, err)
+		}
+		slog.Info(fmt.Sprintf("no records for %s, no SQL generated", w.name))
+		return nil
+	}
+	defer w.file.Discard()
+	if err := w.flushRows(); err != nil {
+		return err
+	}
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("error flushing SQL file for %s: %w", w.name, err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("error closing SQL file for %s: %w", w.name, err)
+	}
+	slog.Info(fmt.Sprintf("generated SQL file %s with %d records", w.file.Name(), w.count))
+	return nil
+}
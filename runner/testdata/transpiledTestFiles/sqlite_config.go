@@ -0,0 +1,22 @@
+package main
+
+import (
+	"errors"
+)
+
+type __dgi_SQLiteConfig struct {
+	// Path is the database file, which is created when it does not exist.
+	Path           string `json:"path"`
+	BatchSize      int    `json:"batch_size,omitempty"`
+	// Timeout is how long statements wait for the database to be unlocked
+	// by other connections, 5s by default.
+	Timeout        string `json:"timeout,omitempty"`
+	Throttle       string `json:"throttle,omitempty"`
+}
+
+func (c *__dgi_SQLiteConfig) Validate() error {
+	if c.Path == "" {
+		return errors.New("sqlite: path is required")
+	}
+	return nil
+}
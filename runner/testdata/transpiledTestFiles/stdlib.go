@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"image"
 	"math"
-	"math/rand"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -32,35 +31,35 @@ type ParagraphOptions struct {
 	Separator      string
 }
 
-func Name() string {
-	return gofakeit.Name()
+func Name(r *__dgi_Rand) string {
+	return r.faker.Name()
 }
 
-func Email() string {
-	return gofakeit.Email()
+func Email(r *__dgi_Rand) string {
+	return r.faker.Email()
 }
 
-func Phone() string {
-	return gofakeit.Phone()
+func Phone(r *__dgi_Rand) string {
+	return r.faker.Phone()
 }
 
-func Boolean() bool {
-	return gofakeit.Bool()
+func Boolean(r *__dgi_Rand) bool {
+	return r.faker.Bool()
 }
 
-func Url() string {
-	return gofakeit.URL()
+func Url(r *__dgi_Rand) string {
+	return r.faker.URL()
 }
 
-func Company() string {
-	return gofakeit.Company()
+func Company(r *__dgi_Rand) string {
+	return r.faker.Company()
 }
 
-func HexColor() string {
-	return gofakeit.HexColor()
+func HexColor(r *__dgi_Rand) string {
+	return r.faker.HexColor()
 }
 
-func DateBetweenStr(startStr, endStr string) string {
+func DateBetweenStr(r *__dgi_Rand, startStr, endStr string) string {
 	const layout = "2006-01-02 15:04:05"
 
 	startDate, err := time.Parse(layout, startStr)
@@ -78,14 +77,14 @@ func DateBetweenStr(startStr, endStr string) string {
 	}
 
 	diff := endDate.Unix() - startDate.Unix()
-	randomOffset := rand.Int63n(diff + 1)
+	randomOffset := r.rand.Int64N(diff + 1)
 	randomTime := startDate.Add(time.Duration(randomOffset) * time.Second)
 
 	return randomTime.Format(layout)
 }
 
-func Text(len int) string {
-	return gofakeit.LetterN(uint(len))
+func Text(r *__dgi_Rand, len int) string {
+	return r.faker.LetterN(uint(len))
 }
 
 func ToJSON(v interface{}) string {
@@ -96,86 +95,86 @@ func ToJSON(v interface{}) string {
 	return string(bytes)
 }
 
-func IntBetween(min, max int) int {
+func IntBetween(r *__dgi_Rand, min, max int) int {
 	if min > max {
 		min, max = max, min
 	}
-	return rand.Intn(max-min+1) + min
+	return r.rand.IntN(max-min+1) + min
 }
 
-func DateBetween(startDate, endDate time.Time) time.Time {
+func DateBetween(r *__dgi_Rand, startDate, endDate time.Time) time.Time {
 	if startDate.After(endDate) {
 		startDate, endDate = endDate, startDate
 	}
 
 	diff := endDate.Unix() - startDate.Unix()
-	randomOffset := rand.Int63n(diff + 1)
+	randomOffset := r.rand.Int64N(diff + 1)
 	randomTime := startDate.Add(time.Duration(randomOffset) * time.Second)
 	return randomTime
 }
 
-func RandomFrom[T any](values ...T) T {
+func RandomFrom[T any](r *__dgi_Rand, values ...T) T {
 	if len(values) == 0 {
 		var zero T
 		return zero
 	}
-	return values[rand.Intn(len(values))]
+	return values[r.rand.IntN(len(values))]
 }
 
-func UUID() string {
-	return gofakeit.UUID()
+func UUID(r *__dgi_Rand) string {
+	return r.faker.UUID()
 }
 
-func FloatBetween(min, max float64) float64 {
-	value := min + rand.Float64()*(max-min)
+func FloatBetween(r *__dgi_Rand, min, max float64) float64 {
+	value := min + r.rand.Float64()*(max-min)
 	return math.Round(value*100) / 100
 }
 
-func Float32Between(min, max float32) float32 {
-	value := float64(min) + rand.Float64()*(float64(max)-float64(min))
+func Float32Between(r *__dgi_Rand, min, max float32) float32 {
+	value := float64(min) + r.rand.Float64()*(float64(max)-float64(min))
 	rounded := math.Round(value*100) / 100
 	return float32(rounded)
 }
 
-func FirstName() string {
-	return gofakeit.FirstName()
+func FirstName(r *__dgi_Rand) string {
+	return r.faker.FirstName()
 }
 
-func LastName() string {
-	return gofakeit.LastName()
+func LastName(r *__dgi_Rand) string {
+	return r.faker.LastName()
 }
 
-func NamePrefix() string {
-	return gofakeit.NamePrefix()
+func NamePrefix(r *__dgi_Rand) string {
+	return r.faker.NamePrefix()
 }
 
-func NameSuffix() string {
-	return gofakeit.NameSuffix()
+func NameSuffix(r *__dgi_Rand) string {
+	return r.faker.NameSuffix()
 }
 
-func MiddleName() string {
-	return gofakeit.MiddleName()
+func MiddleName(r *__dgi_Rand) string {
+	return r.faker.MiddleName()
 }
 
-func Gender() string {
-	return gofakeit.Gender()
+func Gender(r *__dgi_Rand) string {
+	return r.faker.Gender()
 }
 
-func SSN() string {
-	return gofakeit.SSN()
+func SSN(r *__dgi_Rand) string {
+	return r.faker.SSN()
 }
 
-func PhoneFormatted() string {
-	return gofakeit.PhoneFormatted()
+func PhoneFormatted(r *__dgi_Rand) string {
+	return r.faker.PhoneFormatted()
 }
 
-func Username() string {
-	return gofakeit.Username()
+func Username(r *__dgi_Rand) string {
+	return r.faker.Username()
 }
 
 
-func Password(opts PasswordOptions) string {
-	return gofakeit.Password(
+func Password(r *__dgi_Rand, opts PasswordOptions) string {
+	return r.faker.Password(
 		opts.Lower,
 		opts.Upper,
 		opts.Numeric,
@@ -185,465 +184,465 @@ func Password(opts PasswordOptions) string {
 	)
 }
 
-func AddressInfo() string {
-	return gofakeit.Address().Address
+func AddressInfo(r *__dgi_Rand) string {
+	return r.faker.Address().Address
 }
 
-func Street() string {
-	return gofakeit.Street()
+func Street(r *__dgi_Rand) string {
+	return r.faker.Street()
 }
 
-func StreetName() string {
-	return gofakeit.StreetName()
+func StreetName(r *__dgi_Rand) string {
+	return r.faker.StreetName()
 }
 
-func StreetNumber() string {
-	return gofakeit.StreetNumber()
+func StreetNumber(r *__dgi_Rand) string {
+	return r.faker.StreetNumber()
 }
 
-func StreetPrefix() string {
-	return gofakeit.StreetPrefix()
+func StreetPrefix(r *__dgi_Rand) string {
+	return r.faker.StreetPrefix()
 }
 
-func StreetSuffix() string {
-	return gofakeit.StreetSuffix()
+func StreetSuffix(r *__dgi_Rand) string {
+	return r.faker.StreetSuffix()
 }
 
-func City() string {
-	return gofakeit.City()
+func City(r *__dgi_Rand) string {
+	return r.faker.City()
 }
 
-func State() string {
-	return gofakeit.State()
+func State(r *__dgi_Rand) string {
+	return r.faker.State()
 }
 
-func StateAbr() string {
-	return gofakeit.StateAbr()
+func StateAbr(r *__dgi_Rand) string {
+	return r.faker.StateAbr()
 }
 
-func Zip() string {
-	return gofakeit.Zip()
+func Zip(r *__dgi_Rand) string {
+	return r.faker.Zip()
 }
 
-func Country() string {
-	return gofakeit.Country()
+func Country(r *__dgi_Rand) string {
+	return r.faker.Country()
 }
 
-func CountryAbr() string {
-	return gofakeit.CountryAbr()
+func CountryAbr(r *__dgi_Rand) string {
+	return r.faker.CountryAbr()
 }
 
-func Latitude() float64 {
-	return gofakeit.Latitude()
+func Latitude(r *__dgi_Rand) float64 {
+	return r.faker.Latitude()
 }
 
-func Longitude() float64 {
-	return gofakeit.Longitude()
+func Longitude(r *__dgi_Rand) float64 {
+	return r.faker.Longitude()
 }
 
-func Date() time.Time {
-	return gofakeit.Date()
+func Date(r *__dgi_Rand) time.Time {
+	return r.faker.Date()
 }
 
-func FutureDate() time.Time {
-	return gofakeit.FutureDate()
+func FutureDate(r *__dgi_Rand) time.Time {
+	return r.faker.FutureDate()
 }
 
-func PastDate() time.Time {
-	return gofakeit.PastDate()
+func PastDate(r *__dgi_Rand) time.Time {
+	return r.faker.PastDate()
 }
 
-func NanoSecond() int {
-	return gofakeit.NanoSecond()
+func NanoSecond(r *__dgi_Rand) int {
+	return r.faker.NanoSecond()
 }
 
-func Second() int {
-	return gofakeit.Second()
+func Second(r *__dgi_Rand) int {
+	return r.faker.Second()
 }
 
-func Minute() int {
-	return gofakeit.Minute()
+func Minute(r *__dgi_Rand) int {
+	return r.faker.Minute()
 }
 
-func Hour() int {
-	return gofakeit.Hour()
+func Hour(r *__dgi_Rand) int {
+	return r.faker.Hour()
 }
 
-func Month() int {
-	return gofakeit.Month()
+func Month(r *__dgi_Rand) int {
+	return r.faker.Month()
 }
 
-func MonthString() string {
-	return gofakeit.MonthString()
+func MonthString(r *__dgi_Rand) string {
+	return r.faker.MonthString()
 }
 
-func Day() int {
-	return gofakeit.Day()
+func Day(r *__dgi_Rand) int {
+	return r.faker.Day()
 }
 
-func WeekDay() string {
-	return gofakeit.WeekDay()
+func WeekDay(r *__dgi_Rand) string {
+	return r.faker.WeekDay()
 }
 
-func Year() int {
-	return gofakeit.Year()
+func Year(r *__dgi_Rand) int {
+	return r.faker.Year()
 }
 
-func TimeZone() string {
-	return gofakeit.TimeZone()
+func TimeZone(r *__dgi_Rand) string {
+	return r.faker.TimeZone()
 }
 
-func TimeZoneAbv() string {
-	return gofakeit.TimeZoneAbv()
+func TimeZoneAbv(r *__dgi_Rand) string {
+	return r.faker.TimeZoneAbv()
 }
 
-func TimeZoneFull() string {
-	return gofakeit.TimeZoneFull()
+func TimeZoneFull(r *__dgi_Rand) string {
+	return r.faker.TimeZoneFull()
 }
 
-func TimeZoneOffset() float32 {
-	return gofakeit.TimeZoneOffset()
+func TimeZoneOffset(r *__dgi_Rand) float32 {
+	return r.faker.TimeZoneOffset()
 }
 
-func TimeZoneRegion() string {
-	return gofakeit.TimeZoneRegion()
+func TimeZoneRegion(r *__dgi_Rand) string {
+	return r.faker.TimeZoneRegion()
 }
 
-func DomainName() string {
-	return gofakeit.DomainName()
+func DomainName(r *__dgi_Rand) string {
+	return r.faker.DomainName()
 }
 
-func DomainSuffix() string {
-	return gofakeit.DomainSuffix()
+func DomainSuffix(r *__dgi_Rand) string {
+	return r.faker.DomainSuffix()
 }
 
-func IPv4Address() string {
-	return gofakeit.IPv4Address()
+func IPv4Address(r *__dgi_Rand) string {
+	return r.faker.IPv4Address()
 }
 
-func IPv6Address() string {
-	return gofakeit.IPv6Address()
+func IPv6Address(r *__dgi_Rand) string {
+	return r.faker.IPv6Address()
 }
 
-func MacAddress() string {
-	return gofakeit.MacAddress()
+func MacAddress(r *__dgi_Rand) string {
+	return r.faker.MacAddress()
 }
 
-func HTTPMethod() string {
-	return gofakeit.HTTPMethod()
+func HTTPMethod(r *__dgi_Rand) string {
+	return r.faker.HTTPMethod()
 }
 
-func HTTPStatusCode() int {
-	return gofakeit.HTTPStatusCode()
+func HTTPStatusCode(r *__dgi_Rand) int {
+	return r.faker.HTTPStatusCode()
 }
 
-func UserAgent() string {
-	return gofakeit.UserAgent()
+func UserAgent(r *__dgi_Rand) string {
+	return r.faker.UserAgent()
 }
 
-func ChromeUserAgent() string {
-	return gofakeit.ChromeUserAgent()
+func ChromeUserAgent(r *__dgi_Rand) string {
+	return r.faker.ChromeUserAgent()
 }
 
-func FirefoxUserAgent() string {
-	return gofakeit.FirefoxUserAgent()
+func FirefoxUserAgent(r *__dgi_Rand) string {
+	return r.faker.FirefoxUserAgent()
 }
 
-func SafariUserAgent() string {
-	return gofakeit.SafariUserAgent()
+func SafariUserAgent(r *__dgi_Rand) string {
+	return r.faker.SafariUserAgent()
 }
 
-func OperaUserAgent() string {
-	return gofakeit.OperaUserAgent()
+func OperaUserAgent(r *__dgi_Rand) string {
+	return r.faker.OperaUserAgent()
 }
 
-func Int8() int8 {
-	return gofakeit.Int8()
+func Int8(r *__dgi_Rand) int8 {
+	return r.faker.Int8()
 }
 
-func Int16() int16 {
-	return gofakeit.Int16()
+func Int16(r *__dgi_Rand) int16 {
+	return r.faker.Int16()
 }
 
-func Int32() int32 {
-	return gofakeit.Int32()
+func Int32(r *__dgi_Rand) int32 {
+	return r.faker.Int32()
 }
 
-func Int64() int64 {
-	return gofakeit.Int64()
+func Int64(r *__dgi_Rand) int64 {
+	return r.faker.Int64()
 }
 
-func Uint8() uint8 {
-	return gofakeit.Uint8()
+func Uint8(r *__dgi_Rand) uint8 {
+	return r.faker.Uint8()
 }
 
-func Uint16() uint16 {
-	return gofakeit.Uint16()
+func Uint16(r *__dgi_Rand) uint16 {
+	return r.faker.Uint16()
 }
 
-func Uint32() uint32 {
-	return gofakeit.Uint32()
+func Uint32(r *__dgi_Rand) uint32 {
+	return r.faker.Uint32()
 }
 
-func Uint64() uint64 {
-	return gofakeit.Uint64()
+func Uint64(r *__dgi_Rand) uint64 {
+	return r.faker.Uint64()
 }
 
-func Float32() float32 {
-	return gofakeit.Float32()
+func Float32(r *__dgi_Rand) float32 {
+	return r.faker.Float32()
 }
 
-func Float64() float64 {
-	return gofakeit.Float64()
+func Float64(r *__dgi_Rand) float64 {
+	return r.faker.Float64()
 }
 
-func Color() string {
-	return gofakeit.Color()
+func Color(r *__dgi_Rand) string {
+	return r.faker.Color()
 }
 
-func SafeColor() string {
-	return gofakeit.SafeColor()
+func SafeColor(r *__dgi_Rand) string {
+	return r.faker.SafeColor()
 }
 
-func RGBColor() []int {
-	return gofakeit.RGBColor()
+func RGBColor(r *__dgi_Rand) []int {
+	return r.faker.RGBColor()
 }
 
-func FileExtension() string {
-	return gofakeit.FileExtension()
+func FileExtension(r *__dgi_Rand) string {
+	return r.faker.FileExtension()
 }
 
-func CreditCardNumber() string {
-	cc := gofakeit.CreditCard()
+func CreditCardNumber(r *__dgi_Rand) string {
+	cc := r.faker.CreditCard()
 	return cc.Number
 }
 
-func CreditCardType() string {
-	cc := gofakeit.CreditCard()
+func CreditCardType(r *__dgi_Rand) string {
+	cc := r.faker.CreditCard()
 	return cc.Type
 }
 
-func CreditCardExp() string {
-	cc := gofakeit.CreditCard()
+func CreditCardExp(r *__dgi_Rand) string {
+	cc := r.faker.CreditCard()
 	return cc.Exp
 }
 
-func CreditCardCvv() string {
-	cc := gofakeit.CreditCard()
+func CreditCardCvv(r *__dgi_Rand) string {
+	cc := r.faker.CreditCard()
 	return cc.Cvv
 }
 
-func Currency() string {
-	curr := gofakeit.Currency()
+func Currency(r *__dgi_Rand) string {
+	curr := r.faker.Currency()
 	return curr.Short
 }
 
-func CurrencyLong() string {
-	curr := gofakeit.Currency()
+func CurrencyLong(r *__dgi_Rand) string {
+	curr := r.faker.Currency()
 	return curr.Long
 }
 
-func JobTitle() string {
-	return gofakeit.JobTitle()
+func JobTitle(r *__dgi_Rand) string {
+	return r.faker.JobTitle()
 }
 
-func JobDescriptor() string {
-	return gofakeit.JobDescriptor()
+func JobDescriptor(r *__dgi_Rand) string {
+	return r.faker.JobDescriptor()
 }
 
-func JobLevel() string {
-	return gofakeit.JobLevel()
+func JobLevel(r *__dgi_Rand) string {
+	return r.faker.JobLevel()
 }
 
-func Emoji() string {
-	return gofakeit.Emoji()
+func Emoji(r *__dgi_Rand) string {
+	return r.faker.Emoji()
 }
 
-func Language() string {
-	return gofakeit.Language()
+func Language(r *__dgi_Rand) string {
+	return r.faker.Language()
 }
 
-func ProgrammingLanguage() string {
-	return gofakeit.ProgrammingLanguage()
+func ProgrammingLanguage(r *__dgi_Rand) string {
+	return r.faker.ProgrammingLanguage()
 }
 
-func CompanySuffix() string {
-	return gofakeit.CompanySuffix()
+func CompanySuffix(r *__dgi_Rand) string {
+	return r.faker.CompanySuffix()
 }
 
-func AddressFull() string {
-	addr := gofakeit.Address()
+func AddressFull(r *__dgi_Rand) string {
+	addr := r.faker.Address()
 	return addr.Street + ", " + addr.City + ", " + addr.State + " " + addr.Zip
 }
 
-func HTTPVersion() string {
-	return gofakeit.HTTPVersion()
+func HTTPVersion(r *__dgi_Rand) string {
+	return r.faker.HTTPVersion()
 }
 
-func LogLevel() string {
-	return gofakeit.LogLevel("general")
+func LogLevel(r *__dgi_Rand) string {
+	return r.faker.LogLevel("general")
 }
 
-func Digit() string {
-	return gofakeit.Digit()
+func Digit(r *__dgi_Rand) string {
+	return r.faker.Digit()
 }
 
-func DigitN(n uint) string {
-	return gofakeit.DigitN(n)
+func DigitN(r *__dgi_Rand, n uint) string {
+	return r.faker.DigitN(n)
 }
 
-func Letter() string {
-	return gofakeit.Letter()
+func Letter(r *__dgi_Rand) string {
+	return r.faker.Letter()
 }
 
-func Numerify(str string) string {
-	return gofakeit.Numerify(str)
+func Numerify(r *__dgi_Rand, str string) string {
+	return r.faker.Numerify(str)
 }
 
-func Word() string {
-	return gofakeit.LoremIpsumWord()
+func Word(r *__dgi_Rand) string {
+	return r.faker.LoremIpsumWord()
 }
 
-func Sentence(wordCount int) string {
-	return gofakeit.LoremIpsumSentence(wordCount)
+func Sentence(r *__dgi_Rand, wordCount int) string {
+	return r.faker.LoremIpsumSentence(wordCount)
 }
 
-func Paragraph(lp ParagraphOptions) string {
-	return gofakeit.LoremIpsumParagraph(lp.ParagraphCount, lp.SentenceCount, lp.WordCount, lp.Separator)
+func Paragraph(r *__dgi_Rand, lp ParagraphOptions) string {
+	return r.faker.LoremIpsumParagraph(lp.ParagraphCount, lp.SentenceCount, lp.WordCount, lp.Separator)
 }
 
-func Regex(regexStr string) string {
-	return gofakeit.Regex(regexStr)
+func Regex(r *__dgi_Rand, regexStr string) string {
+	return r.faker.Regex(regexStr)
 }
 
-func Map() map[string]interface{} {
-	return gofakeit.Map()
+func Map(r *__dgi_Rand) map[string]interface{} {
+	return r.faker.Map()
 }
 
-func Slice(v interface{}) {
-	gofakeit.Slice(v)
+func Slice(r *__dgi_Rand, v interface{}) {
+	r.faker.Slice(v)
 }
 
-func Struct(v interface{}) error {
-	return gofakeit.Struct(v)
+func Struct(r *__dgi_Rand, v interface{}) error {
+	return r.faker.Struct(v)
 }
 
-func ErrorObject() error {
-	return gofakeit.ErrorObject()
+func ErrorObject(r *__dgi_Rand) error {
+	return r.faker.ErrorObject()
 }
 
-func ErrorDatabase() error {
-	return gofakeit.ErrorDatabase()
+func ErrorDatabase(r *__dgi_Rand) error {
+	return r.faker.ErrorDatabase()
 }
 
-func ErrorGRPC() error {
-	return gofakeit.ErrorGRPC()
+func ErrorGRPC(r *__dgi_Rand) error {
+	return r.faker.ErrorGRPC()
 }
 
-func ErrorHTTP() error {
-	return gofakeit.ErrorHTTP()
+func ErrorHTTP(r *__dgi_Rand) error {
+	return r.faker.ErrorHTTP()
 }
 
-func ErrorRuntime() error {
-	return gofakeit.ErrorRuntime()
+func ErrorRuntime(r *__dgi_Rand) error {
+	return r.faker.ErrorRuntime()
 }
 
-func ErrorValidation() error {
-	return gofakeit.ErrorValidation()
+func ErrorValidation(r *__dgi_Rand) error {
+	return r.faker.ErrorValidation()
 }
 
-func Fruit() string {
-	return gofakeit.Fruit()
+func Fruit(r *__dgi_Rand) string {
+	return r.faker.Fruit()
 }
 
-func Vegetable() string {
-	return gofakeit.Vegetable()
+func Vegetable(r *__dgi_Rand) string {
+	return r.faker.Vegetable()
 }
 
-func ShuffleInts(a []int) {
-	gofakeit.ShuffleInts(a)
+func ShuffleInts(r *__dgi_Rand, a []int) {
+	r.faker.ShuffleInts(a)
 }
 
-func ShuffleStrings(a []string) {
-	gofakeit.ShuffleStrings(a)
+func ShuffleStrings(r *__dgi_Rand, a []string) {
+	r.faker.ShuffleStrings(a)
 }
 
-func FileMimeType() string {
-	return gofakeit.FileMimeType()
+func FileMimeType(r *__dgi_Rand) string {
+	return r.faker.FileMimeType()
 }
-func Image(width, height int) *image.RGBA {
-	return gofakeit.Image(width, height)
+func Image(r *__dgi_Rand, width, height int) *image.RGBA {
+	return r.faker.Image(width, height)
 }
-func ImageJpeg(width, height int) []byte {
-	return gofakeit.ImageJpeg(width, height)
+func ImageJpeg(r *__dgi_Rand, width, height int) []byte {
+	return r.faker.ImageJpeg(width, height)
 }
-func ImagePng(width, height int) []byte {
-	return gofakeit.ImagePng(width, height)
+func ImagePng(r *__dgi_Rand, width, height int) []byte {
+	return r.faker.ImagePng(width, height)
 }
 
-func InputName() string {
-	return gofakeit.InputName()
+func InputName(r *__dgi_Rand) string {
+	return r.faker.InputName()
 }
-func HTTPStatusCodeSimple() int {
-	return gofakeit.HTTPStatusCodeSimple()
+func HTTPStatusCodeSimple(r *__dgi_Rand) int {
+	return r.faker.HTTPStatusCodeSimple()
 }
 
-func Int() int {
-	return gofakeit.Int()
+func Int(r *__dgi_Rand) int {
+	return r.faker.Int()
 }
-func IntN(n int) int {
-	return gofakeit.IntN(n)
+func IntN(r *__dgi_Rand, n int) int {
+	return r.faker.IntN(n)
 }
-func Uint() uint {
-	return gofakeit.Uint()
+func Uint(r *__dgi_Rand) uint {
+	return r.faker.Uint()
 }
-func UintN(n uint) uint {
-	return gofakeit.UintN(n)
+func UintN(r *__dgi_Rand, n uint) uint {
+	return r.faker.UintN(n)
 }
-func UintRange(min, max uint) uint {
-	return gofakeit.UintRange(min, max)
+func UintRange(r *__dgi_Rand, min, max uint) uint {
+	return r.faker.UintRange(min, max)
 }
-func HexUint(bitSize int) string {
-	return gofakeit.HexUint(bitSize)
+func HexUint(r *__dgi_Rand, bitSize int) string {
+	return r.faker.HexUint(bitSize)
 }
-func Unit() string {
-	return gofakeit.Unit()
+func Unit(r *__dgi_Rand) string {
+	return r.faker.Unit()
 }
-func Weighted(options []any, weights []float32) (any, error) {
-	return gofakeit.Weighted(options, weights)
+func Weighted(r *__dgi_Rand, options []any, weights []float32) (any, error) {
+	return r.faker.Weighted(options, weights)
 }
-func ShuffleAnySlice(v any) {
-	gofakeit.ShuffleAnySlice(v)
+func ShuffleAnySlice(r *__dgi_Rand, v any) {
+	r.faker.ShuffleAnySlice(v)
 }
-func LatitudeInRange(min, max float64) (float64, error) {
-	return gofakeit.LatitudeInRange(min, max)
+func LatitudeInRange(r *__dgi_Rand, min, max float64) (float64, error) {
+	return r.faker.LatitudeInRange(min, max)
 }
-func LongitudeInRange(min, max float64) (float64, error) {
-	return gofakeit.LongitudeInRange(min, max)
+func LongitudeInRange(r *__dgi_Rand, min, max float64) (float64, error) {
+	return r.faker.LongitudeInRange(min, max)
 }
-func NiceColors() []string {
-	return gofakeit.NiceColors()
+func NiceColors(r *__dgi_Rand) []string {
+	return r.faker.NiceColors()
 }
-func LanguageBCP() string {
-	return gofakeit.LanguageBCP()
+func LanguageBCP(r *__dgi_Rand) string {
+	return r.faker.LanguageBCP()
 }
-func SvgString(opts SVGOpts) string {
+func SvgString(r *__dgi_Rand, opts SVGOpts) string {
 	goOpts := &gofakeit.SVGOptions{Width: opts.Width, Height: opts.Height, Type: opts.Type}
-	return gofakeit.Svg(goOpts)
+	return r.faker.Svg(goOpts)
 }
 
-func MarkdownSimple() (string, error) {
-	return gofakeit.Markdown(nil)
+func MarkdownSimple(r *__dgi_Rand) (string, error) {
+	return r.faker.Markdown(nil)
 }
 
-func Error() error {
-	return gofakeit.Error()
+func Error(r *__dgi_Rand) error {
+	return r.faker.Error()
 }
-func ErrorHTTPClient() error {
-	return gofakeit.ErrorHTTPClient()
+func ErrorHTTPClient(r *__dgi_Rand) error {
+	return r.faker.ErrorHTTPClient()
 }
-func ErrorHTTPServer() error {
-	return gofakeit.ErrorHTTPServer()
+func ErrorHTTPServer(r *__dgi_Rand) error {
+	return r.faker.ErrorHTTPServer()
 }
 
 var _ = json.Marshal
@@ -52,6 +52,9 @@ func __dgi_getModelsMetadata(datagen *__dgi_DataGenGenerators) map[string]__dgi_
 	if datagen.with_builtin_functions != nil {
 		out["with_builtin_functions"] = datagen.with_builtin_functions().Metadata()
 	}
+	if datagen.with_columns != nil {
+		out["with_columns"] = datagen.with_columns().Metadata()
+	}
 	if datagen.with_conditionals != nil {
 		out["with_conditionals"] = datagen.with_conditionals().Metadata()
 	}
@@ -390,65 +390,49 @@ type __datagen_with_builtin_functionsGenerator struct {
 }
 
 type __datagen_with_builtin_functionsDataHolder struct {
-	id           []int
-	random_int   []int
-	random_float []float64
+	id           *__dgi_Memo[int]
+	random_int   *__dgi_Memo[int]
+	random_float *__dgi_Memo[float64]
 }
 
 func (cg *__datagen_with_builtin_functionsGenerator) __gen_wrapper_random_float() func(iter int) float64 {
+	key := __dgi_streamKey("with_builtin_functions", "random_float")
+	gen := func(i int) float64 {
+		return cg.__gen_random_float(__dgi_fieldRand(key, i), i)
+	}
 	return func(iter int) float64 {
-		if iter < len(cg.all.random_float) {
-			return cg.all.random_float[iter]
-		}
-
-		for i := len(cg.all.random_float); i <= iter; i++ {
-			val := cg.__gen_random_float(i)
-			cg.all.random_float = append(cg.all.random_float, val)
-		}
-
-		return cg.all.random_float[iter]
+		return cg.all.random_float.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_builtin_functionsGenerator) __gen_random_float(iter int) float64 {
-	return FloatBetween(0.0, 100.0)
+func (self *__datagen_with_builtin_functionsGenerator) __gen_random_float(__dgi_r *__dgi_Rand, iter int) float64 {
+	return FloatBetween(__dgi_r, 0.0, 100.0)
 }
 
 func (cg *__datagen_with_builtin_functionsGenerator) __gen_wrapper_random_int() func(iter int) int {
+	key := __dgi_streamKey("with_builtin_functions", "random_int")
+	gen := func(i int) int {
+		return cg.__gen_random_int(__dgi_fieldRand(key, i), i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.random_int) {
-			return cg.all.random_int[iter]
-		}
-
-		for i := len(cg.all.random_int); i <= iter; i++ {
-			val := cg.__gen_random_int(i)
-			cg.all.random_int = append(cg.all.random_int, val)
-		}
-
-		return cg.all.random_int[iter]
+		return cg.all.random_int.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_builtin_functionsGenerator) __gen_random_int(iter int) int {
-	return IntBetween(1, 1000)
+func (self *__datagen_with_builtin_functionsGenerator) __gen_random_int(__dgi_r *__dgi_Rand, iter int) int {
+	return IntBetween(__dgi_r, 1, 1000)
 }
 
 func (cg *__datagen_with_builtin_functionsGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_builtin_functionsGenerator) __gen_id(iter int) int {
+func (self *__datagen_with_builtin_functionsGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -460,8 +444,12 @@ func (cg *__datagen_with_builtin_functionsGenerator) Gen(iter int) __dgi_Record
 	}
 }
 
-func __init___datagen_with_builtin_functionsGenerator() *__datagen_with_builtin_functionsGenerator {
-	all := &__datagen_with_builtin_functionsDataHolder{}
+func __init___datagen_with_builtin_functionsGenerator(memoWindow int) *__datagen_with_builtin_functionsGenerator {
+	all := &__datagen_with_builtin_functionsDataHolder{
+		id:           __dgi_NewMemo[int]("with_builtin_functions", "id", __dgi_memoCurrentRow, false),
+		random_int:   __dgi_NewMemo[int]("with_builtin_functions", "random_int", __dgi_memoCurrentRow, false),
+		random_float: __dgi_NewMemo[float64]("with_builtin_functions", "random_float", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_with_builtin_functionsGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.random_int = cg.__gen_wrapper_random_int()
@@ -469,11 +457,11 @@ func __init___datagen_with_builtin_functionsGenerator() *__datagen_with_builtin_
 	return cg
 }
 
-func (e *__datagen_with_builtin_functions) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.random_int),
-		fmt.Sprintf("%v", e.random_float),
+func (e *__datagen_with_builtin_functions) ToCSV() []any {
+	return []any{
+		e.id,
+		e.random_int,
+		e.random_float,
 	}
 }
 
@@ -486,10 +474,10 @@ func (e *__datagen_with_builtin_functions) CSVHeaders() []string {
 }
 
 func (e *__datagen_with_builtin_functions) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":           e.id,
-		"random_int":   e.random_int,
-		"random_float": e.random_float,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "random_int", Value: e.random_int},
+		{Key: "random_float", Value: e.random_float},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -498,24 +486,75 @@ func (e *__datagen_with_builtin_functions) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_with_builtin_functions) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName          xml.Name `xml:"with_builtin_functions"`
-		Xml_id           int      `xml:"id"`
-		Xml_random_int   int      `xml:"random_int"`
-		Xml_random_float float64  `xml:"random_float"`
+func (e *__datagen_with_builtin_functions) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_with_builtin_functions) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("with_builtin_functions", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "random_int", Attr: false, Value: e.random_int},
+		{Name: "random_float", Attr: false, Value: e.random_float},
+	})
+}
+
+func (e *__datagen_with_builtin_functions) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id           int     `parquet:"id"`
+		Parquet_random_int   int     `parquet:"random_int"`
+		Parquet_random_float float64 `parquet:"random_float"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:           e.id,
-		Xml_random_int:   e.random_int,
-		Xml_random_float: e.random_float,
+	return &__dgi_parquetRow{
+		Parquet_id:           e.id,
+		Parquet_random_int:   e.random_int,
+		Parquet_random_float: e.random_float,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_with_builtin_functions) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.random_int, e.random_float)
+}
+
+func (e *__datagen_with_builtin_functions) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"with_builtin_functions\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"random_int\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"random_float\",\n      \"type\": \"double\"\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_with_builtin_functions) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.random_int, e.random_float)
+}
+
+func (e *__datagen_with_builtin_functions) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage with_builtin_functions {\n  int64 id = 1;\n  int64 random_int = 2;\n  double random_float = 3;\n}\n", nil
+}
+
+func (e *__datagen_with_builtin_functions) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `with_builtin_functions` (`id`, `random_int`, `random_float`) VALUES",
+			Truncate:         "DELETE FROM `with_builtin_functions`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `with_builtin_functions` (\n  `id` BIGINT NOT NULL,\n  `random_int` BIGINT NOT NULL,\n  `random_float` DOUBLE NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_builtin_functions\" (\"id\", \"random_int\", \"random_float\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"with_builtin_functions\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_builtin_functions\" (\n  \"id\" BIGINT NOT NULL,\n  \"random_int\" BIGINT NOT NULL,\n  \"random_float\" DOUBLE PRECISION NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_builtin_functions\" (\"id\", \"random_int\", \"random_float\") VALUES",
+			Truncate:         "DELETE FROM \"with_builtin_functions\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_builtin_functions\" (\n  \"id\" INTEGER NOT NULL,\n  \"random_int\" INTEGER NOT NULL,\n  \"random_float\" REAL NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_with_builtin_functions) SQLValues() []any {
+	return []any{e.id, e.random_int, e.random_float}
 }
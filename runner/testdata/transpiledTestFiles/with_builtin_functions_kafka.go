@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Key___datagen_with_builtin_functions_kafka serializes the configured key field of a record.
+func Key___datagen_with_builtin_functions_kafka(record *__datagen_with_builtin_functions, config *__dgi_KafkaConfig) ([]byte, error) {
+	if config.Key == "" {
+		return nil, nil
+	}
+
+	var value interface{}
+	switch config.Key {
+	case "id":
+		value = record.id
+	case "random_int":
+		value = record.random_int
+	case "random_float":
+		value = record.random_float
+	default:
+		return nil, fmt.Errorf("key field %q does not exist in model with_builtin_functions", config.Key)
+	}
+
+	if config.KeySerializer == __dgi_KafkaSerializerJSON {
+		return json.Marshal(value)
+	}
+	return []byte(fmt.Sprintf("%v", value)), nil
+}
+
+// Load___datagen_with_builtin_functions_kafka produces a single batch of records using the provided client.
+func Load___datagen_with_builtin_functions_kafka(records []*__datagen_with_builtin_functions, client *kgo.Client, config *__dgi_KafkaConfig) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	messages := make([]*kgo.Record, 0, len(records))
+	for _, record := range records {
+		key, err := Key___datagen_with_builtin_functions_kafka(record, config)
+		if err != nil {
+			return fmt.Errorf("serializing key failed with error : %w", err)
+		}
+		messages = append(messages, &kgo.Record{
+			Topic: config.Topic,
+			Key:   key,
+			Value: []byte(record.ToJSON()),
+		})
+	}
+
+	if err := client.ProduceSync(ctx, messages...).FirstErr(); err != nil {
+		return fmt.Errorf("produce failed with error : %w", err)
+	}
+
+	return nil
+}
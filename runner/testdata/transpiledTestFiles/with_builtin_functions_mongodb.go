@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Load___datagen_with_builtin_functions_mongodb inserts a single batch of records into the collection, in order unless ordered is false.
+func Load___datagen_with_builtin_functions_mongodb(records []*__datagen_with_builtin_functions, collection *mongo.Collection, ordered bool) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	if _, err := collection.InsertMany(ctx, Documents___datagen_with_builtin_functions_mongodb(records), options.InsertMany().SetOrdered(ordered)); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+	return nil
+}
+
+// Documents___datagen_with_builtin_functions_mongodb returns records as documents keyed by column, leaving maps, slices and structs
+// to be encoded as embedded documents and arrays.
+func Documents___datagen_with_builtin_functions_mongodb(records []*__datagen_with_builtin_functions) []interface{} {
+	documents := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		documents = append(documents, bson.D{
+			{Key: "id", Value: record.id},
+			{Key: "random_int", Value: record.random_int},
+			{Key: "random_float", Value: record.random_float},
+		})
+	}
+	return documents
+}
+
+// Collection___datagen_with_builtin_functions_mongodb returns the collection of the model in the configured database, as mapped in config.
+func Collection___datagen_with_builtin_functions_mongodb(client *mongo.Client, modelName string, config *__dgi_MongoDBConfig) *mongo.Collection {
+	return client.Database(config.Database).Collection(config.collection(modelName, "with_builtin_functions"))
+}
+
+// Truncate___datagen_with_builtin_functions_mongodb empties the collection of the model, deleting its documents or dropping it
+// as the clear mode of the config says.
+func Truncate___datagen_with_builtin_functions_mongodb(collection *mongo.Collection, config *__dgi_MongoDBConfig) error {
+	ctx := context.Background()
+	if config.ClearMode == __dgi_MongoDBClearDrop {
+		if err := collection.Drop(ctx); err != nil {
+			return fmt.Errorf("drop failed with error : %w", err)
+		}
+		return nil
+	}
+	if _, err := collection.DeleteMany(ctx, bson.D{}); err != nil {
+		return fmt.Errorf("delete failed with error : %w", err)
+	}
+	return nil
+}
@@ -8,8 +8,8 @@ import (
 	"strings"
 )
 
-// Load___datagen_with_builtin_functions_postgres executes a single batch of records using the provided transaction.
-func Load___datagen_with_builtin_functions_postgres(records []*__datagen_with_builtin_functions, tx *sql.Tx) error {
+// Load___datagen_with_builtin_functions_postgres executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_with_builtin_functions_postgres(records []*__datagen_with_builtin_functions, tx *sql.Tx, stmt __dgi_writeStatement) error {
 	if len(records) == 0 {
 		slog.Warn(fmt.Sprintf("no records to insert for model %s", "with_builtin_functions"))
 		return nil
@@ -18,14 +18,7 @@ func Load___datagen_with_builtin_functions_postgres(records []*__datagen_with_bu
 	ctx := context.Background()
 
 	var b strings.Builder
-	columns := []string{
-		"\"id\"",
-		"\"random_int\"",
-		"\"random_float\"",
-	}
-	b.WriteString("INSERT INTO \"with_builtin_functions\" (")
-	b.WriteString(strings.Join(columns, ","))
-	b.WriteString(") VALUES ")
+	b.WriteString(stmt.prefix)
 
 	// Build placeholders for Postgres ($1, $2, ... format)
 	placeholderCount := 0
@@ -43,14 +36,15 @@ func Load___datagen_with_builtin_functions_postgres(records []*__datagen_with_bu
 		}
 		b.WriteString(")")
 	}
+	b.WriteString(stmt.suffix)
 	sqlStmt := b.String()
 
 	var args []interface{}
-	for _, record := range records {
-		args = append(args, record.id)
-		args = append(args, record.random_int)
-		args = append(args, record.random_float)
-
+	for _, row := range Rows___datagen_with_builtin_functions_postgres(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
 	}
 
 	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
@@ -60,11 +54,69 @@ func Load___datagen_with_builtin_functions_postgres(records []*__datagen_with_bu
 	return nil
 }
 
-// Truncate___datagen_with_builtin_functions_postgres() truncates the model's table using the shared connection.
+// Copy___datagen_with_builtin_functions_postgres loads a single batch of records with COPY, using the provided transaction.
+func Copy___datagen_with_builtin_functions_postgres(records []*__datagen_with_builtin_functions, tx *sql.Tx) error {
+	if len(records) == 0 {
+		return nil
+	}
+	columns := []string{
+		"\"id\"",
+		"\"random_int\"",
+		"\"random_float\"",
+	}
+	if err := __dgi_postgresCopy(context.Background(), tx, "\"with_builtin_functions\"", columns, Rows___datagen_with_builtin_functions_postgres(records)); err != nil {
+		return fmt.Errorf("copy failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_with_builtin_functions_postgres returns the values of the columns of records, in order.
+func Rows___datagen_with_builtin_functions_postgres(records []*__datagen_with_builtin_functions) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.random_int,
+			record.random_float,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_with_builtin_functions_postgres returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_with_builtin_functions_postgres(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+		"random_int",
+		"random_float",
+	}
+	columns := []string{
+		"\"id\"",
+		"\"random_int\"",
+		"\"random_float\"",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectPostgres, "\"with_builtin_functions\"", names, columns, keys, mode)
+}
+
+// Truncate___datagen_with_builtin_functions_postgres() empties the model's table using the shared connection. Rows are deleted
+// rather than truncated with CASCADE, so that tables referencing it outside the run are never emptied.
 func Truncate___datagen_with_builtin_functions_postgres(tx *sql.Tx) error {
 	ctx := context.Background()
-	if _, err := tx.ExecContext(ctx, "TRUNCATE TABLE \"with_builtin_functions\" RESTART IDENTITY CASCADE;"); err != nil {
-		return fmt.Errorf("truncate failed with error : %w", err)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM \"with_builtin_functions\";"); err != nil {
+		return fmt.Errorf("delete failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_with_builtin_functions_postgres_table creates the model's table unless it already exists.
+func Create___datagen_with_builtin_functions_postgres_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS \"with_builtin_functions\" (\n  \"id\" BIGINT NOT NULL,\n  \"random_int\" BIGINT NOT NULL,\n  \"random_float\" DOUBLE PRECISION NOT NULL\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// __datagen_with_builtin_functions_cassandraSink streams __datagen_with_builtin_functions data into Cassandra with prepared statements or unlogged batches
+type __datagen_with_builtin_functions_cassandraSink struct {
+	modelName     string
+	config        *__dgi_CassandraConfig
+	table         *__dgi_cassandraTable
+	session       *gocql.Session
+	total         int
+	totalInserted int
+}
+
+// Open_cassandra___datagen_with_builtin_functions_sink connects to Cassandra for __datagen_with_builtin_functions data to be written to its table,
+// keyed on keys
+func Open_cassandra___datagen_with_builtin_functions_sink(modelName string, total int, config *__dgi_CassandraConfig, keys []string) (*__datagen_with_builtin_functions_cassandraSink, error) {
+	table, err := Table___datagen_with_builtin_functions_cassandra(modelName, config, keys)
+	if err == nil && config.UnloggedBatches {
+		// batches are grouped by partition key
+		_, err = table.partitionKey()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("✘ [Cassandra] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("initializing Cassandra session for %s with %d records", modelName, total))
+	session, err := Open___datagen_with_builtin_functions_cassandra_session(config)
+	if err != nil {
+		return nil, fmt.Errorf("✘ [Cassandra] %s: FAILED\n   └─ Rows inserted: 0/%d\n   └─ Error: %v\n",
+			modelName, total, err)
+	}
+
+	slog.Debug(fmt.Sprintf("writing %s into Cassandra table %s with batch size %d", modelName, table.name, config.batchSize()))
+	return &__datagen_with_builtin_functions_cassandraSink{modelName: modelName, config: config, table: table, session: session, total: total}, nil
+}
+
+// Load writes a chunk of __datagen_with_builtin_functions records in batches of config.batchSize(),
+// each batch split into one unlogged batch per partition when config.UnloggedBatches is set
+func (s *__datagen_with_builtin_functions_cassandraSink) Load(chunk []__dgi_Record) error {
+	records := make([]*__datagen_with_builtin_functions, 0, len(chunk))
+	for _, r := range chunk {
+		records = append(records, r.(*__datagen_with_builtin_functions))
+	}
+
+	batchSize := s.config.batchSize()
+
+	for i := 0; i < len(records); i += batchSize {
+		end := i + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[i:end]
+
+		slog.Debug(fmt.Sprintf("loading batch starting at %d of size %d for %s into Cassandra", s.totalInserted, len(batch), s.modelName))
+		if err := Load___datagen_with_builtin_functions_cassandra(batch, s.session, s.config, s.table); err != nil {
+			return fmt.Errorf("✘ [Cassandra] %s: FAILED\n   └─ Rows inserted: %d/%d\n   └─ Error: %v\n",
+				s.modelName, s.totalInserted, s.total, err)
+		}
+
+		s.totalInserted += len(batch)
+
+		if s.config.Throttle != "" && s.totalInserted < s.total {
+			if throttleDuration, err := time.ParseDuration(s.config.Throttle); err == nil {
+				slog.Debug(fmt.Sprintf("throttling %s between batches for %s", throttleDuration, s.modelName))
+				time.Sleep(throttleDuration)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit closes the Cassandra session; every batch has already been written
+func (s *__datagen_with_builtin_functions_cassandraSink) Commit() error {
+	s.session.Close()
+	slog.Info(fmt.Sprintf("successfully loaded %d/%d rows for %s into Cassandra", s.totalInserted, s.total, s.modelName))
+	return nil
+}
+
+// Abort closes the Cassandra session; Cassandra has no transactions, so batches already written stay
+func (s *__datagen_with_builtin_functions_cassandraSink) Abort() {
+	s.session.Close()
+}
+
+// Clear_cassandra___datagen_with_builtin_functions_data clears __datagen_with_builtin_functions data from Cassandra
+func Clear_cassandra___datagen_with_builtin_functions_data(modelName string, config *__dgi_CassandraConfig) error {
+	table, err := Table___datagen_with_builtin_functions_cassandra(modelName, config, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get table for model %s: %w", modelName, err)
+	}
+
+	slog.Debug(fmt.Sprintf("initializing Cassandra session for clearing data for %s", modelName))
+	if err := Init___datagen_with_builtin_functions_cassandra_session(config); err != nil {
+		return fmt.Errorf("Cassandra connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_with_builtin_functions_cassandra_session()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to close Cassandra session: %s", err.Error()))
+		}
+	}()
+
+	session, err := Get___datagen_with_builtin_functions_cassandra_session()
+	if err != nil {
+		return fmt.Errorf("failed to get Cassandra session: %w", err)
+	}
+
+	if err := Truncate___datagen_with_builtin_functions_cassandra(session, table); err != nil {
+		return fmt.Errorf("failed to truncate table for model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("successfully cleared data for %s from Cassandra", modelName))
+	return nil
+}
+
+// Create_cassandra___datagen_with_builtin_functions_table creates the table __datagen_with_builtin_functions data is written to in Cassandra, keyed on keys,
+// unless it already exists
+func Create_cassandra___datagen_with_builtin_functions_table(modelName string, config *__dgi_CassandraConfig, keys []string) error {
+	table, err := Table___datagen_with_builtin_functions_cassandra(modelName, config, keys)
+	if err != nil {
+		return fmt.Errorf("failed to get table for model %s: %w", modelName, err)
+	}
+
+	slog.Debug(fmt.Sprintf("initializing Cassandra session for creating the table of %s", modelName))
+	if err := Init___datagen_with_builtin_functions_cassandra_session(config); err != nil {
+		return fmt.Errorf("Cassandra connection failed: %w", err)
+	}
+
+	defer func() {
+		err := Close___datagen_with_builtin_functions_cassandra_session()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to close Cassandra session: %s", err.Error()))
+		}
+	}()
+
+	session, err := Get___datagen_with_builtin_functions_cassandra_session()
+	if err != nil {
+		return fmt.Errorf("failed to get Cassandra session: %w", err)
+	}
+
+	if err := Create___datagen_with_builtin_functions_cassandra_table(session, table); err != nil {
+		return fmt.Errorf("failed to create table for model %s: %w", modelName, err)
+	}
+
+	slog.Info(fmt.Sprintf("table for %s is ready in Cassandra", modelName))
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Load___datagen_with_builtin_functions_sqlite executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_with_builtin_functions_sqlite(records []*__datagen_with_builtin_functions, tx *sql.Tx, stmt __dgi_writeStatement) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	var b strings.Builder
+	b.WriteString(stmt.prefix)
+
+	placeholderGroup := "(" + strings.Repeat("?,", 3)
+	placeholderGroup = placeholderGroup[:len(placeholderGroup)-1] + ")"
+	for i := range records {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(placeholderGroup)
+	}
+	b.WriteString(stmt.suffix)
+	sqlStmt := b.String()
+
+	var args []interface{}
+	for _, row := range Rows___datagen_with_builtin_functions_sqlite(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+
+	return nil
+}
+
+// Rows___datagen_with_builtin_functions_sqlite returns the values of the columns of records, in order.
+func Rows___datagen_with_builtin_functions_sqlite(records []*__datagen_with_builtin_functions) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.random_int,
+			record.random_float,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_with_builtin_functions_sqlite returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_with_builtin_functions_sqlite(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+		"random_int",
+		"random_float",
+	}
+	columns := []string{
+		"\"id\"",
+		"\"random_int\"",
+		"\"random_float\"",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectSQLite, "\"with_builtin_functions\"", names, columns, keys, mode)
+}
+
+// Truncate___datagen_with_builtin_functions_sqlite() empties the model's table using the shared connection.
+func Truncate___datagen_with_builtin_functions_sqlite(tx *sql.Tx) error {
+	ctx := context.Background()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM \"with_builtin_functions\";"); err != nil {
+		return fmt.Errorf("delete failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_with_builtin_functions_sqlite_table creates the model's table unless it already exists.
+func Create___datagen_with_builtin_functions_sqlite_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS \"with_builtin_functions\" (\n  \"id\" INTEGER NOT NULL,\n  \"random_int\" INTEGER NOT NULL,\n  \"random_float\" REAL NOT NULL\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,552 @@
+package main
+
+import (
+	// archive
+	"archive/tar"
+	"archive/zip"
+
+	// buf / bytes
+	"bufio"
+	"bytes"
+
+	// compress
+	"compress/bzip2"
+	"compress/flate"
+	"compress/gzip"
+	"compress/lzw"
+	"compress/zlib"
+
+	// container
+	"container/heap"
+	"container/list"
+	"container/ring"
+
+	// context
+	"context"
+
+	// crypto (selected; many more below)
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/md5"
+	crand "crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+
+	// database
+	"database/sql"
+	"database/sql/driver"
+
+	// embed (package name is embed; blank ref below)
+	_ "embed"
+
+	// encoding
+	"encoding"
+	"encoding/ascii85"
+	"encoding/asn1"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+
+	// errors & expvar
+	"errors"
+	"expvar"
+
+	// flag, fmt
+	"flag"
+	"fmt"
+
+	// hash
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/crc64"
+	"hash/fnv"
+
+	// html
+	"html"
+	htmltmpl "html/template"
+
+	// image
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+
+	// index
+	"index/suffixarray"
+
+	// io
+	"io"
+	"io/fs"
+	"io/ioutil"
+
+	// log
+	"log"
+	"log/slog"
+
+	"cmp"
+
+	// math
+	"math"
+	"math/big"
+	"math/bits"
+	"math/cmplx"
+	mrand "math/rand"
+
+	// mime
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+
+	// net
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/http/cookiejar"
+	"net/http/fcgi"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/http/httputil"
+	"net/mail"
+	"net/netip"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+
+	// os
+	"os"
+	"os/exec"
+	"os/signal"
+	"os/user"
+
+	// path
+	"path"
+	"path/filepath"
+
+	// reflect/regexp
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+
+	// sort/strconv/strings
+	"sort"
+	"strconv"
+	"strings"
+
+	// sync
+	"sync"
+	"sync/atomic"
+
+	// syscall (portable API only here)
+	"syscall"
+
+	// text
+	textscanner "text/scanner"
+	"text/tabwriter"
+	texttmpl "text/template"
+	"text/template/parse"
+
+	// time
+	"time"
+
+	// unicode
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	// unsafe
+	"unsafe"
+)
+
+var (
+	// archive
+	_ = tar.Header{}
+	_ = zip.File{}
+
+	// buf / bytes
+	_ = bufio.Reader{}
+	_ = bytes.Buffer{}
+
+	// compress
+	_ = bzip2.NewReader
+	_ = flate.NewReader
+	_ = gzip.Writer{}
+	_ = lzw.NewReader
+	_ = zlib.NewReader
+
+	// container
+	_ = heap.Init
+	_ = list.List{}
+	_ = ring.Ring{}
+
+	// context
+	_ = context.Background
+
+	// crypto
+	_ crypto.Hash
+	_ = crand.Reader
+	_ = aes.BlockSize
+	_ = cipher.NewGCM
+	_ = des.BlockSize
+	_ = dsa.Parameters{}
+	_ = ecdsa.PublicKey{}
+	_ = ed25519.PrivateKey{}
+	_ = elliptic.P256
+	_ = hmac.New
+	_ = md5.New
+	_ = rsa.GenerateKey
+	_ = sha1.New
+	_ = sha256.New
+	_ = sha512.New
+	_ = subtle.ConstantTimeCompare
+	_ = tls.VersionTLS13
+	_ = x509.Certificate{}
+	_ = pkix.Name{}
+
+	// database
+	_               = sql.ErrNoRows
+	_ driver.Valuer = nil
+
+	// embed
+	// (embed has no exported identifiers intended for direct use; the blank import above makes the compiler link it
+	// but it has no side effects. Keeping no var-ref here is fine.)
+
+	// encoding
+	_ = encoding.BinaryMarshaler(nil)
+	_ = ascii85.Encode
+	_ = asn1.Marshal
+	_ = base32.StdEncoding
+	_ = base64.StdEncoding
+	_ = binary.BigEndian
+	_ = csv.Reader{}
+	_ = gob.NewEncoder
+	_ = hex.EncodeToString
+	_ = json.Marshal
+	_ = pem.Encode
+	_ = xml.Marshal
+
+	// errors & expvar
+	_ = errors.New
+	_ = expvar.NewInt
+
+	// flag, fmt
+	_ = flag.String
+	_ = fmt.Println
+
+	// hash
+	_ = hash.Hash(nil)
+	_ = adler32.New
+	_ = crc32.New
+	_ = crc64.New
+	_ = fnv.New32
+
+	// html
+	_ = html.EscapeString
+	_ = htmltmpl.Template{}
+
+	// image
+	_ = image.NewRGBA
+	_ = color.RGBA{}
+	_ = palette.Plan9
+	_ = draw.Draw
+	_ = gif.Decode
+	_ = jpeg.Encode
+	_ = png.Decode
+
+	// index
+	_ = suffixarray.New
+
+	// io
+	_       = io.Copy
+	_ fs.FS = nil
+	_       = ioutil.ReadFile
+
+	// log
+	_ = log.Println
+	_ = slog.Any // Go 1.21 structured logging
+
+	// maps/slices/cmp
+	_ = cmp.Compare[int]
+
+	// math
+	_ = math.Pi
+	_ = big.Int{}
+	_ = bits.LeadingZeros
+	_ = cmplx.Abs
+	_ = mrand.Int
+
+	// mime
+	_ = mime.TypeByExtension
+	_ = multipart.Writer{}
+	_ = quotedprintable.NewReader
+
+	// net
+	_ = net.Dial
+	_ = http.ListenAndServe
+	_ = cgi.Handler{}
+	_ = cookiejar.New
+	_ = fcgi.Serve
+	_ = httptest.NewServer
+	_ = httptrace.WithClientTrace
+	_ = httputil.DumpRequest
+	_ = mail.ReadMessage
+	_ = netip.Addr{}
+	_ = rpc.NewServer
+	_ = jsonrpc.NewServerCodec
+	_ = smtp.SendMail
+	_ = textproto.NewReader
+	_ = url.Parse
+
+	// os
+	_ = os.Open
+	_ = exec.Command
+	_ = signal.Notify
+	_ = user.Current
+
+	// path
+	_ = path.Join
+	_ = filepath.Abs
+
+	// reflect/regexp
+	_ = reflect.TypeOf
+	_ = regexp.MustCompile
+	_ = syntax.Op(0)
+
+	// sort/strconv/strings
+	_ = sort.Sort
+	_ = strconv.Itoa
+	_ = strings.Split
+
+	// sync
+	_ = sync.Mutex{}
+	_ = atomic.AddInt32
+
+	// syscall
+	_ = syscall.Getpid
+
+	// text
+	_ = textscanner.Scanner{}
+	_ = tabwriter.NewWriter
+	_ = texttmpl.Must
+	_ = parse.Tree{}
+
+	// time
+	_ = time.Now
+
+	// unicode
+	_ = unicode.IsLetter
+	_ = utf16.Encode
+	_ = utf8.RuneCountInString
+
+	// unsafe
+	_ = unsafe.Sizeof(0)
+)
+
+var with_columnsMetadata __dgi_Metadata = __dgi_Metadata{
+	Count: 10,
+	Tags:  map[string]string{},
+}
+
+func (cg *__datagen_with_columnsGenerator) Metadata() __dgi_Metadata {
+	return with_columnsMetadata
+}
+
+type __datagen_with_columns struct {
+	id     int
+	domain string
+	email  string
+}
+
+type __datagen_with_columnsGenerator struct {
+	id      func(iter int) int
+	domain  func(iter int) string
+	email   func(iter int) string
+	all     *__datagen_with_columnsDataHolder
+	datagen *__dgi_DataGenGenerators
+}
+
+type __datagen_with_columnsDataHolder struct {
+	id     *__dgi_Memo[int]
+	domain *__dgi_Memo[string]
+	email  *__dgi_Memo[string]
+}
+
+func (cg *__datagen_with_columnsGenerator) __gen_wrapper_email() func(iter int) string {
+	gen := func(i int) string {
+		return cg.__gen_email(nil, i)
+	}
+	return func(iter int) string {
+		return cg.all.email.Get(iter, gen)
+	}
+}
+
+func (self *__datagen_with_columnsGenerator) __gen_email(__dgi_r *__dgi_Rand, iter int) string {
+	return fmt.Sprintf("user_%d@%s", iter, self.domain(iter))
+}
+
+func (cg *__datagen_with_columnsGenerator) __gen_wrapper_domain() func(iter int) string {
+	gen := func(i int) string {
+		return cg.__gen_domain(nil, i)
+	}
+	return func(iter int) string {
+		return cg.all.domain.Get(iter, gen)
+	}
+}
+
+func (self *__datagen_with_columnsGenerator) __gen_domain(__dgi_r *__dgi_Rand, iter int) string {
+	return "example.com"
+}
+
+func (cg *__datagen_with_columnsGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
+	return func(iter int) int {
+		return cg.all.id.Get(iter, gen)
+	}
+}
+
+func (self *__datagen_with_columnsGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
+	return iter
+}
+
+func (cg *__datagen_with_columnsGenerator) Gen(iter int) __dgi_Record {
+	return &__datagen_with_columns{
+		id:     cg.id(iter),
+		domain: cg.domain(iter),
+		email:  cg.email(iter),
+	}
+}
+
+func __init___datagen_with_columnsGenerator(memoWindow int) *__datagen_with_columnsGenerator {
+	all := &__datagen_with_columnsDataHolder{
+		id:     __dgi_NewMemo[int]("with_columns", "id", __dgi_memoCurrentRow, false),
+		domain: __dgi_NewMemo[string]("with_columns", "domain", __dgi_memoCurrentRow, false),
+		email:  __dgi_NewMemo[string]("with_columns", "email", __dgi_memoCurrentRow, false),
+	}
+	cg := &__datagen_with_columnsGenerator{all: all}
+	cg.id = cg.__gen_wrapper_id()
+	cg.domain = cg.__gen_wrapper_domain()
+	cg.email = cg.__gen_wrapper_email()
+	return cg
+}
+
+func (e *__datagen_with_columns) ToCSV() []any {
+	return []any{
+		e.id,
+		e.email,
+	}
+}
+
+func (e *__datagen_with_columns) CSVHeaders() []string {
+	return []string{
+		"id",
+		"E-Mail Address",
+	}
+}
+
+func (e *__datagen_with_columns) ToJSON() string {
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "E-Mail Address", Value: e.email},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return ""
+	}
+	return string(data)
+}
+
+func (e *__datagen_with_columns) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_with_columns) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("with_columns", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "email", Attr: false, Value: e.email},
+	})
+}
+
+func (e *__datagen_with_columns) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id    int    `parquet:"id"`
+		Parquet_email string `parquet:"E-Mail Address"`
+	}
+
+	return &__dgi_parquetRow{
+		Parquet_id:    e.id,
+		Parquet_email: e.email,
+	}
+}
+
+func (e *__datagen_with_columns) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.email)
+}
+
+func (e *__datagen_with_columns) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"with_columns\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"E_Mail_Address\",\n      \"type\": \"string\"\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_with_columns) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.email)
+}
+
+func (e *__datagen_with_columns) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage with_columns {\n  int64 id = 1;\n  string E_Mail_Address = 2;\n}\n", nil
+}
+
+func (e *__datagen_with_columns) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `billing`.`user_accounts` (`id`, `E-Mail Address`) VALUES",
+			Truncate:         "DELETE FROM `billing`.`user_accounts`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `billing`.`user_accounts` (\n  `id` BIGINT NOT NULL,\n  `E-Mail Address` TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"billing\".\"user_accounts\" (\"id\", \"E-Mail Address\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"billing\".\"user_accounts\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"billing\".\"user_accounts\" (\n  \"id\" BIGINT NOT NULL,\n  \"E-Mail Address\" TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"billing\".\"user_accounts\" (\"id\", \"E-Mail Address\") VALUES",
+			Truncate:         "DELETE FROM \"billing\".\"user_accounts\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"billing\".\"user_accounts\" (\n  \"id\" INTEGER NOT NULL,\n  \"E-Mail Address\" TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	}
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_with_columns) SQLValues() []any {
+	return []any{e.id, e.email}
+}
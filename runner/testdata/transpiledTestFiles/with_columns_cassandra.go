@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+)
+
+// Table___datagen_with_columns_cassandra returns the table the records of the model are written to, as mapped in config,
+// keyed on keys, or on the primary key of the table when there are none.
+func Table___datagen_with_columns_cassandra(modelName string, config *__dgi_CassandraConfig, keys []string) (*__dgi_cassandraTable, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	columns := []__dgi_cassandraColumn{
+		{name: "id", typ: "bigint"},
+		{name: "E-Mail Address", typ: "text"},
+	}
+	return &__dgi_cassandraTable{name: config.table(modelName, "\"billing\".\"user_accounts\""), columns: columns, keys: keys}, nil
+}
+
+// Load___datagen_with_columns_cassandra writes a single batch of records to table, using the provided session.
+func Load___datagen_with_columns_cassandra(records []*__datagen_with_columns, session *gocql.Session, config *__dgi_CassandraConfig, table *__dgi_cassandraTable) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := __dgi_cassandraInsert(context.Background(), session, config, table, Rows___datagen_with_columns_cassandra(records)); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_with_columns_cassandra returns the values of the columns of records, in order.
+func Rows___datagen_with_columns_cassandra(records []*__datagen_with_columns) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.email,
+		})
+	}
+	return rows
+}
+
+// Truncate___datagen_with_columns_cassandra truncates the model's table using the provided session.
+func Truncate___datagen_with_columns_cassandra(session *gocql.Session, table *__dgi_cassandraTable) error {
+	if err := session.Query("TRUNCATE TABLE " + table.name).Exec(); err != nil {
+		return fmt.Errorf("truncate failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_with_columns_cassandra_table creates the model's table unless it already exists.
+func Create___datagen_with_columns_cassandra_table(session *gocql.Session, table *__dgi_cassandraTable) error {
+	stmt, err := table.createStatement()
+	if err != nil {
+		return err
+	}
+	if err := session.Query(stmt).Exec(); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
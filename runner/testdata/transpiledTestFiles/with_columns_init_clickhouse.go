@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+)
+
+var __datagen_with_columns_clickhouse_connection *__dgi_chClient
+
+// Init___datagen_with_columns_clickhouse_connection initializes a shared ClickHouse connection for __datagen_with_columns.
+func Init___datagen_with_columns_clickhouse_connection(req *__dgi_ClickHouseConfig) error {
+	if _, err := Get___datagen_with_columns_clickhouse_connection(); err == nil {
+		return nil
+	}
+
+	conn, err := Open___datagen_with_columns_clickhouse_connection(req)
+	if err != nil {
+		return err
+	}
+
+	__datagen_with_columns_clickhouse_connection = conn
+	return nil
+}
+
+// Open___datagen_with_columns_clickhouse_connection opens a new ClickHouse connection for __datagen_with_columns that is owned by the caller.
+func Open___datagen_with_columns_clickhouse_connection(req *__dgi_ClickHouseConfig) (*__dgi_chClient, error) {
+	conn, err := __dgi_newCHClient(req)
+	if err != nil {
+		return nil, fmt.Errorf("open connection: %w", err)
+	}
+	return conn, nil
+}
+
+// Get___datagen_with_columns_clickhouse_connection returns the shared ClickHouse connection or an error if not initialized.
+func Get___datagen_with_columns_clickhouse_connection() (*__dgi_chClient, error) {
+	if __datagen_with_columns_clickhouse_connection == nil {
+		return nil, fmt.Errorf("clickhouse connection for __datagen_with_columns is not initialized")
+	}
+	return __datagen_with_columns_clickhouse_connection, nil
+}
+
+// Close___datagen_with_columns_clickhouse_connection closes the shared ClickHouse connection for __datagen_with_columns if initialized.
+func Close___datagen_with_columns_clickhouse_connection() error {
+	if __datagen_with_columns_clickhouse_connection == nil {
+		return nil
+	}
+	__datagen_with_columns_clickhouse_connection.close()
+	__datagen_with_columns_clickhouse_connection = nil
+	return nil
+}
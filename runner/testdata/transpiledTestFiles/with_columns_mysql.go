@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Load___datagen_with_columns_mysql executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_with_columns_mysql(records []*__datagen_with_columns, tx *sql.Tx, stmt __dgi_writeStatement) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	var b strings.Builder
+	b.WriteString(stmt.prefix)
+
+	placeholderGroup := "(" + strings.Repeat("?,", 2)
+	placeholderGroup = placeholderGroup[:len(placeholderGroup)-1] + ")"
+	for i := range records {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(placeholderGroup)
+	}
+	b.WriteString(stmt.suffix)
+	sqlStmt := b.String()
+
+	var args []interface{}
+	for _, row := range Rows___datagen_with_columns_mysql(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+
+	return nil
+}
+
+// LoadData___datagen_with_columns_mysql loads a single batch of records with LOAD DATA LOCAL INFILE, using the provided transaction.
+func LoadData___datagen_with_columns_mysql(records []*__datagen_with_columns, tx *sql.Tx, mode __dgi_WriteMode) error {
+	if len(records) == 0 {
+		return nil
+	}
+	columns := []string{
+		"`id`",
+		"`E-Mail Address`",
+	}
+	if err := __dgi_mysqlLoadData(context.Background(), tx, "`billing`.`user_accounts`", columns, mode, Rows___datagen_with_columns_mysql(records)); err != nil {
+		return fmt.Errorf("load data failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_with_columns_mysql returns the values of the columns of records, in order.
+func Rows___datagen_with_columns_mysql(records []*__datagen_with_columns) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.email,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_with_columns_mysql returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_with_columns_mysql(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+		"E-Mail Address",
+	}
+	columns := []string{
+		"`id`",
+		"`E-Mail Address`",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectMySQL, "`billing`.`user_accounts`", names, columns, keys, mode)
+}
+
+// Truncate___datagen_with_columns_mysql() truncates the model's table using the shared connection.
+func Truncate___datagen_with_columns_mysql(tx *sql.Tx) error {
+	ctx := context.Background()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `billing`.`user_accounts`;"); err != nil {
+		return fmt.Errorf("delete failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_with_columns_mysql_table creates the model's table unless it already exists.
+func Create___datagen_with_columns_mysql_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `billing`.`user_accounts` (\n  `id` BIGINT NOT NULL,\n  `E-Mail Address` TEXT NOT NULL\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
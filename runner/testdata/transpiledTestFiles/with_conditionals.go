@@ -390,27 +390,21 @@ type __datagen_with_conditionalsGenerator struct {
 }
 
 type __datagen_with_conditionalsDataHolder struct {
-	id       []int
-	category []string
-	value    []int
+	id       *__dgi_Memo[int]
+	category *__dgi_Memo[string]
+	value    *__dgi_Memo[int]
 }
 
 func (cg *__datagen_with_conditionalsGenerator) __gen_wrapper_value() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_value(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.value) {
-			return cg.all.value[iter]
-		}
-
-		for i := len(cg.all.value); i <= iter; i++ {
-			val := cg.__gen_value(i)
-			cg.all.value = append(cg.all.value, val)
-		}
-
-		return cg.all.value[iter]
+		return cg.all.value.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_conditionalsGenerator) __gen_value(iter int) int {
+func (self *__datagen_with_conditionalsGenerator) __gen_value(__dgi_r *__dgi_Rand, iter int) int {
 	if iter < 50 {
 		return iter * 10
 	}
@@ -418,21 +412,15 @@ func (self *__datagen_with_conditionalsGenerator) __gen_value(iter int) int {
 }
 
 func (cg *__datagen_with_conditionalsGenerator) __gen_wrapper_category() func(iter int) string {
+	gen := func(i int) string {
+		return cg.__gen_category(nil, i)
+	}
 	return func(iter int) string {
-		if iter < len(cg.all.category) {
-			return cg.all.category[iter]
-		}
-
-		for i := len(cg.all.category); i <= iter; i++ {
-			val := cg.__gen_category(i)
-			cg.all.category = append(cg.all.category, val)
-		}
-
-		return cg.all.category[iter]
+		return cg.all.category.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_conditionalsGenerator) __gen_category(iter int) string {
+func (self *__datagen_with_conditionalsGenerator) __gen_category(__dgi_r *__dgi_Rand, iter int) string {
 	if iter%3 == 0 {
 		return "type_a"
 	} else if iter%3 == 1 {
@@ -442,21 +430,15 @@ func (self *__datagen_with_conditionalsGenerator) __gen_category(iter int) strin
 }
 
 func (cg *__datagen_with_conditionalsGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_conditionalsGenerator) __gen_id(iter int) int {
+func (self *__datagen_with_conditionalsGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -468,8 +450,12 @@ func (cg *__datagen_with_conditionalsGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_with_conditionalsGenerator() *__datagen_with_conditionalsGenerator {
-	all := &__datagen_with_conditionalsDataHolder{}
+func __init___datagen_with_conditionalsGenerator(memoWindow int) *__datagen_with_conditionalsGenerator {
+	all := &__datagen_with_conditionalsDataHolder{
+		id:       __dgi_NewMemo[int]("with_conditionals", "id", __dgi_memoCurrentRow, false),
+		category: __dgi_NewMemo[string]("with_conditionals", "category", __dgi_memoCurrentRow, false),
+		value:    __dgi_NewMemo[int]("with_conditionals", "value", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_with_conditionalsGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.category = cg.__gen_wrapper_category()
@@ -477,11 +463,11 @@ func __init___datagen_with_conditionalsGenerator() *__datagen_with_conditionalsG
 	return cg
 }
 
-func (e *__datagen_with_conditionals) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.category),
-		fmt.Sprintf("%v", e.value),
+func (e *__datagen_with_conditionals) ToCSV() []any {
+	return []any{
+		e.id,
+		e.category,
+		e.value,
 	}
 }
 
@@ -494,10 +480,10 @@ func (e *__datagen_with_conditionals) CSVHeaders() []string {
 }
 
 func (e *__datagen_with_conditionals) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":       e.id,
-		"category": e.category,
-		"value":    e.value,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "category", Value: e.category},
+		{Key: "value", Value: e.value},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -506,24 +492,75 @@ func (e *__datagen_with_conditionals) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_with_conditionals) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName      xml.Name `xml:"with_conditionals"`
-		Xml_id       int      `xml:"id"`
-		Xml_category string   `xml:"category"`
-		Xml_value    int      `xml:"value"`
+func (e *__datagen_with_conditionals) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_with_conditionals) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("with_conditionals", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "category", Attr: false, Value: e.category},
+		{Name: "value", Attr: false, Value: e.value},
+	})
+}
+
+func (e *__datagen_with_conditionals) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id       int    `parquet:"id"`
+		Parquet_category string `parquet:"category"`
+		Parquet_value    int    `parquet:"value"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:       e.id,
-		Xml_category: e.category,
-		Xml_value:    e.value,
+	return &__dgi_parquetRow{
+		Parquet_id:       e.id,
+		Parquet_category: e.category,
+		Parquet_value:    e.value,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_with_conditionals) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.category, e.value)
+}
+
+func (e *__datagen_with_conditionals) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"with_conditionals\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"category\",\n      \"type\": \"string\"\n    },\n    {\n      \"name\": \"value\",\n      \"type\": \"long\"\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_with_conditionals) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.category, e.value)
+}
+
+func (e *__datagen_with_conditionals) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage with_conditionals {\n  int64 id = 1;\n  string category = 2;\n  int64 value = 3;\n}\n", nil
+}
+
+func (e *__datagen_with_conditionals) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `with_conditionals` (`id`, `category`, `value`) VALUES",
+			Truncate:         "DELETE FROM `with_conditionals`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `with_conditionals` (\n  `id` BIGINT NOT NULL,\n  `category` TEXT NOT NULL,\n  `value` BIGINT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_conditionals\" (\"id\", \"category\", \"value\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"with_conditionals\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_conditionals\" (\n  \"id\" BIGINT NOT NULL,\n  \"category\" TEXT NOT NULL,\n  \"value\" BIGINT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_conditionals\" (\"id\", \"category\", \"value\") VALUES",
+			Truncate:         "DELETE FROM \"with_conditionals\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_conditionals\" (\n  \"id\" INTEGER NOT NULL,\n  \"category\" TEXT NOT NULL,\n  \"value\" INTEGER NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_with_conditionals) SQLValues() []any {
+	return []any{e.id, e.category, e.value}
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Load___datagen_with_conditionals_elasticsearch indexes a single batch of records into the index with one _bulk request.
+func Load___datagen_with_conditionals_elasticsearch(records []*__datagen_with_conditionals, client *__dgi_esClient, index string) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var b bytes.Buffer
+	for _, record := range records {
+		id, err := ID___datagen_with_conditionals_elasticsearch(record, client.config)
+		if err != nil {
+			return fmt.Errorf("reading document id failed with error : %w", err)
+		}
+		action, err := __dgi_esBulkAction(index, id)
+		if err != nil {
+			return fmt.Errorf("encoding bulk action failed with error : %w", err)
+		}
+		document, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+			{Key: "id", Value: record.id},
+			{Key: "category", Value: record.category},
+			{Key: "value", Value: record.value},
+		})
+		if err != nil {
+			return fmt.Errorf("encoding document failed with error : %w", err)
+		}
+		b.Write(action)
+		b.WriteByte('\n')
+		b.Write(document)
+		b.WriteByte('\n')
+	}
+
+	if err := client.bulk(b.Bytes()); err != nil {
+		return fmt.Errorf("bulk request failed with error : %w", err)
+	}
+	return nil
+}
+
+// ID___datagen_with_conditionals_elasticsearch returns the _id of the document of a record, the value of the configured id field,
+// or nothing to let the cluster generate one.
+func ID___datagen_with_conditionals_elasticsearch(record *__datagen_with_conditionals, config *__dgi_ElasticsearchConfig) (string, error) {
+	if config.IDField == "" {
+		return "", nil
+	}
+
+	var value interface{}
+	switch config.IDField {
+	case "id":
+		value = record.id
+	case "category":
+		value = record.category
+	case "value":
+		value = record.value
+	default:
+		return "", fmt.Errorf("id field %q does not exist in model with_conditionals", config.IDField)
+	}
+	return __dgi_esID(value)
+}
+
+// Index___datagen_with_conditionals_elasticsearch returns the index of the model, the configured one or the name of the model
+// in lower case, as index names are.
+func Index___datagen_with_conditionals_elasticsearch(config *__dgi_ElasticsearchConfig) string {
+	if config.Index != "" {
+		return config.Index
+	}
+	return strings.ToLower("with_conditionals")
+}
+
+// Mapping___datagen_with_conditionals_elasticsearch returns the body creating the index of the model, with the mapping derived
+// from the Go types of its fields.
+func Mapping___datagen_with_conditionals_elasticsearch() (string, error) {
+	return "{\"mappings\":{\"properties\":{\"category\":{\"type\":\"keyword\"},\"id\":{\"type\":\"long\"},\"value\":{\"type\":\"long\"}}}}", nil
+}
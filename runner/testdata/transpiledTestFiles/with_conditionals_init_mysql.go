@@ -16,6 +16,17 @@ func Init___datagen_with_conditionals_mysql_connection(req *__dgi_MySQLConfig) e
 		return nil
 	}
 
+	conn, err := Open___datagen_with_conditionals_mysql_connection(req)
+	if err != nil {
+		return err
+	}
+
+	__datagen_with_conditionals_mysql_connection = conn
+	return nil
+}
+
+// Open___datagen_with_conditionals_mysql_connection opens a new MySQL connection for __datagen_with_conditionals that is owned by the caller.
+func Open___datagen_with_conditionals_mysql_connection(req *__dgi_MySQLConfig) (*sql.DB, error) {
 	cfg := mysql.Config{
 		User:            req.Username,
 		Passwd:          req.Password,
@@ -35,16 +46,15 @@ func Init___datagen_with_conditionals_mysql_connection(req *__dgi_MySQLConfig) e
 	}
 	db, err := sql.Open("mysql", cfg.FormatDSN())
 	if err != nil {
-		return fmt.Errorf("open db: %w", err)
+		return nil, fmt.Errorf("open db: %w", err)
 	}
 
 	if err := db.Ping(); err != nil {
 		_ = db.Close()
-		return fmt.Errorf("ping db: %w", err)
+		return nil, fmt.Errorf("ping db: %w", err)
 	}
 
-	__datagen_with_conditionals_mysql_connection = db
-	return nil
+	return db, nil
 }
 
 // Get___datagen_with_conditionals_mysql_connection returns the shared MySQL DB or an error if not initialized.
@@ -8,8 +8,8 @@ import (
 	"strings"
 )
 
-// Load___datagen_with_conditionals_postgres executes a single batch of records using the provided transaction.
-func Load___datagen_with_conditionals_postgres(records []*__datagen_with_conditionals, tx *sql.Tx) error {
+// Load___datagen_with_conditionals_postgres executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_with_conditionals_postgres(records []*__datagen_with_conditionals, tx *sql.Tx, stmt __dgi_writeStatement) error {
 	if len(records) == 0 {
 		slog.Warn(fmt.Sprintf("no records to insert for model %s", "with_conditionals"))
 		return nil
@@ -18,14 +18,7 @@ func Load___datagen_with_conditionals_postgres(records []*__datagen_with_conditi
 	ctx := context.Background()
 
 	var b strings.Builder
-	columns := []string{
-		"\"id\"",
-		"\"category\"",
-		"\"value\"",
-	}
-	b.WriteString("INSERT INTO \"with_conditionals\" (")
-	b.WriteString(strings.Join(columns, ","))
-	b.WriteString(") VALUES ")
+	b.WriteString(stmt.prefix)
 
 	// Build placeholders for Postgres ($1, $2, ... format)
 	placeholderCount := 0
@@ -43,14 +36,15 @@ func Load___datagen_with_conditionals_postgres(records []*__datagen_with_conditi
 		}
 		b.WriteString(")")
 	}
+	b.WriteString(stmt.suffix)
 	sqlStmt := b.String()
 
 	var args []interface{}
-	for _, record := range records {
-		args = append(args, record.id)
-		args = append(args, record.category)
-		args = append(args, record.value)
-
+	for _, row := range Rows___datagen_with_conditionals_postgres(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
 	}
 
 	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
@@ -60,11 +54,69 @@ func Load___datagen_with_conditionals_postgres(records []*__datagen_with_conditi
 	return nil
 }
 
-// Truncate___datagen_with_conditionals_postgres() truncates the model's table using the shared connection.
+// Copy___datagen_with_conditionals_postgres loads a single batch of records with COPY, using the provided transaction.
+func Copy___datagen_with_conditionals_postgres(records []*__datagen_with_conditionals, tx *sql.Tx) error {
+	if len(records) == 0 {
+		return nil
+	}
+	columns := []string{
+		"\"id\"",
+		"\"category\"",
+		"\"value\"",
+	}
+	if err := __dgi_postgresCopy(context.Background(), tx, "\"with_conditionals\"", columns, Rows___datagen_with_conditionals_postgres(records)); err != nil {
+		return fmt.Errorf("copy failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_with_conditionals_postgres returns the values of the columns of records, in order.
+func Rows___datagen_with_conditionals_postgres(records []*__datagen_with_conditionals) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.category,
+			record.value,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_with_conditionals_postgres returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_with_conditionals_postgres(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+		"category",
+		"value",
+	}
+	columns := []string{
+		"\"id\"",
+		"\"category\"",
+		"\"value\"",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectPostgres, "\"with_conditionals\"", names, columns, keys, mode)
+}
+
+// Truncate___datagen_with_conditionals_postgres() empties the model's table using the shared connection. Rows are deleted
+// rather than truncated with CASCADE, so that tables referencing it outside the run are never emptied.
 func Truncate___datagen_with_conditionals_postgres(tx *sql.Tx) error {
 	ctx := context.Background()
-	if _, err := tx.ExecContext(ctx, "TRUNCATE TABLE \"with_conditionals\" RESTART IDENTITY CASCADE;"); err != nil {
-		return fmt.Errorf("truncate failed with error : %w", err)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM \"with_conditionals\";"); err != nil {
+		return fmt.Errorf("delete failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_with_conditionals_postgres_table creates the model's table unless it already exists.
+func Create___datagen_with_conditionals_postgres_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS \"with_conditionals\" (\n  \"id\" BIGINT NOT NULL,\n  \"category\" TEXT NOT NULL,\n  \"value\" BIGINT NOT NULL\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
 	}
 	return nil
 }
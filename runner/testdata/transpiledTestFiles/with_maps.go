@@ -388,45 +388,33 @@ type __datagen_with_mapsGenerator struct {
 }
 
 type __datagen_with_mapsDataHolder struct {
-	id       []int
-	metadata []map[string]string
+	id       *__dgi_Memo[int]
+	metadata *__dgi_Memo[map[string]string]
 }
 
 func (cg *__datagen_with_mapsGenerator) __gen_wrapper_metadata() func(iter int) map[string]string {
+	gen := func(i int) map[string]string {
+		return cg.__gen_metadata(nil, i)
+	}
 	return func(iter int) map[string]string {
-		if iter < len(cg.all.metadata) {
-			return cg.all.metadata[iter]
-		}
-
-		for i := len(cg.all.metadata); i <= iter; i++ {
-			val := cg.__gen_metadata(i)
-			cg.all.metadata = append(cg.all.metadata, val)
-		}
-
-		return cg.all.metadata[iter]
+		return cg.all.metadata.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_mapsGenerator) __gen_metadata(iter int) map[string]string {
+func (self *__datagen_with_mapsGenerator) __gen_metadata(__dgi_r *__dgi_Rand, iter int) map[string]string {
 	return map[string]string{"key1": "value1", "key2": fmt.Sprintf("value_%d", iter)}
 }
 
 func (cg *__datagen_with_mapsGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_mapsGenerator) __gen_id(iter int) int {
+func (self *__datagen_with_mapsGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -437,18 +425,21 @@ func (cg *__datagen_with_mapsGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_with_mapsGenerator() *__datagen_with_mapsGenerator {
-	all := &__datagen_with_mapsDataHolder{}
+func __init___datagen_with_mapsGenerator(memoWindow int) *__datagen_with_mapsGenerator {
+	all := &__datagen_with_mapsDataHolder{
+		id:       __dgi_NewMemo[int]("with_maps", "id", __dgi_memoCurrentRow, false),
+		metadata: __dgi_NewMemo[map[string]string]("with_maps", "metadata", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_with_mapsGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.metadata = cg.__gen_wrapper_metadata()
 	return cg
 }
 
-func (e *__datagen_with_maps) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.metadata),
+func (e *__datagen_with_maps) ToCSV() []any {
+	return []any{
+		e.id,
+		e.metadata,
 	}
 }
 
@@ -460,9 +451,9 @@ func (e *__datagen_with_maps) CSVHeaders() []string {
 }
 
 func (e *__datagen_with_maps) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":       e.id,
-		"metadata": e.metadata,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "metadata", Value: e.metadata},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -471,22 +462,72 @@ func (e *__datagen_with_maps) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_with_maps) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName      xml.Name          `xml:"with_maps"`
-		Xml_id       int               `xml:"id"`
-		Xml_metadata map[string]string `xml:"metadata"`
+func (e *__datagen_with_maps) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_with_maps) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("with_maps", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "metadata", Attr: false, Value: e.metadata},
+	})
+}
+
+func (e *__datagen_with_maps) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id       int               `parquet:"id"`
+		Parquet_metadata map[string]string `parquet:"metadata"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:       e.id,
-		Xml_metadata: e.metadata,
+	return &__dgi_parquetRow{
+		Parquet_id:       e.id,
+		Parquet_metadata: e.metadata,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_with_maps) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.metadata)
+}
+
+func (e *__datagen_with_maps) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"with_maps\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"metadata\",\n      \"type\": {\n        \"type\": \"map\",\n        \"values\": \"string\"\n      }\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_with_maps) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.metadata)
+}
+
+func (e *__datagen_with_maps) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage with_maps {\n  int64 id = 1;\n  map<string, string> metadata = 2;\n}\n", nil
+}
+
+func (e *__datagen_with_maps) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `with_maps` (`id`, `metadata`) VALUES",
+			Truncate:         "DELETE FROM `with_maps`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `with_maps` (\n  `id` BIGINT NOT NULL,\n  `metadata` JSON\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_maps\" (\"id\", \"metadata\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"with_maps\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_maps\" (\n  \"id\" BIGINT NOT NULL,\n  \"metadata\" JSONB\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_maps\" (\"id\", \"metadata\") VALUES",
+			Truncate:         "DELETE FROM \"with_maps\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_maps\" (\n  \"id\" INTEGER NOT NULL,\n  \"metadata\" TEXT\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_with_maps) SQLValues() []any {
+	return []any{e.id, e.metadata}
 }
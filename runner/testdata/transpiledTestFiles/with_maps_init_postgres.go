@@ -17,6 +17,17 @@ func Init___datagen_with_maps_postgres_connection(req *__dgi_PostgresConfig) err
 		return nil
 	}
 
+	conn, err := Open___datagen_with_maps_postgres_connection(req)
+	if err != nil {
+		return err
+	}
+
+	__datagen_with_maps_postgres_connection = conn
+	return nil
+}
+
+// Open___datagen_with_maps_postgres_connection opens a new Postgres connection for __datagen_with_maps that is owned by the caller.
+func Open___datagen_with_maps_postgres_connection(req *__dgi_PostgresConfig) (*sql.DB, error) {
 	port := req.Port
 	if port == 0 {
 		port = 5432
@@ -32,16 +43,15 @@ func Init___datagen_with_maps_postgres_connection(req *__dgi_PostgresConfig) err
 
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
-		return fmt.Errorf("open db: %w", err)
+		return nil, fmt.Errorf("open db: %w", err)
 	}
 
 	if err := db.Ping(); err != nil {
 		_ = db.Close()
-		return fmt.Errorf("ping db: %w", err)
+		return nil, fmt.Errorf("ping db: %w", err)
 	}
 
-	__datagen_with_maps_postgres_connection = db
-	return nil
+	return db, nil
 }
 
 // Get___datagen_with_maps_postgres_connection returns the shared Postgres DB or an error if not initialized.
@@ -7,8 +7,8 @@ import (
 	"strings"
 )
 
-// Load___datagen_with_maps_mysql executes a single batch of records using the provided transaction.
-func Load___datagen_with_maps_mysql(records []*__datagen_with_maps, tx *sql.Tx) error {
+// Load___datagen_with_maps_mysql executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_with_maps_mysql(records []*__datagen_with_maps, tx *sql.Tx, stmt __dgi_writeStatement) error {
 	if len(records) == 0 {
 		return nil
 	}
@@ -16,13 +16,7 @@ func Load___datagen_with_maps_mysql(records []*__datagen_with_maps, tx *sql.Tx)
 	ctx := context.Background()
 
 	var b strings.Builder
-	columns := []string{
-		"`id`",
-		"`metadata`",
-	}
-	b.WriteString("INSERT INTO with_maps (")
-	b.WriteString(strings.Join(columns, ","))
-	b.WriteString(") VALUES ")
+	b.WriteString(stmt.prefix)
 
 	placeholderGroup := "(" + strings.Repeat("?,", 2)
 	placeholderGroup = placeholderGroup[:len(placeholderGroup)-1] + ")"
@@ -32,13 +26,15 @@ func Load___datagen_with_maps_mysql(records []*__datagen_with_maps, tx *sql.Tx)
 		}
 		b.WriteString(placeholderGroup)
 	}
+	b.WriteString(stmt.suffix)
 	sqlStmt := b.String()
 
 	var args []interface{}
-	for _, record := range records {
-		args = append(args, record.id)
-		args = append(args, record.metadata)
-
+	for _, row := range Rows___datagen_with_maps_mysql(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
 	}
 
 	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
@@ -48,11 +44,64 @@ func Load___datagen_with_maps_mysql(records []*__datagen_with_maps, tx *sql.Tx)
 	return nil
 }
 
+// LoadData___datagen_with_maps_mysql loads a single batch of records with LOAD DATA LOCAL INFILE, using the provided transaction.
+func LoadData___datagen_with_maps_mysql(records []*__datagen_with_maps, tx *sql.Tx, mode __dgi_WriteMode) error {
+	if len(records) == 0 {
+		return nil
+	}
+	columns := []string{
+		"`id`",
+		"`metadata`",
+	}
+	if err := __dgi_mysqlLoadData(context.Background(), tx, "`with_maps`", columns, mode, Rows___datagen_with_maps_mysql(records)); err != nil {
+		return fmt.Errorf("load data failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_with_maps_mysql returns the values of the columns of records, in order.
+func Rows___datagen_with_maps_mysql(records []*__datagen_with_maps) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.metadata,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_with_maps_mysql returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_with_maps_mysql(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+		"metadata",
+	}
+	columns := []string{
+		"`id`",
+		"`metadata`",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectMySQL, "`with_maps`", names, columns, keys, mode)
+}
+
 // Truncate___datagen_with_maps_mysql() truncates the model's table using the shared connection.
 func Truncate___datagen_with_maps_mysql(tx *sql.Tx) error {
 	ctx := context.Background()
-	if _, err := tx.ExecContext(ctx, "DELETE FROM with_maps;"); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `with_maps`;"); err != nil {
 		return fmt.Errorf("delete failed with error : %w", err)
 	}
 	return nil
 }
+
+// Create___datagen_with_maps_mysql_table creates the model's table unless it already exists.
+func Create___datagen_with_maps_mysql_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `with_maps` (\n  `id` BIGINT NOT NULL,\n  `metadata` JSON\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
@@ -391,45 +391,33 @@ type __datagen_with_metadataGenerator struct {
 }
 
 type __datagen_with_metadataDataHolder struct {
-	id    []int
-	value []string
+	id    *__dgi_Memo[int]
+	value *__dgi_Memo[string]
 }
 
 func (cg *__datagen_with_metadataGenerator) __gen_wrapper_value() func(iter int) string {
+	gen := func(i int) string {
+		return cg.__gen_value(nil, i)
+	}
 	return func(iter int) string {
-		if iter < len(cg.all.value) {
-			return cg.all.value[iter]
-		}
-
-		for i := len(cg.all.value); i <= iter; i++ {
-			val := cg.__gen_value(i)
-			cg.all.value = append(cg.all.value, val)
-		}
-
-		return cg.all.value[iter]
+		return cg.all.value.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_metadataGenerator) __gen_value(iter int) string {
+func (self *__datagen_with_metadataGenerator) __gen_value(__dgi_r *__dgi_Rand, iter int) string {
 	return fmt.Sprintf("value_%d", iter)
 }
 
 func (cg *__datagen_with_metadataGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_metadataGenerator) __gen_id(iter int) int {
+func (self *__datagen_with_metadataGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -440,18 +428,21 @@ func (cg *__datagen_with_metadataGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_with_metadataGenerator() *__datagen_with_metadataGenerator {
-	all := &__datagen_with_metadataDataHolder{}
+func __init___datagen_with_metadataGenerator(memoWindow int) *__datagen_with_metadataGenerator {
+	all := &__datagen_with_metadataDataHolder{
+		id:    __dgi_NewMemo[int]("with_metadata", "id", __dgi_memoCurrentRow, false),
+		value: __dgi_NewMemo[string]("with_metadata", "value", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_with_metadataGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.value = cg.__gen_wrapper_value()
 	return cg
 }
 
-func (e *__datagen_with_metadata) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.value),
+func (e *__datagen_with_metadata) ToCSV() []any {
+	return []any{
+		e.id,
+		e.value,
 	}
 }
 
@@ -463,9 +454,9 @@ func (e *__datagen_with_metadata) CSVHeaders() []string {
 }
 
 func (e *__datagen_with_metadata) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":    e.id,
-		"value": e.value,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "value", Value: e.value},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -474,22 +465,72 @@ func (e *__datagen_with_metadata) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_with_metadata) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName   xml.Name `xml:"with_metadata"`
-		Xml_id    int      `xml:"id"`
-		Xml_value string   `xml:"value"`
+func (e *__datagen_with_metadata) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_with_metadata) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("with_metadata", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "value", Attr: false, Value: e.value},
+	})
+}
+
+func (e *__datagen_with_metadata) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id    int    `parquet:"id"`
+		Parquet_value string `parquet:"value"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:    e.id,
-		Xml_value: e.value,
+	return &__dgi_parquetRow{
+		Parquet_id:    e.id,
+		Parquet_value: e.value,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_with_metadata) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.value)
+}
+
+func (e *__datagen_with_metadata) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"with_metadata\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"value\",\n      \"type\": \"string\"\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_with_metadata) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.value)
+}
+
+func (e *__datagen_with_metadata) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage with_metadata {\n  int64 id = 1;\n  string value = 2;\n}\n", nil
+}
+
+func (e *__datagen_with_metadata) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `with_metadata` (`id`, `value`) VALUES",
+			Truncate:         "DELETE FROM `with_metadata`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `with_metadata` (\n  `id` BIGINT NOT NULL,\n  `value` TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_metadata\" (\"id\", \"value\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"with_metadata\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_metadata\" (\n  \"id\" BIGINT NOT NULL,\n  \"value\" TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_metadata\" (\"id\", \"value\") VALUES",
+			Truncate:         "DELETE FROM \"with_metadata\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_metadata\" (\n  \"id\" INTEGER NOT NULL,\n  \"value\" TEXT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_with_metadata) SQLValues() []any {
+	return []any{e.id, e.value}
 }
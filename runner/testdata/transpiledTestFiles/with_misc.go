@@ -393,65 +393,48 @@ type __datagen_with_miscGenerator struct {
 }
 
 type __datagen_with_miscDataHolder struct {
-	id    []int
-	label []string
-	count []int
+	id    *__dgi_Memo[int]
+	label *__dgi_Memo[string]
+	count *__dgi_Memo[int]
 }
 
 func (cg *__datagen_with_miscGenerator) __gen_wrapper_count() func(iter int) int {
+	key := __dgi_streamKey("with_misc", "count")
+	gen := func(i int) int {
+		return cg.__gen_count(__dgi_fieldRand(key, i), i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.count) {
-			return cg.all.count[iter]
-		}
-
-		for i := len(cg.all.count); i <= iter; i++ {
-			val := cg.__gen_count(i)
-			cg.all.count = append(cg.all.count, val)
-		}
-
-		return cg.all.count[iter]
+		return cg.all.count.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_miscGenerator) __gen_count(iter int) int {
-	return IntBetween(1, MaxValue)
+func (self *__datagen_with_miscGenerator) __gen_count(__dgi_r *__dgi_Rand, iter int) int {
+	return IntBetween(__dgi_r, 1, MaxValue)
 }
 
 func (cg *__datagen_with_miscGenerator) __gen_wrapper_label() func(iter int) string {
+	gen := func(i int) string {
+		return cg.__gen_label(nil, i)
+	}
 	return func(iter int) string {
-		if iter < len(cg.all.label) {
-			return cg.all.label[iter]
-		}
-
-		for i := len(cg.all.label); i <= iter; i++ {
-			val := cg.__gen_label(i)
-			cg.all.label = append(cg.all.label, val)
-		}
-
-		return cg.all.label[iter]
+		return cg.all.label.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_miscGenerator) __gen_label(iter int) string {
+func (self *__datagen_with_miscGenerator) __gen_label(__dgi_r *__dgi_Rand, iter int) string {
 	return fmt.Sprintf("%s_%d", Prefix, iter)
 }
 
 func (cg *__datagen_with_miscGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_miscGenerator) __gen_id(iter int) int {
+func (self *__datagen_with_miscGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -463,8 +446,12 @@ func (cg *__datagen_with_miscGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_with_miscGenerator() *__datagen_with_miscGenerator {
-	all := &__datagen_with_miscDataHolder{}
+func __init___datagen_with_miscGenerator(memoWindow int) *__datagen_with_miscGenerator {
+	all := &__datagen_with_miscDataHolder{
+		id:    __dgi_NewMemo[int]("with_misc", "id", __dgi_memoCurrentRow, false),
+		label: __dgi_NewMemo[string]("with_misc", "label", __dgi_memoCurrentRow, false),
+		count: __dgi_NewMemo[int]("with_misc", "count", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_with_miscGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.label = cg.__gen_wrapper_label()
@@ -472,11 +459,11 @@ func __init___datagen_with_miscGenerator() *__datagen_with_miscGenerator {
 	return cg
 }
 
-func (e *__datagen_with_misc) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.label),
-		fmt.Sprintf("%v", e.count),
+func (e *__datagen_with_misc) ToCSV() []any {
+	return []any{
+		e.id,
+		e.label,
+		e.count,
 	}
 }
 
@@ -489,10 +476,10 @@ func (e *__datagen_with_misc) CSVHeaders() []string {
 }
 
 func (e *__datagen_with_misc) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":    e.id,
-		"label": e.label,
-		"count": e.count,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "label", Value: e.label},
+		{Key: "count", Value: e.count},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -501,24 +488,75 @@ func (e *__datagen_with_misc) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_with_misc) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName   xml.Name `xml:"with_misc"`
-		Xml_id    int      `xml:"id"`
-		Xml_label string   `xml:"label"`
-		Xml_count int      `xml:"count"`
+func (e *__datagen_with_misc) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_with_misc) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("with_misc", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "label", Attr: false, Value: e.label},
+		{Name: "count", Attr: false, Value: e.count},
+	})
+}
+
+func (e *__datagen_with_misc) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id    int    `parquet:"id"`
+		Parquet_label string `parquet:"label"`
+		Parquet_count int    `parquet:"count"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:    e.id,
-		Xml_label: e.label,
-		Xml_count: e.count,
+	return &__dgi_parquetRow{
+		Parquet_id:    e.id,
+		Parquet_label: e.label,
+		Parquet_count: e.count,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_with_misc) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.label, e.count)
+}
+
+func (e *__datagen_with_misc) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"with_misc\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"label\",\n      \"type\": \"string\"\n    },\n    {\n      \"name\": \"count\",\n      \"type\": \"long\"\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_with_misc) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.label, e.count)
+}
+
+func (e *__datagen_with_misc) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage with_misc {\n  int64 id = 1;\n  string label = 2;\n  int64 count = 3;\n}\n", nil
+}
+
+func (e *__datagen_with_misc) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `with_misc` (`id`, `label`, `count`) VALUES",
+			Truncate:         "DELETE FROM `with_misc`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `with_misc` (\n  `id` BIGINT NOT NULL,\n  `label` TEXT NOT NULL,\n  `count` BIGINT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_misc\" (\"id\", \"label\", \"count\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"with_misc\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_misc\" (\n  \"id\" BIGINT NOT NULL,\n  \"label\" TEXT NOT NULL,\n  \"count\" BIGINT NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_misc\" (\"id\", \"label\", \"count\") VALUES",
+			Truncate:         "DELETE FROM \"with_misc\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_misc\" (\n  \"id\" INTEGER NOT NULL,\n  \"label\" TEXT NOT NULL,\n  \"count\" INTEGER NOT NULL\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_with_misc) SQLValues() []any {
+	return []any{e.id, e.label, e.count}
 }
@@ -7,8 +7,8 @@ import (
 	"strings"
 )
 
-// Load___datagen_with_misc_mysql executes a single batch of records using the provided transaction.
-func Load___datagen_with_misc_mysql(records []*__datagen_with_misc, tx *sql.Tx) error {
+// Load___datagen_with_misc_mysql executes a single batch of records with the statement of the write mode, using the provided transaction.
+func Load___datagen_with_misc_mysql(records []*__datagen_with_misc, tx *sql.Tx, stmt __dgi_writeStatement) error {
 	if len(records) == 0 {
 		return nil
 	}
@@ -16,14 +16,7 @@ func Load___datagen_with_misc_mysql(records []*__datagen_with_misc, tx *sql.Tx)
 	ctx := context.Background()
 
 	var b strings.Builder
-	columns := []string{
-		"`id`",
-		"`label`",
-		"`count`",
-	}
-	b.WriteString("INSERT INTO with_misc (")
-	b.WriteString(strings.Join(columns, ","))
-	b.WriteString(") VALUES ")
+	b.WriteString(stmt.prefix)
 
 	placeholderGroup := "(" + strings.Repeat("?,", 3)
 	placeholderGroup = placeholderGroup[:len(placeholderGroup)-1] + ")"
@@ -33,14 +26,15 @@ func Load___datagen_with_misc_mysql(records []*__datagen_with_misc, tx *sql.Tx)
 		}
 		b.WriteString(placeholderGroup)
 	}
+	b.WriteString(stmt.suffix)
 	sqlStmt := b.String()
 
 	var args []interface{}
-	for _, record := range records {
-		args = append(args, record.id)
-		args = append(args, record.label)
-		args = append(args, record.count)
-
+	for _, row := range Rows___datagen_with_misc_mysql(records) {
+		if err := __dgi_sinkValues(row); err != nil {
+			return fmt.Errorf("insertion failed with error : %w", err)
+		}
+		args = append(args, row...)
 	}
 
 	if _, err := tx.ExecContext(ctx, sqlStmt, args...); err != nil {
@@ -50,11 +44,68 @@ func Load___datagen_with_misc_mysql(records []*__datagen_with_misc, tx *sql.Tx)
 	return nil
 }
 
+// LoadData___datagen_with_misc_mysql loads a single batch of records with LOAD DATA LOCAL INFILE, using the provided transaction.
+func LoadData___datagen_with_misc_mysql(records []*__datagen_with_misc, tx *sql.Tx, mode __dgi_WriteMode) error {
+	if len(records) == 0 {
+		return nil
+	}
+	columns := []string{
+		"`id`",
+		"`label`",
+		"`count`",
+	}
+	if err := __dgi_mysqlLoadData(context.Background(), tx, "`with_misc`", columns, mode, Rows___datagen_with_misc_mysql(records)); err != nil {
+		return fmt.Errorf("load data failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_with_misc_mysql returns the values of the columns of records, in order.
+func Rows___datagen_with_misc_mysql(records []*__datagen_with_misc) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.label,
+			record.count,
+		})
+	}
+	return rows
+}
+
+// Statement___datagen_with_misc_mysql returns the statement writing records to the model's table in the write mode,
+// matching rows on keys, or on the primary key of the table when there are none.
+func Statement___datagen_with_misc_mysql(mode __dgi_WriteMode, keys []string) (__dgi_writeStatement, error) {
+	if len(keys) == 0 {
+		keys = []string{}
+	}
+	names := []string{
+		"id",
+		"label",
+		"count",
+	}
+	columns := []string{
+		"`id`",
+		"`label`",
+		"`count`",
+	}
+	return __dgi_newWriteStatement(__dgi_DialectMySQL, "`with_misc`", names, columns, keys, mode)
+}
+
 // Truncate___datagen_with_misc_mysql() truncates the model's table using the shared connection.
 func Truncate___datagen_with_misc_mysql(tx *sql.Tx) error {
 	ctx := context.Background()
-	if _, err := tx.ExecContext(ctx, "DELETE FROM with_misc;"); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `with_misc`;"); err != nil {
 		return fmt.Errorf("delete failed with error : %w", err)
 	}
 	return nil
 }
+
+// Create___datagen_with_misc_mysql_table creates the model's table unless it already exists.
+func Create___datagen_with_misc_mysql_table(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `with_misc` (\n  `id` BIGINT NOT NULL,\n  `label` TEXT NOT NULL,\n  `count` BIGINT NOT NULL\n);"); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
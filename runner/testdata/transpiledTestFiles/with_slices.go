@@ -390,65 +390,47 @@ type __datagen_with_slicesGenerator struct {
 }
 
 type __datagen_with_slicesDataHolder struct {
-	id     []int
-	tags   [][]string
-	scores [][]int
+	id     *__dgi_Memo[int]
+	tags   *__dgi_Memo[[]string]
+	scores *__dgi_Memo[[]int]
 }
 
 func (cg *__datagen_with_slicesGenerator) __gen_wrapper_scores() func(iter int) []int {
+	gen := func(i int) []int {
+		return cg.__gen_scores(nil, i)
+	}
 	return func(iter int) []int {
-		if iter < len(cg.all.scores) {
-			return cg.all.scores[iter]
-		}
-
-		for i := len(cg.all.scores); i <= iter; i++ {
-			val := cg.__gen_scores(i)
-			cg.all.scores = append(cg.all.scores, val)
-		}
-
-		return cg.all.scores[iter]
+		return cg.all.scores.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_slicesGenerator) __gen_scores(iter int) []int {
+func (self *__datagen_with_slicesGenerator) __gen_scores(__dgi_r *__dgi_Rand, iter int) []int {
 	return []int{iter, iter * 2, iter * 3}
 }
 
 func (cg *__datagen_with_slicesGenerator) __gen_wrapper_tags() func(iter int) []string {
+	gen := func(i int) []string {
+		return cg.__gen_tags(nil, i)
+	}
 	return func(iter int) []string {
-		if iter < len(cg.all.tags) {
-			return cg.all.tags[iter]
-		}
-
-		for i := len(cg.all.tags); i <= iter; i++ {
-			val := cg.__gen_tags(i)
-			cg.all.tags = append(cg.all.tags, val)
-		}
-
-		return cg.all.tags[iter]
+		return cg.all.tags.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_slicesGenerator) __gen_tags(iter int) []string {
+func (self *__datagen_with_slicesGenerator) __gen_tags(__dgi_r *__dgi_Rand, iter int) []string {
 	return []string{"tag1", "tag2", "tag3"}
 }
 
 func (cg *__datagen_with_slicesGenerator) __gen_wrapper_id() func(iter int) int {
+	gen := func(i int) int {
+		return cg.__gen_id(nil, i)
+	}
 	return func(iter int) int {
-		if iter < len(cg.all.id) {
-			return cg.all.id[iter]
-		}
-
-		for i := len(cg.all.id); i <= iter; i++ {
-			val := cg.__gen_id(i)
-			cg.all.id = append(cg.all.id, val)
-		}
-
-		return cg.all.id[iter]
+		return cg.all.id.Get(iter, gen)
 	}
 }
 
-func (self *__datagen_with_slicesGenerator) __gen_id(iter int) int {
+func (self *__datagen_with_slicesGenerator) __gen_id(__dgi_r *__dgi_Rand, iter int) int {
 	return iter
 }
 
@@ -460,8 +442,12 @@ func (cg *__datagen_with_slicesGenerator) Gen(iter int) __dgi_Record {
 	}
 }
 
-func __init___datagen_with_slicesGenerator() *__datagen_with_slicesGenerator {
-	all := &__datagen_with_slicesDataHolder{}
+func __init___datagen_with_slicesGenerator(memoWindow int) *__datagen_with_slicesGenerator {
+	all := &__datagen_with_slicesDataHolder{
+		id:     __dgi_NewMemo[int]("with_slices", "id", __dgi_memoCurrentRow, false),
+		tags:   __dgi_NewMemo[[]string]("with_slices", "tags", __dgi_memoCurrentRow, false),
+		scores: __dgi_NewMemo[[]int]("with_slices", "scores", __dgi_memoCurrentRow, false),
+	}
 	cg := &__datagen_with_slicesGenerator{all: all}
 	cg.id = cg.__gen_wrapper_id()
 	cg.tags = cg.__gen_wrapper_tags()
@@ -469,11 +455,11 @@ func __init___datagen_with_slicesGenerator() *__datagen_with_slicesGenerator {
 	return cg
 }
 
-func (e *__datagen_with_slices) ToCSV() []string {
-	return []string{
-		fmt.Sprintf("%v", e.id),
-		fmt.Sprintf("%v", e.tags),
-		fmt.Sprintf("%v", e.scores),
+func (e *__datagen_with_slices) ToCSV() []any {
+	return []any{
+		e.id,
+		e.tags,
+		e.scores,
 	}
 }
 
@@ -486,10 +472,10 @@ func (e *__datagen_with_slices) CSVHeaders() []string {
 }
 
 func (e *__datagen_with_slices) ToJSON() string {
-	data, err := json.Marshal(map[string]interface{}{
-		"id":     e.id,
-		"tags":   e.tags,
-		"scores": e.scores,
+	data, err := __dgi_marshalJSONObject([]__dgi_JSONField{
+		{Key: "id", Value: e.id},
+		{Key: "tags", Value: e.tags},
+		{Key: "scores", Value: e.scores},
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -498,24 +484,75 @@ func (e *__datagen_with_slices) ToJSON() string {
 	return string(data)
 }
 
-func (e *__datagen_with_slices) ToXML() string {
-	type __dgi_xmlAlias struct {
-		XMLName    xml.Name `xml:"with_slices"`
-		Xml_id     int      `xml:"id"`
-		Xml_tags   []string `xml:"tags"`
-		Xml_scores []int    `xml:"scores"`
+func (e *__datagen_with_slices) JSONEmbeds() []__dgi_JSONEmbed {
+	return nil
+}
+
+func (e *__datagen_with_slices) ToXML() ([]byte, error) {
+	return __dgi_marshalXMLRecord("with_slices", []__dgi_XMLField{
+		{Name: "id", Attr: false, Value: e.id},
+		{Name: "tags", Attr: false, Value: e.tags},
+		{Name: "scores", Attr: false, Value: e.scores},
+	})
+}
+
+func (e *__datagen_with_slices) ToParquet() any {
+	type __dgi_parquetRow struct {
+		Parquet_id     int      `parquet:"id"`
+		Parquet_tags   []string `parquet:"tags,list"`
+		Parquet_scores []int    `parquet:"scores,list"`
 	}
 
-	data := __dgi_xmlAlias{
-		Xml_id:     e.id,
-		Xml_tags:   e.tags,
-		Xml_scores: e.scores,
+	return &__dgi_parquetRow{
+		Parquet_id:     e.id,
+		Parquet_tags:   e.tags,
+		Parquet_scores: e.scores,
 	}
+}
 
-	xmlData, err := xml.Marshal(data)
-	if err != nil {
-		fmt.Println(err)
-		return ""
+func (e *__datagen_with_slices) ToAvro() ([]byte, error) {
+	return __dgi_avroEncode(nil, e.id, e.tags, e.scores)
+}
+
+func (e *__datagen_with_slices) AvroSchema() (string, error) {
+	return "{\n  \"type\": \"record\",\n  \"name\": \"with_slices\",\n  \"namespace\": \"datagen\",\n  \"fields\": [\n    {\n      \"name\": \"id\",\n      \"type\": \"long\"\n    },\n    {\n      \"name\": \"tags\",\n      \"type\": {\n        \"items\": \"string\",\n        \"type\": \"array\"\n      }\n    },\n    {\n      \"name\": \"scores\",\n      \"type\": {\n        \"items\": \"long\",\n        \"type\": \"array\"\n      }\n    }\n  ]\n}", nil
+}
+
+func (e *__datagen_with_slices) ToProto() ([]byte, error) {
+	return __dgi_protoEncode(nil, e.id, e.tags, e.scores)
+}
+
+func (e *__datagen_with_slices) ProtoSchema() (string, error) {
+	return "syntax = \"proto3\";\n\npackage datagen;\n\nmessage with_slices {\n  int64 id = 1;\n  repeated string tags = 2;\n  repeated int64 scores = 3;\n}\n", nil
+}
+
+func (e *__datagen_with_slices) SQLTable(dialect string) (__dgi_SQLTable, error) {
+	switch dialect {
+	case "mysql":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO `with_slices` (`id`, `tags`, `scores`) VALUES",
+			Truncate:         "DELETE FROM `with_slices`;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS `with_slices` (\n  `id` BIGINT NOT NULL,\n  `tags` JSON,\n  `scores` JSON\n);",
+			CreateTableError: "",
+		}, nil
+	case "postgres":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_slices\" (\"id\", \"tags\", \"scores\") VALUES",
+			Truncate:         "TRUNCATE TABLE \"with_slices\" RESTART IDENTITY CASCADE;",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_slices\" (\n  \"id\" BIGINT NOT NULL,\n  \"tags\" JSONB,\n  \"scores\" JSONB\n);",
+			CreateTableError: "",
+		}, nil
+	case "sqlite":
+		return __dgi_SQLTable{
+			Insert:           "INSERT INTO \"with_slices\" (\"id\", \"tags\", \"scores\") VALUES",
+			Truncate:         "DELETE FROM \"with_slices\";",
+			CreateTable:      "CREATE TABLE IF NOT EXISTS \"with_slices\" (\n  \"id\" INTEGER NOT NULL,\n  \"tags\" TEXT,\n  \"scores\" TEXT\n);",
+			CreateTableError: "",
+		}, nil
 	}
-	return string(xmlData)
+	return __dgi_SQLTable{}, fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+func (e *__datagen_with_slices) SQLValues() []any {
+	return []any{e.id, e.tags, e.scores}
 }
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Load___datagen_with_slices_clickhouse inserts a single batch of records in one JSONEachRow insert, using the provided connection.
+func Load___datagen_with_slices_clickhouse(records []*__datagen_with_slices, conn *__dgi_chClient) error {
+	if len(records) == 0 {
+		return nil
+	}
+	names := []string{
+		"id",
+		"tags",
+		"scores",
+	}
+	columns := []string{
+		"`id`",
+		"`tags`",
+		"`scores`",
+	}
+	if err := conn.insert("`with_slices`", names, columns, Rows___datagen_with_slices_clickhouse(records)); err != nil {
+		return fmt.Errorf("insertion failed with error : %w", err)
+	}
+	return nil
+}
+
+// Rows___datagen_with_slices_clickhouse returns the values of the columns of records, in order.
+func Rows___datagen_with_slices_clickhouse(records []*__datagen_with_slices) [][]any {
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []any{
+			record.id,
+			record.tags,
+			record.scores,
+		})
+	}
+	return rows
+}
+
+// Truncate___datagen_with_slices_clickhouse truncates the model's table using the provided connection.
+func Truncate___datagen_with_slices_clickhouse(conn *__dgi_chClient) error {
+	if err := conn.exec("TRUNCATE TABLE `with_slices`", nil); err != nil {
+		return fmt.Errorf("truncate failed with error : %w", err)
+	}
+	return nil
+}
+
+// Create___datagen_with_slices_clickhouse_table creates the model's table unless it already exists.
+func Create___datagen_with_slices_clickhouse_table(conn *__dgi_chClient) error {
+	if err := conn.exec("CREATE TABLE IF NOT EXISTS `with_slices` (\n  `id` Int64,\n  `tags` Array(LowCardinality(String)),\n  `scores` Array(Int64)\n) ENGINE = MergeTree\nORDER BY tuple()", nil); err != nil {
+		return fmt.Errorf("create table failed with error : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var __datagen_with_slices_mongodb_client *mongo.Client
+
+// Init___datagen_with_slices_mongodb_client initializes a shared MongoDB client for __datagen_with_slices.
+func Init___datagen_with_slices_mongodb_client(req *__dgi_MongoDBConfig) error {
+	if _, err := Get___datagen_with_slices_mongodb_client(); err == nil {
+		return nil
+	}
+
+	client, err := Open___datagen_with_slices_mongodb_client(req)
+	if err != nil {
+		return err
+	}
+
+	__datagen_with_slices_mongodb_client = client
+	return nil
+}
+
+// Open___datagen_with_slices_mongodb_client connects a new MongoDB client for __datagen_with_slices that is owned by the caller.
+// Structs are encoded with the names of their json tags, as in JSON output.
+func Open___datagen_with_slices_mongodb_client(req *__dgi_MongoDBConfig) (*mongo.Client, error) {
+	opts := options.Client().ApplyURI(req.URI).SetBSONOptions(&options.BSONOptions{UseJSONStructTags: true})
+
+	// Optional timeout: accept duration strings; ignore if empty or invalid
+	timeout := 10 * time.Second
+	if d, err := time.ParseDuration(req.Timeout); err == nil && d > 0 {
+		timeout = d
+		opts = opts.SetConnectTimeout(d).SetServerSelectionTimeout(d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("ping server: %w", err)
+	}
+
+	return client, nil
+}
+
+// Get___datagen_with_slices_mongodb_client returns the shared MongoDB client or an error if not initialized.
+func Get___datagen_with_slices_mongodb_client() (*mongo.Client, error) {
+	if __datagen_with_slices_mongodb_client == nil {
+		return nil, fmt.Errorf("mongodb client for __datagen_with_slices is not initialized")
+	}
+	return __datagen_with_slices_mongodb_client, nil
+}
+
+// Close___datagen_with_slices_mongodb_client disconnects the shared MongoDB client for __datagen_with_slices if initialized.
+func Close___datagen_with_slices_mongodb_client() error {
+	if __datagen_with_slices_mongodb_client == nil {
+		slog.Warn(fmt.Sprintf("Attempted to close MongoDB client for %s, but client was never initialized or already closed", "with_slices"))
+		return nil
+	}
+	err := __datagen_with_slices_mongodb_client.Disconnect(context.Background())
+	__datagen_with_slices_mongodb_client = nil
+	return err
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var __datagen_with_slices_sqlite_connection *sql.DB
+
+// Init___datagen_with_slices_sqlite_connection initializes a shared SQLite connection for __datagen_with_slices.
+func Init___datagen_with_slices_sqlite_connection(req *__dgi_SQLiteConfig) error {
+	if _, err := Get___datagen_with_slices_sqlite_connection(); err == nil {
+		return nil
+	}
+
+	conn, err := Open___datagen_with_slices_sqlite_connection(req)
+	if err != nil {
+		return err
+	}
+
+	__datagen_with_slices_sqlite_connection = conn
+	return nil
+}
+
+// Open___datagen_with_slices_sqlite_connection opens a new SQLite connection for __datagen_with_slices that is owned by the caller,
+// creating the database file and its directory when they do not exist.
+func Open___datagen_with_slices_sqlite_connection(req *__dgi_SQLiteConfig) (*sql.DB, error) {
+	busyTimeout := 5 * time.Second
+	if d, err := time.ParseDuration(req.Timeout); err == nil && d > 0 {
+		busyTimeout = d
+	}
+
+	if err := os.MkdirAll(filepath.Dir(req.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("create database directory: %w", err)
+	}
+
+	// foreign keys are only enforced when enabled on each connection
+	dsn := fmt.Sprintf("%s?_foreign_keys=on&_busy_timeout=%d", req.Path, busyTimeout.Milliseconds())
+
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping db: %w", err)
+	}
+
+	return db, nil
+}
+
+// Get___datagen_with_slices_sqlite_connection returns the shared SQLite DB or an error if not initialized.
+func Get___datagen_with_slices_sqlite_connection() (*sql.DB, error) {
+	if __datagen_with_slices_sqlite_connection == nil {
+		return nil, fmt.Errorf("sqlite connection for __datagen_with_slices is not initialized")
+	}
+	return __datagen_with_slices_sqlite_connection, nil
+}
+
+// Close___datagen_with_slices_sqlite_connection closes the shared SQLite DB for __datagen_with_slices if initialized.
+func Close___datagen_with_slices_sqlite_connection() error {
+	if __datagen_with_slices_sqlite_connection == nil {
+		slog.Warn(fmt.Sprintf("Attempted to close SQLite connection for %s, but connection was never initialized or already closed", "with_slices"))
+		return nil
+	}
+	err := __datagen_with_slices_sqlite_connection.Close()
+	__datagen_with_slices_sqlite_connection = nil
+	return err
+}
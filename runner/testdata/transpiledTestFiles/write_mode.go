@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// __dgi_WriteMode is how a SQL sink writes records whose keys are already in
+// the table, set per model with write_mode in config.json.
+type __dgi_WriteMode string
+
+const (
+	// __dgi_WriteModeInsert fails on duplicate keys.
+	__dgi_WriteModeInsert __dgi_WriteMode = "insert"
+	// __dgi_WriteModeInsertIgnore keeps the rows already in the table.
+	__dgi_WriteModeInsertIgnore __dgi_WriteMode = "insert_ignore"
+	// __dgi_WriteModeUpsert updates the rows already in the table.
+	__dgi_WriteModeUpsert __dgi_WriteMode = "upsert"
+	// __dgi_WriteModeReplace replaces the rows already in the table.
+	__dgi_WriteModeReplace __dgi_WriteMode = "replace"
+)
+
+var __dgi_writeModes = []__dgi_WriteMode{__dgi_WriteModeInsert, __dgi_WriteModeInsertIgnore, __dgi_WriteModeUpsert, __dgi_WriteModeReplace}
+
+func (m __dgi_WriteMode) validate() error {
+	if m == "" || slices.Contains(__dgi_writeModes, m) {
+		return nil
+	}
+	modes := make([]string, 0, len(__dgi_writeModes))
+	for _, mode := range __dgi_writeModes {
+		modes = append(modes, string(mode))
+	}
+	return fmt.Errorf("write_mode must be one of %s, got %q", strings.Join(modes, ", "), m)
+}
+
+// __dgi_writeStatement is an INSERT statement of a write mode, split around
+// the rows of VALUES.
+type __dgi_writeStatement struct {
+	prefix string
+	suffix string
+}
+
+// __dgi_newWriteStatement returns the statement writing rows to table in the
+// given mode. Columns are given by name and quoted in the dialect, and
+// upserts match rows on the key columns, updating the other ones.
+func __dgi_newWriteStatement(dialect, table string, names, quoted, keys []string, mode __dgi_WriteMode) (__dgi_writeStatement, error) {
+	isKey := make([]bool, len(names))
+	for _, key := range keys {
+		i := slices.Index(names, key)
+		if i < 0 {
+			return __dgi_writeStatement{}, fmt.Errorf("key column %q is not a column of %s, expected one of %s", key, table, strings.Join(names, ", "))
+		}
+		isKey[i] = true
+	}
+	var keyColumns, updated []string
+	for i, column := range quoted {
+		if isKey[i] {
+			keyColumns = append(keyColumns, column)
+		} else {
+			updated = append(updated, column)
+		}
+	}
+
+	columns := " (" + strings.Join(quoted, ",") + ") VALUES "
+	switch dialect {
+	case __dgi_DialectMySQL:
+		switch mode {
+		case __dgi_WriteModeInsertIgnore:
+			return __dgi_writeStatement{prefix: "INSERT IGNORE INTO " + table + columns}, nil
+		case __dgi_WriteModeReplace:
+			return __dgi_writeStatement{prefix: "REPLACE INTO " + table + columns}, nil
+		case __dgi_WriteModeUpsert:
+			// a row whose columns are all keys has nothing to update
+			if len(updated) == 0 {
+				updated = quoted[:1]
+			}
+			sets := make([]string, 0, len(updated))
+			for _, column := range updated {
+				sets = append(sets, column+"=VALUES("+column+")")
+			}
+			return __dgi_writeStatement{prefix: "INSERT INTO " + table + columns, suffix: " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ",")}, nil
+		}
+	case __dgi_DialectPostgres:
+		switch mode {
+		case __dgi_WriteModeInsertIgnore:
+			return __dgi_writeStatement{prefix: "INSERT INTO " + table + columns, suffix: " ON CONFLICT DO NOTHING"}, nil
+		case __dgi_WriteModeUpsert, __dgi_WriteModeReplace:
+			// every column is written, so replacing a row is updating it
+			return __dgi_onConflictUpdate(table, columns, keyColumns, updated, mode)
+		}
+	case __dgi_DialectSQLite:
+		switch mode {
+		case __dgi_WriteModeInsertIgnore:
+			return __dgi_writeStatement{prefix: "INSERT OR IGNORE INTO " + table + columns}, nil
+		case __dgi_WriteModeReplace:
+			return __dgi_writeStatement{prefix: "INSERT OR REPLACE INTO " + table + columns}, nil
+		case __dgi_WriteModeUpsert:
+			return __dgi_onConflictUpdate(table, columns, keyColumns, updated, mode)
+		}
+	}
+	return __dgi_writeStatement{prefix: "INSERT INTO " + table + columns}, nil
+}
+
+// __dgi_onConflictUpdate returns the INSERT statement updating the rows whose
+// key columns are already in the table, as Postgres and SQLite write it.
+func __dgi_onConflictUpdate(table, columns string, keyColumns, updated []string, mode __dgi_WriteMode) (__dgi_writeStatement, error) {
+	if len(keyColumns) == 0 {
+		return __dgi_writeStatement{}, fmt.Errorf("write_mode %s needs the key_columns of %s, which has no primary key", mode, table)
+	}
+	conflict := " ON CONFLICT (" + strings.Join(keyColumns, ",") + ")"
+	if len(updated) == 0 {
+		return __dgi_writeStatement{prefix: "INSERT INTO " + table + columns, suffix: conflict + " DO NOTHING"}, nil
+	}
+	sets := make([]string, 0, len(updated))
+	for _, column := range updated {
+		sets = append(sets, column+"=EXCLUDED."+column)
+	}
+	return __dgi_writeStatement{prefix: "INSERT INTO " + table + columns, suffix: conflict + " DO UPDATE SET " + strings.Join(sets, ",")}, nil
+}
@@ -3,13 +3,14 @@ package main
 import (
 	"bufio"
 	"errors"
-	"encoding/csv"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
+
+	"github.com/parquet-go/parquet-go"
 )
 
 // format constants
@@ -17,19 +18,42 @@ const (
     __dgi_FormatCSV    = "csv"
     __dgi_FormatJSON   = "json"
     __dgi_FormatXML    = "xml"
+    __dgi_FormatParquet = "parquet"
+    __dgi_FormatAvro   = "avro"
+    __dgi_FormatProtobuf = "protobuf"
+    __dgi_FormatSQL    = "sql"
     __dgi_FormatStdout = "stdout"
 )
 
+// __dgi_ProtobufExt is the extension of files of length-delimited Protobuf messages
+const __dgi_ProtobufExt = "pb"
+
 type __dgi_Record interface {
-    ToCSV() []string
+    ToCSV() []any
     CSVHeaders() []string
     ToJSON() string
-    ToXML() string
+    JSONEmbeds() []__dgi_JSONEmbed
+    ToXML() ([]byte, error)
+    ToParquet() any
+    ToAvro() ([]byte, error)
+    AvroSchema() (string, error)
+    ToProto() ([]byte, error)
+    ProtoSchema() (string, error)
+    SQLTable(dialect string) (__dgi_SQLTable, error)
+    SQLValues() []any
 }
 
 type __dgi_RecordGenerator func(i int) __dgi_Record
 
-type __dgi_OutputWriter func(name string, records []__dgi_Record, outPath string) error
+// __dgi_OutputWriter receives the records of a single model chunk by chunk
+type __dgi_OutputWriter interface {
+    Write(records []__dgi_Record) error
+    Close() error
+}
+
+// __dgi_OutputWriterFactory returns the writer of the model name, which
+// writes to the files of create
+type __dgi_OutputWriterFactory func(name string, create __dgi_OutputFileFactory) (__dgi_OutputWriter, error)
 
 func __dgi_resolveOutputFilePath(outPath, name, ext string) (string, error) {
 	if outPath == "" {
@@ -41,7 +65,7 @@ func __dgi_resolveOutputFilePath(outPath, name, ext string) (string, error) {
 		}
 		return outPath, nil
 	} else if errors.Is(err, os.ErrNotExist) {
-		if strings.EqualFold(filepath.Ext(outPath), "."+ext) {
+		if strings.HasSuffix(strings.ToLower(outPath), "."+strings.ToLower(ext)) {
 			return outPath, nil
 		}
 		return filepath.Join(outPath, fmt.Sprintf("%s.%s", name, ext)), nil
@@ -50,89 +74,96 @@ func __dgi_resolveOutputFilePath(outPath, name, ext string) (string, error) {
 	}
 }
 
-func __dgi_getOutputFile(outPath, name, ext string) (*os.File, error) {
-    filePath, err := __dgi_resolveOutputFilePath(outPath, name, ext)
-	if err != nil {
-		return nil, err
-	}
-	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
-		return nil, fmt.Errorf("error creating output directory for %s: %v", name, err)
-	}
-	outputFile, err := os.Create(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("error creating output file for %s: %v", name, err)
-	}
-	return outputFile, nil
+// __dgi_parquetWriter writes the records of a model to a Parquet file. The
+// schema is derived from the first record, so no file is left behind for
+// models without records.
+type __dgi_parquetWriter struct {
+	name         string
+	file         *__dgi_OutputFile
+	rowGroupSize int
+	writer       *parquet.Writer
+	count        int
 }
 
-func __dgi_writeCSV(name string, records []__dgi_Record, outPath string) error {
-    csvFile, err := __dgi_getOutputFile(outPath, name, __dgi_FormatCSV)
-	if err != nil {
-		return fmt.Errorf("error creating CSV file for %s: %v", name, err)
+// __dgi_newParquetWriterFactory returns a factory of Parquet writers that
+// put rowGroupSize records in each row group, or all of them when it is 0.
+func __dgi_newParquetWriterFactory(rowGroupSize int) __dgi_OutputWriterFactory {
+	return func(name string, create __dgi_OutputFileFactory) (__dgi_OutputWriter, error) {
+		parquetFile, err := create(__dgi_FormatParquet, false)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Parquet file for %s: %v", name, err)
+		}
+		return &__dgi_parquetWriter{name: name, file: parquetFile, rowGroupSize: rowGroupSize}, nil
 	}
-	defer csvFile.Close()
-	writer := csv.NewWriter(csvFile)
-	defer writer.Flush()
-
-    headersWritten := false
-    for _, record := range records {
-        row := record.ToCSV()
-        if !headersWritten {
-            if err := writer.Write(record.CSVHeaders()); err != nil {
-				return fmt.Errorf("error writing CSV headers for %s: %w", name, err)
+}
+
+func (w *__dgi_parquetWriter) Write(records []__dgi_Record) (err error) {
+	// parquet-go panics on field types it has no parquet type for
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("error writing Parquet row for %s: %v", w.name, r)
+		}
+	}()
+	for _, record := range records {
+		row := record.ToParquet()
+		if w.writer == nil {
+			options := []parquet.WriterOption{
+				parquet.NewSchema(w.name, parquet.SchemaOf(row)),
+				parquet.Compression(&parquet.Snappy),
 			}
-			headersWritten = true
+			if w.rowGroupSize > 0 {
+				options = append(options, parquet.MaxRowsPerRowGroup(int64(w.rowGroupSize)))
+			}
+			w.writer = parquet.NewWriter(w.file, options...)
 		}
-		if err := writer.Write(row); err != nil {
-			return fmt.Errorf("error writing CSV row for %s: %w", name, err)
+		if err := w.writer.Write(row); err != nil {
+			return fmt.Errorf("error writing Parquet row for %s: %w", w.name, err)
 		}
 	}
-    slog.Info(fmt.Sprintf("generated CSV file %s with %d records", csvFile.Name(), len(records)))
+	w.count += len(records)
 	return nil
 }
 
-func __dgi_writeJSON(name string, records []__dgi_Record, outPath string) error {
-    jsonFile, jsonErr := __dgi_getOutputFile(outPath, name, __dgi_FormatJSON)
-	if jsonErr != nil {
-		return fmt.Errorf("error creating JSON file for %s: %v", name, jsonErr)
+func (w *__dgi_parquetWriter) Close() error {
+	if w.writer == nil {
+		if err := w.file.Discard(); err != nil {
+			return fmt.Errorf("error removing empty Parquet file for %s: %w", w.name, err)
+		}
+		slog.Info(fmt.Sprintf("no records for %s, no Parquet file generated", w.name))
+		return nil
+	}
+	defer w.file.Discard()
+	if err := w.writer.Close(); err != nil {
+		return fmt.Errorf("error flushing Parquet file for %s: %w", w.name, err)
 	}
-	defer jsonFile.Close()
-	jsonWriter := bufio.NewWriter(jsonFile)
-	defer jsonWriter.Flush()
-    for _, record := range records {
-        jsonRow := record.ToJSON()
-		fmt.Fprintln(jsonWriter, jsonRow)
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("error closing Parquet file for %s: %w", w.name, err)
 	}
-    slog.Info(fmt.Sprintf("generated JSON file %s with %d records", jsonFile.Name(), len(records)))
+	slog.Info(fmt.Sprintf("generated Parquet file %s with %d records", w.file.Name(), w.count))
 	return nil
 }
 
-func __dgi_writeXML(name string, records []__dgi_Record, outPath string) error {
-    xmlFile, xmlErr := __dgi_getOutputFile(outPath, name, __dgi_FormatXML)
-	if xmlErr != nil {
-		return fmt.Errorf("error creating XML file for %s: %v", name, xmlErr)
-	}
-	defer xmlFile.Close()
-	xmlWriter := bufio.NewWriter(xmlFile)
-	defer xmlWriter.Flush()
-    for _, record := range records {
-        xmlRow := record.ToXML()
-		fmt.Fprintln(xmlWriter, xmlRow)
-	}
-    slog.Info(fmt.Sprintf("generated XML file %s with %d records", xmlFile.Name(), len(records)))
-	return nil
+type __dgi_stdoutWriter struct {
+	name   string
+	writer *bufio.Writer
 }
 
-func __dgi_writeStdout(name string, records []__dgi_Record, outPath string) error {
-	outWriter := bufio.NewWriter(os.Stdout)
-	defer outWriter.Flush()
+func __dgi_newStdoutWriter(name string, _ __dgi_OutputFileFactory) (__dgi_OutputWriter, error) {
+	return &__dgi_stdoutWriter{name: name, writer: bufio.NewWriter(os.Stdout)}, nil
+}
+
+func (w *__dgi_stdoutWriter) Write(records []__dgi_Record) error {
 	for _, record := range records {
 		val := interface{}(record)
 		rv := reflect.ValueOf(record)
 		if rv.Kind() == reflect.Ptr && !rv.IsNil() {
 			val = rv.Elem().Interface()
 		}
-		fmt.Fprintf(outWriter, "%+v%+v\n", name, val)
+		fmt.Fprintf(w.writer, "%+v%+v\n", w.name, val)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (w *__dgi_stdoutWriter) Close() error {
+	return w.writer.Flush()
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// __dgi_XMLOptions holds the flags of the xml format.
+type __dgi_XMLOptions struct {
+	Root      string
+	Namespace string
+}
+
+func (o __dgi_XMLOptions) validate() error {
+	if !__dgi_isXMLName(o.Root) {
+		return fmt.Errorf("--xml-root must be a valid XML element name, got %q", o.Root)
+	}
+	return nil
+}
+
+// __dgi_isXMLName reports whether s is an XML name without a namespace prefix.
+func __dgi_isXMLName(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', r > 0x7f:
+		case i > 0 && (r == '-' || r == '.' || '0' <= r && r <= '9'):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+// __dgi_XMLField is a value written as the attribute or child element Name
+// of the element of a record.
+type __dgi_XMLField struct {
+	Name  string
+	Attr  bool
+	Value any
+}
+
+var __dgi_xmlTimeType = reflect.TypeOf(time.Time{})
+
+// __dgi_marshalXMLRecord marshals a record as the element name, with fields
+// as its attributes and child elements in order.
+func __dgi_marshalXMLRecord(name string, fields []__dgi_XMLField) ([]byte, error) {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	for _, field := range fields {
+		if !field.Attr {
+			continue
+		}
+		text, ok, err := __dgi_xmlText(reflect.ValueOf(field.Value))
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling %s: %w", field.Name, err)
+		}
+		if ok {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: field.Name}, Value: text})
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := xml.NewEncoder(&buf)
+	if err := enc.EncodeToken(start); err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		if field.Attr {
+			continue
+		}
+		if err := __dgi_encodeXMLValue(enc, field.Name, nil, reflect.ValueOf(field.Value)); err != nil {
+			return nil, fmt.Errorf("error marshaling %s: %w", field.Name, err)
+		}
+	}
+	if err := enc.EncodeToken(start.End()); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// __dgi_xmlText returns v as the text of an attribute or element, and false
+// for nil values. Times are written in RFC 3339 and bytes in base64.
+func __dgi_xmlText(v reflect.Value) (string, bool, error) {
+	if !v.IsValid() {
+		return "", false, nil
+	}
+	if v.Type() == __dgi_xmlTimeType {
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), true, nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return "", false, nil
+		}
+		return __dgi_xmlText(v.Elem())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), true, nil
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.IsNil() {
+				return "", false, nil
+			}
+			return base64.StdEncoding.EncodeToString(v.Bytes()), true, nil
+		}
+	}
+	return "", false, fmt.Errorf("cannot write %s as XML text", v.Type())
+}
+
+// __dgi_encodeXMLValue writes v as the element name. Slices and arrays hold
+// an item element per value, maps an entry element per key, sorted and in a
+// key attribute, and structs an element per exported field. Nil values are
+// left out.
+func __dgi_encodeXMLValue(enc *xml.Encoder, name string, attrs []xml.Attr, v reflect.Value) error {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs}
+	isBytes := v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+	switch {
+	case v.Type() == __dgi_xmlTimeType, isBytes:
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := __dgi_encodeXMLValue(enc, "item", nil, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	case v.Kind() == reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			key, _, err := __dgi_xmlText(iter.Key())
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+			values[key] = iter.Value()
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			attr := []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}}
+			if err := __dgi_encodeXMLValue(enc, "entry", attr, values[key]); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	case v.Kind() == reflect.Struct:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.IsExported() {
+				if err := __dgi_encodeXMLValue(enc, f.Name, nil, v.Field(i)); err != nil {
+					return err
+				}
+			}
+		}
+		return enc.EncodeToken(start.End())
+	}
+
+	text, ok, err := __dgi_xmlText(v)
+	if err != nil || !ok {
+		return err
+	}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(xml.CharData(text)); err != nil {
+		return err
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// __dgi_xmlWriter writes the records of a model as one XML document: the
+// declaration, and the root element holding an element per record.
+type __dgi_xmlWriter struct {
+	name   string
+	opts   __dgi_XMLOptions
+	file   *__dgi_OutputFile
+	writer *bufio.Writer
+	count  int
+}
+
+// __dgi_newXMLWriterFactory returns a factory of XML writers, whose documents
+// have the root and namespace of opts.
+func __dgi_newXMLWriterFactory(opts __dgi_XMLOptions) __dgi_OutputWriterFactory {
+	return func(name string, create __dgi_OutputFileFactory) (__dgi_OutputWriter, error) {
+		xmlFile, err := create(__dgi_FormatXML, false)
+		if err != nil {
+			return nil, fmt.Errorf("error creating XML file for %s: %v", name, err)
+		}
+		w := &__dgi_xmlWriter{name: name, opts: opts, file: xmlFile, writer: bufio.NewWriter(xmlFile)}
+		root := xml.StartElement{Name: xml.Name{Space: opts.Namespace, Local: opts.Root}}
+		w.writer.WriteString(xml.Header)
+		enc := xml.NewEncoder(w.writer)
+		if err := enc.EncodeToken(root); err != nil {
+			xmlFile.Close()
+			return nil, fmt.Errorf("error writing XML file for %s: %w", name, err)
+		}
+		if err := enc.Flush(); err != nil {
+			xmlFile.Close()
+			return nil, fmt.Errorf("error writing XML file for %s: %w", name, err)
+		}
+		return w, nil
+	}
+}
+
+func (w *__dgi_xmlWriter) Write(records []__dgi_Record) error {
+	for _, record := range records {
+		data, err := record.ToXML()
+		if err != nil {
+			return fmt.Errorf("error marshaling XML row for %s: %w", w.name, err)
+		}
+		w.writer.WriteString("\n  ")
+		if _, err := w.writer.Write(data); err != nil {
+			return fmt.Errorf("error writing XML row for %s: %w", w.name, err)
+		}
+	}
+	w.count += len(records)
+	return nil
+}
+
+func (w *__dgi_xmlWriter) Close() error {
+	defer w.file.Discard()
+	if _, err := fmt.Fprintf(w.writer, "\n</%s>\n", w.opts.Root); err != nil {
+		return fmt.Errorf("error writing XML file for %s: %w", w.name, err)
+	}
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("error flushing XML file for %s: %w", w.name, err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("error closing XML file for %s: %w", w.name, err)
+	}
+	slog.Info(fmt.Sprintf("generated XML file %s with %d records", w.file.Name(), w.count))
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 func RemoveDirIfExists(dirPath string) error {
@@ -15,3 +17,13 @@ func RemoveDirIfExists(dirPath string) error {
 	}
 	return nil
 }
+
+// ModelFilePath returns the path of the .dg file a model was read from,
+// relative to the input directory, given its key in DgDir.Models.
+func ModelFilePath(key string) string {
+	path := filepath.Join(strings.Split(key, DgDirDelimeter)...)
+	if filepath.Ext(path) != ".dg" {
+		path += ".dg"
+	}
+	return path
+}
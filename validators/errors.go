@@ -2,7 +2,10 @@ package validators
 
 import (
 	"fmt"
+	"go/token"
 	"strings"
+
+	"github.com/dream-horizon-org/datagen/codegen"
 )
 
 type MultiErr struct {
@@ -26,6 +29,26 @@ func (m *MultiErr) Addf(format string, args ...any) {
 	m.Errors = append(m.Errors, fmt.Errorf(format, args...))
 }
 
+// AddAtf adds an error at pos in the .dg file of src, underlining length
+// bytes. Without a source or position the error has no position.
+func (m *MultiErr) AddAtf(src *codegen.Source, pos token.Pos, length int, format string, args ...any) {
+	m.Errors = append(m.Errors, src.Errorf(pos, length, format, args...))
+}
+
+// Diagnostics returns the errors as diagnostics. Errors without a position
+// only have their message set.
+func (m *MultiErr) Diagnostics() []*codegen.Diagnostic {
+	diags := make([]*codegen.Diagnostic, 0, len(m.Errors))
+	for _, err := range m.Errors {
+		if found := codegen.Diagnostics(err); len(found) > 0 {
+			diags = append(diags, found...)
+			continue
+		}
+		diags = append(diags, &codegen.Diagnostic{Msg: err.Error()})
+	}
+	return diags
+}
+
 func (m *MultiErr) Any() bool {
 	return len(m.Errors) > 0
 }
@@ -39,7 +62,8 @@ func (m *MultiErr) Error() string {
 	b.WriteString("multiple errors occurred\n")
 	for _, err := range m.Errors {
 		b.WriteString("  - ")
-		b.WriteString(err.Error())
+		// excerpts of diagnostics are indented under their message
+		b.WriteString(strings.ReplaceAll(err.Error(), "\n", "\n    "))
 		b.WriteString("\n")
 	}
 	return b.String()
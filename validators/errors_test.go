@@ -2,10 +2,13 @@ package validators
 
 import (
 	"fmt"
+	"go/token"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/dream-horizon-org/datagen/codegen"
 )
 
 func TestMultiErr_AddAndCount(t *testing.T) {
@@ -83,3 +86,26 @@ func Test_errorOrNil(t *testing.T) {
 	assert.NotNil(t, got, "expected non-nil for MultiErr with errors")
 	assert.Same(t, nonEmpty, got, "expected returned error to be the same MultiErr instance")
 }
+
+func TestMultiErr_AddAtfAndDiagnostics(t *testing.T) {
+	src := codegen.NewSource("users.dg", []byte("model users {\n  fields {\n    id() int\n  }\n}\n"))
+	m := &MultiErr{}
+
+	m.AddAtf(src, src.Pos(29), 2, "model has missing gen functions: %s", "id")
+	m.AddAtf(nil, token.NoPos, 0, "no position")
+	m.Addf("plain")
+
+	assert.Equal(t, "multiple errors occurred\n"+
+		"  - users.dg:3:5: model has missing gen functions: id\n"+
+		"            id() int\n"+
+		"            ^~\n"+
+		"  - no position\n"+
+		"  - plain\n", m.Error())
+
+	diags := m.Diagnostics()
+	assert.Len(t, diags, 3)
+	assert.Equal(t, 3, diags[0].Pos.Line)
+	assert.Equal(t, 5, diags[0].Pos.Column)
+	assert.False(t, diags[1].Pos.IsValid())
+	assert.Equal(t, "plain", diags[2].Msg)
+}
@@ -0,0 +1,271 @@
+package validators
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+const (
+	generatorPrefix = "__datagen_"
+	generatorSuffix = "Generator"
+	genFnPrefix     = "__gen_"
+	// genFnRecv is the receiver of the methods gen function bodies are
+	// generated into, which tells them apart from the wrappers around them.
+	genFnRecv = "self"
+)
+
+// TypeCheck type-checks the package generated into dir for the parsed
+// models, so that mistakes in gen function bodies are reported against the
+// .dg file and gen function they were written in rather than by go build
+// against the generated code. Errors in code generated from other sections
+// are reported against the model they were generated for.
+//
+// The export data of the dependencies is built by the go command. When it is
+// missing or the dependencies cannot be imported, type-checking is skipped
+// and errors are left for go build to report.
+func TypeCheck(parsed []*codegen.DatagenParsed, dir string) error {
+	slog.Debug(fmt.Sprintf("type-checking generated package in %s", dir))
+
+	fset := token.NewFileSet()
+	files, err := parseGeneratedFiles(fset, dir)
+	if err != nil {
+		return err
+	}
+
+	exports, err := exportData(dir, importPaths(files))
+	if errors.Is(err, exec.ErrNotFound) {
+		slog.Warn("go command not found, skipping type-checking of the generated code")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var typeErrs []types.Error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+			export, ok := exports[path]
+			if !ok {
+				return nil, fmt.Errorf("no export data for %s", path)
+			}
+			return os.Open(export) // #nosec G304 -- Export data path reported by the go command
+		}),
+		Error: func(err error) {
+			var typeErr types.Error
+			if errors.As(err, &typeErr) {
+				typeErrs = append(typeErrs, typeErr)
+			}
+		},
+	}
+	if _, err := conf.Check("main", fset, files, nil); err != nil && len(typeErrs) == 0 {
+		return fmt.Errorf("failed to type-check generated code\n  dir: %s\n  cause: %w", dir, err)
+	}
+	if importFailed(typeErrs) {
+		slog.Warn(fmt.Sprintf("could not import the dependencies of the generated code, skipping type-checking\n  dir: %s", dir))
+		return nil
+	}
+
+	models := make(map[string]*codegen.DatagenParsed, len(parsed))
+	for _, d := range parsed {
+		models[d.FullyQualifiedModelName] = d
+	}
+	var errs MultiErr
+	for _, typeErr := range typeErrs {
+		errs.Add(mapTypeError(fset, files, models, dir, typeErr))
+	}
+	// the generated code is not laid out like the models, so the errors are
+	// put back in the order of the .dg files
+	sort.SliceStable(errs.Errors, func(i, j int) bool {
+		return diagnosticBefore(codegen.Diagnostics(errs.Errors[i]), codegen.Diagnostics(errs.Errors[j]))
+	})
+	if errs.HasErrors() {
+		return errorOrNil(&errs)
+	}
+
+	slog.Debug(fmt.Sprintf("generated package in %s type-checked", dir))
+	return nil
+}
+
+// diagnosticBefore orders errors by their position in the .dg files, after
+// which come the errors without one.
+func diagnosticBefore(a, b []*codegen.Diagnostic) bool {
+	if len(b) == 0 || !b[0].Pos.IsValid() {
+		return len(a) > 0 && a[0].Pos.IsValid()
+	}
+	if len(a) == 0 || !a[0].Pos.IsValid() {
+		return false
+	}
+	if a[0].Pos.Filename != b[0].Pos.Filename {
+		return a[0].Pos.Filename < b[0].Pos.Filename
+	}
+	return a[0].Pos.Offset < b[0].Pos.Offset
+}
+
+func importFailed(typeErrs []types.Error) bool {
+	for _, typeErr := range typeErrs {
+		if strings.HasPrefix(typeErr.Msg, "could not import") {
+			return true
+		}
+	}
+	return false
+}
+
+func parseGeneratedFiles(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated code\n  dir: %s\n  cause: %w", dir, err)
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		file, err := goparser.ParseFile(fset, path, nil, goparser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse generated code\n  file: %s\n  cause: %w", path, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func importPaths(files []*ast.File) []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, file := range files {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil || path == "C" || path == "unsafe" || seen[path] {
+				continue
+			}
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// exportData asks the go command to build the packages imported by the
+// generated code and their dependencies, and returns where their export
+// data is, by import path.
+func exportData(dir string, paths []string) (map[string]string, error) {
+	exports := map[string]string{}
+	if len(paths) == 0 {
+		return exports, nil
+	}
+
+	args := append([]string{"list", "-export", "-deps", "-f", "{{if .Export}}{{.ImportPath}}\t{{.Export}}{{end}}"}, paths...)
+	// #nosec G204 -- import paths are those of the generated code
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to build dependencies of generated code\n  dir: %s\n  cause: %w\n%s", dir, err, strings.TrimSpace(stderr.String()))
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if path, export, ok := strings.Cut(scanner.Text(), "\t"); ok {
+			exports[path] = export
+		}
+	}
+	return exports, scanner.Err()
+}
+
+// mapTypeError reports an error in the generated code against the model it
+// was generated for, at its position in the gen function body it is in.
+func mapTypeError(fset *token.FileSet, files []*ast.File, models map[string]*codegen.DatagenParsed, dir string, typeErr types.Error) error {
+	msg := strings.ReplaceAll(typeErr.Msg, utils.DgDirDelimeter, ".")
+	position := fset.Position(typeErr.Pos)
+	generated := position.String()
+	if rel, err := filepath.Rel(dir, position.Filename); err == nil {
+		position.Filename = rel
+		generated = position.String()
+	}
+
+	for _, file := range files {
+		if file.FileStart > typeErr.Pos || typeErr.Pos > file.FileEnd {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Pos() > typeErr.Pos || typeErr.Pos >= fn.End() {
+				continue
+			}
+			model, field, ok := genFnOf(fn)
+			if d := models[model]; ok && d != nil {
+				pos, length := d.GenBodyPos(field, fn.Body, typeErr.Pos)
+				if pos.IsValid() {
+					return d.Source.Errorf(pos, length, "gens.%s: %s", field, msg)
+				}
+			}
+		}
+	}
+
+	if d := modelOfFile(models, filepath.Base(position.Filename)); d != nil {
+		return fmt.Errorf("%s: %s\n  generated: %s", utils.ModelFilePath(d.Filepath), msg, generated)
+	}
+	return fmt.Errorf("%s: %s", generated, msg)
+}
+
+// genFnOf returns the model and field a gen function body was generated
+// into fn for.
+func genFnOf(fn *ast.FuncDecl) (string, string, bool) {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 || len(fn.Recv.List[0].Names) != 1 || fn.Recv.List[0].Names[0].Name != genFnRecv {
+		return "", "", false
+	}
+	star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return "", "", false
+	}
+	recv, ok := star.X.(*ast.Ident)
+	if !ok || !strings.HasPrefix(recv.Name, generatorPrefix) || !strings.HasSuffix(recv.Name, generatorSuffix) {
+		return "", "", false
+	}
+	field, ok := strings.CutPrefix(fn.Name.Name, genFnPrefix)
+	if !ok {
+		return "", "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(recv.Name, generatorPrefix), generatorSuffix), field, true
+}
+
+// modelOfFile returns the model a generated file belongs to: <model>.go or
+// <model>_<sink>.go. The longest matching model name wins.
+func modelOfFile(models map[string]*codegen.DatagenParsed, name string) *codegen.DatagenParsed {
+	var found *codegen.DatagenParsed
+	for model, d := range models {
+		if name != model+".go" && !strings.HasPrefix(name, model+"_") {
+			continue
+		}
+		if found == nil || len(model) > len(found.FullyQualifiedModelName) {
+			found = d
+		}
+	}
+	return found
+}
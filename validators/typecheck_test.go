@@ -0,0 +1,105 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/elliotchance/orderedmap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/parser"
+	"github.com/dream-horizon-org/datagen/utils"
+)
+
+// typeCheckModel generates the code of a single model and type-checks it.
+func typeCheckModel(t *testing.T, name, src string) error {
+	t.Helper()
+	d, err := parser.Parse([]byte(src), name)
+	require.NoError(t, err)
+	d.FullyQualifiedModelName = name
+	require.NoError(t, Validate(d))
+
+	models := orderedmap.NewOrderedMap[string, []byte]()
+	models.Set(name, []byte(src))
+	dir := t.TempDir()
+	parsed := []*codegen.DatagenParsed{d}
+	require.NoError(t, codegen.Codegen(parsed, dir, &utils.DgDir{Models: models}))
+	return TypeCheck(parsed, dir)
+}
+
+func TestTypeCheck_Valid(t *testing.T) {
+	err := typeCheckModel(t, "Order", `model Order {
+  fields {
+    id() int
+    total_amount() float64
+  }
+  gens {
+    func id() {
+      return iter + 1
+    }
+    func total_amount() {
+      return FloatBetween(1, 100)
+    }
+  }
+}
+`)
+	assert.NoError(t, err)
+}
+
+func TestTypeCheck_GenBodyErrors(t *testing.T) {
+	err := typeCheckModel(t, "Order", `model Order {
+  fields {
+    id() int
+    total_amount() float64
+    note() string
+  }
+  gens {
+    func id() {
+      return iter + 1
+    }
+    func total_amount() {
+      return "12.5"
+    }
+    func note() {
+      return Sentence(3) + missing
+    }
+  }
+}
+`)
+	require.Error(t, err)
+	var errs *MultiErr
+	require.ErrorAs(t, err, &errs)
+
+	var got []string
+	for _, diag := range errs.Diagnostics() {
+		got = append(got, diag.Pos.String()+": "+diag.Msg)
+	}
+	assert.Equal(t, []string{
+		`Order.dg:12:14: gens.total_amount: cannot use "12.5" (untyped string constant) as float64 value in return statement`,
+		"Order.dg:15:28: gens.note: undefined: missing",
+	}, got)
+	assert.Contains(t, err.Error(), "    return \"12.5\"\n                     ^~~~~~")
+}
+
+func TestTypeCheck_MiscErrors(t *testing.T) {
+	err := typeCheckModel(t, "Order", `model Order {
+  fields {
+    id() int
+  }
+  misc {
+    func helper() int {
+      return "x"
+    }
+  }
+  gens {
+    func id() {
+      return helper()
+    }
+  }
+}
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `Order.dg: cannot use "x" (untyped string constant) as int value in return statement`)
+	assert.Contains(t, err.Error(), "generated: Order.go:")
+}
@@ -42,10 +42,10 @@ func Validate(d *codegen.DatagenParsed) error {
 
 func RequiredSectionsValidator(d *codegen.DatagenParsed, errs *MultiErr) {
 	if d.Fields == nil || d.Fields.List == nil {
-		errs.AddMsg("model has no fields section")
+		errs.AddAtf(d.Source, d.ModelNamePos, len(d.ModelName), "model has no fields section")
 	}
 	if d.GenFuns == nil {
-		errs.AddMsg("model has no gens section")
+		errs.AddAtf(d.Source, d.ModelNamePos, len(d.ModelName), "model has no gens section")
 	}
 }
 
@@ -55,7 +55,8 @@ func NoDuplicateFieldNamesValidator(d *codegen.DatagenParsed, errs *MultiErr) {
 	}
 	duplicates, _ := fetchDuplicateAndFieldSet(d)
 	if len(duplicates) > 0 {
-		errs.Addf("model has duplicate field names: %s", strings.Join(duplicates, ", "))
+		pos, length := identPos(fieldIdents(d), duplicates[0], 1)
+		errs.AddAtf(d.Source, pos, length, "model has duplicate field names: %s", strings.Join(duplicates, ", "))
 	}
 }
 
@@ -66,7 +67,8 @@ func NoMissingGensValidator(d *codegen.DatagenParsed, errs *MultiErr) {
 	genSet := buildGenSet(d)
 	missingGens := fetchMissingGens(d, genSet)
 	if len(missingGens) > 0 {
-		errs.Addf("model has missing gen functions: %s", strings.Join(missingGens, ", "))
+		pos, length := identPos(fieldIdents(d), missingGens[0], 0)
+		errs.AddAtf(d.Source, pos, length, "model has missing gen functions: %s", strings.Join(missingGens, ", "))
 	}
 }
 
@@ -74,22 +76,22 @@ func NoExtraGensValidator(d *codegen.DatagenParsed, errs *MultiErr) {
 	if d == nil || d.Fields == nil || d.Fields.List == nil || d.GenFuns == nil {
 		return
 	}
-	genSet := buildGenSet(d)
 	_, fieldSet := fetchDuplicateAndFieldSet(d)
-	extrasGens := listExtrasGen(genSet, fieldSet)
+	extrasGens := listExtrasGen(d.GenFuns, fieldSet)
 	if len(extrasGens) > 0 {
-		errs.Addf("found extra gen functions: %s", strings.Join(extrasGens, ", "))
+		pos, length := genFnPos(d, extrasGens[0])
+		errs.AddAtf(d.Source, pos, length, "found extra gen functions: %s", strings.Join(extrasGens, ", "))
 	}
 }
 
 func FilePathModelNameValidator(d *codegen.DatagenParsed, errs *MultiErr) {
 	splitPath := strings.Split(d.Filepath, utils.DgDirDelimeter)
 	if len(splitPath) < 1 {
-		errs.Addf("model should be in file named %s.dg, found in %s", d.ModelName, d.Filepath)
+		errs.AddAtf(d.Source, d.ModelNamePos, len(d.ModelName), "model should be in file named %s.dg, found in %s", d.ModelName, d.Filepath)
 	}
 	fileName := filepath.Base(splitPath[len(splitPath)-1])
 	if fileName != d.ModelName {
-		errs.Addf("model should be in file named %s.dg, found in %s.dg", d.ModelName, fileName)
+		errs.AddAtf(d.Source, d.ModelNamePos, len(d.ModelName), "model should be in file named %s.dg, found in %s.dg", d.ModelName, fileName)
 	}
 }
 
@@ -99,10 +101,12 @@ func CallExprsValidator(d *codegen.DatagenParsed, errs *MultiErr) {
 	}
 	fieldFuncTypes := fetchFieldFuncTypes(d)
 	callExprs := buildCallExprs(d)
+	idents := fieldIdents(d)
 
 	for fname, ftype := range fieldFuncTypes {
+		pos, length := identPos(idents, fname, 0)
 		if ftype.Results == nil || ftype.Results.List == nil || len(ftype.Results.List) == 0 {
-			errs.Addf("field %s must declare a return type", fname)
+			errs.AddAtf(d.Source, pos, length, "field %s must declare a return type", fname)
 		}
 		expected := countParams(ftype)
 		// Skip validation for zero-parameter function fields
@@ -111,18 +115,18 @@ func CallExprsValidator(d *codegen.DatagenParsed, errs *MultiErr) {
 		}
 		call, ok := callExprs[fname]
 		if !ok {
-			errs.Addf("missing call for field %s", fname)
+			errs.AddAtf(d.Source, pos, length, "missing call for field %s", fname)
 			continue
 		}
 		actual := len(call.Args)
 		if expected != actual {
-			errs.Addf("field %s expects %d args, got %d", fname, expected, actual)
+			errs.AddAtf(d.Source, call.Pos(), int(call.End()-call.Pos()), "field %s expects %d args, got %d", fname, expected, actual)
 		}
 	}
 	// Ensure no extra calls
-	for cname := range callExprs {
+	for cname, call := range callExprs {
 		if _, ok := fieldFuncTypes[cname]; !ok {
-			errs.Addf("unknown call %s", cname)
+			errs.AddAtf(d.Source, call.Pos(), int(call.End()-call.Pos()), "unknown call %s", cname)
 		}
 	}
 }
@@ -143,7 +147,7 @@ func GenFnsReturnValidator(d *codegen.DatagenParsed, errs *MultiErr) {
 			return true
 		})
 		if !found {
-			errs.Addf("gen func %s must return a value", g.Name)
+			errs.AddAtf(d.Source, g.NamePos, len(g.Name), "gen func %s must return a value", g.Name)
 		}
 	}
 }
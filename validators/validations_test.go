@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dream-horizon-org/datagen/codegen"
+	"github.com/dream-horizon-org/datagen/parser"
 	"github.com/dream-horizon-org/datagen/utils"
 )
 
@@ -144,3 +146,39 @@ func TestGenFnsReturnValidator(t *testing.T) {
 	assert.Contains(t, msg, "gen func A must return a value")
 	assert.Contains(t, msg, "gen func B must return a value")
 }
+
+func TestValidate_Positions(t *testing.T) {
+	src := `model users {
+  fields {
+    id() int
+    id() string
+    name() string
+  }
+  gens {
+    func id() {
+      return iter
+    }
+    func extra() {
+      return 1
+    }
+  }
+}
+`
+	d, err := parser.Parse([]byte(src), "models"+utils.DgDirDelimeter+"users")
+	require.NoError(t, err)
+
+	err = Validate(d)
+	require.Error(t, err)
+	var errs *MultiErr
+	require.ErrorAs(t, err, &errs)
+
+	positions := map[string]string{}
+	for _, diag := range errs.Diagnostics() {
+		positions[diag.Msg] = diag.Pos.String()
+	}
+	assert.Equal(t, map[string]string{
+		"model has duplicate field names: id":   "models/users.dg:4:5",
+		"model has missing gen functions: name": "models/users.dg:5:5",
+		"found extra gen functions: extra":      "models/users.dg:11:10",
+	}, positions)
+}
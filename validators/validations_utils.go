@@ -2,6 +2,7 @@ package validators
 
 import (
 	"go/ast"
+	"go/token"
 	"strings"
 
 	"github.com/dream-horizon-org/datagen/codegen"
@@ -96,17 +97,70 @@ func fetchFieldFuncTypes(d *codegen.DatagenParsed) map[string]*ast.FuncType {
 	return fieldFuncTypes
 }
 
-// listExtrasGen returns gens declared without a corresponding field.
-func listExtrasGen(genSet, fieldSet map[string]struct{}) []string {
+// listExtrasGen returns gens declared without a corresponding field, in the
+// order they are declared.
+func listExtrasGen(gens []*codegen.GenFn, fieldSet map[string]struct{}) []string {
 	var extras []string
-	for gen := range genSet {
-		if _, ok := fieldSet[gen]; !ok {
-			extras = append(extras, gen)
+	seen := map[string]struct{}{}
+	for _, g := range gens {
+		if g == nil {
+			continue
+		}
+		name := strings.TrimSpace(g.Name)
+		if _, ok := seen[name]; ok || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+		if _, ok := fieldSet[name]; !ok {
+			extras = append(extras, name)
 		}
 	}
 	return extras
 }
 
+// fieldIdents returns the identifiers each field name is declared with.
+func fieldIdents(d *codegen.DatagenParsed) map[string][]*ast.Ident {
+	idents := map[string][]*ast.Ident{}
+	if d == nil || d.Fields == nil {
+		return idents
+	}
+	for _, f := range d.Fields.List {
+		for _, n := range f.Names {
+			if n != nil {
+				name := strings.TrimSpace(n.Name)
+				idents[name] = append(idents[name], n)
+			}
+		}
+	}
+	return idents
+}
+
+// genFnNamed returns the first gen function declared with name.
+func genFnNamed(d *codegen.DatagenParsed, name string) *codegen.GenFn {
+	for _, g := range d.GenFuns {
+		if g != nil && strings.TrimSpace(g.Name) == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// identPos returns the position and length of the nth declaration of a field.
+func identPos(idents map[string][]*ast.Ident, name string, nth int) (token.Pos, int) {
+	if nth >= len(idents[name]) {
+		return token.NoPos, 0
+	}
+	return idents[name][nth].Pos(), len(name)
+}
+
+// genFnPos returns the position and length of the name of a gen function.
+func genFnPos(d *codegen.DatagenParsed, name string) (token.Pos, int) {
+	if g := genFnNamed(d, name); g != nil {
+		return g.NamePos, len(name)
+	}
+	return token.NoPos, 0
+}
+
 // buildCallExprs builds call name -> *ast.CallExpr
 func buildCallExprs(d *codegen.DatagenParsed) map[string]*ast.CallExpr {
 	callExprs := map[string]*ast.CallExpr{}